@@ -0,0 +1,263 @@
+package i18n
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Accessor is a function GenerateAccessors writes for a catalog key
+type Accessor struct {
+	Key    string   `json:"key"`
+	Func   string   `json:"func"`   // function name, e.g. "MsgWelcomeUser"
+	Params []string `json:"params"` // parameters with their types, e.g. "name any"
+}
+
+// GenerateAccessors writes a Go file to outputPath with one function per key
+// of the catalog at catalog, so a missing key or a wrong number of arguments
+// is a compile error rather than a fallback at run time:
+//
+//	// "welcome_user": "Welcome {0}, you have {count, plural, one {# message} other {# messages}}"
+//	func MsgWelcomeUser(count int, arg0 string) i18n.TranslatedFunc
+//
+// Function names are Msg followed by the key in camel case. Parameters
+// follow the placeholders of the catalog's values: the count of a
+// {count, plural, ...} block (calling P), the selector of select blocks
+// (Sel) or the position of a selectordinal block (O), then {0}, {1}, ... in
+// order, then named placeholders, passed as Args. Positional parameters are
+// typed from the printf verbs the extractor recorded ("%d" is an int, "%s" a
+// string), and any otherwise. Keys mixing block kinds, and lists, are left
+// out with a warning logged.
+//
+// Generate from the source language's catalog. The functions translate with
+// the default bundle. pkg is the package clause; if empty, it is taken from
+// the Go files already in the output directory, or else the directory's name.
+// It returns the functions written, in key order.
+func GenerateAccessors(catalog, pkg, outputPath string) ([]Accessor, error) {
+	tf, err := readTranslationFile(catalog)
+	if err != nil {
+		return nil, err
+	}
+	if pkg == "" {
+		if pkg, err = outputPackage(filepath.Dir(outputPath)); err != nil {
+			return nil, err
+		}
+	}
+
+	var accessors []Accessor
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by i18n gen-go from %s; DO NOT EDIT.\n\n", filepath.Base(catalog))
+	fmt.Fprintf(&buf, "package %s\n\n", pkg)
+	fmt.Fprintf(&buf, "import \"github.com/nyxstack/i18n\"\n")
+
+	keysByFunc := make(map[string]string)
+	for _, key := range sortedKeys(tf.Translations) {
+		value := tf.Translations[key]
+		call, params, err := accessorCall(key, value, tf.Placeholders[key])
+		if err != nil {
+			logger().Warn("no accessor generated", "key", key, "reason", err)
+			continue
+		}
+
+		name := "Msg" + exportedIdent(key)
+		if other, ok := keysByFunc[name]; ok {
+			return nil, fmt.Errorf("keys '%s' and '%s' both generate %s", other, key, name)
+		}
+		keysByFunc[name] = key
+
+		fmt.Fprintf(&buf, "\n// %s translates %q: %s\n", name, key, valueSummary(value))
+		fmt.Fprintf(&buf, "func %s(%s) i18n.TranslatedFunc {\n\treturn %s\n}\n", name, strings.Join(params, ", "), call)
+		accessors = append(accessors, Accessor{Key: key, Func: name, Params: params})
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format generated code: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(outputPath), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create output directory: %w", err)
+	}
+	if err := os.WriteFile(filepath.Clean(outputPath), src, 0644); err != nil {
+		return nil, fmt.Errorf("failed to write %s: %w", outputPath, err)
+	}
+
+	logger().Info("accessors generated", "catalog", catalog, "functions", len(accessors), "path", outputPath)
+	return accessors, nil
+}
+
+// accessorCall returns the call an accessor of key makes and its parameters
+func accessorCall(key, value string, verbs []string) (string, []string, error) {
+	names := placeholderNames(value)
+
+	// The argument of each plural, select and selectordinal block
+	blocks := make(map[string]string)
+	for name := range names {
+		for _, kind := range []string{"plural", "select", "selectordinal"} {
+			if strings.Contains(value, "{"+name+", "+kind+",") {
+				blocks[kind] = name
+				delete(names, name)
+			}
+		}
+	}
+
+	used := make(map[string]bool)
+	var params, args []string
+	fn := "i18n.T(" + strconv.Quote(key)
+	switch {
+	case len(blocks) > 1:
+		return "", nil, fmt.Errorf("combines %s blocks", strings.Join(sortedKeys(blocks), " and "))
+	case blocks["plural"] != "":
+		if blocks["plural"] != "count" {
+			return "", nil, fmt.Errorf("plural block argument is not count")
+		}
+		params = append(params, "count int")
+		used["count"] = true
+		fn = "i18n.P(" + strconv.Quote(key) + ", count"
+	case blocks["select"] != "":
+		param := paramName(blocks["select"], used)
+		params = append(params, param+" string")
+		fn = "i18n.Sel(" + strconv.Quote(key) + ", " + param
+	case blocks["selectordinal"] != "":
+		if len(names) > 0 {
+			return "", nil, fmt.Errorf("has placeholders besides its selectordinal block")
+		}
+		param := paramName(blocks["selectordinal"], used)
+		return "i18n.O(" + strconv.Quote(key) + ", " + param + ")", []string{param + " int"}, nil
+	}
+
+	// Positional placeholders, including those skipped in the value
+	last := -1
+	var named []string
+	for name := range names {
+		if i, err := strconv.Atoi(name); err == nil {
+			last = max(last, i)
+		} else {
+			named = append(named, name)
+		}
+	}
+	for i := 0; i <= last; i++ {
+		param := paramName("arg"+strconv.Itoa(i), used)
+		typ := "any"
+		if i < len(verbs) {
+			typ = verbType(verbs[i])
+		}
+		params = append(params, param+" "+typ)
+		args = append(args, param)
+	}
+
+	sort.Strings(named)
+	if len(named) > 0 {
+		fields := make([]string, 0, len(named))
+		for _, name := range named {
+			param := paramName(name, used)
+			params = append(params, param+" any")
+			fields = append(fields, strconv.Quote(name)+": "+param)
+		}
+		args = append(args, "i18n.Args{"+strings.Join(fields, ", ")+"}")
+	}
+
+	for _, arg := range args {
+		fn += ", " + arg
+	}
+	return fn + ")", params, nil
+}
+
+// verbType returns the Go type of the argument of a printf verb
+func verbType(verb string) string {
+	switch verb[len(verb)-1] {
+	case 'd', 'x', 'X', 'o', 'c':
+		return "int"
+	case 's', 'q':
+		return "string"
+	case 'f', 'F', 'e', 'E', 'g', 'G':
+		return "float64"
+	case 't':
+		return "bool"
+	}
+	return "any"
+}
+
+// paramName returns a parameter name for a placeholder that is a valid
+// identifier, does not shadow the i18n package and is not used yet
+func paramName(placeholder string, used map[string]bool) string {
+	name := unexportedName(exportedIdent(placeholder))
+	if unicode.IsDigit([]rune(name)[0]) {
+		name = "arg" + name
+	}
+	if !token.IsIdentifier(name) || token.IsKeyword(name) || name == "i18n" {
+		name += "Value"
+	}
+	for base, i := name, 2; used[name]; i++ {
+		name = base + strconv.Itoa(i)
+	}
+	used[name] = true
+	return name
+}
+
+// exportedIdent turns a key into a camel case identifier: "auth.login_button"
+// becomes "AuthLoginButton". Characters other than letters and digits
+// separate words. It returns "X" for keys without letters or digits.
+func exportedIdent(key string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range key {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	if b.Len() == 0 {
+		return "X"
+	}
+	return b.String()
+}
+
+// valueSummary returns the first line of a value for a doc comment, shortened
+func valueSummary(value string) string {
+	line, _, cut := strings.Cut(value, "\n")
+	if r := []rune(line); len(r) > 80 {
+		line, cut = string(r[:80]), true
+	}
+	if cut {
+		line += "…"
+	}
+	return line
+}
+
+// outputPackage returns the package of the Go files in dir, or the name of
+// dir if it has none
+func outputPackage(dir string) (string, error) {
+	files, _ := filepath.Glob(filepath.Join(dir, "*.go"))
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(token.NewFileSet(), file, nil, parser.PackageClauseOnly)
+		if err == nil {
+			return f.Name.Name, nil
+		}
+	}
+
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	name := strings.ToLower(filepath.Base(abs))
+	if !token.IsIdentifier(name) || token.IsKeyword(name) {
+		return "", fmt.Errorf("cannot derive a package name from %s: give one", abs)
+	}
+	return name, nil
+}
@@ -0,0 +1,113 @@
+package i18n
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestGenerateAccessors(t *testing.T) {
+	dir := t.TempDir()
+	catalog := filepath.Join(dir, "default.en.json")
+	writeTestFile(t, catalog, `{
+  "meta": {"lang": "en", "name": "default"},
+  "translations": {
+    "welcome_user": "Welcome {0}!",
+    "hello-0-you-have-1-messages": "Hello {0}, you have {1} messages",
+    "auth.greeting": "Hi {name}, it is {type} {2}",
+    "item_count": "{count, plural, one {# item in {0}} other {# items in {0}}}",
+    "invite": "{0} invited you to {gender, select, male {his team} female {her team} other {their team}}",
+    "finished": "You finished {position, selectordinal, one {#st} two {#nd} few {#rd} other {#th}}",
+    "mixed": "{count, plural, other {{gender, select, other {x}}}}",
+    "dashboard": "Dashboard",
+    "weekdays": ["Mon", "Tue"]
+  },
+  "placeholders": {"hello-0-you-have-1-messages": ["%s", "%d"]}
+}`)
+	output := filepath.Join(dir, "messages", "messages.go")
+
+	logs := captureLogs(t)
+	accessors, err := GenerateAccessors(catalog, "", output)
+	if err != nil {
+		t.Fatalf("GenerateAccessors failed: %v", err)
+	}
+
+	want := []Accessor{
+		{Key: "auth.greeting", Func: "MsgAuthGreeting", Params: []string{"arg0 any", "arg1 any", "arg2 any", "name any", "typeValue any"}},
+		{Key: "dashboard", Func: "MsgDashboard"},
+		{Key: "finished", Func: "MsgFinished", Params: []string{"position int"}},
+		{Key: "hello-0-you-have-1-messages", Func: "MsgHello0YouHave1Messages", Params: []string{"arg0 string", "arg1 int"}},
+		{Key: "invite", Func: "MsgInvite", Params: []string{"gender string", "arg0 any"}},
+		{Key: "item_count", Func: "MsgItemCount", Params: []string{"count int", "arg0 any"}},
+		{Key: "welcome_user", Func: "MsgWelcomeUser", Params: []string{"arg0 any"}},
+	}
+	if !reflect.DeepEqual(accessors, want) {
+		t.Errorf("Expected %+v, got %+v", want, accessors)
+	}
+	if !strings.Contains(logs.String(), "key=mixed") {
+		t.Errorf("Expected a warning for the key mixing blocks, got %s", logs)
+	}
+
+	data, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := parser.ParseFile(token.NewFileSet(), output, data, 0); err != nil {
+		t.Fatalf("Generated code does not parse: %v\n%s", err, data)
+	}
+	src := string(data)
+	for _, line := range []string{
+		"package messages",
+		`// MsgWelcomeUser translates "welcome_user": Welcome {0}!`,
+		`return i18n.T("auth.greeting", arg0, arg1, arg2, i18n.Args{"name": name, "type": typeValue})`,
+		`return i18n.P("item_count", count, arg0)`,
+		`return i18n.Sel("invite", gender, arg0)`,
+		`return i18n.O("finished", position)`,
+		`func MsgDashboard() i18n.TranslatedFunc {`,
+	} {
+		if !strings.Contains(src, line) {
+			t.Errorf("Expected %q in:\n%s", line, src)
+		}
+	}
+}
+
+func TestGenerateAccessors_Package(t *testing.T) {
+	dir := t.TempDir()
+	catalog := writeTestCatalog(t, dir, "default.en.json", "en", map[string]string{"a.b": "A", "a_b": "B"})
+	writeTestFile(t, filepath.Join(dir, "out", "doc.go"), "package texts\n")
+
+	_, err := GenerateAccessors(catalog, "", filepath.Join(dir, "out", "messages.go"))
+	if err == nil || !strings.Contains(err.Error(), "MsgAB") {
+		t.Errorf("Expected an error for colliding names, got %v", err)
+	}
+
+	catalog = writeTestCatalog(t, dir, "default.en.json", "en", map[string]string{"a": "A"})
+	output := filepath.Join(dir, "out", "messages.go")
+	if _, err := GenerateAccessors(catalog, "", output); err != nil {
+		t.Fatalf("GenerateAccessors failed: %v", err)
+	}
+	if data, _ := os.ReadFile(output); !strings.Contains(string(data), "package texts") {
+		t.Errorf("Expected the package of the directory's files, got:\n%s", data)
+	}
+}
+
+func TestExportedIdent(t *testing.T) {
+	for key, want := range map[string]string{
+		"welcome_user":  "WelcomeUser",
+		"auth.login":    "AuthLogin",
+		"itemCount":     "ItemCount",
+		"hello-0":       "Hello0",
+		"404":           "404",
+		"...":           "X",
+		"été.résumé":    "ÉtéRésumé",
+		"a.b-c_d e/f:g": "ABCDEFG",
+	} {
+		if got := exportedIdent(key); got != want {
+			t.Errorf("exportedIdent(%q) = %q, expected %q", key, got, want)
+		}
+	}
+}
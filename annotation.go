@@ -0,0 +1,98 @@
+package i18n
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// Annotation levels understood by GitHub Actions
+const (
+	AnnotationError   = "error"
+	AnnotationWarning = "warning"
+	AnnotationNotice  = "notice"
+)
+
+// Annotation is a CI diagnostic rendered in the GitHub Actions workflow command
+// format, so problems show up inline on pull requests:
+//
+//	::error file=locales/default.fr.json,line=42::translation key 'hello' has empty value
+type Annotation struct {
+	Level   string `json:"level"`
+	File    string `json:"file,omitempty"`
+	Line    int    `json:"line,omitempty"`
+	Column  int    `json:"column,omitempty"`
+	Title   string `json:"title,omitempty"`
+	Message string `json:"message"`
+}
+
+func (a Annotation) String() string {
+	var props []string
+	if a.File != "" {
+		props = append(props, "file="+escapeAnnotationProperty(filepath.ToSlash(a.File)))
+	}
+	if a.Line > 0 {
+		props = append(props, fmt.Sprintf("line=%d", a.Line))
+	}
+	if a.Column > 0 {
+		props = append(props, fmt.Sprintf("col=%d", a.Column))
+	}
+	if a.Title != "" {
+		props = append(props, "title="+escapeAnnotationProperty(a.Title))
+	}
+
+	level := a.Level
+	if level == "" {
+		level = AnnotationError
+	}
+
+	s := "::" + level
+	if len(props) > 0 {
+		s += " " + strings.Join(props, ",")
+	}
+	return s + "::" + escapeAnnotationData(a.Message)
+}
+
+// ErrorAnnotations converts load and validation errors into error annotations.
+// Joined errors produce one annotation each; a *FileError contributes its file
+// and position.
+func ErrorAnnotations(err error) []Annotation {
+	if err == nil {
+		return nil
+	}
+
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		var annotations []Annotation
+		for _, e := range joined.Unwrap() {
+			annotations = append(annotations, ErrorAnnotations(e)...)
+		}
+		return annotations
+	}
+
+	var fe *FileError
+	if !errors.As(err, &fe) {
+		return []Annotation{{Level: AnnotationError, Message: err.Error()}}
+	}
+
+	message := fe.Reason
+	if fe.Err != nil {
+		message += ": " + fe.Err.Error()
+	}
+
+	return []Annotation{{
+		Level:   AnnotationError,
+		File:    fe.Path,
+		Line:    fe.Line,
+		Column:  fe.Column,
+		Message: message,
+	}}
+}
+
+func escapeAnnotationData(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+func escapeAnnotationProperty(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(s)
+}
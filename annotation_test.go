@@ -0,0 +1,72 @@
+package i18n
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestAnnotationString(t *testing.T) {
+	tests := []struct {
+		annotation Annotation
+		expected   string
+	}{
+		{
+			Annotation{Level: AnnotationError, File: "locales/default.fr.json", Line: 42, Message: "empty value"},
+			"::error file=locales/default.fr.json,line=42::empty value",
+		},
+		{
+			Annotation{Level: AnnotationWarning, Title: "Budget: fr", Message: "100%\nover"},
+			"::warning title=Budget%3A fr::100%25%0Aover",
+		},
+		{
+			Annotation{Message: "no level", Column: 3, Line: 1, File: "a,b.json"},
+			"::error file=a%2Cb.json,line=1,col=3::no level",
+		},
+	}
+
+	for _, tt := range tests {
+		if result := tt.annotation.String(); result != tt.expected {
+			t.Errorf("Expected %q, got %q", tt.expected, result)
+		}
+	}
+}
+
+func TestErrorAnnotations(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "default.fr.json")
+	content := `{
+  "meta": {"lang": "fr", "name": "default"},
+  "translations": {
+    "hello": "Bonjour",
+    "banner": {
+      "title": "Titre",
+      "body": ""
+    }
+  }
+}`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	_, loadErr := LoadDictionaryFile(path)
+	plain := errors.New("something else")
+
+	annotations := ErrorAnnotations(errors.Join(loadErr, plain))
+	if len(annotations) != 2 {
+		t.Fatalf("Expected 2 annotations, got %v", annotations)
+	}
+
+	a := annotations[0]
+	if a.File != path || a.Line != 7 || a.Column != 7 || a.Message != "translation key 'banner.body' has empty value" {
+		t.Errorf("Unexpected annotation: %+v", a)
+	}
+	if annotations[1].File != "" || annotations[1].Message != "something else" {
+		t.Errorf("Unexpected annotation for plain error: %+v", annotations[1])
+	}
+
+	if ErrorAnnotations(nil) != nil {
+		t.Error("Expected no annotations for nil error")
+	}
+}
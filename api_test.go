@@ -0,0 +1,259 @@
+package i18n
+
+import (
+	"flag"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+var updateAPI = flag.Bool("update-api", false, "record API additions in "+apiFile)
+
+// apiFile lists the exported API of the package, one feature per line.
+// Minor releases may add lines but never change or remove one.
+const apiFile = "api/v1.txt"
+
+// TestAPICompatibility fails when an exported identifier recorded in
+// api/v1.txt is removed or its signature changes, which would break callers
+// in a minor release. Additions must be recorded too: run
+//
+//	go test -run TestAPICompatibility -update-api
+//
+// which refuses to drop recorded lines.
+func TestAPICompatibility(t *testing.T) {
+	current, err := exportedAPI(".")
+	if err != nil {
+		t.Fatalf("Failed to read the package API: %v", err)
+	}
+
+	data, err := os.ReadFile(apiFile)
+	if err != nil && !os.IsNotExist(err) {
+		t.Fatal(err)
+	}
+	var recorded []string
+	for _, line := range strings.Split(string(data), "\n") {
+		if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "#") {
+			recorded = append(recorded, line)
+		}
+	}
+
+	have := make(map[string]bool, len(current))
+	for _, line := range current {
+		have[line] = true
+	}
+	known := make(map[string]bool, len(recorded))
+	for _, line := range recorded {
+		known[line] = true
+		if !have[line] {
+			t.Errorf("Breaking change: %s", line)
+		}
+	}
+
+	var added []string
+	for _, line := range current {
+		if !known[line] {
+			added = append(added, line)
+		}
+	}
+	if len(added) == 0 {
+		return
+	}
+	if !*updateAPI || t.Failed() {
+		t.Errorf("New API not recorded in %s (run go test -run TestAPICompatibility -update-api):\n%s", apiFile, strings.Join(added, "\n"))
+		return
+	}
+
+	lines := append(recorded, added...)
+	sort.Strings(lines)
+	header := "# Exported API of github.com/nyxstack/i18n, checked by TestAPICompatibility.\n# Lines may be added in minor releases, never changed or removed.\n"
+	if err := os.MkdirAll(filepath.Dir(apiFile), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(apiFile, []byte(header+strings.Join(lines, "\n")+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// exportedAPI returns one line per exported feature of the package in dir:
+// functions, methods, types, struct fields, interface methods, constants and
+// variables, with their types but without parameter names, sorted
+func exportedAPI(dir string) ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+
+	fset := token.NewFileSet()
+	var lines []string
+	for _, path := range files {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			return nil, err
+		}
+		for _, decl := range file.Decls {
+			lines = append(lines, declAPI(decl)...)
+		}
+	}
+	sort.Strings(lines)
+	return lines, nil
+}
+
+// declAPI returns the exported features of a declaration
+func declAPI(decl ast.Decl) []string {
+	var lines []string
+	switch d := decl.(type) {
+	case *ast.FuncDecl:
+		if !d.Name.IsExported() {
+			return nil
+		}
+		if d.Recv == nil {
+			return []string{"func " + d.Name.Name + typeParamsAPI(d.Type.TypeParams) + signatureAPI(d.Type)}
+		}
+		recv := types.ExprString(d.Recv.List[0].Type)
+		if !ast.IsExported(strings.TrimLeft(strings.SplitN(recv, "[", 2)[0], "*")) {
+			return nil
+		}
+		return []string{"method (" + recv + ") " + d.Name.Name + signatureAPI(d.Type)}
+
+	case *ast.GenDecl:
+		var typ ast.Expr // the type of iota constants carries over
+		for _, spec := range d.Specs {
+			switch s := spec.(type) {
+			case *ast.TypeSpec:
+				if !s.Name.IsExported() {
+					continue
+				}
+				name := s.Name.Name
+				switch u := s.Type.(type) {
+				case *ast.StructType:
+					lines = append(lines, "type "+name+typeParamsAPI(s.TypeParams)+" struct")
+					for _, f := range u.Fields.List {
+						for _, n := range f.Names {
+							if n.IsExported() {
+								lines = append(lines, "field "+name+"."+n.Name+" "+types.ExprString(f.Type))
+							}
+						}
+						if len(f.Names) == 0 {
+							lines = append(lines, "embedded "+name+" "+types.ExprString(f.Type))
+						}
+					}
+				case *ast.InterfaceType:
+					lines = append(lines, "type "+name+typeParamsAPI(s.TypeParams)+" interface")
+					for _, m := range u.Methods.List {
+						if ft, ok := m.Type.(*ast.FuncType); ok {
+							lines = append(lines, "method ("+name+") "+m.Names[0].Name+signatureAPI(ft))
+						} else {
+							lines = append(lines, "embedded "+name+" "+types.ExprString(m.Type))
+						}
+					}
+				case *ast.FuncType:
+					lines = append(lines, "type "+name+typeParamsAPI(s.TypeParams)+" func"+signatureAPI(u))
+				default:
+					assign := " "
+					if s.Assign.IsValid() {
+						assign = " = "
+					}
+					lines = append(lines, "type "+name+typeParamsAPI(s.TypeParams)+assign+types.ExprString(s.Type))
+				}
+			case *ast.ValueSpec:
+				if s.Type != nil || len(s.Values) > 0 {
+					typ = s.Type
+				}
+				for _, n := range s.Names {
+					if !n.IsExported() {
+						continue
+					}
+					line := d.Tok.String() + " " + n.Name
+					if typ != nil {
+						line += " " + types.ExprString(typ)
+					}
+					lines = append(lines, line)
+				}
+			}
+		}
+	}
+	return lines
+}
+
+// signatureAPI renders the parameters and results of a function type
+func signatureAPI(ft *ast.FuncType) string {
+	sig := "(" + fieldsAPI(ft.Params) + ")"
+	if ft.Results == nil {
+		return sig
+	}
+	results := fieldsAPI(ft.Results)
+	if len(ft.Results.List) == 1 && len(ft.Results.List[0].Names) <= 1 {
+		return sig + " " + results
+	}
+	return sig + " (" + results + ")"
+}
+
+// typeParamsAPI renders type parameters, if any
+func typeParamsAPI(params *ast.FieldList) string {
+	if params == nil {
+		return ""
+	}
+	var list []string
+	for _, f := range params.List {
+		for _, n := range f.Names {
+			list = append(list, n.Name+" "+types.ExprString(f.Type))
+		}
+	}
+	return "[" + strings.Join(list, ", ") + "]"
+}
+
+// fieldsAPI renders the types of a parameter list, one per parameter
+func fieldsAPI(fields *ast.FieldList) string {
+	if fields == nil {
+		return ""
+	}
+	var list []string
+	for _, f := range fields.List {
+		typ := types.ExprString(f.Type)
+		for range max(len(f.Names), 1) {
+			list = append(list, typ)
+		}
+	}
+	return strings.Join(list, ", ")
+}
+
+// TestV1CallSites pins the behavior of the original call forms on a catalog
+// in the original file format, without meta.schema_version
+func TestV1CallSites(t *testing.T) {
+	dict, err := LoadDictionaryBytes([]byte(`{
+		"meta": {"lang": "fr", "name": "default"},
+		"translations": {
+			"hello-0": "Bonjour {0}",
+			"dashboard": "Tableau de bord",
+			"welcome_user": "Bienvenue {0} !",
+			"item_count": "{count, plural, one {# élément} other {# éléments}}"
+		}
+	}`))
+	if err != nil {
+		t.Fatalf("Failed to load a v1 catalog: %v", err)
+	}
+	b := NewBundle()
+	b.Register(dict)
+
+	for _, tt := range []struct{ got, want string }{
+		{b.F("Hello %s", "Anne")("fr"), "Bonjour Anne"},
+		{b.S("Dashboard")("fr"), "Tableau de bord"},
+		{b.T("welcome_user", "Anne")("fr"), "Bienvenue Anne !"},
+		{b.P("item_count", 3)("fr"), "3 éléments"},
+		{b.R("fr", "Dashboard"), "Tableau de bord"},
+		{b.S("Dashboard")("de"), "Dashboard"},
+	} {
+		if tt.got != tt.want {
+			t.Errorf("Expected %q, got %q", tt.want, tt.got)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package i18n
+
+import (
+	"embed"
+	"path"
+)
+
+// baseCatalogs holds the built-in catalogs of common UI strings
+//
+//go:embed base/*.json
+var baseCatalogs embed.FS
+
+// UseBaseCatalog enables the built-in catalogs of common UI strings in the
+// default bundle. See Bundle.UseBaseCatalog.
+func UseBaseCatalog() error {
+	return defaultBundle.UseBaseCatalog()
+}
+
+// UseBaseCatalog enables the built-in catalogs of common UI strings (buttons
+// such as Save, Cancel and Delete, validation messages and date words) for
+// about twenty languages. Their keys are those generated by S and F:
+//
+//	i18n.S("Save")("de")                                  // "Speichern"
+//	i18n.F("Must be at least %d characters", 8)("fr")      // "Doit contenir au moins 8 caractères"
+//
+// Registered dictionaries always take precedence: a base catalog is only
+// consulted for keys missing from the dictionary of its language, and stands
+// in for languages without a registered dictionary.
+func (b *Bundle) UseBaseCatalog() error {
+	files, err := baseCatalogs.ReadDir("base")
+	if err != nil {
+		return err
+	}
+
+	base := make(map[string]*Dictionary, len(files))
+	for _, file := range files {
+		name := path.Join("base", file.Name())
+		data, err := baseCatalogs.ReadFile(name)
+		if err != nil {
+			return err
+		}
+
+		tf, err := decodeTranslationFile(data)
+		if err != nil {
+			return withPath(err, name, "invalid base catalog")
+		}
+
+		dict := NewDictionary(tf.Meta.Lang)
+		dict.AddAll(tf.Translations)
+		dict.bundle = b
+		base[dict.Lang] = dict
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.base = base
+	return nil
+}
+
+// baseDictionary returns the base catalog of a language, or nil if base
+// catalogs are not enabled or do not cover the language
+func (b *Bundle) baseDictionary(lang string) *Dictionary {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.base[lang]
+}
@@ -0,0 +1,81 @@
+package i18n
+
+import (
+	"sort"
+	"testing"
+)
+
+func TestUseBaseCatalog(t *testing.T) {
+	b := NewBundle()
+
+	if result := b.S("Save")("de"); result != "Save" {
+		t.Errorf("Expected base catalogs to be disabled by default, got '%s'", result)
+	}
+
+	if err := b.UseBaseCatalog(); err != nil {
+		t.Fatalf("UseBaseCatalog failed: %v", err)
+	}
+
+	en := NewDictionary("en")
+	en.AddAll(map[string]string{"welcome": "Welcome", "save": "Save changes"})
+	b.Register(en)
+
+	de := NewDictionary("de")
+	de.Add("welcome", "Willkommen")
+	b.Register(de)
+
+	tests := []struct {
+		name     string
+		result   string
+		expected string
+	}{
+		{"registered language", b.S("Cancel")("de"), "Abbrechen"},
+		{"unregistered language", b.S("Delete")("ja"), "削除"},
+		{"placeholders", b.F("Must be at least %d characters", 8)("fr"), "Doit contenir au moins 8 caractères"},
+		{"override", b.S("Save")("en"), "Save changes"},
+		{"app keys first", b.S("Welcome")("de"), "Willkommen"},
+		{"app fallback from base language", b.S("Welcome")("fr"), "Welcome"},
+		{"parent language", b.S("Today")("pt-BR"), "Hoje"},
+	}
+
+	for _, tt := range tests {
+		if tt.result != tt.expected {
+			t.Errorf("%s: expected '%s', got '%s'", tt.name, tt.expected, tt.result)
+		}
+	}
+
+	if languages := b.Languages(); len(languages) != 2 {
+		t.Errorf("Expected base catalogs not to be listed as languages, got %v", languages)
+	}
+}
+
+func TestBaseCatalogs_Consistent(t *testing.T) {
+	b := NewBundle()
+	if err := b.UseBaseCatalog(); err != nil {
+		t.Fatalf("UseBaseCatalog failed: %v", err)
+	}
+
+	if len(b.base) < 20 {
+		t.Errorf("Expected at least 20 base catalogs, got %d", len(b.base))
+	}
+
+	reference := b.base["en"].Keys()
+	sort.Strings(reference)
+	for lang, dict := range b.base {
+		keys := dict.Keys()
+		sort.Strings(keys)
+		if len(keys) != len(reference) {
+			t.Errorf("Base catalog '%s' has %d keys, expected %d", lang, len(keys), len(reference))
+			continue
+		}
+		for i := range keys {
+			if keys[i] != reference[i] {
+				t.Errorf("Base catalog '%s' has key '%s', expected '%s'", lang, keys[i], reference[i])
+				break
+			}
+		}
+		if err := dict.Validate(); err != nil {
+			t.Errorf("Base catalog '%s' is invalid: %v", lang, err)
+		}
+	}
+}
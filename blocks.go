@@ -0,0 +1,209 @@
+package i18n
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// blockSource resolves the flattened fields of an object value
+type blockSource struct {
+	lookup func(key string) (string, bool)
+	slice  func(key string) []string
+}
+
+// GetInto decodes the object value stored under key into dst, which must be a
+// pointer to a struct. The struct acts as the schema of the content block:
+// every field is resolved independently from "key.field", so a field missing
+// in this dictionary falls back to the default language while the others stay
+// localized.
+//
+// Field names come from the `i18n` tag, then the `json` tag, then the lowercased
+// field name. Fields tagged `i18n:",required"` must resolve, and `i18n:"-"`
+// skips a field. Supported field types are string, []string, signed and
+// unsigned integers, bool, time.Duration and nested structs; a number that
+// does not fit its field is an error.
+//
+// Example:
+//
+//	type Banner struct {
+//		Title string `i18n:"title,required"`
+//		Body  string `i18n:"body"`
+//		CTA   string `i18n:"cta"`
+//	}
+//
+//	var banner Banner
+//	err := dict.GetInto("empty_state", &banner)
+//
+// Dictionary should contain:
+//
+//	"empty_state": {"title": "Nothing here yet", "body": "Create your first project", "cta": "New project"}
+func (d *Dictionary) GetInto(key string, dst any) error {
+	return decodeBlock(blockSource{lookup: d.lookup, slice: d.GetSlice}, key, dst)
+}
+
+// GetInto decodes the object value of a key for a locale into dst
+func GetInto(locale, key string, dst any) error {
+	src := blockSource{
+		lookup: func(k string) (string, bool) { return defaultBundle.lookup(locale, k) },
+		slice:  func(k string) []string { return GetSlice(locale, k) },
+	}
+	return decodeBlock(src, key, dst)
+}
+
+// decodeBlock fills the struct pointed to by dst from the fields of key
+func decodeBlock(src blockSource, key string, dst any) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("GetInto requires a non-nil pointer to a struct, got %T", dst)
+	}
+
+	var missing []string
+	found, err := decodeStruct(src, key, v.Elem(), &missing)
+	if err != nil {
+		return err
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("%w: '%s' is missing required field(s) %s",
+			ErrKeyNotFound, key, strings.Join(missing, ", "))
+	}
+	if found == 0 {
+		return fmt.Errorf("%w: '%s'", ErrKeyNotFound, key)
+	}
+	return nil
+}
+
+// decodeStruct resolves every field of v and returns how many were found
+func decodeStruct(src blockSource, prefix string, v reflect.Value, missing *[]string) (int, error) {
+	found := 0
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, required := blockFieldName(field)
+		if name == "-" {
+			continue
+		}
+
+		key := prefix + KeySeparator + name
+		ok, err := decodeField(src, key, v.Field(i), missing)
+		if err != nil {
+			return found, err
+		}
+
+		if ok {
+			found++
+		} else if required {
+			*missing = append(*missing, name)
+		}
+	}
+	return found, nil
+}
+
+// decodeField resolves a single field and reports whether a value was found
+func decodeField(src blockSource, key string, fv reflect.Value, missing *[]string) (bool, error) {
+	if fv.Type() == durationType {
+		value, ok := src.lookup(key)
+		if !ok {
+			return false, nil
+		}
+		d, err := parseDuration(key, value, ok)
+		if err != nil {
+			return false, err
+		}
+		fv.SetInt(int64(d))
+		return true, nil
+	}
+
+	switch fv.Kind() {
+	case reflect.String:
+		value, ok := src.lookup(key)
+		if ok {
+			fv.SetString(value)
+		}
+		return ok, nil
+
+	case reflect.Slice:
+		if fv.Type().Elem().Kind() != reflect.String {
+			return false, fmt.Errorf("unsupported field type %s for '%s'", fv.Type(), key)
+		}
+		list := src.slice(key)
+		if list == nil {
+			return false, nil
+		}
+		fv.Set(reflect.ValueOf(list).Convert(fv.Type()))
+		return true, nil
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		value, ok := src.lookup(key)
+		if !ok {
+			return false, nil
+		}
+		n, err := parseInt(key, value, ok)
+		if err != nil {
+			return false, err
+		}
+		if fv.OverflowInt(int64(n)) {
+			return false, fmt.Errorf("translation key '%s' overflows %s: %d", key, fv.Type(), n)
+		}
+		fv.SetInt(int64(n))
+		return true, nil
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		value, ok := src.lookup(key)
+		if !ok {
+			return false, nil
+		}
+		n, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return false, fmt.Errorf("translation key '%s' is not an unsigned integer: %w", key, err)
+		}
+		if fv.OverflowUint(n) {
+			return false, fmt.Errorf("translation key '%s' overflows %s: %d", key, fv.Type(), n)
+		}
+		fv.SetUint(n)
+		return true, nil
+
+	case reflect.Bool:
+		value, ok := src.lookup(key)
+		if !ok {
+			return false, nil
+		}
+		b, err := parseBool(key, value, ok)
+		if err != nil {
+			return false, err
+		}
+		fv.SetBool(b)
+		return true, nil
+
+	case reflect.Struct:
+		found, err := decodeStruct(src, key, fv, missing)
+		return found > 0, err
+
+	default:
+		return false, fmt.Errorf("unsupported field type %s for '%s'", fv.Type(), key)
+	}
+}
+
+// blockFieldName returns the catalog name of a struct field and whether it is required
+func blockFieldName(field reflect.StructField) (string, bool) {
+	name, opts, _ := strings.Cut(field.Tag.Get("i18n"), ",")
+	required := opts == "required"
+
+	if name == "" {
+		name, _, _ = strings.Cut(field.Tag.Get("json"), ",")
+	}
+	if name == "" {
+		name = strings.ToLower(field.Name)
+	}
+	return name, required
+}
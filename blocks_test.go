@@ -0,0 +1,198 @@
+package i18n
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testBanner struct {
+	Title   string `i18n:"title,required"`
+	Body    string `json:"body"`
+	CTA     string
+	Tips    []string `i18n:"tips"`
+	Weight  int      `i18n:"weight"`
+	Dismiss bool     `i18n:"dismissible"`
+	Delay   time.Duration
+	Ignored string `i18n:"-"`
+	Link    struct {
+		Label string `i18n:"label"`
+		URL   string `i18n:"url"`
+	} `i18n:"link"`
+}
+
+func TestLoadDictionaryFile_ObjectValues(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "blocks.json")
+	content := `{
+  "meta": {"lang": "en", "name": "default"},
+  "translations": {
+    "empty_state": {
+      "title": "Nothing here yet",
+      "body": "Create your first project",
+      "link": {"label": "Docs", "url": "https://example.com"}
+    }
+  }
+}`
+	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	dict, err := LoadDictionaryFile(filePath)
+	if err != nil {
+		t.Fatalf("Failed to load dictionary: %v", err)
+	}
+
+	expected := map[string]string{
+		"empty_state.title":      "Nothing here yet",
+		"empty_state.body":       "Create your first project",
+		"empty_state.link.label": "Docs",
+	}
+	for key, value := range expected {
+		if got := dict.Get(key); got != value {
+			t.Errorf("Expected '%s' for key '%s', got '%s'", value, key, got)
+		}
+	}
+}
+
+func TestDictionaryGetInto(t *testing.T) {
+	defaultBundle.mu.Lock()
+	defaultBundle.dictionaries = make(map[string]*Dictionary)
+	defaultBundle.mu.Unlock()
+	defer func() {
+		defaultBundle.mu.Lock()
+		defaultBundle.dictionaries = make(map[string]*Dictionary)
+		defaultBundle.mu.Unlock()
+	}()
+	SetDefaultLanguage("en")
+
+	enDict := NewDictionary("en")
+	enDict.AddAll(map[string]string{
+		"banner.title":       "Welcome",
+		"banner.body":        "Thanks for joining",
+		"banner.cta":         "Get started",
+		"banner.weight":      "3",
+		"banner.dismissible": "true",
+		"banner.delay":       "5s",
+		"banner.ignored":     "should not be read",
+		"banner.link.label":  "Docs",
+		"banner.link.url":    "https://example.com",
+	})
+	enDict.AddSlice("banner.tips", []string{"Tip one"})
+	Register(enDict)
+
+	frDict := NewDictionary("fr")
+	frDict.AddAll(map[string]string{
+		"banner.title":      "Bienvenue",
+		"banner.link.label": "Documentation",
+	})
+	Register(frDict)
+
+	var banner testBanner
+	if err := frDict.GetInto("banner", &banner); err != nil {
+		t.Fatalf("GetInto failed: %v", err)
+	}
+
+	if banner.Title != "Bienvenue" {
+		t.Errorf("Expected localized title, got '%s'", banner.Title)
+	}
+	if banner.Body != "Thanks for joining" || banner.CTA != "Get started" {
+		t.Errorf("Expected per-field fallback for body and cta, got %+v", banner)
+	}
+	if len(banner.Tips) != 1 || banner.Weight != 3 || !banner.Dismiss || banner.Delay != 5*time.Second {
+		t.Errorf("Unexpected typed fields: %+v", banner)
+	}
+	if banner.Ignored != "" {
+		t.Errorf("Expected ignored field to be skipped, got '%s'", banner.Ignored)
+	}
+	if banner.Link.Label != "Documentation" || banner.Link.URL != "https://example.com" {
+		t.Errorf("Unexpected nested struct: %+v", banner.Link)
+	}
+
+	// Package-level accessor with unknown locale uses the default language
+	var fallback testBanner
+	if err := GetInto("ja", "banner", &fallback); err != nil {
+		t.Fatalf("GetInto failed: %v", err)
+	}
+	if fallback.Title != "Welcome" {
+		t.Errorf("Expected default title, got '%s'", fallback.Title)
+	}
+}
+
+func TestDictionaryGetInto_Errors(t *testing.T) {
+	dict := NewDictionary("en")
+	dict.AddAll(map[string]string{
+		"partial.body":  "Body only",
+		"invalid.title": "Title",
+		"invalid.delay": "soon",
+	})
+
+	var banner testBanner
+	if err := dict.GetInto("partial", &banner); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("Expected missing required field error, got %v", err)
+	}
+	if err := dict.GetInto("missing", &banner); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("Expected ErrKeyNotFound for missing block, got %v", err)
+	}
+	if err := dict.GetInto("invalid", &banner); err == nil || errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("Expected parse error, got %v", err)
+	}
+	if err := dict.GetInto("partial", banner); err == nil {
+		t.Error("Expected error for non-pointer destination, got nil")
+	}
+
+	var unsupported struct {
+		Rate float64 `i18n:"body"`
+	}
+	if err := dict.GetInto("partial", &unsupported); err == nil {
+		t.Error("Expected error for unsupported field type, got nil")
+	}
+}
+
+func TestDictionaryGetInto_Integers(t *testing.T) {
+	dict := NewDictionary("en")
+	dict.AddAll(map[string]string{
+		"limits.small":    "127",
+		"limits.port":     "8080",
+		"limits.big":      "128",
+		"limits.negative": "-1",
+		"limits.huge":     "70000",
+	})
+
+	var ok struct {
+		Small int8   `i18n:"small"`
+		Port  uint16 `i18n:"port"`
+	}
+	if err := dict.GetInto("limits", &ok); err != nil {
+		t.Fatalf("GetInto failed: %v", err)
+	}
+	if ok.Small != 127 || ok.Port != 8080 {
+		t.Errorf("Expected 127 and 8080, got %d and %d", ok.Small, ok.Port)
+	}
+
+	tests := []struct {
+		name string
+		dst  any
+		want string
+	}{
+		{"int8 overflow", &struct {
+			V int8 `i18n:"big"`
+		}{}, "overflows int8: 128"},
+		{"uint16 overflow", &struct {
+			V uint16 `i18n:"huge"`
+		}{}, "overflows uint16: 70000"},
+		{"negative unsigned", &struct {
+			V uint `i18n:"negative"`
+		}{}, "is not an unsigned integer"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := dict.GetInto("limits", tt.dst)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Expected an error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package i18n
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// AllLocales is the SizeBudget key that applies to every locale without its own budget
+const AllLocales = "*"
+
+// SizeBudget maps a language code to the maximum number of bytes a served
+// catalog may have. The AllLocales key sets the budget for all other locales.
+type SizeBudget map[string]int
+
+// BudgetViolation describes a catalog file that exceeds its size budget
+type BudgetViolation struct {
+	File   string `json:"file"`
+	Lang   string `json:"lang"`
+	Size   int    `json:"size"`
+	Budget int    `json:"budget"`
+}
+
+func (v BudgetViolation) String() string {
+	return fmt.Sprintf("%s (%s): %s exceeds budget of %s",
+		v.File, v.Lang, FormatSize(v.Size), FormatSize(v.Budget))
+}
+
+// For returns the budget for a language, or 0 if the language is unlimited
+func (b SizeBudget) For(lang string) int {
+	if limit, ok := b[lang]; ok {
+		return limit
+	}
+	return b[AllLocales]
+}
+
+// CatalogSize returns the number of bytes the translations occupy when
+// served to clients as compact JSON
+func CatalogSize(translations map[string]string) int {
+	data, err := json.Marshal(translations)
+	if err != nil {
+		return 0
+	}
+	return len(data)
+}
+
+// CheckBudget measures every catalog file (namespace) in dir against the budget
+// of its language and returns the files that exceed it.
+func CheckBudget(dir string, budget SizeBudget) ([]BudgetViolation, error) {
+	files, err := catalogFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var violations []BudgetViolation
+	for _, file := range files {
+		tf, err := readTranslationFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		limit := budget.For(tf.Meta.Lang)
+		if limit <= 0 {
+			continue
+		}
+
+		if size := CatalogSize(tf.Translations); size > limit {
+			violations = append(violations, BudgetViolation{
+				File:   filepath.Base(file),
+				Lang:   tf.Meta.Lang,
+				Size:   size,
+				Budget: limit,
+			})
+		}
+	}
+	return violations, nil
+}
+
+// ParseSize parses a byte size such as "512", "64KB", "1.5MB" or "2 KiB"
+// (1KB = 1KiB = 1024 bytes)
+func ParseSize(s string) (int, error) {
+	units := []struct {
+		suffix string
+		factor float64
+	}{
+		{"GIB", 1 << 30},
+		{"MIB", 1 << 20},
+		{"KIB", 1 << 10},
+		{"GB", 1 << 30},
+		{"MB", 1 << 20},
+		{"KB", 1 << 10},
+		{"B", 1},
+	}
+
+	value := strings.ToUpper(strings.TrimSpace(s))
+	factor := 1.0
+	for _, u := range units {
+		if strings.HasSuffix(value, u.suffix) {
+			value = strings.TrimSpace(strings.TrimSuffix(value, u.suffix))
+			factor = u.factor
+			break
+		}
+	}
+
+	n, err := strconv.ParseFloat(value, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid size '%s'", s)
+	}
+	return int(n * factor), nil
+}
+
+// FormatSize renders a byte count with English binary units ("2 KiB"), which
+// ParseSize reads back. Use FormatBytes for other locales.
+func FormatSize(n int) string {
+	return FormatBytes("en", int64(n), true)
+}
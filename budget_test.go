@@ -0,0 +1,82 @@
+package i18n
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCheckBudget(t *testing.T) {
+	dir := t.TempDir()
+	writeTestCatalog(t, dir, "default.en.json", "en", map[string]string{"hello": "Hello"})
+	writeTestCatalog(t, dir, "default.fr.json", "fr", map[string]string{"hello": strings.Repeat("x", 200)})
+	writeTestCatalog(t, dir, "default.de.json", "de", map[string]string{"hello": strings.Repeat("x", 200)})
+
+	budget := SizeBudget{AllLocales: 100, "de": 500}
+	violations, err := CheckBudget(dir, budget)
+	if err != nil {
+		t.Fatalf("CheckBudget failed: %v", err)
+	}
+
+	if len(violations) != 1 {
+		t.Fatalf("Expected 1 violation, got %v", violations)
+	}
+	if violations[0].File != "default.fr.json" || violations[0].Budget != 100 {
+		t.Errorf("Unexpected violation: %+v", violations[0])
+	}
+	if violations[0].Size != CatalogSize(map[string]string{"hello": strings.Repeat("x", 200)}) {
+		t.Errorf("Unexpected size %d", violations[0].Size)
+	}
+
+	// Locales without a budget are unlimited
+	violations, err = CheckBudget(dir, SizeBudget{"en": 1})
+	if err != nil {
+		t.Fatalf("CheckBudget failed: %v", err)
+	}
+	if len(violations) != 1 || violations[0].Lang != "en" {
+		t.Errorf("Expected only en to be checked, got %v", violations)
+	}
+}
+
+func TestParseSize(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int
+		wantErr  bool
+	}{
+		{"512", 512, false},
+		{"512B", 512, false},
+		{"64KB", 64 * 1024, false},
+		{"64kb", 64 * 1024, false},
+		{"1.5MB", 3 * 1024 * 1024 / 2, false},
+		{"2 KiB", 2 * 1024, false},
+		{"1GB", 1 << 30, false},
+		{"", 0, true},
+		{"lots", 0, true},
+		{"-1KB", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result, err := ParseSize(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseSize(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if result != tt.expected {
+				t.Errorf("ParseSize(%q) = %d, expected %d", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFormatSize(t *testing.T) {
+	tests := map[int]string{
+		100:             "100 B",
+		1536:            "1.5 KiB",
+		3 * 1024 * 1024: "3 MiB",
+	}
+	for n, expected := range tests {
+		if result := FormatSize(n); result != expected {
+			t.Errorf("FormatSize(%d) = %q, expected %q", n, result, expected)
+		}
+	}
+}
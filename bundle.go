@@ -0,0 +1,445 @@
+package i18n
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"path/filepath"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// Bundle is an isolated set of dictionaries with its own default language.
+// Several bundles can coexist (per tenant, per test) without sharing state.
+// The package-level functions (Register, T, F, S, P, R, ...) operate on a
+// default bundle returned by DefaultBundle.
+//
+// Example:
+//
+//	tenant := i18n.NewBundle()
+//	tenant.SetDefaultLanguage("fr")
+//	tenant.LoadFrom("tenants/acme/default.fr.json")
+//
+//	title := tenant.S("Dashboard")
+//	fmt.Println(title("fr")) // "Tableau de bord"
+type Bundle struct {
+	dictionaries map[string]*Dictionary
+	defaultLang  string
+	fallbacks    map[string][]string
+	base         map[string]*Dictionary // built-in catalogs, see UseBaseCatalog
+	onChange     []func(lang string)
+	onMissing    []func(locale, key string)
+	onReplace    []func(lang string)
+	lazy         *lazyLoader  // set by LoadLazily
+	debug        bool         // see SetDebug
+	escape       EscapeMode   // see SetEscapeMode
+	excludeFuzzy atomic.Bool  // see SetExcludeFuzzy
+	minState     atomic.Int32 // rank of the least State served, see SetMinState
+	mu           sync.RWMutex
+}
+
+// defaultBundle backs the package-level API
+var defaultBundle = NewBundle()
+
+// NewBundle creates an empty bundle using DefaultLang as fallback language
+func NewBundle() *Bundle {
+	b := &Bundle{
+		dictionaries: make(map[string]*Dictionary),
+		defaultLang:  DefaultLang,
+		fallbacks:    make(map[string][]string),
+		debug:        debugFromEnv(),
+	}
+	b.minState.Store(minStateFromEnv())
+	return b
+}
+
+// DefaultBundle returns the bundle used by the package-level functions
+func DefaultBundle() *Bundle {
+	return defaultBundle
+}
+
+// SetDefaultLanguage sets the fallback language code of the bundle
+func (b *Bundle) SetDefaultLanguage(lang string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.defaultLang = lang
+}
+
+// DefaultLanguage returns the fallback language of the bundle
+func (b *Bundle) DefaultLanguage() string {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.defaultLang
+}
+
+// Register adds a dictionary to the bundle. A dictionary falls back to the
+// default language of the bundle it was last registered in.
+//
+// A dictionary already registered for the language is replaced. As that is
+// rarely intended outside reloads, the replacement is logged as a warning and
+// reported to the OnReplace listeners; use RegisterNew to refuse it or
+// RegisterMerged to keep both.
+func (b *Bundle) Register(dict *Dictionary) {
+	if previous := b.set(dict); previous != nil && previous != dict {
+		logger().Warn("dictionary replaced", "lang", dict.Lang)
+		b.notifyReplace(dict.Lang)
+	}
+}
+
+// ErrLanguageRegistered is returned by RegisterNew for a language that
+// already has a dictionary
+var ErrLanguageRegistered = errors.New("language already registered")
+
+// RegisterNew adds a dictionary to the bundle unless its language already
+// has one, in which case it returns an error wrapping ErrLanguageRegistered
+// and leaves the registered dictionary in place.
+//
+// Example:
+//
+//	if err := bundle.RegisterNew(dict); errors.Is(err, i18n.ErrLanguageRegistered) {
+//		return fmt.Errorf("plugin %s: %w", name, err)
+//	}
+func (b *Bundle) RegisterNew(dict *Dictionary) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.dictionaries[dict.Lang]; ok {
+		return fmt.Errorf("language '%s': %w", dict.Lang, ErrLanguageRegistered)
+	}
+
+	dict.mu.Lock()
+	dict.bundle = b
+	dict.mu.Unlock()
+	b.dictionaries[dict.Lang] = dict
+	return nil
+}
+
+// MustRegister is like RegisterNew but panics if the language already has a
+// dictionary, for registrations at program start
+func (b *Bundle) MustRegister(dict *Dictionary) {
+	if err := b.RegisterNew(dict); err != nil {
+		panic(err)
+	}
+}
+
+// RegisterMerged adds the translations, lists and scheduled values of dict
+// to the dictionary registered for its language, dict's values winning, or
+// registers dict if there is none. The merged dictionary is a new one
+// swapped in one step; neither dict nor the previous dictionary is modified.
+//
+// Example:
+//
+//	// Two packages contribute English strings without clobbering each other
+//	bundle.RegisterMerged(billingEN)
+//	bundle.RegisterMerged(accountsEN)
+func (b *Bundle) RegisterMerged(dict *Dictionary) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	merged := dict
+	if current, ok := b.dictionaries[dict.Lang]; ok {
+		merged = current.Clone()
+		merged.merge(dict)
+	}
+	merged.mu.Lock()
+	merged.bundle = b
+	merged.mu.Unlock()
+	b.dictionaries[dict.Lang] = merged
+}
+
+// OnReplace registers fn to be called with the language of every dictionary
+// Register replaces with a different one. Reloads (ReloadLanguage,
+// LoadDirLanguage, ReplaceAll) replace dictionaries on purpose and call
+// OnChange listeners instead.
+func (b *Bundle) OnReplace(fn func(lang string)) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.onReplace = append(b.onReplace, fn)
+}
+
+// notifyReplace calls the OnReplace listeners
+func (b *Bundle) notifyReplace(lang string) {
+	b.mu.RLock()
+	listeners := b.onReplace
+	b.mu.RUnlock()
+
+	for _, fn := range listeners {
+		fn(lang)
+	}
+}
+
+// set registers dict and returns the dictionary it replaces, if any
+func (b *Bundle) set(dict *Dictionary) *Dictionary {
+	dict.mu.Lock()
+	dict.bundle = b
+	dict.mu.Unlock()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	previous := b.dictionaries[dict.Lang]
+	b.dictionaries[dict.Lang] = dict
+	return previous
+}
+
+// Unregister removes the dictionary of a language from the bundle. Lookups
+// in that language fall back as if it had never been registered.
+func (b *Bundle) Unregister(lang string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.dictionaries, lang)
+}
+
+// Reset restores the bundle to the state of a new bundle
+func (b *Bundle) Reset() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.dictionaries = make(map[string]*Dictionary)
+	b.defaultLang = DefaultLang
+	b.fallbacks = make(map[string][]string)
+	b.base = nil
+	b.onChange = nil
+	b.onMissing = nil
+	b.onReplace = nil
+	b.lazy = nil
+	b.debug = debugFromEnv()
+	b.escape = EscapeHTML
+	b.excludeFuzzy.Store(false)
+	b.minState.Store(minStateFromEnv())
+}
+
+// RegisterStrict validates every template of a dictionary (see
+// Dictionary.Validate) and adds it to the bundle only if all of them are
+// well-formed
+func (b *Bundle) RegisterStrict(dict *Dictionary) error {
+	if err := dict.Validate(); err != nil {
+		logger().Warn("dictionary failed validation", "lang", dict.Lang, "error", err)
+		return err
+	}
+	b.Register(dict)
+	return nil
+}
+
+// ReplaceAll swaps every dictionary of the bundle for dicts, registered under
+// their Lang, in one step: concurrent lookups see either the old set or the
+// new one, never a mix. Build the new dictionaries completely before calling
+// it, for example on SIGHUP:
+//
+//	dicts := map[string]*i18n.Dictionary{}
+//	for _, path := range paths {
+//		dict, err := i18n.LoadDictionaryFile(path)
+//		if err != nil {
+//			return err // keep serving the current dictionaries
+//		}
+//		dicts[dict.Lang] = dict
+//	}
+//	bundle.ReplaceAll(dicts)
+func (b *Bundle) ReplaceAll(dicts map[string]*Dictionary) {
+	b.mu.RLock()
+	current := maps.Clone(b.dictionaries)
+	b.mu.RUnlock()
+
+	var changed []string
+	replaced := make(map[string]*Dictionary, len(dicts))
+	for _, dict := range dicts {
+		if dict == nil {
+			continue
+		}
+		// Keep the registered dictionary when the content is identical
+		if old := current[dict.Lang]; old != nil && old.Hash() == dict.Hash() {
+			replaced[dict.Lang] = old
+			continue
+		}
+		dict.mu.Lock()
+		dict.bundle = b
+		dict.mu.Unlock()
+		replaced[dict.Lang] = dict
+		changed = append(changed, dict.Lang)
+	}
+	for lang := range current {
+		if _, ok := replaced[lang]; !ok {
+			changed = append(changed, lang)
+		}
+	}
+
+	b.mu.Lock()
+	b.dictionaries = replaced
+	b.mu.Unlock()
+
+	sort.Strings(changed)
+	b.notifyChange(changed...)
+}
+
+// OnChange registers fn to be called with the language of every dictionary
+// that a reload (ReloadLanguage, LoadDirLanguage, ReplaceAll) actually
+// changes, added or removed. Reloads whose content hashes the same as the
+// registered dictionary are skipped and call nothing, so touching a file does
+// not invalidate caches.
+func (b *Bundle) OnChange(fn func(lang string)) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.onChange = append(b.onChange, fn)
+}
+
+// notifyChange calls the OnChange listeners for each language
+func (b *Bundle) notifyChange(langs ...string) {
+	b.mu.RLock()
+	listeners := b.onChange
+	b.mu.RUnlock()
+
+	for _, lang := range langs {
+		for _, fn := range listeners {
+			fn(lang)
+		}
+	}
+}
+
+// OnMissing registers fn to be called with the locale and key of every
+// translation that no dictionary in the locale's fallback chain has, when a
+// function renders the key or source text instead. Dictionary.Get reports
+// the dictionary's language. fn runs on the rendering goroutine, so it should
+// be quick and safe for concurrent use.
+//
+// Example:
+//
+//	bundle.OnMissing(func(locale, key string) {
+//		missingTotal.WithLabelValues(locale).Inc()
+//	})
+func (b *Bundle) OnMissing(fn func(locale, key string)) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.onMissing = append(b.onMissing, fn)
+}
+
+// notifyMissing calls the OnMissing listeners
+func (b *Bundle) notifyMissing(locale, key string) {
+	b.mu.RLock()
+	listeners := b.onMissing
+	b.mu.RUnlock()
+
+	logger().Debug("missing translation", "locale", locale, "key", key)
+	for _, fn := range listeners {
+		fn(locale, key)
+	}
+}
+
+// find returns the translation of the first of keys found for locale, and
+// the dictionary the locale resolves to. If none is found, the first key is
+// reported to the OnMissing listeners. Lookups are counted (see SetMetrics)
+// and fallbacks logged.
+func (b *Bundle) find(locale string, keys ...string) (string, *Dictionary, bool) {
+	m := metrics()
+	if m != nil {
+		m.Lookup(locale)
+	}
+
+	dict := b.dictionaryFor(locale)
+	if dict != nil {
+		if value, ok := dict.lookupFirst(keys...); ok {
+			l := logger()
+			debug := l.Enabled(context.Background(), slog.LevelDebug)
+			if m != nil || debug {
+				if lang, _ := dict.source(keys...); lang != locale {
+					if m != nil {
+						m.Fallback(locale, lang)
+					}
+					if debug {
+						l.Debug("translation from fallback language", "locale", locale, "key", keys[0], "lang", lang)
+					}
+				}
+			}
+			return value, dict, true
+		}
+	}
+
+	if m != nil {
+		m.Missing(locale, keys[0])
+	}
+	b.notifyMissing(locale, keys[0])
+	return "", dict, false
+}
+
+// GetDictionary returns a dictionary of the bundle by language code,
+// loading it first if the bundle loads lazily (see LoadLazily)
+func (b *Bundle) GetDictionary(lang string) *Dictionary {
+	if dict := b.registered(lang); dict != nil {
+		return dict
+	}
+
+	b.mu.RLock()
+	lazy := b.lazy
+	b.mu.RUnlock()
+	if lazy == nil {
+		return nil
+	}
+	lazy.load(b, lang)
+	return b.registered(lang)
+}
+
+// registered returns the registered dictionary of a language, without loading it
+func (b *Bundle) registered(lang string) *Dictionary {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.dictionaries[lang]
+}
+
+// Languages returns the sorted language codes of all registered dictionaries
+func (b *Bundle) Languages() []string {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return sortedKeys(b.dictionaries)
+}
+
+// Load loads the default dictionary from locales/default.en.json into the bundle
+func (b *Bundle) Load() error {
+	return b.LoadFrom(DefaultFilePath)
+}
+
+// LoadFrom loads a dictionary from a specific path and registers it in the bundle
+func (b *Bundle) LoadFrom(path string) error {
+	dict, err := LoadDictionaryFile(path)
+	if err != nil {
+		return err
+	}
+	b.Register(dict)
+	return nil
+}
+
+// LoadLanguage loads a dictionary for a specific language from locales/default.{lang}.json
+func (b *Bundle) LoadLanguage(lang string) error {
+	path := filepath.Join(DefaultFolder, fmt.Sprintf("%s.%s.json", DefaultDictionary, lang))
+	return b.LoadFrom(path)
+}
+
+// ReloadLanguage reloads every {name}.{lang}.json file of the default folder
+// and swaps the merged dictionary into the bundle in one step (see
+// LoadDirLanguage), unless the content is unchanged. If a file fails to load,
+// the current dictionary stays registered and the error is returned.
+func (b *Bundle) ReloadLanguage(lang string) error {
+	return b.LoadDirLanguage(DefaultFolder, lang)
+}
+
+// dictionaryFor returns the dictionary of a locale, or the first dictionary
+// of its fallback chain if the locale is not registered. Base catalogs stand
+// in for languages without a registered dictionary.
+func (b *Bundle) dictionaryFor(locale string) *Dictionary {
+	for _, lang := range append([]string{locale}, b.FallbackChain(locale)...) {
+		if dict := b.GetDictionary(lang); dict != nil {
+			return dict
+		}
+		if dict := b.baseDictionary(lang); dict != nil {
+			return dict
+		}
+	}
+	return nil
+}
+
+// lookup finds a translation for a locale, using its fallback chain when the
+// locale has no dictionary of its own
+func (b *Bundle) lookup(locale, key string) (string, bool) {
+	if dict := b.dictionaryFor(locale); dict != nil {
+		return dict.lookup(key)
+	}
+	return "", false
+}
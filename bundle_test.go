@@ -0,0 +1,392 @@
+package i18n
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestBundleIsolation(t *testing.T) {
+	tenantA := NewBundle()
+	tenantB := NewBundle()
+
+	dictA := NewDictionary("en")
+	dictA.Add("dashboard", "Control Panel")
+	tenantA.Register(dictA)
+
+	dictB := NewDictionary("en")
+	dictB.Add("dashboard", "Overview")
+	tenantB.Register(dictB)
+
+	if result := tenantA.S("Dashboard")("en"); result != "Control Panel" {
+		t.Errorf("Expected 'Control Panel', got '%s'", result)
+	}
+	if result := tenantB.S("Dashboard")("en"); result != "Overview" {
+		t.Errorf("Expected 'Overview', got '%s'", result)
+	}
+
+	// The default bundle is not affected
+	if GetDictionary("en") == dictA || GetDictionary("en") == dictB {
+		t.Error("Expected bundle dictionaries not to leak into the default bundle")
+	}
+}
+
+func TestBundleDefaultLanguage(t *testing.T) {
+	b := NewBundle()
+	if b.DefaultLanguage() != DefaultLang {
+		t.Errorf("Expected default language '%s', got '%s'", DefaultLang, b.DefaultLanguage())
+	}
+
+	original := DefaultLanguage()
+	b.SetDefaultLanguage("fr")
+	if DefaultLanguage() != original {
+		t.Error("Expected SetDefaultLanguage on a bundle not to change the global default")
+	}
+
+	frDict := NewDictionary("fr")
+	frDict.Add("welcome", "Bienvenue")
+	b.Register(frDict)
+
+	deDict := NewDictionary("de")
+	b.Register(deDict)
+
+	// Dictionary fallback uses the default language of its own bundle
+	if result := deDict.Get("welcome"); result != "Bienvenue" {
+		t.Errorf("Expected fallback 'Bienvenue', got '%s'", result)
+	}
+
+	// Unknown locales use the bundle's default dictionary
+	if result := b.T("welcome")("ja"); result != "Bienvenue" {
+		t.Errorf("Expected 'Bienvenue' for unknown locale, got '%s'", result)
+	}
+}
+
+func TestBundleTranslationFunctions(t *testing.T) {
+	b := NewBundle()
+	enDict := NewDictionary("en")
+	enDict.AddAll(map[string]string{
+		"hello-0":    "Hello {0}",
+		"dashboard":  "Dashboard",
+		"item-count": "{count, plural, one {# item} other {# items}}",
+	})
+	b.Register(enDict)
+
+	frDict := NewDictionary("fr")
+	frDict.AddAll(map[string]string{
+		"hello-0":    "Bonjour {0}",
+		"dashboard":  "Tableau de bord",
+		"item-count": "{count, plural, one {# élément} other {# éléments}}",
+	})
+	b.Register(frDict)
+
+	tests := []struct {
+		name     string
+		result   string
+		expected string
+	}{
+		{"T", b.T("hello-0", "John")("fr"), "Bonjour John"},
+		{"F", b.F("Hello %s", "John")("fr"), "Bonjour John"},
+		{"S", b.S("Dashboard")("fr"), "Tableau de bord"},
+		{"P", b.P("item-count", 3)("fr"), "3 éléments"},
+		{"R", b.R("fr", "Dashboard"), "Tableau de bord"},
+	}
+
+	for _, tt := range tests {
+		if tt.result != tt.expected {
+			t.Errorf("%s: expected '%s', got '%s'", tt.name, tt.expected, tt.result)
+		}
+	}
+}
+
+func TestBundleLoadFrom(t *testing.T) {
+	dir := t.TempDir()
+	path := writeTestCatalog(t, dir, "default.fr.json", "fr", map[string]string{"welcome": "Bienvenue"})
+
+	b := NewBundle()
+	if err := b.LoadFrom(path); err != nil {
+		t.Fatalf("LoadFrom failed: %v", err)
+	}
+	if b.GetDictionary("fr") == nil {
+		t.Fatal("Expected fr dictionary to be registered in the bundle")
+	}
+	if GetDictionary("fr") != nil && GetDictionary("fr") == b.GetDictionary("fr") {
+		t.Error("Expected LoadFrom on a bundle not to register globally")
+	}
+
+	if err := b.LoadFrom(filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("Expected error for missing file, got nil")
+	}
+}
+
+func TestBundleReplaceAll(t *testing.T) {
+	b := NewBundle()
+	old := NewDictionary("de")
+	old.Add("title", "Alt")
+	b.Register(old)
+
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				if got := b.T("title")("en"); got != "title" && got != "Dashboard" {
+					t.Errorf("Unexpected translation during swap: %q", got)
+					return
+				}
+			}
+		}
+	}()
+
+	en := NewDictionary("en")
+	en.Add("title", "Dashboard")
+	fr := NewDictionary("fr")
+	fr.Add("title", "Tableau de bord")
+	b.ReplaceAll(map[string]*Dictionary{"en": en, "fr": fr, "xx": nil})
+	close(stop)
+	wg.Wait()
+
+	if got := b.Languages(); len(got) != 2 || got[0] != "en" || got[1] != "fr" {
+		t.Errorf("Expected [en fr], got %v", got)
+	}
+	if b.GetDictionary("de") != nil {
+		t.Error("Expected de to be removed")
+	}
+	if got := b.T("missing")("fr"); got != "missing" {
+		t.Errorf("Expected fr to fall back within the new set, got %q", got)
+	}
+}
+
+func TestBundleReloadLanguage(t *testing.T) {
+	t.Chdir(t.TempDir())
+	if err := os.MkdirAll(DefaultFolder, 0755); err != nil {
+		t.Fatalf("Failed to create folder: %v", err)
+	}
+	writeTestCatalog(t, DefaultFolder, "default.fr.json", "fr", map[string]string{"title": "Tableau"})
+
+	b := NewBundle()
+	if err := b.ReloadLanguage("fr"); err != nil {
+		t.Fatalf("ReloadLanguage failed: %v", err)
+	}
+	writeTestCatalog(t, DefaultFolder, "default.fr.json", "fr", map[string]string{"title": "Tableau de bord"})
+	if err := b.ReloadLanguage("fr"); err != nil {
+		t.Fatalf("ReloadLanguage failed: %v", err)
+	}
+	if got := b.T("title")("fr"); got != "Tableau de bord" {
+		t.Errorf("Expected reloaded translation, got %q", got)
+	}
+
+	os.WriteFile(filepath.Join(DefaultFolder, "default.fr.json"), []byte("{"), 0644)
+	if err := b.ReloadLanguage("fr"); err == nil {
+		t.Error("Expected error for a broken catalog, got nil")
+	}
+	if got := b.T("title")("fr"); got != "Tableau de bord" {
+		t.Errorf("Expected the previous dictionary to stay registered, got %q", got)
+	}
+}
+
+func TestBundleOnChange(t *testing.T) {
+	t.Chdir(t.TempDir())
+	if err := os.MkdirAll(DefaultFolder, 0755); err != nil {
+		t.Fatalf("Failed to create folder: %v", err)
+	}
+	writeTestCatalog(t, DefaultFolder, "default.fr.json", "fr", map[string]string{"title": "Tableau"})
+
+	b := NewBundle()
+	var changed []string
+	b.OnChange(func(lang string) { changed = append(changed, lang) })
+
+	if err := b.ReloadLanguage("fr"); err != nil {
+		t.Fatalf("ReloadLanguage failed: %v", err)
+	}
+	fr := b.GetDictionary("fr")
+
+	// Rewriting the same content is not a change
+	writeTestCatalog(t, DefaultFolder, "default.fr.json", "fr", map[string]string{"title": "Tableau"})
+	if err := b.ReloadLanguage("fr"); err != nil {
+		t.Fatalf("ReloadLanguage failed: %v", err)
+	}
+	if b.GetDictionary("fr") != fr {
+		t.Error("Expected identical content not to be registered again")
+	}
+	if len(changed) != 1 {
+		t.Errorf("Expected one change event, got %v", changed)
+	}
+
+	same := NewDictionary("fr")
+	same.Add("title", "Tableau")
+	en := NewDictionary("en")
+	en.Add("title", "Dashboard")
+	changed = nil
+	b.ReplaceAll(map[string]*Dictionary{"fr": same, "en": en})
+	if b.GetDictionary("fr") != fr {
+		t.Error("Expected ReplaceAll to keep the identical fr dictionary")
+	}
+	if len(changed) != 1 || changed[0] != "en" {
+		t.Errorf("Expected a change event for en only, got %v", changed)
+	}
+
+	changed = nil
+	b.ReplaceAll(map[string]*Dictionary{"en": en})
+	if len(changed) != 1 || changed[0] != "fr" {
+		t.Errorf("Expected a change event for the removed fr, got %v", changed)
+	}
+}
+
+func TestBundleOnMissing(t *testing.T) {
+	b := NewBundle()
+	en := NewDictionary("en")
+	en.AddAll(map[string]string{"title": "Dashboard", "ok": "ok"})
+	fr := NewDictionary("fr")
+	fr.Add("title", "Tableau de bord")
+	b.Register(en)
+	b.Register(fr)
+
+	var missing []string
+	b.OnMissing(func(locale, key string) { missing = append(missing, locale+":"+key) })
+
+	b.T("title")("fr") // translated
+	b.T("ok")("fr")    // falls back to en
+	b.S("Save")("fr")  // no translation anywhere
+	b.T("greeting")("de")
+	b.P("items", 2)("fr")
+	b.R("fr", "Cancel")
+	fr.Get("logout")
+
+	want := []string{"fr:save", "de:greeting", "fr:items", "fr:cancel", "fr:logout"}
+	if !reflect.DeepEqual(missing, want) {
+		t.Errorf("Expected %v, got %v", want, missing)
+	}
+
+	// Without any dictionary every lookup is missing
+	missing = nil
+	b.Reset()
+	b.OnMissing(func(locale, key string) { missing = append(missing, locale+":"+key) })
+	if got := b.F("Hello %s", "Ann")("en"); got != "Hello Ann" {
+		t.Errorf("Expected the format string, got %q", got)
+	}
+	if len(missing) != 1 || missing[0] != "en:hello-0" {
+		t.Errorf("Expected the slug key to be reported, got %v", missing)
+	}
+}
+
+func TestBundleRegisterConflicts(t *testing.T) {
+	b := NewBundle()
+	var replaced []string
+	b.OnReplace(func(lang string) { replaced = append(replaced, lang) })
+
+	billing := NewDictionary("en")
+	billing.AddAll(map[string]string{"invoice": "Invoice", "title": "Billing"})
+	if err := b.RegisterNew(billing); err != nil {
+		t.Fatalf("RegisterNew failed: %v", err)
+	}
+
+	accounts := NewDictionary("en")
+	accounts.AddAll(map[string]string{"profile": "Profile", "title": "Accounts"})
+	err := b.RegisterNew(accounts)
+	if !errors.Is(err, ErrLanguageRegistered) || !strings.Contains(err.Error(), "'en'") {
+		t.Errorf("Expected ErrLanguageRegistered for en, got %v", err)
+	}
+	if b.GetDictionary("en") != billing {
+		t.Error("Expected RegisterNew to keep the registered dictionary")
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("Expected MustRegister to panic on a conflict")
+			}
+		}()
+		b.MustRegister(accounts)
+	}()
+
+	b.RegisterMerged(accounts)
+	merged := b.GetDictionary("en")
+	if merged.Get("invoice") != "Invoice" || merged.Get("profile") != "Profile" || merged.Get("title") != "Accounts" {
+		t.Errorf("Expected both dictionaries merged, later values winning, got %v", merged.Translations)
+	}
+	if billing.Count() != 2 || accounts.Count() != 2 {
+		t.Error("Expected RegisterMerged to leave its inputs unchanged")
+	}
+	if len(replaced) != 0 {
+		t.Errorf("Expected no replacement events so far, got %v", replaced)
+	}
+
+	b.Register(merged) // the same dictionary again is not a replacement
+	b.Register(billing)
+	if len(replaced) != 1 || replaced[0] != "en" {
+		t.Errorf("Expected one replacement event for en, got %v", replaced)
+	}
+}
+
+func TestBundleUnregisterAndReset(t *testing.T) {
+	b := NewBundle()
+	en := NewDictionary("en")
+	en.Add("title", "Dashboard")
+	fr := NewDictionary("fr")
+	fr.Add("title", "Tableau de bord")
+	b.Register(en)
+	b.Register(fr)
+
+	b.Unregister("fr")
+	b.Unregister("missing")
+	if got := b.T("title")("fr"); got != "Dashboard" {
+		t.Errorf("Expected fr to fall back to en, got %q", got)
+	}
+
+	b.SetDefaultLanguage("fr")
+	b.SetFallbacks("pt-BR", "es")
+	b.Reset()
+	if len(b.Languages()) != 0 || b.DefaultLanguage() != DefaultLang {
+		t.Errorf("Expected a fresh bundle, got %v and %q", b.Languages(), b.DefaultLanguage())
+	}
+	if chain := b.FallbackChain("pt-BR"); len(chain) != 2 || chain[0] != "pt" {
+		t.Errorf("Expected the implicit chain, got %v", chain)
+	}
+}
+
+func TestResetRegistry(t *testing.T) {
+	defer ResetRegistry()
+
+	Register(NewDictionary("xx"))
+	SetDefaultLanguage("xx")
+	ResetRegistry()
+	if GetDictionary("xx") != nil || DefaultLanguage() != DefaultLang {
+		t.Error("Expected ResetRegistry to clear the global registry")
+	}
+}
+
+func TestDefaultBundle(t *testing.T) {
+	if DefaultBundle() != defaultBundle {
+		t.Error("Expected DefaultBundle to return the package-level bundle")
+	}
+}
+
+func TestBundleRegisterStrict(t *testing.T) {
+	b := NewBundle()
+
+	dict := NewDictionary("fr")
+	dict.Add("hello-0", "Bonjour {0")
+	if err := b.RegisterStrict(dict); err == nil {
+		t.Error("Expected error for malformed template, got nil")
+	}
+	if b.GetDictionary("fr") != nil {
+		t.Error("Expected invalid dictionary not to be registered")
+	}
+
+	dict.Add("hello-0", "Bonjour {0}")
+	if err := b.RegisterStrict(dict); err != nil {
+		t.Fatalf("RegisterStrict failed: %v", err)
+	}
+	if b.GetDictionary("fr") != dict {
+		t.Error("Expected valid dictionary to be registered")
+	}
+}
@@ -0,0 +1,66 @@
+package i18n
+
+import (
+	"strconv"
+	"strings"
+)
+
+// byteLocale holds the built-in byte unit names of a language
+type byteLocale struct {
+	units  []string // B, kB, MB, ... (powers of 1000)
+	binary []string // B, KiB, MiB, ... (powers of 1024)
+}
+
+// byteLocales are the built-in byte unit names. Locales without an entry use
+// the names of their parent language, then the English (SI) names.
+var byteLocales = map[string]byteLocale{
+	"en": {
+		units:  []string{"B", "kB", "MB", "GB", "TB", "PB", "EB"},
+		binary: []string{"B", "KiB", "MiB", "GiB", "TiB", "PiB", "EiB"},
+	},
+	"fr": {
+		units:  []string{"o", "ko", "Mo", "Go", "To", "Po", "Eo"},
+		binary: []string{"o", "Kio", "Mio", "Gio", "Tio", "Pio", "Eio"},
+	},
+	"ru": {
+		units:  []string{"Б", "кБ", "МБ", "ГБ", "ТБ", "ПБ", "ЭБ"},
+		binary: []string{"Б", "КиБ", "МиБ", "ГиБ", "ТиБ", "ПиБ", "ЭиБ"},
+	},
+}
+
+// FormatBytes formats a byte count in a locale with one decimal and localized
+// unit names and decimal separator. Units are powers of 1000 (kB, MB, ...), or
+// powers of 1024 (KiB, MiB, ...) when binary is true.
+//
+//	i18n.FormatBytes("en", 1_500_000, false) // "1.5 MB"
+//	i18n.FormatBytes("fr", 1_500_000, false) // "1,5 Mo"
+//	i18n.FormatBytes("en", 1536, true)       // "1.5 KiB"
+func FormatBytes(locale string, n int64, binary bool) string {
+	_, vocab := builtinLocale(byteLocales, locale)
+
+	units, base := vocab.units, 1000.0
+	if binary {
+		units, base = vocab.binary, 1024.0
+	}
+
+	sign := ""
+	value := float64(n)
+	if n < 0 {
+		sign = "-"
+		value = -value
+	}
+
+	// Move to the next unit while the value would round to base or more
+	unit := 0
+	for unit < len(units)-1 && value >= base-0.05 {
+		value /= base
+		unit++
+	}
+
+	if unit == 0 {
+		return sign + strconv.FormatFloat(value, 'f', 0, 64) + " " + units[0]
+	}
+
+	number := strings.TrimSuffix(strconv.FormatFloat(value, 'f', 1, 64), ".0")
+	return sign + strings.Replace(number, ".", decimalSeparator(locale), 1) + " " + units[unit]
+}
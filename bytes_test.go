@@ -0,0 +1,38 @@
+package i18n
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFormatBytes(t *testing.T) {
+	tests := []struct {
+		locale   string
+		n        int64
+		binary   bool
+		expected string
+	}{
+		{"en", 0, false, "0 B"},
+		{"en", 999, false, "999 B"},
+		{"en", 1000, false, "1 kB"},
+		{"en", 1_500_000, false, "1.5 MB"},
+		{"en", 999_960, false, "1 MB"},
+		{"en", 1536, true, "1.5 KiB"},
+		{"en", 1023, true, "1023 B"},
+		{"en", 5 << 30, true, "5 GiB"},
+		{"en", -2048, true, "-2 KiB"},
+		{"en", math.MaxInt64, true, "8 EiB"},
+		{"fr", 1_500_000, false, "1,5 Mo"},
+		{"fr", 512, false, "512 o"},
+		{"fr-CA", 1536, true, "1,5 Kio"},
+		{"de", 2_500_000_000, false, "2,5 GB"},
+		{"ru", 1_500_000, false, "1,5 МБ"},
+		{"ja", 1_500_000, false, "1.5 MB"},
+	}
+
+	for _, tt := range tests {
+		if result := FormatBytes(tt.locale, tt.n, tt.binary); result != tt.expected {
+			t.Errorf("FormatBytes(%q, %d, %v) = %q, expected %q", tt.locale, tt.n, tt.binary, result, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package i18n
+
+import (
+	"sync"
+	"time"
+)
+
+// LocaleCache memoizes the negotiated locale per client (connection, session,
+// API key, ...) for a limited time, so repeated header parsing and matching
+// is skipped for clients that send many requests. It is safe for concurrent use.
+type LocaleCache struct {
+	ttl        time.Duration
+	maxEntries int
+	entries    map[string]localeCacheEntry
+	now        func() time.Time
+	mu         sync.Mutex
+}
+
+type localeCacheEntry struct {
+	locale  string
+	expires time.Time
+}
+
+// NewLocaleCache creates a cache whose entries expire after ttl.
+// At most maxEntries clients are remembered; 0 means unlimited.
+func NewLocaleCache(ttl time.Duration, maxEntries int) *LocaleCache {
+	return &LocaleCache{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		entries:    make(map[string]localeCacheEntry),
+		now:        time.Now,
+	}
+}
+
+// Get returns the cached locale of a client if it has not expired
+func (c *LocaleCache) Get(client string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[client]
+	if ok && !c.now().Before(entry.expires) {
+		delete(c.entries, client)
+		ok = false
+	}
+	if m := metrics(); m != nil {
+		m.CacheLookup(ok)
+	}
+	if !ok {
+		return "", false
+	}
+	return entry.locale, true
+}
+
+// Set remembers the locale of a client for the cache's TTL
+func (c *LocaleCache) Set(client, locale string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	if _, exists := c.entries[client]; !exists && c.maxEntries > 0 && len(c.entries) >= c.maxEntries {
+		c.evict(now)
+	}
+	c.entries[client] = localeCacheEntry{locale: locale, expires: now.Add(c.ttl)}
+}
+
+// Resolve returns the cached locale of a client, calling negotiate and caching
+// its result on a miss
+func (c *LocaleCache) Resolve(client string, negotiate func() string) string {
+	if locale, ok := c.Get(client); ok {
+		return locale
+	}
+	locale := negotiate()
+	c.Set(client, locale)
+	return locale
+}
+
+// Len returns the number of cached clients, including expired ones not yet evicted
+func (c *LocaleCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+// evict drops expired entries, or the entry closest to expiry if none expired.
+// The caller must hold c.mu.
+func (c *LocaleCache) evict(now time.Time) {
+	var oldest string
+	var oldestExpiry time.Time
+	removed := false
+
+	for client, entry := range c.entries {
+		if !now.Before(entry.expires) {
+			delete(c.entries, client)
+			removed = true
+			continue
+		}
+		if oldest == "" || entry.expires.Before(oldestExpiry) {
+			oldest, oldestExpiry = client, entry.expires
+		}
+	}
+
+	if !removed && oldest != "" {
+		delete(c.entries, oldest)
+	}
+}
@@ -0,0 +1,109 @@
+package i18n
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock returns a controllable time source for cache tests
+func fakeClock(start time.Time) (func() time.Time, func(time.Duration)) {
+	now := start
+	var mu sync.Mutex
+	return func() time.Time {
+			mu.Lock()
+			defer mu.Unlock()
+			return now
+		}, func(d time.Duration) {
+			mu.Lock()
+			defer mu.Unlock()
+			now = now.Add(d)
+		}
+}
+
+func TestLocaleCache_Expiry(t *testing.T) {
+	cache := NewLocaleCache(time.Minute, 0)
+	now, advance := fakeClock(time.Unix(0, 0))
+	cache.now = now
+
+	cache.Set("client-1", "fr")
+	if locale, ok := cache.Get("client-1"); !ok || locale != "fr" {
+		t.Errorf("Expected cached 'fr', got '%s' (ok=%v)", locale, ok)
+	}
+
+	advance(time.Minute)
+	if _, ok := cache.Get("client-1"); ok {
+		t.Error("Expected entry to expire after TTL")
+	}
+	if cache.Len() != 0 {
+		t.Errorf("Expected expired entry to be removed, got %d entries", cache.Len())
+	}
+}
+
+func TestLocaleCache_Resolve(t *testing.T) {
+	cache := NewLocaleCache(time.Minute, 0)
+
+	calls := 0
+	negotiate := func() string {
+		calls++
+		return "de"
+	}
+
+	for i := 0; i < 3; i++ {
+		if locale := cache.Resolve("client-1", negotiate); locale != "de" {
+			t.Errorf("Expected 'de', got '%s'", locale)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("Expected negotiation to run once, ran %d times", calls)
+	}
+}
+
+func TestLocaleCache_MaxEntries(t *testing.T) {
+	cache := NewLocaleCache(time.Minute, 2)
+	now, advance := fakeClock(time.Unix(0, 0))
+	cache.now = now
+
+	cache.Set("a", "en")
+	advance(time.Second)
+	cache.Set("b", "fr")
+	advance(time.Second)
+	cache.Set("c", "de")
+
+	if cache.Len() != 2 {
+		t.Fatalf("Expected 2 entries, got %d", cache.Len())
+	}
+	if _, ok := cache.Get("a"); ok {
+		t.Error("Expected the oldest entry to be evicted")
+	}
+
+	// Updating an existing client never evicts
+	cache.Set("c", "es")
+	if cache.Len() != 2 {
+		t.Errorf("Expected 2 entries after update, got %d", cache.Len())
+	}
+	if locale, _ := cache.Get("c"); locale != "es" {
+		t.Errorf("Expected updated locale 'es', got '%s'", locale)
+	}
+}
+
+func TestLocaleCache_Concurrency(t *testing.T) {
+	cache := NewLocaleCache(time.Minute, 10)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				client := string(rune('a' + (id+j)%20))
+				cache.Resolve(client, func() string { return "en" })
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if cache.Len() > 10 {
+		t.Errorf("Expected at most 10 entries, got %d", cache.Len())
+	}
+}
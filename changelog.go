@@ -0,0 +1,173 @@
+package i18n
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+)
+
+// ChangeEntry describes a single translation that differs between two snapshots
+type ChangeEntry struct {
+	Key string `json:"key"`
+	Old string `json:"old,omitempty"`
+	New string `json:"new,omitempty"`
+}
+
+// LocaleChanges lists the added, changed and removed translations of one locale
+type LocaleChanges struct {
+	Lang    string        `json:"lang"`
+	Added   []ChangeEntry `json:"added,omitempty"`
+	Changed []ChangeEntry `json:"changed,omitempty"`
+	Removed []ChangeEntry `json:"removed,omitempty"`
+	Fuzzy   []string      `json:"fuzzy,omitempty"` // keys marked fuzzy as their source text changed
+}
+
+// Empty reports whether the locale has no changes
+func (c LocaleChanges) Empty() bool {
+	return len(c.Added) == 0 && len(c.Changed) == 0 && len(c.Removed) == 0 && len(c.Fuzzy) == 0
+}
+
+// ReadCatalogs reads a catalog snapshot from a directory of translation files
+// or from a single file. Translations are grouped by language; several files
+// for the same language are merged.
+func ReadCatalogs(path string) (map[string]map[string]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read catalogs %s: %w", path, err)
+	}
+
+	files := []string{path}
+	if info.IsDir() {
+		if files, err = catalogFiles(path); err != nil {
+			return nil, err
+		}
+	}
+
+	catalogs := make(map[string]map[string]string)
+	for _, file := range files {
+		tf, err := readTranslationFile(file)
+		if err != nil {
+			return nil, err
+		}
+		if catalogs[tf.Meta.Lang] == nil {
+			catalogs[tf.Meta.Lang] = make(map[string]string, len(tf.Translations))
+		}
+		for k, v := range tf.Translations {
+			catalogs[tf.Meta.Lang][k] = v
+		}
+	}
+	return catalogs, nil
+}
+
+// CompareCatalogs computes the per-locale changes between two catalog snapshots.
+// Locales without changes are omitted; the result is sorted by language and key.
+func CompareCatalogs(from, to map[string]map[string]string) []LocaleChanges {
+	langs := make(map[string]bool)
+	for lang := range from {
+		langs[lang] = true
+	}
+	for lang := range to {
+		langs[lang] = true
+	}
+
+	var result []LocaleChanges
+	for _, lang := range sortedKeys(langs) {
+		changes := compareTranslations(lang, from[lang], to[lang])
+		if !changes.Empty() {
+			result = append(result, changes)
+		}
+	}
+	return result
+}
+
+// compareTranslations diffs the translations of a single locale
+func compareTranslations(lang string, from, to map[string]string) LocaleChanges {
+	changes := LocaleChanges{Lang: lang}
+
+	for k, newValue := range to {
+		oldValue, ok := from[k]
+		switch {
+		case !ok:
+			changes.Added = append(changes.Added, ChangeEntry{Key: k, New: newValue})
+		case oldValue != newValue:
+			changes.Changed = append(changes.Changed, ChangeEntry{Key: k, Old: oldValue, New: newValue})
+		}
+	}
+
+	for k, oldValue := range from {
+		if _, ok := to[k]; !ok {
+			changes.Removed = append(changes.Removed, ChangeEntry{Key: k, Old: oldValue})
+		}
+	}
+
+	for _, entries := range [][]ChangeEntry{changes.Added, changes.Changed, changes.Removed} {
+		sort.Slice(entries, func(i, j int) bool { return entries[i].Key < entries[j].Key })
+	}
+	return changes
+}
+
+// WriteChangelog renders the changes as human-readable release notes
+func WriteChangelog(w io.Writer, changes []LocaleChanges) error {
+	if len(changes) == 0 {
+		_, err := fmt.Fprintln(w, "No translation changes.")
+		return err
+	}
+
+	for i, c := range changes {
+		if i > 0 {
+			if _, err := fmt.Fprintln(w); err != nil {
+				return err
+			}
+		}
+		if _, err := fmt.Fprintf(w, "## %s\n", c.Lang); err != nil {
+			return err
+		}
+
+		sections := []struct {
+			title   string
+			entries []ChangeEntry
+			format  func(ChangeEntry) string
+		}{
+			{"Added", c.Added, func(e ChangeEntry) string { return fmt.Sprintf("  + %s: %q", e.Key, e.New) }},
+			{"Changed", c.Changed, func(e ChangeEntry) string { return fmt.Sprintf("  ~ %s: %q → %q", e.Key, e.Old, e.New) }},
+			{"Removed", c.Removed, func(e ChangeEntry) string { return fmt.Sprintf("  - %s: %q", e.Key, e.Old) }},
+		}
+
+		for _, s := range sections {
+			if len(s.entries) == 0 {
+				continue
+			}
+			if _, err := fmt.Fprintf(w, "%s (%d):\n", s.title, len(s.entries)); err != nil {
+				return err
+			}
+			for _, e := range s.entries {
+				if _, err := fmt.Fprintln(w, s.format(e)); err != nil {
+					return err
+				}
+			}
+		}
+
+		if len(c.Fuzzy) > 0 {
+			if _, err := fmt.Fprintf(w, "Fuzzy (%d):\n", len(c.Fuzzy)); err != nil {
+				return err
+			}
+			for _, key := range c.Fuzzy {
+				if _, err := fmt.Fprintf(w, "  ? %s\n", key); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// sortedKeys returns the keys of a set in sorted order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,102 @@
+package i18n
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestCompareCatalogs(t *testing.T) {
+	from := map[string]map[string]string{
+		"en": {"hello": "Hello", "bye": "Bye", "old": "Old"},
+		"fr": {"hello": "Bonjour"},
+		"de": {"hello": "Hallo"},
+	}
+	to := map[string]map[string]string{
+		"en": {"hello": "Hi", "bye": "Bye", "new": "New"},
+		"fr": {"hello": "Bonjour"},
+		"es": {"hello": "Hola"},
+	}
+
+	changes := CompareCatalogs(from, to)
+
+	langs := make([]string, len(changes))
+	for i, c := range changes {
+		langs[i] = c.Lang
+	}
+	if strings.Join(langs, ",") != "de,en,es" {
+		t.Fatalf("Expected changes for de,en,es, got %v", langs)
+	}
+
+	en := changes[1]
+	if len(en.Added) != 1 || en.Added[0].Key != "new" {
+		t.Errorf("Expected 'new' to be added, got %+v", en.Added)
+	}
+	if len(en.Changed) != 1 || en.Changed[0] != (ChangeEntry{Key: "hello", Old: "Hello", New: "Hi"}) {
+		t.Errorf("Expected 'hello' to be changed, got %+v", en.Changed)
+	}
+	if len(en.Removed) != 1 || en.Removed[0].Key != "old" {
+		t.Errorf("Expected 'old' to be removed, got %+v", en.Removed)
+	}
+
+	if len(changes[0].Removed) != 1 || len(changes[2].Added) != 1 {
+		t.Errorf("Expected removed/added locales to list all keys, got %+v", changes)
+	}
+}
+
+func TestReadCatalogs(t *testing.T) {
+	dir := t.TempDir()
+	writeTestCatalog(t, dir, "default.en.json", "en", map[string]string{"hello": "Hello"})
+	writeTestCatalog(t, dir, "auth.en.json", "en", map[string]string{"login": "Log in"})
+	fr := writeTestCatalog(t, dir, "default.fr.json", "fr", map[string]string{"hello": "Bonjour"})
+
+	catalogs, err := ReadCatalogs(dir)
+	if err != nil {
+		t.Fatalf("ReadCatalogs failed: %v", err)
+	}
+	if len(catalogs["en"]) != 2 || len(catalogs["fr"]) != 1 {
+		t.Errorf("Unexpected catalogs: %v", catalogs)
+	}
+
+	single, err := ReadCatalogs(fr)
+	if err != nil {
+		t.Fatalf("ReadCatalogs failed for single file: %v", err)
+	}
+	if len(single) != 1 || single["fr"]["hello"] != "Bonjour" {
+		t.Errorf("Unexpected catalogs from single file: %v", single)
+	}
+
+	if _, err := ReadCatalogs(dir + "/missing"); err == nil {
+		t.Error("Expected error for missing path, got nil")
+	}
+}
+
+func TestWriteChangelog(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteChangelog(&buf, nil); err != nil {
+		t.Fatalf("WriteChangelog failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), "No translation changes") {
+		t.Errorf("Expected empty changelog message, got %q", buf.String())
+	}
+
+	buf.Reset()
+	changes := []LocaleChanges{{
+		Lang:    "fr",
+		Added:   []ChangeEntry{{Key: "new", New: "Nouveau"}},
+		Changed: []ChangeEntry{{Key: "hello", Old: "Salut", New: "Bonjour"}},
+	}}
+	if err := WriteChangelog(&buf, changes); err != nil {
+		t.Fatalf("WriteChangelog failed: %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{"## fr", "Added (1):", `+ new: "Nouveau"`, `~ hello: "Salut" → "Bonjour"`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected changelog to contain %q, got:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "Removed") {
+		t.Errorf("Expected empty sections to be omitted, got:\n%s", out)
+	}
+}
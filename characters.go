@@ -0,0 +1,41 @@
+package i18n
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+// suspiciousRunes are invisible characters that translations pick up when
+// text is pasted from documents and that break rendering: they hide inside
+// words, defeat search and comparison, or reverse the surrounding text.
+// Joiners (U+200C, U+200D) and direction marks (U+200E, U+200F) are left out
+// as they are needed by some scripts and by emoji sequences.
+var suspiciousRunes = map[rune]string{
+	'\u180E': "mongolian vowel separator",
+	'\u200B': "zero width space",
+	'\u2060': "word joiner",
+	'\u2061': "invisible function application",
+	'\u2062': "invisible times",
+	'\u2063': "invisible separator",
+	'\u2064': "invisible plus",
+	'\u202D': "left-to-right override",
+	'\u202E': "right-to-left override",
+	'\uFEFF': "byte order mark",
+	'\uFFFD': "replacement character (a broken surrogate pair or invalid encoding)",
+}
+
+// validateCharacters reports the first invalid UTF-8 sequence or suspicious
+// invisible character in value, with its byte offset
+func validateCharacters(value string) error {
+	for i, r := range value {
+		if r == utf8.RuneError {
+			if _, size := utf8.DecodeRuneInString(value[i:]); size == 1 {
+				return fmt.Errorf("invalid UTF-8 at offset %d", i)
+			}
+		}
+		if name, ok := suspiciousRunes[r]; ok {
+			return fmt.Errorf("contains %s (U+%04X) at offset %d", name, r, i)
+		}
+	}
+	return nil
+}
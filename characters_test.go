@@ -0,0 +1,49 @@
+package i18n
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateCharacters(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string // substring of the error, "" for valid
+	}{
+		{"Dashboard", ""},
+		{"👩\u200D💻 Développeuse", ""}, // zero width joiner in an emoji sequence
+		{"می\u200Cخواهم", ""},         // zero width non-joiner in Persian
+		{"\u200Fשלום {0}", ""},        // right-to-left mark
+		{"Sign\u200Bin", "zero width space (U+200B) at offset 4"},
+		{"\uFEFFHello", "byte order mark (U+FEFF) at offset 0"},
+		{"Total: \u202E{0}", "right-to-left override (U+202E)"},
+		{"Hello \uFFFD", "replacement character"},
+		{"caf\xe9", "invalid UTF-8 at offset 3"},
+	}
+
+	for _, tt := range tests {
+		err := validateCharacters(tt.value)
+		if tt.want == "" {
+			if err != nil {
+				t.Errorf("validateCharacters(%q) = %v, want nil", tt.value, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("validateCharacters(%q) = %v, want error containing %q", tt.value, err, tt.want)
+		}
+	}
+}
+
+func TestDictionaryValidate_Characters(t *testing.T) {
+	dict := NewDictionary("en")
+	dict.AddAll(map[string]string{"login": "Sign\u200Bin", "title": "Dashboard"})
+
+	err := dict.Validate()
+	if err == nil || !strings.Contains(err.Error(), "suspicious characters in key 'login'") {
+		t.Errorf("Expected suspicious character error for login, got %v", err)
+	}
+	if err := RegisterStrict(dict); err == nil {
+		t.Error("Expected RegisterStrict to reject the dictionary")
+	}
+}
@@ -0,0 +1,109 @@
+package i18n
+
+import (
+	"maps"
+	"slices"
+)
+
+// Clone returns an independent copy of the dictionary, with its
+// translations, lists, scheduled values, fuzzy marks, states and meta. The copy is not
+// registered in any bundle; changes to one do not affect the other.
+//
+// Example:
+//
+//	// A/B test new copy without touching the shared registry
+//	variant := i18n.GetDictionary("en").Clone()
+//	variant.Add("signup-cta", "Start your free trial")
+func (d *Dictionary) Clone() *Dictionary {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	clone := &Dictionary{
+		Lang:         d.Lang,
+		Translations: maps.Clone(d.Translations),
+		fuzzy:        maps.Clone(d.fuzzy),
+		states:       maps.Clone(d.states),
+		meta:         d.meta,
+	}
+	if clone.Translations == nil {
+		clone.Translations = make(map[string]string)
+	}
+	if d.Lists != nil {
+		clone.Lists = make(map[string][]string, len(d.Lists))
+		for key, list := range d.Lists {
+			clone.Lists[key] = slices.Clone(list)
+		}
+	}
+	if d.Schedule != nil {
+		clone.Schedule = make(map[string][]ScheduledValue, len(d.Schedule))
+		for key, values := range d.Schedule {
+			clone.Schedule[key] = slices.Clone(values)
+		}
+	}
+	return clone
+}
+
+// Clone returns an independent copy of the bundle: clones of its
+// dictionaries and base catalogs, its default language, fallbacks, listeners,
+// debug setting, escape mode, and fuzzy and state settings. A bundle that loads lazily
+// keeps doing so, into the copy, for the languages it has not loaded yet.
+// Request-scoped overrides can then be registered in the copy without
+// affecting the original.
+//
+// Example:
+//
+//	b := i18n.DefaultBundle().Clone()
+//	b.GetDictionary("en").Add("checkout-button", "Buy now")
+//	label := b.T("checkout-button")("en")
+func (b *Bundle) Clone() *Bundle {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	clone := &Bundle{
+		dictionaries: make(map[string]*Dictionary, len(b.dictionaries)),
+		defaultLang:  b.defaultLang,
+		fallbacks:    make(map[string][]string, len(b.fallbacks)),
+		onChange:     slices.Clone(b.onChange),
+		onMissing:    slices.Clone(b.onMissing),
+		onReplace:    slices.Clone(b.onReplace),
+		debug:        b.debug,
+		escape:       b.escape,
+	}
+	clone.excludeFuzzy.Store(b.excludeFuzzy.Load())
+	clone.minState.Store(b.minState.Load())
+	for lang, dict := range b.dictionaries {
+		dict = dict.Clone()
+		dict.bundle = clone
+		clone.dictionaries[lang] = dict
+	}
+	for locale, chain := range b.fallbacks {
+		clone.fallbacks[locale] = slices.Clone(chain)
+	}
+	if b.base != nil {
+		clone.base = make(map[string]*Dictionary, len(b.base))
+		for lang, dict := range b.base {
+			dict = dict.Clone()
+			dict.bundle = clone
+			clone.base[lang] = dict
+		}
+	}
+	if b.lazy != nil {
+		clone.lazy = b.lazy.clone()
+	}
+	return clone
+}
+
+// clone returns a loader for the same directory that skips the languages
+// this one has already tried
+func (l *lazyLoader) clone() *lazyLoader {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	calls := make(map[string]chan struct{}, len(l.calls))
+	for lang := range l.calls {
+		done := make(chan struct{})
+		close(done)
+		calls[lang] = done
+	}
+	return &lazyLoader{dir: l.dir, available: l.available, onError: l.onError, calls: calls}
+}
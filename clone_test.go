@@ -0,0 +1,103 @@
+package i18n
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDictionaryClone(t *testing.T) {
+	dict, err := LoadDictionaryBytes([]byte(`{
+  "meta": {"lang": "en", "name": "default", "version": "1.2.0"},
+  "translations": {"title": "Dashboard", "days": ["Mon", "Tue"]}
+}`))
+	if err != nil {
+		t.Fatalf("Failed to load dictionary: %v", err)
+	}
+	dict.AddScheduled("title", ScheduledValue{
+		Value:      "Holiday dashboard",
+		ValidFrom:  time.Now().Add(-time.Hour),
+		ValidUntil: time.Now().Add(time.Hour),
+	})
+
+	clone := dict.Clone()
+	if clone.Hash() != dict.Hash() || clone.Meta() != dict.Meta() {
+		t.Fatalf("Expected an identical copy, got %+v", clone)
+	}
+
+	clone.Add("title", "Overview")
+	clone.GetSlice("days")[0] = "Monday"
+	clone.AddSlice("months", []string{"Jan"})
+	clone.Schedule["title"][0].Value = "Changed"
+
+	if dict.Translations["title"] != "Dashboard" {
+		t.Errorf("Expected the original translation to be unchanged, got %q", dict.Translations["title"])
+	}
+	if dict.Lists["days"][0] != "Mon" || dict.Lists["months"] != nil {
+		t.Errorf("Expected the original lists to be unchanged, got %v", dict.Lists)
+	}
+	if dict.Schedule["title"][0].Value != "Holiday dashboard" {
+		t.Errorf("Expected the original schedule to be unchanged, got %v", dict.Schedule)
+	}
+}
+
+func TestBundleClone(t *testing.T) {
+	b := NewBundle()
+	en := NewDictionary("en")
+	en.Add("cta", "Sign up")
+	fr := NewDictionary("fr")
+	fr.Add("cta", "S'inscrire")
+	b.Register(en)
+	b.Register(fr)
+	b.SetFallbacks("fr-CA", "fr")
+
+	var missing []string
+	b.OnMissing(func(locale, key string) { missing = append(missing, key) })
+
+	clone := b.Clone()
+	clone.GetDictionary("en").Add("cta", "Start your free trial")
+	clone.SetFallbacks("fr-CA", "en")
+	clone.Unregister("fr")
+
+	if got := b.T("cta")("en"); got != "Sign up" {
+		t.Errorf("Expected the original bundle to be unchanged, got %q", got)
+	}
+	if got := b.T("cta")("fr-CA"); got != "S'inscrire" {
+		t.Errorf("Expected the original fallbacks to be unchanged, got %q", got)
+	}
+	if got := clone.T("cta")("fr-CA"); got != "Start your free trial" {
+		t.Errorf("Expected the clone's override, got %q", got)
+	}
+
+	// Fallback lookups of cloned dictionaries stay within the clone
+	if got := clone.GetDictionary("en").bundle; got != clone {
+		t.Error("Expected cloned dictionaries to belong to the clone")
+	}
+
+	clone.T("unknown")("en")
+	if len(missing) != 1 {
+		t.Errorf("Expected the clone to keep the OnMissing listeners, got %v", missing)
+	}
+}
+
+func TestBundleClone_Lazy(t *testing.T) {
+	dir := t.TempDir()
+	writeTestCatalog(t, dir, "default.de.json", "de", map[string]string{"title": "Übersicht"})
+	writeTestCatalog(t, dir, "default.fr.json", "fr", map[string]string{"title": "Tableau"})
+
+	b := NewBundle()
+	if err := b.LoadLazily(dir, nil); err != nil {
+		t.Fatalf("LoadLazily failed: %v", err)
+	}
+	b.GetDictionary("de")
+
+	clone := b.Clone()
+	if got := clone.T("title")("fr"); got != "Tableau" {
+		t.Errorf("Expected the clone to load fr lazily, got %q", got)
+	}
+	if b.registered("fr") != nil {
+		t.Error("Expected the clone's lazy load to leave the original untouched")
+	}
+	if got := clone.T("title")("de"); got != "Übersicht" {
+		t.Errorf("Expected the cloned de dictionary, got %q", got)
+	}
+}
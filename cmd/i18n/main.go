@@ -0,0 +1,14 @@
+// Command i18n extracts the strings of Go code into translation catalogs
+// and checks, merges and maintains those catalogs. Run i18n help for its
+// commands.
+package main
+
+import (
+	"os"
+
+	"github.com/nyxstack/i18n/internal/cli"
+)
+
+func main() {
+	os.Exit(cli.Run("i18n", os.Args[1:]))
+}
@@ -0,0 +1,45 @@
+package i18n
+
+import "sync/atomic"
+
+// Calls is the v1 call surface: the T, F, S, P and R methods of *Bundle,
+// which the package-level functions of the same names forward to. Code that
+// takes a Calls instead of a *Bundle keeps compiling against any
+// implementation, such as an adapter over the bundle of a later major
+// version.
+type Calls interface {
+	T(key string, args ...any) TranslatedFunc
+	F(format string, args ...any) TranslatedFunc
+	S(text string) TranslatedFunc
+	P(key string, count int, args ...any) TranslatedFunc
+	R(locale, text string) string
+}
+
+var _ Calls = (*Bundle)(nil)
+
+// forwarded boxes the Calls set with Forward, atomic.Pointer needing a
+// concrete type
+type forwarded struct{ Calls }
+
+var forward atomic.Pointer[forwarded]
+
+// Forward routes the package-level T, F, S, P and R through c instead of
+// the default bundle, so existing call sites can be served by an adapter
+// without being rewritten. Forward(nil) and ResetRegistry restore the
+// default bundle. The other package-level functions keep using the default
+// bundle.
+func Forward(c Calls) {
+	if c == nil {
+		forward.Store(nil)
+		return
+	}
+	forward.Store(&forwarded{c})
+}
+
+// calls returns what the package-level T, F, S, P and R forward to
+func calls() Calls {
+	if f := forward.Load(); f != nil {
+		return f.Calls
+	}
+	return defaultBundle
+}
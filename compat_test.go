@@ -0,0 +1,47 @@
+package i18n
+
+import "testing"
+
+// upperCalls stands in for an adapter over another bundle implementation
+type upperCalls struct{ *Bundle }
+
+func (u upperCalls) S(text string) TranslatedFunc {
+	return func(string) string { return "S:" + text }
+}
+
+func TestForward(t *testing.T) {
+	defer ResetRegistry()
+	ResetRegistry()
+
+	b := NewBundle()
+	b.Register(&Dictionary{Lang: "fr", Translations: map[string]string{
+		"dashboard":    "Tableau de bord",
+		"hello-0":      "Bonjour {0}",
+		"welcome_user": "Bienvenue {0} !",
+		"item_count":   "{count, plural, one {# élément} other {# éléments}}",
+	}})
+	Forward(upperCalls{b})
+
+	for _, tt := range []struct{ got, want string }{
+		{T("welcome_user", "Anne")("fr"), "Bienvenue Anne !"},
+		{F("Hello %s", "Anne")("fr"), "Bonjour Anne"},
+		{S("Dashboard")("fr"), "S:Dashboard"},
+		{P("item_count", 3)("fr"), "3 éléments"},
+		{R("fr", "Dashboard"), "Tableau de bord"},
+	} {
+		if tt.got != tt.want {
+			t.Errorf("Expected %q, got %q", tt.want, tt.got)
+		}
+	}
+
+	Forward(nil)
+	if got := S("Dashboard")("fr"); got != "Dashboard" {
+		t.Errorf("Expected the default bundle after Forward(nil), got %q", got)
+	}
+
+	Forward(b)
+	ResetRegistry()
+	if got := R("fr", "Dashboard"); got != "Dashboard" {
+		t.Errorf("Expected ResetRegistry to stop forwarding, got %q", got)
+	}
+}
@@ -0,0 +1,637 @@
+package i18n
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// ConfigFile is the name of the project configuration file FindConfig
+// looks for
+const ConfigFile = "i18n.yaml"
+
+// Config is the project configuration read from i18n.yaml, so the CLI runs
+// the same way for every developer and in CI without long flag lists:
+//
+//	sources: [./cmd, ./internal]
+//	exclude: [vendor, testdata, "*_test.go"]
+//	locales: [en, fr, de]   # the first is the source language
+//	output: locales
+//	layout: nested
+//	indent: 4
+//	group: package          # or file
+//	keep_removed: true      # move unused keys to "obsolete" instead of deleting them
+//	references: true        # record where each key is used
+//	keys:
+//	  pattern: "^[a-z0-9_.-]+$"
+//	  max_length: 64
+//	coverage:
+//	  min: 0.8
+//	  locales: {de: 0.95}
+//
+// Relative paths are relative to the directory of the file.
+type Config struct {
+	Sources  []string       `json:"sources"`  // directories to scan, the config's directory if empty
+	Exclude  []string       `json:"exclude"`  // paths to skip, by relative path or base name (filepath.Match syntax)
+	Locales  []string       `json:"locales"`  // locales to extract, the source language first
+	Output   string         `json:"output"`   // catalog directory, DefaultFolder if empty
+	Layout   string         `json:"layout"`   // "nested" or "flat" keys, each catalog's own layout if empty
+	Indent   int            `json:"indent"`   // spaces to indent catalogs with, each catalog's own indentation if 0
+	Group    string         `json:"group"`    // "package" or "file" to group keys by where they are used, see TranslationFile.Groups
+	Keys     KeyPolicy      `json:"keys"`     // rules extracted keys must follow
+	Coverage CoverageConfig `json:"coverage"` // translation coverage each locale must reach
+
+	// KeepRemoved moves the keys no longer used in the code to the
+	// "obsolete" section of each catalog instead of deleting them, see
+	// TranslationFile.Obsolete
+	KeepRemoved bool `json:"keep_removed"`
+
+	// References records the file:line locations each key is used at in
+	// the "references" section of each catalog, see
+	// TranslationFile.References
+	References bool `json:"references"`
+}
+
+// KeyPolicy restricts the catalog keys extracted from the source code
+type KeyPolicy struct {
+	Pattern   string `json:"pattern"`    // regular expression every key must match, if set
+	MaxLength int    `json:"max_length"` // maximum key length in characters, if not 0
+}
+
+// CoverageConfig holds the share of the source language's keys (0.8 = 80%)
+// each locale must translate
+type CoverageConfig struct {
+	Min     float64            `json:"min"`     // threshold of every locale
+	Locales map[string]float64 `json:"locales"` // thresholds of specific locales, overriding Min
+}
+
+// Layouts accepted in Config.Layout
+const (
+	LayoutNested = "nested"
+	LayoutFlat   = "flat"
+)
+
+// Groupings accepted in Config.Group
+const (
+	GroupByPackage = "package"
+	GroupByFile    = "file"
+)
+
+// FindConfig returns the path of the i18n.yaml in dir or its closest parent
+// directory that has one, or "" if there is none
+func FindConfig(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	for {
+		path := filepath.Join(dir, ConfigFile)
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return "", err
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", nil
+		}
+		dir = parent
+	}
+}
+
+// LoadConfig reads and validates a configuration file. Its paths are
+// resolved against the file's directory. Problems, including unknown
+// settings, are reported as *FileError.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+
+	cfg, err := ParseConfig(data)
+	if err != nil {
+		return nil, withPath(err, path, "invalid config")
+	}
+
+	base := filepath.Dir(path)
+	if len(cfg.Sources) == 0 {
+		cfg.Sources = []string{"."}
+	}
+	for i, source := range cfg.Sources {
+		cfg.Sources[i] = resolvePath(base, source)
+	}
+	cfg.Output = resolvePath(base, cfg.Dir())
+	return cfg, nil
+}
+
+// ParseConfig parses and validates the content of a configuration file,
+// leaving its paths as written. The file is YAML limited to mappings,
+// lists and scalars, which is all the configuration needs.
+func ParseConfig(data []byte) (*Config, error) {
+	tree, err := parseYAML(string(data))
+	if err != nil {
+		return nil, err
+	}
+	if tree == nil {
+		tree = map[string]any{}
+	}
+
+	// Decode the tree like JSON so unknown settings and wrong types are caught
+	encoded, err := json.Marshal(tree)
+	if err != nil {
+		return nil, &FileError{Reason: "invalid config", Err: err}
+	}
+	dec := json.NewDecoder(bytes.NewReader(encoded))
+	dec.DisallowUnknownFields()
+	cfg := &Config{}
+	if err := dec.Decode(cfg); err != nil {
+		return nil, &FileError{Reason: "invalid config", Err: err}
+	}
+
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// validate checks the settings that JSON decoding does not
+func (c *Config) validate() error {
+	switch c.Layout {
+	case "", LayoutNested, LayoutFlat:
+	default:
+		return &FileError{Reason: fmt.Sprintf("invalid layout '%s' (expected %s or %s)", c.Layout, LayoutNested, LayoutFlat)}
+	}
+
+	switch c.Group {
+	case "", GroupByPackage, GroupByFile:
+	default:
+		return &FileError{Reason: fmt.Sprintf("invalid group '%s' (expected %s or %s)", c.Group, GroupByPackage, GroupByFile)}
+	}
+
+	if c.Keys.Pattern != "" {
+		if _, err := regexp.Compile(c.Keys.Pattern); err != nil {
+			return &FileError{Reason: "invalid keys.pattern", Err: err}
+		}
+	}
+	if c.Keys.MaxLength < 0 {
+		return &FileError{Reason: fmt.Sprintf("invalid keys.max_length %d", c.Keys.MaxLength)}
+	}
+
+	for _, pattern := range c.Exclude {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return &FileError{Reason: fmt.Sprintf("invalid exclude pattern %q", pattern), Err: err}
+		}
+	}
+
+	seen := make(map[string]bool, len(c.Locales))
+	for _, locale := range c.Locales {
+		if locale == "" || seen[locale] {
+			return &FileError{Lang: locale, Reason: fmt.Sprintf("invalid or duplicate locale '%s'", locale)}
+		}
+		seen[locale] = true
+	}
+
+	if c.Coverage.Min < 0 || c.Coverage.Min > 1 {
+		return &FileError{Reason: fmt.Sprintf("coverage.min %g is not between 0 and 1", c.Coverage.Min)}
+	}
+	for _, lang := range sortedKeys(c.Coverage.Locales) {
+		if v := c.Coverage.Locales[lang]; v < 0 || v > 1 {
+			return &FileError{Lang: lang, Reason: fmt.Sprintf("coverage of '%s' %g is not between 0 and 1", lang, v)}
+		}
+	}
+	return nil
+}
+
+// SourceLanguage returns the first configured locale, or "" if none is
+func (c *Config) SourceLanguage() string {
+	if len(c.Locales) == 0 {
+		return ""
+	}
+	return c.Locales[0]
+}
+
+// Dir returns the configured catalog directory, or DefaultFolder
+func (c *Config) Dir() string {
+	if c.Output == "" {
+		return DefaultFolder
+	}
+	return c.Output
+}
+
+// MinCoverage returns the coverage lang must reach: its own threshold if
+// it has one, else coverage.min
+func (c *Config) MinCoverage(lang string) float64 {
+	if v, ok := c.Coverage.Locales[lang]; ok {
+		return v
+	}
+	return c.Coverage.Min
+}
+
+// CheckKey reports, as a *FileError, a key that breaks the key policy
+func (c *Config) CheckKey(key string) error {
+	if n := c.Keys.MaxLength; n > 0 && utf8.RuneCountInString(key) > n {
+		return fileError("", key, "key '%s' is longer than %d characters", key, n)
+	}
+	if c.Keys.Pattern != "" {
+		if ok, _ := regexp.MatchString(c.Keys.Pattern, key); !ok {
+			return fileError("", key, "key '%s' does not match %s", key, c.Keys.Pattern)
+		}
+	}
+	return nil
+}
+
+// GenerateCatalogs extracts the configured sources and writes the catalog
+// of every configured locale, like the package-level GenerateCatalogs. It
+// writes nothing if an extracted key breaks the key policy; the returned
+// error joins every such key.
+func (c *Config) GenerateCatalogs(ctx context.Context) error {
+	return withCatalogLock(ctx, c.Dir(), func() error {
+		catalogs, err := c.plan(ctx)
+		if err != nil {
+			return err
+		}
+		return writeCatalogs(catalogs)
+	})
+}
+
+// PlanCatalogs reports the changes GenerateCatalogs would make to the
+// catalog of each configured locale, without writing anything
+func (c *Config) PlanCatalogs(ctx context.Context) ([]LocaleChanges, error) {
+	catalogs, err := c.plan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return catalogChanges(catalogs), nil
+}
+
+// plan computes the catalogs GenerateCatalogs writes
+func (c *Config) plan(ctx context.Context) ([]plannedCatalog, error) {
+	sources := c.Sources
+	if len(sources) == 0 {
+		sources = []string{"."}
+	}
+	catalogs, err := planCatalogs(ctx, sources, c.Exclude, c.Dir(), DefaultDictionary, c.Locales)
+	if err != nil || len(catalogs) == 0 {
+		return nil, err
+	}
+
+	// Every catalog has the same keys, so checking the source one is enough
+	var errs []error
+	for _, key := range sortedKeys(catalogs[0].translations) {
+		if err := c.CheckKey(key); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for _, key := range catalogs[0].needsSource {
+		if err := c.CheckKey(key); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	if c.Layout != "" {
+		for i := range catalogs {
+			catalogs[i].nested = c.Layout == LayoutNested
+		}
+	}
+	if c.Indent > 0 {
+		for i := range catalogs {
+			catalogs[i].indent = strings.Repeat(" ", c.Indent)
+		}
+	}
+	if c.Group != "" {
+		groups := groupKeys(catalogs[0].files, c.Group)
+		for i := range catalogs {
+			catalogs[i].groups = groups
+		}
+	}
+	for i := range catalogs {
+		catalogs[i].keepRemoved = c.KeepRemoved
+		catalogs[i].writeReferences = c.References
+	}
+	return catalogs, nil
+}
+
+// groupKeys groups keys by the source file they were found in, or by its
+// directory with GroupByPackage
+func groupKeys(files map[string]string, group string) map[string][]string {
+	groups := make(map[string][]string)
+	for _, key := range sortedKeys(files) {
+		name := files[key]
+		if group == GroupByPackage {
+			name = path.Dir(name)
+		}
+		groups[name] = append(groups[name], key)
+	}
+	return groups
+}
+
+// resolvePath returns path relative to base, unless it is absolute
+func resolvePath(base, path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(base, path)
+}
+
+// yamlLine is a significant line of a YAML document
+type yamlLine struct {
+	num    int    // 1-based line number
+	indent int    // leading spaces
+	text   string // content without indentation and comment
+}
+
+// parseYAML parses the subset of YAML configuration files use: block
+// mappings and sequences, flow sequences ([a, b]) and mappings ({a: 1}) of
+// scalars, and plain, single- and double-quoted scalars. Plain scalars that
+// read as numbers, booleans or null become float64, bool and nil.
+func parseYAML(src string) (any, error) {
+	var lines []yamlLine
+	for i, raw := range strings.Split(src, "\n") {
+		raw = strings.TrimRight(raw, " \t\r")
+		text := strings.TrimLeft(raw, " ")
+		if strings.HasPrefix(text, "\t") {
+			return nil, &FileError{Line: i + 1, Reason: "tabs are not allowed for indentation"}
+		}
+		text = strings.TrimSpace(stripYAMLComment(text))
+		if text == "" || text == "---" {
+			continue
+		}
+		lines = append(lines, yamlLine{num: i + 1, indent: len(raw) - len(strings.TrimLeft(raw, " ")), text: text})
+	}
+	if len(lines) == 0 {
+		return nil, nil
+	}
+
+	p := &yamlParser{lines: lines}
+	value, err := p.block(lines[0].indent)
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.lines) {
+		return nil, p.errorf(p.lines[p.pos], "unexpected indentation")
+	}
+	return value, nil
+}
+
+// yamlParser reads blocks of YAML lines
+type yamlParser struct {
+	lines []yamlLine
+	pos   int
+}
+
+func (p *yamlParser) errorf(line yamlLine, format string, args ...any) error {
+	return &FileError{Line: line.num, Column: line.indent + 1, Reason: fmt.Sprintf(format, args...)}
+}
+
+// block parses the mapping or sequence whose entries start at indent
+func (p *yamlParser) block(indent int) (any, error) {
+	if first := p.lines[p.pos]; first.text == "-" || strings.HasPrefix(first.text, "- ") {
+		return p.sequence(indent)
+	}
+	return p.mapping(indent)
+}
+
+// sequence parses "- item" lines at indent
+func (p *yamlParser) sequence(indent int) (any, error) {
+	list := []any{}
+	for p.pos < len(p.lines) {
+		line := p.lines[p.pos]
+		if line.indent != indent || !(line.text == "-" || strings.HasPrefix(line.text, "- ")) {
+			break
+		}
+		p.pos++
+
+		item := strings.TrimSpace(strings.TrimPrefix(line.text, "-"))
+		if item == "" {
+			return nil, p.errorf(line, "nested blocks in sequences are not supported")
+		}
+		if _, _, ok := yamlKeyValue(item); ok {
+			return nil, p.errorf(line, "mappings in sequences are not supported")
+		}
+		value, err := yamlValue(item)
+		if err != nil {
+			return nil, p.errorf(line, "%v", err)
+		}
+		list = append(list, value)
+	}
+	return list, nil
+}
+
+// mapping parses "key: value" lines at indent
+func (p *yamlParser) mapping(indent int) (any, error) {
+	m := map[string]any{}
+	for p.pos < len(p.lines) {
+		line := p.lines[p.pos]
+		if line.indent < indent {
+			break
+		}
+		if line.indent > indent {
+			return nil, p.errorf(line, "unexpected indentation")
+		}
+
+		key, rest, ok := yamlKeyValue(line.text)
+		if !ok {
+			return nil, p.errorf(line, "expected 'key: value'")
+		}
+		if _, dup := m[key]; dup {
+			return nil, p.errorf(line, "duplicate key '%s'", key)
+		}
+		p.pos++
+
+		if rest != "" {
+			value, err := yamlValue(rest)
+			if err != nil {
+				return nil, p.errorf(line, "%v", err)
+			}
+			m[key] = value
+			continue
+		}
+
+		// A nested block follows, more indented (sequences may stay level)
+		m[key] = nil
+		if p.pos < len(p.lines) {
+			next := p.lines[p.pos]
+			isItem := next.text == "-" || strings.HasPrefix(next.text, "- ")
+			if next.indent > indent || (next.indent == indent && isItem) {
+				value, err := p.block(next.indent)
+				if err != nil {
+					return nil, err
+				}
+				m[key] = value
+			}
+		}
+	}
+	return m, nil
+}
+
+// yamlKeyValue splits "key: value", unquoting the key
+func yamlKeyValue(text string) (key, value string, ok bool) {
+	i := yamlIndex(text, ':')
+	for i >= 0 && i+1 < len(text) && text[i+1] != ' ' {
+		next := yamlIndex(text[i+1:], ':')
+		if next < 0 {
+			return "", "", false
+		}
+		i += 1 + next
+	}
+	if i < 0 {
+		return "", "", false
+	}
+
+	key = strings.TrimSpace(text[:i])
+	if unquoted, err := yamlValue(key); err == nil {
+		if s, isString := unquoted.(string); isString && (key[0] == '"' || key[0] == '\'') {
+			key = s
+		}
+	}
+	if key == "" {
+		return "", "", false
+	}
+	return key, strings.TrimSpace(text[i+1:]), true
+}
+
+// yamlValue parses a scalar or a flow collection
+func yamlValue(text string) (any, error) {
+	switch {
+	case strings.HasPrefix(text, "["):
+		if !strings.HasSuffix(text, "]") {
+			return nil, fmt.Errorf("unterminated flow sequence %s", text)
+		}
+		list := []any{}
+		for _, item := range yamlSplit(text[1 : len(text)-1]) {
+			value, err := yamlValue(item)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, value)
+		}
+		return list, nil
+
+	case strings.HasPrefix(text, "{"):
+		if !strings.HasSuffix(text, "}") {
+			return nil, fmt.Errorf("unterminated flow mapping %s", text)
+		}
+		m := map[string]any{}
+		for _, item := range yamlSplit(text[1 : len(text)-1]) {
+			key, rest, ok := yamlKeyValue(item)
+			if !ok {
+				return nil, fmt.Errorf("expected 'key: value' in %s", text)
+			}
+			value, err := yamlValue(rest)
+			if err != nil {
+				return nil, err
+			}
+			m[key] = value
+		}
+		return m, nil
+
+	case strings.HasPrefix(text, `"`):
+		s, err := strconv.Unquote(text)
+		if err != nil {
+			return nil, fmt.Errorf("invalid string %s", text)
+		}
+		return s, nil
+
+	case strings.HasPrefix(text, "'"):
+		if len(text) < 2 || !strings.HasSuffix(text, "'") {
+			return nil, fmt.Errorf("invalid string %s", text)
+		}
+		return strings.ReplaceAll(text[1:len(text)-1], "''", "'"), nil
+	}
+
+	switch text {
+	case "", "~", "null", "Null", "NULL":
+		return nil, nil
+	case "true", "True", "TRUE":
+		return true, nil
+	case "false", "False", "FALSE":
+		return false, nil
+	}
+	if n, err := strconv.ParseFloat(text, 64); err == nil {
+		return n, nil
+	}
+	return text, nil
+}
+
+// yamlSplit splits the items of a flow collection on commas outside quotes
+// and nested collections
+func yamlSplit(text string) []string {
+	var items []string
+	add := func(item string) {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	var quote byte
+	depth, start := 0, 0
+	for i := 0; i < len(text); i++ {
+		switch c := text[i]; {
+		case quote == '"' && c == '\\':
+			i++
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '[' || c == '{':
+			depth++
+		case c == ']' || c == '}':
+			depth--
+		case c == ',' && depth == 0:
+			add(text[start:i])
+			start = i + 1
+		}
+	}
+	add(text[start:])
+	return items
+}
+
+// yamlIndex returns the index of the first c outside quotes, or -1
+func yamlIndex(text string, c byte) int {
+	var quote byte
+	for i := 0; i < len(text); i++ {
+		switch {
+		case quote == '"' && text[i] == '\\':
+			i++
+		case quote != 0:
+			if text[i] == quote {
+				quote = 0
+			}
+		case text[i] == '"' || text[i] == '\'':
+			quote = text[i]
+		case text[i] == c:
+			return i
+		}
+	}
+	return -1
+}
+
+// stripYAMLComment removes a # comment, which starts a line or follows a
+// space outside quotes
+func stripYAMLComment(text string) string {
+	for i := 0; i < len(text); {
+		j := yamlIndex(text[i:], '#')
+		if j < 0 {
+			return text
+		}
+		if i+j == 0 || text[i+j-1] == ' ' || text[i+j-1] == '\t' {
+			return text[:i+j]
+		}
+		i += j + 1
+	}
+	return text
+}
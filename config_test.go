@@ -0,0 +1,398 @@
+package i18n
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseConfig(t *testing.T) {
+	cfg, err := ParseConfig([]byte(`
+# Shared by every developer and CI
+sources: [./cmd, "./internal"]
+exclude:
+  - vendor
+  - '*_test.go'   # generated fixtures
+locales: [en, fr, de]
+output: locales
+layout: nested
+keys:
+  pattern: "^[a-z0-9_.-]+$"
+  max_length: 64
+coverage:
+  min: 0.8
+  locales: {de: 0.95, "pt-BR": 1}
+`))
+	if err != nil {
+		t.Fatalf("ParseConfig failed: %v", err)
+	}
+
+	want := &Config{
+		Sources:  []string{"./cmd", "./internal"},
+		Exclude:  []string{"vendor", "*_test.go"},
+		Locales:  []string{"en", "fr", "de"},
+		Output:   "locales",
+		Layout:   LayoutNested,
+		Keys:     KeyPolicy{Pattern: "^[a-z0-9_.-]+$", MaxLength: 64},
+		Coverage: CoverageConfig{Min: 0.8, Locales: map[string]float64{"de": 0.95, "pt-BR": 1}},
+	}
+	if !reflect.DeepEqual(cfg, want) {
+		t.Errorf("Expected %+v, got %+v", want, cfg)
+	}
+
+	if cfg.SourceLanguage() != "en" {
+		t.Errorf("Expected source language en, got %q", cfg.SourceLanguage())
+	}
+	if cfg.MinCoverage("de") != 0.95 || cfg.MinCoverage("fr") != 0.8 {
+		t.Errorf("Expected coverage 0.95 for de and 0.8 for fr, got %v and %v", cfg.MinCoverage("de"), cfg.MinCoverage("fr"))
+	}
+}
+
+func TestParseConfig_Empty(t *testing.T) {
+	cfg, err := ParseConfig([]byte("# nothing configured yet\n"))
+	if err != nil {
+		t.Fatalf("ParseConfig failed: %v", err)
+	}
+	if cfg.Dir() != DefaultFolder || cfg.SourceLanguage() != "" {
+		t.Errorf("Expected defaults, got %+v", cfg)
+	}
+}
+
+func TestParseConfig_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		yaml string
+		want string
+	}{
+		{"unknown setting", "locale: [en]", `unknown field "locale"`},
+		{"wrong type", "locales: en", "cannot unmarshal"},
+		{"layout", "layout: tree", "invalid layout 'tree'"},
+		{"pattern", "keys:\n  pattern: \"[a-z\"", "invalid keys.pattern"},
+		{"duplicate locale", "locales: [en, en]", "duplicate locale 'en'"},
+		{"coverage", "coverage:\n  min: 80", "coverage.min 80 is not between 0 and 1"},
+		{"indentation", "keys:\n  pattern: x\n    max_length: 3", "3:5: unexpected indentation"},
+		{"tabs", "keys:\n\tpattern: x", "2: tabs are not allowed"},
+		{"duplicate key", "output: a\noutput: b", "2:1: duplicate key 'output'"},
+		{"not a mapping", "sources", "1:1: expected 'key: value'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadConfig(writeConfig(t, t.TempDir(), tt.yaml))
+			var fe *FileError
+			if !errors.As(err, &fe) {
+				t.Fatalf("Expected a *FileError, got %v", err)
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Expected error containing %q, got %q", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestFindAndLoadConfig(t *testing.T) {
+	root := t.TempDir()
+	path := writeConfig(t, root, "sources: [cmd, /abs/src]\nlocales: [en]\n")
+	nested := filepath.Join(root, "internal", "auth")
+	if err := os.MkdirAll(nested, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	found, err := FindConfig(nested)
+	if err != nil {
+		t.Fatalf("FindConfig failed: %v", err)
+	}
+	if want, _ := filepath.Abs(path); found != want {
+		t.Errorf("Expected %s, got %s", want, found)
+	}
+
+	cfg, err := LoadConfig(found)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	dir := filepath.Dir(found)
+	if want := []string{filepath.Join(dir, "cmd"), "/abs/src"}; !reflect.DeepEqual(cfg.Sources, want) {
+		t.Errorf("Expected sources %v, got %v", want, cfg.Sources)
+	}
+	if want := filepath.Join(dir, DefaultFolder); cfg.Dir() != want {
+		t.Errorf("Expected output %s, got %s", want, cfg.Dir())
+	}
+
+	if found, err := FindConfig(t.TempDir()); err != nil || found != "" {
+		t.Errorf("Expected no config, got %q, %v", found, err)
+	}
+}
+
+func TestConfigGenerateCatalogs(t *testing.T) {
+	root := t.TempDir()
+	writeTestFile(t, filepath.Join(root, "app", "main.go"), `package main
+
+import "github.com/nyxstack/i18n"
+
+var title, home = i18n.S("Dashboard"), i18n.T("nav.home")
+`)
+	writeTestFile(t, filepath.Join(root, "app", "main_test.go"), `package main
+
+import "github.com/nyxstack/i18n"
+
+var fixture = i18n.S("Test fixture")
+`)
+	writeTestFile(t, filepath.Join(root, "vendor", "lib", "lib.go"), `package lib
+
+import "github.com/nyxstack/i18n"
+
+var vendored = i18n.S("Vendored")
+`)
+	cfg, err := LoadConfig(writeConfig(t, root, `
+exclude: [vendor, "*_test.go"]
+locales: [en, fr]
+layout: nested
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(cfg.Dir(), 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeTestCatalog(t, cfg.Dir(), "default.en.json", "en", map[string]string{"nav.home": "Home"})
+
+	if err := cfg.GenerateCatalogs(context.Background()); err != nil {
+		t.Fatalf("GenerateCatalogs failed: %v", err)
+	}
+	for _, lang := range []string{"en", "fr"} {
+		tf, err := readTranslationFile(filepath.Join(root, DefaultFolder, "default."+lang+".json"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := map[string]string{"dashboard": "Dashboard", "nav.home": "Home"}; !reflect.DeepEqual(tf.Translations, want) {
+			t.Errorf("%s: expected %v, got %v", lang, want, tf.Translations)
+		}
+		if !tf.Nested {
+			t.Errorf("%s: expected the configured nested layout", lang)
+		}
+	}
+
+	// Keys that break the policy are all reported and nothing is written
+	writeTestFile(t, filepath.Join(root, "app", "keys.go"), `package main
+
+import "github.com/nyxstack/i18n"
+
+var a, b = i18n.T("Bad Key"), i18n.T("also.bad.because.it.is.long")
+`)
+	cfg.Keys = KeyPolicy{Pattern: "^[a-z.]+$", MaxLength: 20}
+	_, err = cfg.PlanCatalogs(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "key 'Bad Key' does not match") ||
+		!strings.Contains(err.Error(), "key 'also.bad.because.it.is.long' is longer than 20 characters") {
+		t.Errorf("Expected both key policy violations, got %v", err)
+	}
+}
+
+// writeConfig writes an i18n.yaml into dir and returns its path
+func writeConfig(t *testing.T, dir, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, ConfigFile)
+	writeTestFile(t, path, content)
+	return path
+}
+
+// writeTestFile writes content to path, creating its directory
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestConfigGenerateCatalogs_Group(t *testing.T) {
+	root := t.TempDir()
+	writeTestFile(t, filepath.Join(root, "billing", "invoice.go"), `package billing
+
+import "github.com/nyxstack/i18n"
+
+var title, total = i18n.S("Invoice"), i18n.S("Total due")
+`)
+	writeTestFile(t, filepath.Join(root, "auth", "login.go"), `package auth
+
+import "github.com/nyxstack/i18n"
+
+var title, submit = i18n.S("Sign in"), i18n.S("Continue")
+`)
+	writeTestFile(t, filepath.Join(root, "auth", "reset.go"), `package auth
+
+import "github.com/nyxstack/i18n"
+
+var reset = i18n.S("Reset password")
+`)
+
+	for _, tt := range []struct {
+		group string
+		want  map[string][]string
+		order []string
+	}{
+		{GroupByPackage, map[string][]string{
+			"auth":    {"continue", "reset-password", "sign-in"},
+			"billing": {"invoice", "total-due"},
+		}, []string{"continue", "reset-password", "sign-in", "invoice", "total-due"}},
+		{GroupByFile, map[string][]string{
+			"auth/login.go":      {"continue", "sign-in"},
+			"auth/reset.go":      {"reset-password"},
+			"billing/invoice.go": {"invoice", "total-due"},
+		}, []string{"continue", "sign-in", "reset-password", "invoice", "total-due"}},
+	} {
+		t.Run(tt.group, func(t *testing.T) {
+			cfg, err := LoadConfig(writeConfig(t, root, "locales: [en]\ngroup: "+tt.group+"\n"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := cfg.GenerateCatalogs(context.Background()); err != nil {
+				t.Fatalf("GenerateCatalogs failed: %v", err)
+			}
+
+			path := filepath.Join(cfg.Dir(), "default.en.json")
+			tf, err := readTranslationFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(tf.Groups, tt.want) {
+				t.Errorf("Expected groups %v, got %v", tt.want, tf.Groups)
+			}
+
+			// Translations are written group by group
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			last := -1
+			for _, key := range tt.order {
+				i := strings.Index(string(data), `"`+key+`":`)
+				if i < last {
+					t.Errorf("Expected %q after the keys before it in %v:\n%s", key, tt.order, data)
+				}
+				last = i
+			}
+		})
+	}
+
+	if _, err := ParseConfig([]byte("group: module")); err == nil || !strings.Contains(err.Error(), "invalid group 'module'") {
+		t.Errorf("Expected an invalid group to be rejected, got %v", err)
+	}
+}
+
+func TestConfigGenerateCatalogs_KeepRemoved(t *testing.T) {
+	root := t.TempDir()
+	source := filepath.Join(root, "app.go")
+	writeTestFile(t, source, `package app
+
+import "github.com/nyxstack/i18n"
+
+var hello, bye = i18n.S("Hello"), i18n.S("Bye")
+`)
+	cfg, err := LoadConfig(writeConfig(t, root, "locales: [en, fr]\nkeep_removed: true\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cfg.KeepRemoved {
+		t.Fatal("Expected keep_removed to be read")
+	}
+	if err := cfg.GenerateCatalogs(context.Background()); err != nil {
+		t.Fatalf("GenerateCatalogs failed: %v", err)
+	}
+	fr := filepath.Join(cfg.Dir(), "default.fr.json")
+	if err := ImportCatalogs(cfg.Dir(), map[string]map[string]string{"fr": {"hello": "Bonjour", "bye": "Au revoir"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	// A key no longer used moves to the obsolete section with its translation
+	writeTestFile(t, source, "package app\n\nimport \"github.com/nyxstack/i18n\"\n\nvar hello = i18n.S(\"Hello\")\n")
+	if err := cfg.GenerateCatalogs(context.Background()); err != nil {
+		t.Fatalf("GenerateCatalogs failed: %v", err)
+	}
+	tf, err := readTranslationFile(fr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(tf.Translations, map[string]string{"hello": "Bonjour"}) || !reflect.DeepEqual(tf.Obsolete, map[string]string{"bye": "Au revoir"}) {
+		t.Errorf("Expected bye to be obsolete, got %v and %v", tf.Translations, tf.Obsolete)
+	}
+	dict, err := LoadDictionaryFile(fr)
+	if err != nil || dict.Has("bye") {
+		t.Errorf("Expected obsolete keys not to load, got %v (%v)", dict, err)
+	}
+
+	// ... and gets it back when it is used again
+	writeTestFile(t, source, "package app\n\nimport \"github.com/nyxstack/i18n\"\n\nvar hello, bye = i18n.S(\"Hello\"), i18n.S(\"Bye\")\n")
+	if err := cfg.GenerateCatalogs(context.Background()); err != nil {
+		t.Fatalf("GenerateCatalogs failed: %v", err)
+	}
+	if tf, _ = readTranslationFile(fr); tf.Translations["bye"] != "Au revoir" || tf.Obsolete != nil {
+		t.Errorf("Expected bye to be restored, got %v and %v", tf.Translations, tf.Obsolete)
+	}
+
+	// Without keep_removed, removed keys are deleted
+	cfg.KeepRemoved = false
+	writeTestFile(t, source, "package app\n\nimport \"github.com/nyxstack/i18n\"\n\nvar hello = i18n.S(\"Hello\")\n")
+	if err := cfg.GenerateCatalogs(context.Background()); err != nil {
+		t.Fatalf("GenerateCatalogs failed: %v", err)
+	}
+	if tf, _ = readTranslationFile(fr); len(tf.Translations) != 1 || tf.Obsolete != nil {
+		t.Errorf("Expected bye to be deleted, got %v and %v", tf.Translations, tf.Obsolete)
+	}
+}
+
+func TestConfigGenerateCatalogs_References(t *testing.T) {
+	root := t.TempDir()
+	writeTestFile(t, filepath.Join(root, "app.go"), `package app
+
+import "github.com/nyxstack/i18n"
+
+var hello = i18n.S("Hello")
+
+var bye = i18n.T("bye")
+`)
+	writeTestFile(t, filepath.Join(root, "handlers", "user.go"), `package handlers
+
+import "github.com/nyxstack/i18n"
+
+var hi, again = i18n.S("Hello"), i18n.S("Hello")
+`)
+	cfg, err := LoadConfig(writeConfig(t, root, "locales: [en, fr]\nreferences: true\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cfg.References {
+		t.Fatal("Expected references to be read")
+	}
+	if err := cfg.GenerateCatalogs(context.Background()); err != nil {
+		t.Fatalf("GenerateCatalogs failed: %v", err)
+	}
+
+	want := map[string][]string{
+		"hello": {"app.go:5", "handlers/user.go:5"},
+		"bye":   {"app.go:7"},
+	}
+	for _, lang := range []string{"en", "fr"} {
+		tf, err := readTranslationFile(filepath.Join(cfg.Dir(), "default."+lang+".json"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(tf.References, want) {
+			t.Errorf("%s: expected references %v, got %v", lang, want, tf.References)
+		}
+	}
+
+	// They are left out unless asked for
+	cfg.References = false
+	if err := cfg.GenerateCatalogs(context.Background()); err != nil {
+		t.Fatalf("GenerateCatalogs failed: %v", err)
+	}
+	if tf, _ := readTranslationFile(filepath.Join(cfg.Dir(), "default.en.json")); tf.References != nil {
+		t.Errorf("Expected no references, got %v", tf.References)
+	}
+}
@@ -0,0 +1,57 @@
+package i18n
+
+import "context"
+
+// localeKey is the context key under which the request locale is stored
+type localeKey struct{}
+
+// WithLocale returns a copy of ctx carrying the given locale.
+// Use it once per request (e.g. in middleware) and resolve translations
+// later with FromContext or the *Ctx helpers.
+func WithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, localeKey{}, locale)
+}
+
+// FromContext returns the locale stored in ctx, or the default language if none is set
+func FromContext(ctx context.Context) string {
+	if locale, ok := LocaleFromContext(ctx); ok {
+		return locale
+	}
+	return DefaultLanguage()
+}
+
+// LocaleFromContext returns the locale stored in ctx and whether one was set
+func LocaleFromContext(ctx context.Context) (string, bool) {
+	locale, ok := ctx.Value(localeKey{}).(string)
+	return locale, ok && locale != ""
+}
+
+// TCtx translates a key with placeholders using the locale from ctx.
+//
+// Example:
+//
+//	ctx = i18n.WithLocale(ctx, "fr")
+//	fmt.Println(i18n.TCtx(ctx, "welcome_user", "John")) // "Bienvenue John!"
+func TCtx(ctx context.Context, key string, args ...any) string {
+	return T(key, args...)(FromContext(ctx))
+}
+
+// FCtx translates a format string using the locale from ctx
+func FCtx(ctx context.Context, format string, args ...any) string {
+	return F(format, args...)(FromContext(ctx))
+}
+
+// SCtx translates static text using the locale from ctx
+func SCtx(ctx context.Context, text string) string {
+	return S(text)(FromContext(ctx))
+}
+
+// PCtx handles pluralization using the locale from ctx
+func PCtx(ctx context.Context, key string, count int, args ...any) string {
+	return P(key, count, args...)(FromContext(ctx))
+}
+
+// Ctx renders the translation in the locale stored in ctx
+func (fn TranslatedFunc) Ctx(ctx context.Context) string {
+	return fn(FromContext(ctx))
+}
@@ -0,0 +1,60 @@
+package i18n
+
+import (
+	"context"
+	"testing"
+)
+
+func TestWithLocaleAndFromContext(t *testing.T) {
+	original := DefaultLanguage()
+	defer SetDefaultLanguage(original)
+	SetDefaultLanguage("en")
+
+	ctx := context.Background()
+	if locale := FromContext(ctx); locale != "en" {
+		t.Errorf("Expected default language 'en', got '%s'", locale)
+	}
+	if _, ok := LocaleFromContext(ctx); ok {
+		t.Error("Expected no locale in empty context")
+	}
+
+	ctx = WithLocale(ctx, "fr")
+	if locale := FromContext(ctx); locale != "fr" {
+		t.Errorf("Expected 'fr', got '%s'", locale)
+	}
+
+	// An empty locale is treated as unset
+	if locale := FromContext(WithLocale(ctx, "")); locale != "en" {
+		t.Errorf("Expected default language for empty locale, got '%s'", locale)
+	}
+}
+
+func TestContextHelpers(t *testing.T) {
+	setupTestDictionaries()
+	defer func() {
+		defaultBundle.mu.Lock()
+		defaultBundle.dictionaries = make(map[string]*Dictionary)
+		defaultBundle.mu.Unlock()
+	}()
+
+	ctx := WithLocale(context.Background(), "fr")
+
+	tests := []struct {
+		name     string
+		result   string
+		expected string
+	}{
+		{"TCtx", TCtx(ctx, "hello-0", "John"), "Bonjour John"},
+		{"FCtx", FCtx(ctx, "Hello %s", "John"), "Bonjour John"},
+		{"SCtx", SCtx(ctx, "Dashboard"), "Tableau de bord"},
+		{"PCtx", PCtx(ctx, "item-count", 2), "2 éléments"},
+		{"Ctx", S("Welcome").Ctx(ctx), "Bienvenue"},
+		{"default", SCtx(context.Background(), "Dashboard"), "Dashboard"},
+	}
+
+	for _, tt := range tests {
+		if tt.result != tt.expected {
+			t.Errorf("%s: expected '%s', got '%s'", tt.name, tt.expected, tt.result)
+		}
+	}
+}
@@ -0,0 +1,13 @@
+module github.com/nyxstack/i18n/contrib/i18npackages
+
+go 1.24.2
+
+replace github.com/nyxstack/i18n => ../../
+
+require github.com/nyxstack/i18n v0.0.0
+
+require (
+	golang.org/x/mod v0.26.0 // indirect
+	golang.org/x/sync v0.16.0 // indirect
+	golang.org/x/tools v0.35.0
+)
@@ -0,0 +1,282 @@
+// Package i18npackages extracts i18n calls from Go packages with type
+// information, through golang.org/x/tools/go/packages, so calls the
+// syntax-only i18n.GoExtractor cannot resolve are found too:
+//
+//   - package functions however the package is imported or re-declared
+//   - methods of *i18n.Bundle values (bundle.T("key"))
+//   - methods of i18n.Namespace values created with a constant name
+//     (i18n.NS("auth").T("key"), or a variable assigned one), whose keys
+//     get the namespace prefix
+//   - constant first arguments (i18n.T(KeyWelcome)), not only literals
+//
+// Register its entries before generating catalogs:
+//
+//	if err := i18npackages.Register(ctx, ".", "./..."); err != nil {
+//		log.Fatal(err)
+//	}
+//	err := i18n.GenerateCatalogs(ctx, ".", "locales", "en", "fr")
+//
+// Loading packages runs the go command, and so needs the module's
+// dependencies to be available.
+package i18npackages
+
+import (
+	"context"
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"path/filepath"
+
+	"github.com/nyxstack/i18n"
+	"golang.org/x/tools/go/packages"
+)
+
+var (
+	// textFuncs take a source text, keyFuncs a key, like in i18n.GoExtractor
+	textFuncs = map[string]bool{"F": true, "S": true}
+	keyFuncs  = map[string]bool{"T": true, "P": true, "P64": true, "Pf": true, "Sel": true, "O": true, "Errorf": true, "THTML": true, "TRandom": true}
+
+	// localeKeyFuncs take a locale, then a key; GetInto's key names an object
+	localeKeyFuncs = map[string]bool{"GetInt": true, "GetBool": true, "GetDuration": true, "GetSlice": true, "GetInto": true}
+)
+
+// Extract loads the packages matching patterns (see go help packages),
+// relative to dir, and returns the i18n calls in them, in package and file
+// order. Test files are included. Packages with errors are reported rather
+// than skipped, as their calls could not all be resolved.
+func Extract(ctx context.Context, dir string, patterns ...string) ([]i18n.Entry, error) {
+	files, err := extract(ctx, dir, patterns)
+	if err != nil {
+		return nil, err
+	}
+	var entries []i18n.Entry
+	for _, f := range files {
+		entries = append(entries, f.entries...)
+	}
+	return entries, nil
+}
+
+// Register runs Extract and registers an extractor that returns its entries
+// for the Go files they were found in, so GenerateCatalogs and the other
+// generators include them. The entries are those of the time of the call;
+// call Register again after the sources change. i18n.GoExtractor stays
+// registered, and entries both find are merged.
+func Register(ctx context.Context, dir string, patterns ...string) error {
+	files, err := extract(ctx, dir, patterns)
+	if err != nil {
+		return err
+	}
+	byPath := make(map[string][]i18n.Entry, len(files))
+	for _, f := range files {
+		byPath[f.path] = f.entries
+	}
+	return i18n.RegisterExtractor("*.go", i18n.ExtractorFunc(func(path string, src []byte) ([]i18n.Entry, error) {
+		abs, err := filepath.Abs(path)
+		if err != nil {
+			return nil, nil
+		}
+		return byPath[abs], nil
+	}))
+}
+
+// fileEntries are the entries of one file, by its absolute path
+type fileEntries struct {
+	path    string
+	entries []i18n.Entry
+}
+
+// extract loads the packages and returns the entries of each of their files
+func extract(ctx context.Context, dir string, patterns []string) ([]fileEntries, error) {
+	cfg := &packages.Config{
+		Context: ctx,
+		Dir:     dir,
+		Mode:    packages.NeedName | packages.NeedFiles | packages.NeedImports | packages.NeedDeps | packages.NeedSyntax | packages.NeedTypes | packages.NeedTypesInfo,
+		Tests:   true,
+	}
+	pkgs, err := packages.Load(cfg, patterns...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load packages: %w", err)
+	}
+
+	var files []fileEntries
+	seen := make(map[string]bool) // test variants repeat the package's files
+	for _, pkg := range pkgs {
+		if len(pkg.Errors) > 0 {
+			return nil, fmt.Errorf("failed to load package %s: %v", pkg.PkgPath, pkg.Errors[0])
+		}
+		namespaces := namespaceVars(pkg.TypesInfo, pkg.Syntax)
+		for _, file := range pkg.Syntax {
+			path := pkg.Fset.Position(file.Pos()).Filename
+			if seen[path] {
+				continue
+			}
+			seen[path] = true
+			files = append(files, fileEntries{path: path, entries: extractFile(pkg, file, namespaces)})
+		}
+	}
+	return files, nil
+}
+
+// extractFile returns the i18n calls of a type-checked file
+func extractFile(pkg *packages.Package, file *ast.File, namespaces map[types.Object]string) []i18n.Entry {
+	var entries []i18n.Entry
+	ast.Inspect(file, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || len(call.Args) == 0 {
+			return true
+		}
+		fn, recv := callee(pkg.TypesInfo, call)
+		if fn == nil || fn.Pkg() == nil || fn.Pkg().Path() != i18n.ImportPath {
+			return true
+		}
+		name := fn.Name()
+		sig := fn.Type().(*types.Signature)
+		arg := 0
+		switch {
+		case textFuncs[name], keyFuncs[name]:
+		case localeKeyFuncs[name] && sig.Recv() == nil && len(call.Args) > 1:
+			arg = 1
+		default:
+			return true
+		}
+
+		value, ok := stringConst(pkg.TypesInfo, call.Args[arg])
+		if !ok {
+			return true
+		}
+
+		prefix := ""
+		if sig.Recv() != nil {
+			switch named(sig.Recv().Type()) {
+			case "Bundle":
+			case "Namespace":
+				ns, ok := namespaceName(pkg.TypesInfo, recv, namespaces)
+				if !ok {
+					return true
+				}
+				prefix = ns + i18n.KeySeparator
+			default:
+				return true
+			}
+		}
+
+		entry := i18n.Entry{
+			Pos:   pkg.Fset.Position(call.Args[arg].Pos()).String(),
+			Func:  types.ExprString(call.Fun),
+			Block: name == "GetInto",
+		}
+		if keyFuncs[name] || localeKeyFuncs[name] {
+			entry.Key = prefix + value
+		} else {
+			entry.Text = value
+		}
+		entries = append(entries, entry)
+		return true
+	})
+	return entries
+}
+
+// callee returns the function a call calls and, for method calls, the
+// receiver expression
+func callee(info *types.Info, call *ast.CallExpr) (*types.Func, ast.Expr) {
+	switch fun := ast.Unparen(call.Fun).(type) {
+	case *ast.Ident:
+		fn, _ := info.Uses[fun].(*types.Func)
+		return fn, nil
+	case *ast.SelectorExpr:
+		fn, _ := info.Uses[fun.Sel].(*types.Func)
+		if sel, ok := info.Selections[fun]; ok && sel.Kind() == types.MethodVal {
+			return fn, fun.X
+		}
+		return fn, nil
+	}
+	return nil, nil
+}
+
+// named returns the name of a possibly pointer named type of this package
+func named(t types.Type) string {
+	if p, ok := t.(*types.Pointer); ok {
+		t = p.Elem()
+	}
+	n, ok := t.(*types.Named)
+	if !ok || n.Obj().Pkg() == nil || n.Obj().Pkg().Path() != i18n.ImportPath {
+		return ""
+	}
+	return n.Obj().Name()
+}
+
+// stringConst returns the value of a constant string expression
+func stringConst(info *types.Info, expr ast.Expr) (string, bool) {
+	tv, ok := info.Types[expr]
+	if !ok || tv.Value == nil || tv.Value.Kind() != constant.String {
+		return "", false
+	}
+	return constant.StringVal(tv.Value), true
+}
+
+// namespaceName returns the name of the namespace expr evaluates to: a call
+// of NS with a constant name, or a variable assigned one
+func namespaceName(info *types.Info, expr ast.Expr, vars map[types.Object]string) (string, bool) {
+	expr = ast.Unparen(expr)
+	if id, ok := expr.(*ast.Ident); ok {
+		name, ok := vars[info.Uses[id]]
+		return name, ok
+	}
+	call, ok := expr.(*ast.CallExpr)
+	if !ok || len(call.Args) != 1 {
+		return "", false
+	}
+	fn, _ := callee(info, call)
+	if fn == nil || fn.Pkg() == nil || fn.Pkg().Path() != i18n.ImportPath || fn.Name() != "NS" {
+		return "", false
+	}
+	return stringConst(info, call.Args[0])
+}
+
+// namespaceVars returns the variables of a package that are assigned
+// exactly once, with a namespace namespaceName resolves
+func namespaceVars(info *types.Info, files []*ast.File) map[types.Object]string {
+	vars := make(map[types.Object]string)
+	assigned := make(map[types.Object]int)
+	bind := func(lhs []ast.Expr, rhs []ast.Expr) {
+		for i, l := range lhs {
+			id, ok := l.(*ast.Ident)
+			if !ok {
+				continue
+			}
+			obj := info.ObjectOf(id)
+			if obj == nil {
+				continue
+			}
+			assigned[obj]++
+			if len(lhs) != len(rhs) {
+				continue
+			}
+			if name, ok := namespaceName(info, rhs[i], nil); ok {
+				vars[obj] = name
+			}
+		}
+	}
+	for _, file := range files {
+		ast.Inspect(file, func(n ast.Node) bool {
+			switch s := n.(type) {
+			case *ast.AssignStmt:
+				bind(s.Lhs, s.Rhs)
+			case *ast.ValueSpec:
+				lhs := make([]ast.Expr, len(s.Names))
+				for i, id := range s.Names {
+					lhs[i] = id
+				}
+				bind(lhs, s.Values)
+			}
+			return true
+		})
+	}
+	for obj, n := range assigned {
+		if n != 1 {
+			delete(vars, obj)
+		}
+	}
+	return vars
+}
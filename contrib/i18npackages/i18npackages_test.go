@@ -0,0 +1,167 @@
+package i18npackages
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/nyxstack/i18n"
+)
+
+// writeModule writes a module that uses this repository's i18n package
+func writeModule(t *testing.T, files map[string]string) string {
+	t.Helper()
+	root, err := filepath.Abs("../..")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	files["go.mod"] = "module example.com/app\n\ngo 1.24\n\nrequire github.com/nyxstack/i18n v0.0.0\n\nreplace github.com/nyxstack/i18n => " + root + "\n"
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestExtract(t *testing.T) {
+	dir := writeModule(t, map[string]string{
+		"main.go": `package main
+
+import (
+	tr "github.com/nyxstack/i18n"
+)
+
+const KeyWelcome = "welcome"
+
+var auth = tr.NS("auth")
+
+func main() {
+	b := tr.NewBundle()
+	_ = tr.S("Dashboard")
+	_ = tr.T(KeyWelcome)
+	_ = b.F("Hello %s", "Anne")
+	_ = b.P("item_count", 2)
+	_ = auth.T("login")
+	_ = tr.NS("billing").T("invoice")
+	_ = translate("ignored")
+}
+
+func translate(key string) tr.TranslatedFunc {
+	return tr.T(key)
+}
+`,
+		"util/util.go": `package util
+
+import "github.com/nyxstack/i18n"
+
+var dynamic = i18n.NS("a")
+
+func init() {
+	dynamic = i18n.NS("b")
+	_ = dynamic.T("unknown")
+}
+
+func Title() string { return i18n.S("Title " + "case")("en") }
+`,
+	})
+
+	entries, err := Extract(context.Background(), dir, "./...")
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	var got []string
+	for _, e := range entries {
+		got = append(got, e.Func+" "+e.Key+e.Text)
+	}
+	want := []string{
+		"tr.S Dashboard",
+		"tr.T welcome",
+		"b.F Hello %s",
+		"b.P item_count",
+		"auth.T auth.login",
+		`tr.NS("billing").T billing.invoice`,
+		"i18n.S Title case",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
+
+func TestExtract_KeyFuncs(t *testing.T) {
+	dir := writeModule(t, map[string]string{
+		"main.go": `package main
+
+import "github.com/nyxstack/i18n"
+
+func main() {
+	_ = i18n.Errorf("quota_exceeded", "Pro")
+	_ = i18n.NewBundle().THTML("terms_html")
+	_ = i18n.TRandom("empty_inbox")
+	_, _ = i18n.GetInt("fr", "items_per_page")
+	var banner struct{ Title string }
+	_ = i18n.GetInto("fr", "empty_state", &banner)
+}
+`,
+	})
+
+	entries, err := Extract(context.Background(), dir, "./...")
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	var got []string
+	for _, e := range entries {
+		got = append(got, e.Func+" "+e.Key)
+		if e.Block != (e.Key == "empty_state") {
+			t.Errorf("Expected only GetInto's key to be a block, got %+v", e)
+		}
+	}
+	want := []string{"i18n.Errorf quota_exceeded", "i18n.NewBundle().THTML terms_html", "i18n.TRandom empty_inbox", "i18n.GetInt items_per_page", "i18n.GetInto empty_state"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
+
+func TestExtract_Errors(t *testing.T) {
+	dir := writeModule(t, map[string]string{
+		"main.go": "package main\n\nfunc main() { undefined() }\n",
+	})
+	if _, err := Extract(context.Background(), dir, "./..."); err == nil {
+		t.Error("Expected an error for a package that does not type-check")
+	}
+}
+
+func TestRegister(t *testing.T) {
+	dir := writeModule(t, map[string]string{
+		"main.go": `package main
+
+import "github.com/nyxstack/i18n"
+
+var b = i18n.NewBundle()
+
+func main() { _ = b.T("bundle_key") }
+`,
+	})
+	if err := Register(context.Background(), dir, "./..."); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	entries, err := i18n.ExtractDir(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("ExtractDir failed: %v", err)
+	}
+	found := false
+	for _, e := range entries {
+		found = found || e.Key == "bundle_key"
+	}
+	if !found {
+		t.Errorf("Expected the bundle call among %+v", entries)
+	}
+}
@@ -0,0 +1,90 @@
+// Package i18nprom counts i18n translation lookups with Prometheus, so
+// operators can see which locales degrade to the fallback language in
+// production.
+//
+//	metrics, err := i18nprom.New(prometheus.DefaultRegisterer)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	i18n.SetMetrics(metrics)
+//
+// It exports these counters:
+//
+//	i18n_lookups_total{locale}            translations rendered
+//	i18n_fallbacks_total{locale, lang}    served from another language
+//	i18n_missing_total{locale}            not translated in any language
+//	i18n_locale_cache_total{result}       LocaleCache hits and misses
+//
+// Locales are used as label values as given to the translation functions,
+// so negotiate them (MatchLocale, Middleware) before rendering rather than
+// passing raw client input, to keep the number of series bounded.
+package i18nprom
+
+import (
+	"github.com/nyxstack/i18n"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Collector implements i18n.Collector with Prometheus counters
+type Collector struct {
+	lookups   *prometheus.CounterVec
+	fallbacks *prometheus.CounterVec
+	missing   *prometheus.CounterVec
+	cache     *prometheus.CounterVec
+}
+
+var _ i18n.Collector = (*Collector)(nil)
+
+// New creates the counters and registers them with reg
+func New(reg prometheus.Registerer) (*Collector, error) {
+	c := &Collector{
+		lookups: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "i18n_lookups_total",
+			Help: "Translations rendered, by requested locale.",
+		}, []string{"locale"}),
+		fallbacks: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "i18n_fallbacks_total",
+			Help: "Translations served from a fallback language, by requested locale and language used.",
+		}, []string{"locale", "lang"}),
+		missing: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "i18n_missing_total",
+			Help: "Translations missing from every language, by requested locale.",
+		}, []string{"locale"}),
+		cache: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "i18n_locale_cache_total",
+			Help: "LocaleCache lookups, by result (hit or miss).",
+		}, []string{"result"}),
+	}
+
+	for _, counter := range []*prometheus.CounterVec{c.lookups, c.fallbacks, c.missing, c.cache} {
+		if err := reg.Register(counter); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// Lookup counts a translation rendered for locale
+func (c *Collector) Lookup(locale string) {
+	c.lookups.WithLabelValues(locale).Inc()
+}
+
+// Fallback counts a translation for locale served from lang
+func (c *Collector) Fallback(locale, lang string) {
+	c.fallbacks.WithLabelValues(locale, lang).Inc()
+}
+
+// Missing counts a translation missing for locale. Keys are not used as
+// labels, as they are unbounded; use i18n.OnMissing to collect them.
+func (c *Collector) Missing(locale, _ string) {
+	c.missing.WithLabelValues(locale).Inc()
+}
+
+// CacheLookup counts a LocaleCache hit or miss
+func (c *Collector) CacheLookup(hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	c.cache.WithLabelValues(result).Inc()
+}
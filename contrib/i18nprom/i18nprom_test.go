@@ -0,0 +1,66 @@
+package i18nprom
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nyxstack/i18n"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestCollector(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	metrics, err := New(reg)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	i18n.SetMetrics(metrics)
+	t.Cleanup(func() { i18n.SetMetrics(nil) })
+
+	b := i18n.NewBundle()
+	en := i18n.NewDictionary("en")
+	en.AddAll(map[string]string{"title": "Dashboard", "save": "Save"})
+	fr := i18n.NewDictionary("fr")
+	fr.Add("title", "Tableau de bord")
+	b.Register(en)
+	b.Register(fr)
+
+	b.T("title")("fr")
+	b.T("save")("fr")
+	b.T("logout")("fr")
+
+	cache := i18n.NewLocaleCache(time.Minute, 0)
+	cache.Resolve("client", func() string { return "fr" })
+	cache.Resolve("client", func() string { return "fr" })
+
+	want := `
+# HELP i18n_fallbacks_total Translations served from a fallback language, by requested locale and language used.
+# TYPE i18n_fallbacks_total counter
+i18n_fallbacks_total{lang="en",locale="fr"} 1
+# HELP i18n_locale_cache_total LocaleCache lookups, by result (hit or miss).
+# TYPE i18n_locale_cache_total counter
+i18n_locale_cache_total{result="hit"} 1
+i18n_locale_cache_total{result="miss"} 1
+# HELP i18n_lookups_total Translations rendered, by requested locale.
+# TYPE i18n_lookups_total counter
+i18n_lookups_total{locale="fr"} 3
+# HELP i18n_missing_total Translations missing from every language, by requested locale.
+# TYPE i18n_missing_total counter
+i18n_missing_total{locale="fr"} 1
+`
+	if err := testutil.GatherAndCompare(reg, strings.NewReader(want)); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestNew_AlreadyRegistered(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	if _, err := New(reg); err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	if _, err := New(reg); err == nil {
+		t.Error("Expected registering the counters twice to fail")
+	}
+}
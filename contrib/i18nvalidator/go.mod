@@ -0,0 +1,21 @@
+module github.com/nyxstack/i18n/contrib/i18nvalidator
+
+go 1.24.2
+
+replace github.com/nyxstack/i18n => ../../
+
+require (
+	github.com/go-playground/validator/v10 v10.26.0
+	github.com/nyxstack/i18n v0.0.0
+)
+
+require (
+	github.com/gabriel-vasile/mimetype v1.4.8 // indirect
+	github.com/go-playground/locales v0.14.1 // indirect
+	github.com/go-playground/universal-translator v0.18.1 // indirect
+	github.com/leodido/go-urn v1.4.0 // indirect
+	golang.org/x/crypto v0.33.0 // indirect
+	golang.org/x/net v0.34.0 // indirect
+	golang.org/x/sys v0.30.0 // indirect
+	golang.org/x/text v0.22.0 // indirect
+)
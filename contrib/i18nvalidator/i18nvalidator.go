@@ -0,0 +1,98 @@
+// Package i18nvalidator translates go-playground/validator errors into
+// localized messages using i18n catalogs.
+//
+// Messages are looked up per field and tag, then per tag:
+//
+//	"validation.email.required": "Please enter your email address",
+//	"validation.required":       "{field} is required",
+//	"validation.min":            "{field} must be at least {param} characters",
+//	"field.email":               "Email address"
+//
+// {field} is the localized field name ("field.<name>", or the field name
+// itself), {param} the tag parameter and {value} the rejected value. Errors
+// without a catalog entry keep the validator's English message.
+package i18nvalidator
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/nyxstack/i18n"
+)
+
+// Catalog key prefixes
+const (
+	ValidationPrefix = "validation."
+	FieldPrefix      = "field."
+)
+
+// Translator renders validation errors with the dictionaries of a bundle
+type Translator struct {
+	bundle *i18n.Bundle
+}
+
+// New creates a translator using bundle, or the default bundle if nil
+func New(bundle *i18n.Bundle) *Translator {
+	if bundle == nil {
+		bundle = i18n.DefaultBundle()
+	}
+	return &Translator{bundle: bundle}
+}
+
+// Translate returns the localized messages of the validation errors in err
+// using the default bundle. See Translator.Translate.
+func Translate(locale string, err error) map[string]string {
+	return New(nil).Translate(locale, err)
+}
+
+// Translate returns the localized message of every validation error in err,
+// keyed by field name. It returns nil if err holds no validation errors.
+//
+// Example:
+//
+//	if err := validate.Struct(form); err != nil {
+//		messages := i18nvalidator.Translate(locale, err)
+//		// map[email:Please enter your email address password:Password must be at least 8 characters]
+//	}
+func (t *Translator) Translate(locale string, err error) map[string]string {
+	var errs validator.ValidationErrors
+	if !errors.As(err, &errs) {
+		return nil
+	}
+
+	messages := make(map[string]string, len(errs))
+	for _, fe := range errs {
+		messages[fe.Field()] = t.Message(locale, fe)
+	}
+	return messages
+}
+
+// Message returns the localized message of a single field error
+func (t *Translator) Message(locale string, fe validator.FieldError) string {
+	field := strings.ToLower(fe.Field())
+	args := i18n.Args{
+		"field": t.text(locale, FieldPrefix+field, fe.Field()),
+		"param": fe.Param(),
+		"value": fmt.Sprint(fe.Value()),
+	}
+
+	for _, key := range []string{
+		ValidationPrefix + field + "." + fe.Tag(),
+		ValidationPrefix + fe.Tag(),
+	} {
+		if msg := t.bundle.T(key, args)(locale); msg != key {
+			return msg
+		}
+	}
+	return fe.Error()
+}
+
+// text returns the translation of key, or fallback if there is none
+func (t *Translator) text(locale, key, fallback string) string {
+	if msg := t.bundle.T(key)(locale); msg != key {
+		return msg
+	}
+	return fallback
+}
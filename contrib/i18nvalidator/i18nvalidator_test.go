@@ -0,0 +1,70 @@
+package i18nvalidator
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/nyxstack/i18n"
+)
+
+type signupForm struct {
+	Email    string `validate:"required,email"`
+	Password string `validate:"min=8"`
+	Age      int    `validate:"gte=18"`
+}
+
+func newTestBundle() *i18n.Bundle {
+	b := i18n.NewBundle()
+
+	en := i18n.NewDictionary("en")
+	en.AddAll(map[string]string{
+		"validation.required":       "{field} is required",
+		"validation.min":            "{field} must be at least {param} characters",
+		"validation.email.required": "Please enter your email address",
+		"field.password":            "Password",
+	})
+	b.Register(en)
+
+	fr := i18n.NewDictionary("fr")
+	fr.AddAll(map[string]string{
+		"validation.min": "{field} doit contenir au moins {param} caractères",
+		"field.password": "Le mot de passe",
+	})
+	b.Register(fr)
+
+	return b
+}
+
+func TestTranslate(t *testing.T) {
+	err := validator.New().Struct(signupForm{Password: "short", Age: 12})
+	tr := New(newTestBundle())
+
+	messages := tr.Translate("en", err)
+	expected := map[string]string{
+		"Email":    "Please enter your email address",
+		"Password": "Password must be at least 8 characters",
+		"Age":      "Key: 'signupForm.Age' Error:Field validation for 'Age' failed on the 'gte' tag",
+	}
+	if !reflect.DeepEqual(messages, expected) {
+		t.Errorf("Expected %v, got %v", expected, messages)
+	}
+
+	messages = tr.Translate("fr", err)
+	if messages["Password"] != "Le mot de passe doit contenir au moins 8 caractères" {
+		t.Errorf("Expected French message, got '%s'", messages["Password"])
+	}
+	if messages["Email"] != "Please enter your email address" {
+		t.Errorf("Expected fallback to the default language, got '%s'", messages["Email"])
+	}
+}
+
+func TestTranslate_NotValidationErrors(t *testing.T) {
+	if messages := New(newTestBundle()).Translate("en", errors.New("boom")); messages != nil {
+		t.Errorf("Expected nil for non-validation errors, got %v", messages)
+	}
+	if messages := Translate("en", nil); messages != nil {
+		t.Errorf("Expected nil for nil error, got %v", messages)
+	}
+}
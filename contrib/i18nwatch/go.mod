@@ -0,0 +1,12 @@
+module github.com/nyxstack/i18n/contrib/i18nwatch
+
+go 1.24.2
+
+replace github.com/nyxstack/i18n => ../../
+
+require (
+	github.com/fsnotify/fsnotify v1.9.0
+	github.com/nyxstack/i18n v0.0.0
+)
+
+require golang.org/x/sys v0.13.0 // indirect
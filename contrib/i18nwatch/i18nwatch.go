@@ -0,0 +1,160 @@
+// Package i18nwatch reloads i18n catalogs when their files change, so
+// translators see new strings without restarting the server.
+//
+//	w, err := i18nwatch.Watch("locales", func(err error) {
+//		log.Printf("i18n reload: %v", err)
+//	})
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	defer w.Close()
+//
+// A change to any {name}.{lang}.json file reloads every file of that
+// language and swaps the merged dictionary in one step; other languages are
+// not touched. A file that fails to load keeps the previous dictionary
+// registered and is reported to onError. Saves that leave the content of a
+// language unchanged are skipped, and Bundle.OnChange listeners only hear
+// about real changes.
+package i18nwatch
+
+import (
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/nyxstack/i18n"
+)
+
+// Debounce is how long a language's files must be quiet before they are
+// reloaded, as editors often save a file in several writes
+var Debounce = 100 * time.Millisecond
+
+// Watcher reloads the catalogs of a directory until it is closed
+type Watcher struct {
+	bundle  *i18n.Bundle
+	dir     string
+	onError func(error)
+	fs      *fsnotify.Watcher
+	done    chan struct{}
+
+	mu      sync.Mutex
+	pending map[string]*time.Timer // language → scheduled reload
+	closed  bool
+}
+
+// Watch reloads the catalogs of dir into the default bundle when they change.
+// onError, which may be nil, receives reload and watcher errors.
+func Watch(dir string, onError func(error)) (*Watcher, error) {
+	return WatchBundle(nil, dir, onError)
+}
+
+// WatchBundle is like Watch but reloads into bundle, or the default bundle if nil
+func WatchBundle(bundle *i18n.Bundle, dir string, onError func(error)) (*Watcher, error) {
+	if bundle == nil {
+		bundle = i18n.DefaultBundle()
+	}
+	if onError == nil {
+		onError = func(error) {}
+	}
+
+	fsw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	if err := fsw.Add(dir); err != nil {
+		fsw.Close()
+		return nil, err
+	}
+
+	w := &Watcher{
+		bundle:  bundle,
+		dir:     dir,
+		onError: onError,
+		fs:      fsw,
+		done:    make(chan struct{}),
+		pending: make(map[string]*time.Timer),
+	}
+	go w.run()
+	return w, nil
+}
+
+// Close stops watching. Reloads already scheduled are canceled.
+func (w *Watcher) Close() error {
+	w.mu.Lock()
+	w.closed = true
+	for lang, timer := range w.pending {
+		timer.Stop()
+		delete(w.pending, lang)
+	}
+	w.mu.Unlock()
+
+	err := w.fs.Close()
+	<-w.done
+	return err
+}
+
+// run handles watcher events until the watcher is closed
+func (w *Watcher) run() {
+	defer close(w.done)
+	for {
+		select {
+		case event, ok := <-w.fs.Events:
+			if !ok {
+				return
+			}
+			if event.Op == fsnotify.Chmod {
+				continue
+			}
+			if lang := catalogLanguage(event.Name); lang != "" {
+				w.schedule(lang)
+			}
+		case err, ok := <-w.fs.Errors:
+			if !ok {
+				return
+			}
+			w.onError(err)
+		}
+	}
+}
+
+// schedule reloads lang once its files have been quiet for Debounce
+func (w *Watcher) schedule(lang string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return
+	}
+	if timer, ok := w.pending[lang]; ok {
+		timer.Reset(Debounce)
+		return
+	}
+	w.pending[lang] = time.AfterFunc(Debounce, func() {
+		w.mu.Lock()
+		delete(w.pending, lang)
+		closed := w.closed
+		w.mu.Unlock()
+		if closed {
+			return
+		}
+
+		if err := w.bundle.LoadDirLanguage(w.dir, lang); err != nil {
+			w.onError(err)
+		}
+	})
+}
+
+// catalogLanguage returns the language of a {name}.{lang}.json file, or "" if
+// path is not a catalog
+func catalogLanguage(path string) string {
+	base := filepath.Base(path)
+	if filepath.Ext(base) != ".json" {
+		return ""
+	}
+	parts := strings.Split(strings.TrimSuffix(base, ".json"), ".")
+	if len(parts) < 2 {
+		return ""
+	}
+	return parts[len(parts)-1]
+}
@@ -0,0 +1,107 @@
+package i18nwatch
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nyxstack/i18n"
+)
+
+func writeCatalog(t *testing.T, dir, file, lang string, translations map[string]string) {
+	t.Helper()
+	data, err := json.Marshal(map[string]any{
+		"meta":         map[string]string{"lang": lang, "name": "default"},
+		"translations": translations,
+	})
+	if err != nil {
+		t.Fatalf("Failed to marshal catalog: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, file), data, 0644); err != nil {
+		t.Fatalf("Failed to write catalog: %v", err)
+	}
+}
+
+// eventually polls cond until it holds or a second has passed
+func eventually(t *testing.T, cond func() bool, msg string) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal(msg)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestWatchBundle(t *testing.T) {
+	dir := t.TempDir()
+	writeCatalog(t, dir, "default.fr.json", "fr", map[string]string{"title": "Tableau"})
+
+	b := i18n.NewBundle()
+	if err := b.LoadDir(dir); err != nil {
+		t.Fatalf("LoadDir failed: %v", err)
+	}
+	en := i18n.NewDictionary("en")
+	b.Register(en)
+
+	var mu sync.Mutex
+	var errs []error
+	w, err := WatchBundle(b, dir, func(err error) {
+		mu.Lock()
+		errs = append(errs, err)
+		mu.Unlock()
+	})
+	if err != nil {
+		t.Fatalf("WatchBundle failed: %v", err)
+	}
+	defer w.Close()
+
+	writeCatalog(t, dir, "default.fr.json", "fr", map[string]string{"title": "Tableau de bord"})
+	eventually(t, func() bool { return b.S("Title")("fr") == "Tableau de bord" }, "Expected fr to be reloaded")
+
+	// New files are picked up and merged with the other files of the language
+	writeCatalog(t, dir, "auth.fr.json", "fr", map[string]string{"login": "Connexion"})
+	eventually(t, func() bool { return b.T("login")("fr") == "Connexion" }, "Expected the new file to be loaded")
+	if b.S("Title")("fr") != "Tableau de bord" {
+		t.Error("Expected the other fr file to stay loaded")
+	}
+	if b.GetDictionary("en") != en {
+		t.Error("Expected en to be left untouched")
+	}
+
+	// A broken file is reported and the previous dictionary kept
+	os.WriteFile(filepath.Join(dir, "auth.fr.json"), []byte("{"), 0644)
+	eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(errs) > 0
+	}, "Expected the broken file to be reported")
+	if b.T("login")("fr") != "Connexion" {
+		t.Error("Expected the previous fr dictionary to stay registered")
+	}
+}
+
+func TestWatch_MissingDir(t *testing.T) {
+	if _, err := Watch(filepath.Join(t.TempDir(), "missing"), nil); err == nil {
+		t.Error("Expected error for a missing directory, got nil")
+	}
+}
+
+func TestCatalogLanguage(t *testing.T) {
+	tests := map[string]string{
+		"locales/default.fr.json": "fr",
+		"auth.pt-BR.json":         "pt-BR",
+		"default.json":            "",
+		"default.fr.json.swp":     "",
+		"notes.txt":               "",
+	}
+	for path, want := range tests {
+		if got := catalogLanguage(path); got != want {
+			t.Errorf("catalogLanguage(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
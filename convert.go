@@ -0,0 +1,195 @@
+package i18n
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// catalogFormat returns the format of a catalog file by its extension
+func catalogFormat(path string) (Format, error) {
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".json":
+		return FormatJSON, nil
+	case ".csv":
+		return FormatCSV, nil
+	case ".tsv":
+		return FormatTSV, nil
+	case ".yaml", ".yml":
+		return FormatYAML, nil
+	case ".po":
+		return FormatPO, nil
+	case ".xliff", ".xlf":
+		return FormatXLIFF, nil
+	default:
+		return 0, fmt.Errorf("unsupported format '%s' of %s (expected .json, .yaml, .po, .xliff, .csv or .tsv)", ext, path)
+	}
+}
+
+// ConvertCatalog converts the catalog at src into the format of dst, both
+// chosen by extension: .json for translation files, flat or nested as src
+// is, .yaml (or .yml) for the same structure in YAML, .po for gettext files
+// with keys as msgid, .xliff (or .xlf) for XLIFF 1.2 documents with keys as
+// trans-unit ids, and .csv or .tsv for spreadsheets with a key column and a
+// column per language (see ExportCSV). Converting a spreadsheet of several
+// languages takes the column of lang, which may be empty for a single
+// language; lang is also the language of PO and XLIFF files that do not
+// name theirs.
+//
+// It returns warnings for the parts of src that dst cannot hold: JSON and
+// YAML hold everything, PO files string translations with their comments,
+// references and fuzzy marks, XLIFF documents string translations with
+// their comments, and spreadsheets string translations only. Spreadsheet
+// columns are written in language order.
+//
+// Example:
+//
+//	warnings, err := i18n.ConvertCatalog("locales/default.fr.json", "review/fr.po", "")
+//	for _, w := range warnings {
+//		log.Println(w)
+//	}
+func ConvertCatalog(src, dst, lang string) ([]string, error) {
+	from, err := catalogFormat(src)
+	if err != nil {
+		return nil, err
+	}
+	to, err := catalogFormat(dst)
+	if err != nil {
+		return nil, err
+	}
+
+	var tf *TranslationFile
+	var columns map[string]map[string]string
+	if from == FormatJSON {
+		if tf, err = readTranslationFile(src); err != nil {
+			return nil, err
+		}
+	} else {
+		data, err := os.ReadFile(filepath.Clean(src))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", src, err)
+		}
+		switch from {
+		case FormatCSV:
+			columns, err = ReadCSV(bytes.NewReader(data))
+		case FormatTSV:
+			columns, err = ReadTSV(bytes.NewReader(data))
+		case FormatYAML:
+			tf, err = decodeCatalogYAML(data)
+		case FormatPO:
+			tf, err = decodePO(data, lang)
+		case FormatXLIFF:
+			tf, err = decodeXLIFF(data, lang)
+		}
+		if err != nil {
+			return nil, withPath(err, src, "invalid translation file")
+		}
+	}
+	if tf != nil {
+		columns = map[string]map[string]string{tf.Meta.Lang: tf.Translations}
+	}
+
+	var warnings []string
+	var data []byte
+	if to == FormatCSV || to == FormatTSV {
+		if tf != nil {
+			warnings = spreadsheetLosses(tf)
+		}
+		comma := ','
+		if to == FormatTSV {
+			comma = '\t'
+		}
+		var buf bytes.Buffer
+		if err := exportColumns(&buf, comma, columns, nil); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", dst, err)
+		}
+		data = buf.Bytes()
+	} else {
+		if tf == nil {
+			if tf, err = columnCatalog(columns, lang); err != nil {
+				return nil, fmt.Errorf("%s: %w", src, err)
+			}
+		}
+		if err := validateTranslationFile(tf); err != nil {
+			return nil, withPath(err, dst, "invalid translation file")
+		}
+		switch to {
+		case FormatPO:
+			warnings = catalogLosses(tf, "PO files hold string translations, comments, references and fuzzy marks only", "comments", "references", "fuzzy")
+		case FormatXLIFF:
+			warnings = catalogLosses(tf, "XLIFF documents hold string translations and comments only", "comments", "name")
+		}
+		if data, err = marshalCatalogAs(tf, to); err != nil {
+			return nil, fmt.Errorf("failed to marshal dictionary: %w", err)
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create output directory: %w", err)
+	}
+	if err := writeFileAtomic(dst, data); err != nil {
+		return nil, fmt.Errorf("failed to write %s: %w", dst, err)
+	}
+	return warnings, nil
+}
+
+// columnCatalog returns the translation file of one language of a
+// spreadsheet: lang, or the only language if lang is empty
+func columnCatalog(columns map[string]map[string]string, lang string) (*TranslationFile, error) {
+	if lang == "" {
+		if len(columns) != 1 {
+			return nil, fmt.Errorf("has columns for %s: choose the language to convert", strings.Join(sortedKeys(columns), ", "))
+		}
+		lang = sortedKeys(columns)[0]
+	}
+	translations, ok := columns[lang]
+	if !ok {
+		return nil, fmt.Errorf("has no column for '%s'", lang)
+	}
+	return newCatalogFile(lang, translations), nil
+}
+
+// spreadsheetLosses describes the parts of a translation file a spreadsheet
+// does not hold
+func spreadsheetLosses(tf *TranslationFile) []string {
+	return catalogLosses(tf, "spreadsheets hold string translations only")
+}
+
+// catalogLosses describes the parts of a translation file that a format
+// holding only what holds says does not: the sections other than keeps,
+// named as in JSON ("comments", "fuzzy"), and the meta fields other than
+// lang, or than lang and name if keeps has "name"
+func catalogLosses(tf *TranslationFile, holds string, keeps ...string) []string {
+	var losses []string
+	lost := func(section string, n int, what string) {
+		if n > 0 && !slices.Contains(keeps, section) {
+			losses = append(losses, fmt.Sprintf("%d %s not converted: %s", n, what, holds))
+		}
+	}
+	lost("lists", len(tf.Lists), "list value(s)")
+	lost("schedule", len(tf.Schedule), "scheduled value(s)")
+	lost("comments", len(tf.Comments), "translator comment(s)")
+	lost("placeholders", len(tf.Placeholders), "placeholder record(s)")
+	lost("references", len(tf.References), "reference list(s)")
+	lost("groups", len(tf.Groups), "group(s)")
+	lost("needs_source", len(tf.NeedsSource), "needs_source key(s)")
+	lost("obsolete", len(tf.Obsolete), "obsolete value(s)")
+	lost("machine_translated", len(tf.MachineTranslated), "machine-translation mark(s)")
+	lost("source_hashes", len(tf.SourceHashes), "source hash(es)")
+	lost("fuzzy", len(tf.Fuzzy), "fuzzy mark(s)")
+	lost("states", len(tf.States), "review state(s)")
+
+	m := tf.Meta
+	lostName := m.Name != DefaultDictionary && !slices.Contains(keeps, "name")
+	if lostName || m.Version != "" || m.Author != "" || m.Updated != "" || m.Direction != "" {
+		what := "meta fields other than lang"
+		if slices.Contains(keeps, "name") {
+			what = "meta fields other than lang and name"
+		}
+		losses = append(losses, what+" not converted")
+	}
+	return losses
+}
@@ -0,0 +1,258 @@
+package i18n
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestConvertCatalog(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "default.fr.json")
+	writeTestFile(t, src, `{
+  "meta": {"lang": "fr", "name": "default", "author": "Anne"},
+  "translations": {"hello": "Bonjour, {0}", "weekdays": ["lun", "mar"]},
+  "comments": {"hello": "Greeting"}
+}`)
+
+	csvPath := filepath.Join(dir, "out", "fr.csv")
+	warnings, err := ConvertCatalog(src, csvPath, "")
+	if err != nil {
+		t.Fatalf("ConvertCatalog failed: %v", err)
+	}
+	want := []string{
+		"1 list value(s) not converted: spreadsheets hold string translations only",
+		"1 translator comment(s) not converted: spreadsheets hold string translations only",
+		"meta fields other than lang not converted",
+	}
+	if !reflect.DeepEqual(warnings, want) {
+		t.Errorf("Expected warnings %q, got %q", want, warnings)
+	}
+	data, err := os.ReadFile(csvPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(data); got != "key,fr\nhello,\"Bonjour, {0}\"\n" {
+		t.Errorf("Unexpected CSV %q", got)
+	}
+
+	// And back, through TSV
+	tsvPath := filepath.Join(dir, "fr.tsv")
+	if _, err := ConvertCatalog(csvPath, tsvPath, ""); err != nil {
+		t.Fatalf("ConvertCatalog to TSV failed: %v", err)
+	}
+	jsonPath := filepath.Join(dir, "back.fr.json")
+	if warnings, err := ConvertCatalog(tsvPath, jsonPath, ""); err != nil || len(warnings) != 0 {
+		t.Fatalf("ConvertCatalog to JSON failed: %v %q", err, warnings)
+	}
+	dict, err := LoadDictionaryFile(jsonPath)
+	if err != nil {
+		t.Fatalf("Converted catalog does not load: %v", err)
+	}
+	if got := dict.Get("hello"); got != "Bonjour, {0}" || dict.Lang != "fr" {
+		t.Errorf("Expected the French value back, got %q in %s", got, dict.Lang)
+	}
+}
+
+func TestConvertCatalog_KeepsJSON(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "default.fr.json")
+	content := "{\n    \"meta\": {\n        \"lang\": \"fr\",\n        \"name\": \"default\"\n    },\n    \"comments\": {\n        \"auth.login\": \"Button\"\n    },\n    \"translations\": {\n        \"auth\": {\n            \"login\": \"Connexion\"\n        }\n    }\n}\n"
+	writeTestFile(t, src, content)
+
+	dst := filepath.Join(dir, "copy", "default.fr.json")
+	if warnings, err := ConvertCatalog(src, dst, ""); err != nil || len(warnings) != 0 {
+		t.Fatalf("ConvertCatalog failed: %v %q", err, warnings)
+	}
+	data, err := os.ReadFile(dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != content {
+		t.Errorf("Expected the catalog unchanged, got:\n%s", data)
+	}
+}
+
+func TestConvertCatalog_Columns(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "review.csv")
+	writeTestFile(t, src, "key,en,fr\nhello,Hello,Bonjour\n")
+
+	if _, err := ConvertCatalog(src, filepath.Join(dir, "out.json"), ""); err == nil || !strings.Contains(err.Error(), "en, fr") {
+		t.Errorf("Expected an error naming the languages, got %v", err)
+	}
+	if _, err := ConvertCatalog(src, filepath.Join(dir, "out.json"), "de"); err == nil {
+		t.Error("Expected an error for a missing column")
+	}
+
+	dst := filepath.Join(dir, "default.fr.json")
+	if _, err := ConvertCatalog(src, dst, "fr"); err != nil {
+		t.Fatalf("ConvertCatalog failed: %v", err)
+	}
+	dict, err := LoadDictionaryFile(dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := dict.Get("hello"); got != "Bonjour" {
+		t.Errorf("Expected Bonjour, got %q", got)
+	}
+}
+
+func TestConvertCatalog_Unsupported(t *testing.T) {
+	dir := t.TempDir()
+	src := writeTestCatalog(t, dir, "default.fr.json", "fr", map[string]string{"hello": "Bonjour"})
+	for _, dst := range []string{"fr.properties", "fr.xml", "fr.strings"} {
+		if _, err := ConvertCatalog(src, filepath.Join(dir, dst), ""); err == nil || !strings.Contains(err.Error(), "unsupported format") {
+			t.Errorf("Expected an unsupported format error for %s, got %v", dst, err)
+		}
+	}
+}
+
+func TestConvertCatalog_YAML(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "default.ar.json")
+	content := `{
+  "meta": {
+    "lang": "ar",
+    "name": "default",
+    "version": "3",
+    "direction": "rtl"
+  },
+  "translations": {
+    "hello": "مرحبا، {0}: \"أهلا\" # 1",
+    "weekdays": [
+      "الاثنين",
+      "الثلاثاء, الأربعاء"
+    ],
+    "welcome": "Welcome"
+  },
+  "schedule": {
+    "welcome": [
+      {
+        "value": "رمضان كريم",
+        "valid_from": "2026-02-18T00:00:00Z",
+        "valid_until": "2026-03-20T00:00:00Z"
+      }
+    ]
+  },
+  "comments": {
+    "hello": "Greeting, on two\nlines"
+  },
+  "fuzzy": [
+    "hello"
+  ],
+  "states": {
+    "welcome": "draft"
+  }
+}
+`
+	writeTestFile(t, src, content)
+
+	// The catalog comes back as converting JSON to JSON writes it
+	direct := filepath.Join(dir, "direct", "default.ar.json")
+	if _, err := ConvertCatalog(src, direct, ""); err != nil {
+		t.Fatalf("ConvertCatalog failed: %v", err)
+	}
+	yamlPath := filepath.Join(dir, "ar.yaml")
+	if warnings, err := ConvertCatalog(src, yamlPath, ""); err != nil || len(warnings) != 0 {
+		t.Fatalf("ConvertCatalog to YAML failed: %v %q", err, warnings)
+	}
+	back := filepath.Join(dir, "back", "default.ar.json")
+	if warnings, err := ConvertCatalog(yamlPath, back, ""); err != nil || len(warnings) != 0 {
+		t.Fatalf("ConvertCatalog from YAML failed: %v %q", err, warnings)
+	}
+	want, _ := os.ReadFile(direct)
+	got, _ := os.ReadFile(back)
+	if string(got) != string(want) {
+		data, _ := os.ReadFile(yamlPath)
+		t.Errorf("Expected the catalog back unchanged, got\n%s\nthrough\n%s", got, data)
+	}
+}
+
+func TestConvertCatalog_PO(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "default.fr.json")
+	writeTestFile(t, src, `{
+  "meta": {"lang": "fr", "name": "default", "author": "Anne"},
+  "translations": {"hello": "Bonjour, \"{0}\"\n", "bye": "Au revoir", "weekdays": ["lun", "mar"]},
+  "comments": {"hello": "Greeting"},
+  "references": {"hello": ["app.go:3", "mail.go:12"]},
+  "fuzzy": ["bye"],
+  "states": {"bye": "draft"}
+}`)
+
+	poPath := filepath.Join(dir, "fr.po")
+	warnings, err := ConvertCatalog(src, poPath, "")
+	if err != nil {
+		t.Fatalf("ConvertCatalog to PO failed: %v", err)
+	}
+	want := []string{
+		"1 list value(s) not converted: PO files hold string translations, comments, references and fuzzy marks only",
+		"1 review state(s) not converted: PO files hold string translations, comments, references and fuzzy marks only",
+		"meta fields other than lang not converted",
+	}
+	if !reflect.DeepEqual(warnings, want) {
+		t.Errorf("Expected warnings %q, got %q", want, warnings)
+	}
+
+	jsonPath := filepath.Join(dir, "back.fr.json")
+	if _, err := ConvertCatalog(poPath, jsonPath, ""); err != nil {
+		t.Fatalf("ConvertCatalog from PO failed: %v", err)
+	}
+	tf, err := readTranslationFile(jsonPath)
+	if err != nil {
+		t.Fatalf("Converted catalog does not load: %v", err)
+	}
+	if want := map[string]string{"hello": "Bonjour, \"{0}\"\n", "bye": "Au revoir"}; !reflect.DeepEqual(tf.Translations, want) || tf.Meta.Lang != "fr" {
+		t.Errorf("Expected the French values back, got %v in %s", tf.Translations, tf.Meta.Lang)
+	}
+	if tf.Comments["hello"] != "Greeting" || !reflect.DeepEqual(tf.References["hello"], []string{"app.go:3", "mail.go:12"}) || !reflect.DeepEqual(tf.Fuzzy, []string{"bye"}) {
+		t.Errorf("Expected the comment, references and fuzzy mark back, got %v, %v and %v", tf.Comments, tf.References, tf.Fuzzy)
+	}
+}
+
+func TestConvertCatalog_XLIFF(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "billing.de.json")
+	writeTestFile(t, src, `{
+  "meta": {"lang": "de", "name": "billing"},
+  "translations": {"invoice": "Rechnung <{0}> & Co", "total": "Summe"},
+  "comments": {"invoice": "Page title"},
+  "fuzzy": ["total"]
+}`)
+
+	xliffPath := filepath.Join(dir, "de.xliff")
+	warnings, err := ConvertCatalog(src, xliffPath, "")
+	if err != nil {
+		t.Fatalf("ConvertCatalog to XLIFF failed: %v", err)
+	}
+	if want := []string{"1 fuzzy mark(s) not converted: XLIFF documents hold string translations and comments only"}; !reflect.DeepEqual(warnings, want) {
+		t.Errorf("Expected warnings %q, got %q", want, warnings)
+	}
+
+	jsonPath := filepath.Join(dir, "back.de.json")
+	if _, err := ConvertCatalog(xliffPath, jsonPath, ""); err != nil {
+		t.Fatalf("ConvertCatalog from XLIFF failed: %v", err)
+	}
+	tf, err := readTranslationFile(jsonPath)
+	if err != nil {
+		t.Fatalf("Converted catalog does not load: %v", err)
+	}
+	if want := map[string]string{"invoice": "Rechnung <{0}> & Co", "total": "Summe"}; !reflect.DeepEqual(tf.Translations, want) {
+		t.Errorf("Expected the German values back, got %v", tf.Translations)
+	}
+	if tf.Meta.Lang != "de" || tf.Meta.Name != "billing" || tf.Comments["invoice"] != "Page title" {
+		t.Errorf("Expected the language, name and comment back, got %+v and %v", tf.Meta, tf.Comments)
+	}
+
+	// And on to a spreadsheet
+	csvPath := filepath.Join(dir, "de.csv")
+	if _, err := ConvertCatalog(xliffPath, csvPath, ""); err != nil {
+		t.Fatalf("ConvertCatalog to CSV failed: %v", err)
+	}
+	if data, _ := os.ReadFile(csvPath); string(data) != "key,de\ninvoice,Rechnung <{0}> & Co\ntotal,Summe\n" {
+		t.Errorf("Unexpected CSV %q", data)
+	}
+}
@@ -0,0 +1,210 @@
+package i18n
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+)
+
+// ImportCSV reads translations from CSV, taking keys from column keyCol and
+// values from column valueCol (0-based). The first row is a header and is
+// skipped, as are rows with an empty key or value.
+//
+// Example:
+//
+//	translations, err := i18n.ImportCSV(file, 0, 2) // key, en, fr → French
+//	dict := i18n.NewDictionary("fr")
+//	dict.AddAll(translations)
+func ImportCSV(r io.Reader, keyCol, valueCol int) (map[string]string, error) {
+	return importColumns(newCSVReader(r, ','), keyCol, valueCol)
+}
+
+// ImportTSV is like ImportCSV for tab-separated values
+func ImportTSV(r io.Reader, keyCol, valueCol int) (map[string]string, error) {
+	return importColumns(newCSVReader(r, '\t'), keyCol, valueCol)
+}
+
+// ReadCSV reads a multi-locale CSV as written by ExportCSV: a header row of
+// "key" followed by language codes, then one row per key. Empty cells are
+// left out so untranslated keys stay missing.
+func ReadCSV(r io.Reader) (map[string]map[string]string, error) {
+	return readColumns(newCSVReader(r, ','))
+}
+
+// ReadTSV is like ReadCSV for tab-separated values
+func ReadTSV(r io.Reader) (map[string]map[string]string, error) {
+	return readColumns(newCSVReader(r, '\t'))
+}
+
+// ExportCSV writes catalogs (as returned by ReadCatalogs) as CSV with a key
+// column and one column per language, for review in a spreadsheet. Columns
+// follow langs, or all languages in sorted order if none are given; rows are
+// sorted by key.
+//
+//	catalogs, _ := i18n.ReadCatalogs("locales")
+//	i18n.ExportCSV(os.Stdout, catalogs, "en", "fr", "de")
+func ExportCSV(w io.Writer, catalogs map[string]map[string]string, langs ...string) error {
+	return exportColumns(w, ',', catalogs, langs)
+}
+
+// ExportTSV is like ExportCSV for tab-separated values
+func ExportTSV(w io.Writer, catalogs map[string]map[string]string, langs ...string) error {
+	return exportColumns(w, '\t', catalogs, langs)
+}
+
+// ImportCatalogs writes catalogs into the default dictionary file of each
+// language in dir (default.{lang}.json), creating missing files. Imported
+// values replace existing ones, and changed values lose their fuzzy mark;
+// other keys are kept. A catalog whose imported values are invalid (e.g. a
+// malformed plural) is not written.
+func ImportCatalogs(dir string, catalogs map[string]map[string]string) error {
+	return withCatalogLock(context.Background(), dir, func() error {
+		for _, lang := range sortedKeys(catalogs) {
+			path := filepath.Join(dir, fmt.Sprintf("%s.%s.json", DefaultDictionary, lang))
+			if err := updateCatalog(path, lang, catalogs[lang]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// updateCatalog sets translations in the catalog at path, keeping its other
+// entries and metadata, or creates the catalog if it does not exist
+func updateCatalog(path, lang string, translations map[string]string) error {
+	tf := &TranslationFile{}
+	if _, err := os.Stat(path); err == nil {
+		if tf, err = readTranslationFile(path); err != nil {
+			return err
+		}
+	} else {
+		tf = newCatalogFile(lang, nil)
+	}
+
+	if tf.Translations == nil {
+		tf.Translations = make(map[string]string, len(translations))
+	}
+	for key, value := range translations {
+		if old, ok := tf.Translations[key]; ok && old != value {
+			// A rewritten value no longer translates outdated source text
+			tf.Fuzzy = slices.DeleteFunc(tf.Fuzzy, func(k string) bool { return k == key })
+		}
+		tf.Translations[key] = value
+	}
+
+	// Refuse to write a catalog that would fail to load
+	if err := validateTranslationFile(tf); err != nil {
+		return withPath(err, path, "invalid translation file")
+	}
+
+	data, err := MarshalCatalog(tf)
+	if err != nil {
+		return fmt.Errorf("failed to marshal dictionary: %w", err)
+	}
+	if err := writeFileAtomic(path, data); err != nil {
+		return fmt.Errorf("failed to save dictionary: %w", err)
+	}
+	return nil
+}
+
+// newCSVReader returns a reader for the given delimiter that accepts rows of
+// varying length, as spreadsheets drop trailing empty cells
+func newCSVReader(r io.Reader, comma rune) *csv.Reader {
+	cr := csv.NewReader(r)
+	cr.Comma = comma
+	cr.FieldsPerRecord = -1
+	return cr
+}
+
+// importColumns reads the key and value columns of every row after the header
+func importColumns(cr *csv.Reader, keyCol, valueCol int) (map[string]string, error) {
+	if keyCol < 0 || valueCol < 0 {
+		return nil, fmt.Errorf("invalid columns %d and %d", keyCol, valueCol)
+	}
+
+	rows, err := cr.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV: %w", err)
+	}
+
+	translations := make(map[string]string)
+	for i, row := range rows {
+		if i == 0 || keyCol >= len(row) || valueCol >= len(row) {
+			continue
+		}
+		if key, value := row[keyCol], row[valueCol]; key != "" && value != "" {
+			translations[key] = value
+		}
+	}
+	return translations, nil
+}
+
+// readColumns reads a key column followed by one column per language
+func readColumns(cr *csv.Reader) (map[string]map[string]string, error) {
+	rows, err := cr.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV: %w", err)
+	}
+	if len(rows) == 0 || len(rows[0]) < 2 {
+		return nil, fmt.Errorf("CSV header must list the key column and at least one language")
+	}
+
+	header := rows[0]
+	catalogs := make(map[string]map[string]string, len(header)-1)
+	for _, lang := range header[1:] {
+		if lang == "" {
+			return nil, fmt.Errorf("CSV header has an empty language column")
+		}
+		catalogs[lang] = make(map[string]string)
+	}
+
+	for _, row := range rows[1:] {
+		if len(row) == 0 || row[0] == "" {
+			continue
+		}
+		for col := 1; col < len(row) && col < len(header); col++ {
+			if row[col] != "" {
+				catalogs[header[col]][row[0]] = row[col]
+			}
+		}
+	}
+	return catalogs, nil
+}
+
+// exportColumns writes a key column followed by one column per language
+func exportColumns(w io.Writer, comma rune, catalogs map[string]map[string]string, langs []string) error {
+	if len(langs) == 0 {
+		langs = sortedKeys(catalogs)
+	}
+
+	keys := make(map[string]bool)
+	for _, lang := range langs {
+		for key := range catalogs[lang] {
+			keys[key] = true
+		}
+	}
+
+	cw := csv.NewWriter(w)
+	cw.Comma = comma
+	if err := cw.Write(append([]string{"key"}, langs...)); err != nil {
+		return err
+	}
+
+	row := make([]string, len(langs)+1)
+	for _, key := range sortedKeys(keys) {
+		row[0] = key
+		for i, lang := range langs {
+			row[i+1] = catalogs[lang][key]
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
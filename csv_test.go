@@ -0,0 +1,160 @@
+package i18n
+
+import (
+	"bytes"
+	"math/rand/v2"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestImportCSV(t *testing.T) {
+	input := "key,en,fr\nhello,Hello,Bonjour\n\"welcome-0\",\"Welcome, {0}\",\"Bienvenue, {0}\"\nuntranslated,Only English\n,Orphan,Orphelin\n"
+
+	translations, err := ImportCSV(strings.NewReader(input), 0, 2)
+	if err != nil {
+		t.Fatalf("ImportCSV failed: %v", err)
+	}
+
+	expected := map[string]string{"hello": "Bonjour", "welcome-0": "Bienvenue, {0}"}
+	if len(translations) != len(expected) {
+		t.Errorf("Expected %v, got %v", expected, translations)
+	}
+	for key, value := range expected {
+		if translations[key] != value {
+			t.Errorf("Expected %s = %q, got %q", key, value, translations[key])
+		}
+	}
+
+	tsv, err := ImportTSV(strings.NewReader("key\ten\nhello\tHello, world\n"), 0, 1)
+	if err != nil || tsv["hello"] != "Hello, world" {
+		t.Errorf("Expected TSV value with comma, got %v (%v)", tsv, err)
+	}
+
+	if _, err := ImportCSV(strings.NewReader(input), -1, 1); err == nil {
+		t.Error("Expected error for negative column")
+	}
+}
+
+func TestExportCSV_RoundTrip(t *testing.T) {
+	catalogs := map[string]map[string]string{
+		"en": {"hello": "Hello", "welcome-0": "Welcome, {0}", "quote": `Say "hi"`},
+		"fr": {"hello": "Bonjour", "welcome-0": "Bienvenue, {0}"},
+		"de": {"hello": "Hallo"},
+	}
+
+	var buf bytes.Buffer
+	if err := ExportCSV(&buf, catalogs, "en", "fr", "de"); err != nil {
+		t.Fatalf("ExportCSV failed: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if lines[0] != "key,en,fr,de" {
+		t.Errorf("Unexpected header: %q", lines[0])
+	}
+	if lines[1] != "hello,Hello,Bonjour,Hallo" {
+		t.Errorf("Expected rows sorted by key, got %q", lines[1])
+	}
+
+	read, err := ReadCSV(&buf)
+	if err != nil {
+		t.Fatalf("ReadCSV failed: %v", err)
+	}
+	for lang, translations := range catalogs {
+		if len(read[lang]) != len(translations) {
+			t.Errorf("%s: expected %v, got %v", lang, translations, read[lang])
+		}
+		for key, value := range translations {
+			if read[lang][key] != value {
+				t.Errorf("%s: expected %s = %q, got %q", lang, key, value, read[lang][key])
+			}
+		}
+	}
+
+	buf.Reset()
+	if err := ExportTSV(&buf, catalogs); err != nil {
+		t.Fatalf("ExportTSV failed: %v", err)
+	}
+	if !strings.HasPrefix(buf.String(), "key\tde\ten\tfr\n") {
+		t.Errorf("Expected sorted TSV header, got %q", buf.String())
+	}
+	if read, err := ReadTSV(&buf); err != nil || read["fr"]["hello"] != "Bonjour" {
+		t.Errorf("Expected TSV round trip, got %v (%v)", read, err)
+	}
+
+	if _, err := ReadCSV(strings.NewReader("key\nhello\n")); err == nil {
+		t.Error("Expected error for header without languages")
+	}
+}
+
+func TestImportCatalogs(t *testing.T) {
+	dir := t.TempDir()
+	writeTestCatalog(t, dir, "default.fr.json", "fr", map[string]string{"hello": "Salut", "bye": "Au revoir"})
+
+	err := ImportCatalogs(dir, map[string]map[string]string{
+		"fr": {"hello": "Bonjour"},
+		"de": {"hello": "Hallo"},
+	})
+	if err != nil {
+		t.Fatalf("ImportCatalogs failed: %v", err)
+	}
+
+	fr, err := LoadDictionaryFile(filepath.Join(dir, "default.fr.json"))
+	if err != nil {
+		t.Fatalf("Failed to load fr: %v", err)
+	}
+	if fr.Get("hello") != "Bonjour" || fr.Get("bye") != "Au revoir" {
+		t.Errorf("Expected imported value and kept key, got %v", fr.Translations)
+	}
+
+	de, err := LoadDictionaryFile(filepath.Join(dir, "default.de.json"))
+	if err != nil || de.Get("hello") != "Hallo" {
+		t.Errorf("Expected new de catalog, got %v (%v)", de, err)
+	}
+
+	err = ImportCatalogs(dir, map[string]map[string]string{"fr": {"items": "{count, plural, one {# item}"}})
+	if err == nil || !strings.Contains(err.Error(), "default.fr.json") {
+		t.Errorf("Expected validation error naming the file, got %v", err)
+	}
+	if fr, _ := LoadDictionaryFile(filepath.Join(dir, "default.fr.json")); fr == nil || fr.Has("items") {
+		t.Error("Expected invalid catalog not to be written")
+	}
+}
+
+// TestExportCSV_RoundTripProperty checks that ReadCSV and ReadTSV read back
+// every value ExportCSV and ExportTSV write, for random catalogs in several
+// languages that only partly share their keys
+func TestExportCSV_RoundTripProperty(t *testing.T) {
+	r := rand.New(rand.NewPCG(3, 4))
+	for i := range 100 {
+		catalogs := map[string]map[string]string{
+			"en":    randomDictionary(r, "en").Translations,
+			"fr":    randomDictionary(r, "fr").Translations,
+			"pt-BR": randomDictionary(r, "pt-BR").Translations,
+		}
+		for _, translations := range catalogs {
+			for key, value := range translations {
+				translations[key] = strings.ReplaceAll(value, "\r\n", "\n")
+			}
+		}
+
+		for _, tsv := range []bool{false, true} {
+			write, read := ExportCSV, ReadCSV
+			if tsv {
+				write, read = ExportTSV, ReadTSV
+			}
+			var buf bytes.Buffer
+			if err := write(&buf, catalogs); err != nil {
+				t.Fatalf("#%d: export failed: %v", i, err)
+			}
+			got, err := read(&buf)
+			if err != nil {
+				t.Fatalf("#%d: read failed: %v", i, err)
+			}
+			if !reflect.DeepEqual(got, catalogs) {
+				t.Fatalf("#%d (tsv %v): round trip changed the catalogs:\n%v\n%v", i, tsv, catalogs, got)
+			}
+		}
+	}
+}
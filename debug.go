@@ -0,0 +1,97 @@
+package i18n
+
+import (
+	"os"
+	"strconv"
+)
+
+// DebugEnv is the environment variable that turns on locale debugging for
+// new bundles, including the default one: I18N_DEBUG=1 (or true)
+const DebugEnv = "I18N_DEBUG"
+
+// debugFromEnv reports whether DebugEnv is set to a true value
+func debugFromEnv() bool {
+	enabled, _ := strconv.ParseBool(os.Getenv(DebugEnv))
+	return enabled
+}
+
+// SetDebug turns locale debugging on or off. While it is on, translations
+// are suffixed with the language they were rendered from, so staging
+// screenshots show where every string came from:
+//
+//	"Tableau de bord [fr]"          translated in the requested locale
+//	"Dashboard [en→fallback]"       taken from a fallback language
+//	"welcome_user [fr→missing]"     no translation at all
+//
+// It applies to R and to the TranslatedFuncs created after the call; set
+// DebugEnv to turn it on before package-level variables are initialized.
+func SetDebug(enabled bool) {
+	defaultBundle.SetDebug(enabled)
+}
+
+// SetDebug is like the package-level SetDebug but applies to the bundle
+func (b *Bundle) SetDebug(enabled bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.debug = enabled
+}
+
+// debugging reports whether locale debugging is on
+func (b *Bundle) debugging() bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.debug
+}
+
+// debugged returns fn, suffixed with the source of the first of keys found
+// when locale debugging is on
+func (b *Bundle) debugged(fn TranslatedFunc, keys ...string) TranslatedFunc {
+	if !b.debugging() {
+		return fn
+	}
+	return func(locale string) string {
+		return fn(locale) + " " + b.debugSuffix(locale, keys)
+	}
+}
+
+// debugSuffix names the language that translates the first of keys found
+// for locale
+func (b *Bundle) debugSuffix(locale string, keys []string) string {
+	dict := b.dictionaryFor(locale)
+	if dict == nil {
+		return "[" + locale + "→missing]"
+	}
+
+	lang, ok := dict.source(keys...)
+	switch {
+	case !ok:
+		return "[" + locale + "→missing]"
+	case lang != locale:
+		return "[" + lang + "→fallback]"
+	}
+	return "[" + lang + "]"
+}
+
+// source returns the language of the dictionary lookupFirst takes the first
+// of keys from, counting list values too
+func (d *Dictionary) source(keys ...string) (string, bool) {
+	for _, dict := range append([]*Dictionary{d}, d.fallbackDictionaries()...) {
+		for _, key := range keys {
+			if dict.has(key) {
+				return dict.Lang, true
+			}
+		}
+	}
+	return "", false
+}
+
+// has reports whether this dictionary alone has a string or list value for key
+func (d *Dictionary) has(key string) bool {
+	if _, ok := d.translation(key); ok {
+		return true
+	}
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	_, ok := d.Lists[key]
+	return ok
+}
@@ -0,0 +1,71 @@
+package i18n
+
+import "testing"
+
+func TestSetDebug(t *testing.T) {
+	b := NewBundle()
+	en := NewDictionary("en")
+	en.AddAll(map[string]string{"dashboard": "Dashboard", "settings": "Settings", "days": "Days"})
+	fr := NewDictionary("fr")
+	fr.AddAll(map[string]string{"dashboard": "Tableau de bord"})
+	b.Register(en)
+	b.Register(fr)
+
+	plain := b.S("Dashboard")
+	b.SetDebug(true)
+
+	tests := []struct {
+		name   string
+		fn     TranslatedFunc
+		locale string
+		want   string
+	}{
+		{"translated", b.S("Dashboard"), "fr", "Tableau de bord [fr]"},
+		{"key fallback", b.S("Settings"), "fr", "Settings [en→fallback]"},
+		{"locale fallback", b.S("Dashboard"), "de", "Dashboard [en→fallback]"},
+		{"missing", b.T("welcome_user", "Ann"), "fr", "welcome_user [fr→missing]"},
+		{"plural", b.P("days", 2), "en", "Days [en]"},
+		{"created before", plain, "fr", "Tableau de bord"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.fn(tt.locale); got != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+
+	if got := b.R("fr", "Dashboard"); got != "Tableau de bord [fr]" {
+		t.Errorf("Expected R to be suffixed, got %q", got)
+	}
+
+	b.SetDebug(false)
+	if got := b.S("Dashboard")("fr"); got != "Tableau de bord" {
+		t.Errorf("Expected no suffix once disabled, got %q", got)
+	}
+}
+
+func TestDebugFromEnv(t *testing.T) {
+	t.Setenv(DebugEnv, "1")
+	if !NewBundle().debugging() {
+		t.Errorf("Expected %s=1 to enable debugging", DebugEnv)
+	}
+
+	t.Setenv(DebugEnv, "no")
+	if NewBundle().debugging() {
+		t.Errorf("Expected %s=no to leave debugging off", DebugEnv)
+	}
+}
+
+func TestBundleReset_Debug(t *testing.T) {
+	b := NewBundle()
+	en := NewDictionary("en")
+	en.Add("title", "Dashboard")
+	b.SetDebug(true)
+	b.Reset()
+
+	b.Register(en)
+	if got := b.T("title")("en"); got != "Dashboard" {
+		t.Errorf("Expected Reset to turn locale debugging off, got %q", got)
+	}
+}
@@ -3,7 +3,7 @@
 // - File-based translation dictionaries
 // - Advanced pluralization support for multiple languages
 // - Thread-safe operations
-// - Automatic fallback to default languages
+// - Automatic fallback to parent and default languages (pt-BR → pt → en)
 // - Code generation for extracting translation keys
 //
 // Basic usage:
@@ -25,10 +25,16 @@
 package i18n
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
 	"strings"
 	"sync"
 )
@@ -42,29 +48,329 @@ const (
 	DefaultDictionary = "default"
 	DefaultFolder     = "locales"
 	DefaultFilePath   = "locales/default.en.json"
+
+	// KeySeparator joins the field names of object values into flat keys
+	KeySeparator = "."
 )
 
 // -----------------------------------------------------------------------------
 // Data structures
 // -----------------------------------------------------------------------------
 
+// Meta describes a dictionary file: its language and name, and optional
+// details such as the catalog version and text direction ("ltr" or "rtl")
+type Meta struct {
+	Lang          string `json:"lang"`
+	Name          string `json:"name"`
+	Version       string `json:"version,omitempty"`
+	Author        string `json:"author,omitempty"`
+	Updated       string `json:"updated,omitempty"`
+	Direction     string `json:"direction,omitempty"`
+	SchemaVersion int    `json:"schema_version,omitempty"`
+}
+
 // TranslationFile represents a single dictionary file
 type TranslationFile struct {
-	Meta struct {
-		Lang      string `json:"lang"`
-		Name      string `json:"name"`
-		Version   string `json:"version,omitempty"`
-		Author    string `json:"author,omitempty"`
-		Updated   string `json:"updated,omitempty"`
-		Direction string `json:"direction,omitempty"`
-	} `json:"meta"`
+	Meta         Meta              `json:"meta"`
 	Translations map[string]string `json:"translations"`
+
+	// Lists holds array values ("weekdays": ["Mon", "Tue", ...]). They are
+	// stored in the same "translations" object as string values.
+	Lists map[string][]string `json:"-"`
+
+	// Schedule holds values that replace a translation within a time window
+	Schedule map[string][]ScheduledValue `json:"schedule,omitempty"`
+
+	// NeedsSource lists keys used in code that have no source text yet. The
+	// extractor writes it; it is ignored at load time.
+	NeedsSource []string `json:"needs_source,omitempty"`
+
+	// Placeholders records the printf verbs ("%s", "%d") of extracted F
+	// texts, in argument order, so translations can be checked against the
+	// types the code passes. It is ignored at load time.
+	Placeholders map[string][]string `json:"placeholders,omitempty"`
+
+	// Comments holds notes for translators by key, taken from the "// i18n:"
+	// comments before the calls in the code (see GoExtractor). The extractor
+	// writes it; it is ignored at load time.
+	Comments map[string]string `json:"comments,omitempty"`
+
+	// Groups lists the keys found in each source package or file, so
+	// translators see related strings together. The extractor writes it when
+	// asked to (see Config.Group), and the translations are then written in
+	// group order instead of alphabetically. It is ignored at load time.
+	Groups map[string][]string `json:"groups,omitempty"`
+
+	// References lists the locations ("handlers/user.go:42") each key is
+	// used at, relative to the scanned source directory, so translators and
+	// reviewers can find a string's context and keys without references
+	// stand out as unused. The extractor writes it when asked to (see
+	// Config.References); it is ignored at load time.
+	References map[string][]string `json:"references,omitempty"`
+
+	// Obsolete keeps the last value of keys no longer used in the code,
+	// when the extractor is asked to (see Config.KeepRemoved), instead of
+	// deleting them; a key that comes back gets its value back. It is
+	// ignored at load time.
+	Obsolete map[string]string `json:"obsolete,omitempty"`
+
+	// MachineTranslated lists the keys whose value a machine translation
+	// filled in (see MachineTranslate), until a reviewer removes them. The
+	// extractor keeps it for the keys still used; it is ignored at load time.
+	MachineTranslated []string `json:"machine_translated,omitempty"`
+
+	// SourceHashes records, for the catalogs of languages other than the
+	// source language, a hash of the source text each key's value
+	// translates. The extractor writes it and marks the keys whose source
+	// text changed as Fuzzy; it is ignored at load time.
+	SourceHashes map[string]string `json:"source_hashes,omitempty"`
+
+	// Fuzzy lists the keys whose source text changed since they were
+	// translated, until a reviewer updates the translation and removes them.
+	// Dictionaries keep it, so fuzzy values can be left out at run time (see
+	// SetExcludeFuzzy).
+	Fuzzy []string `json:"fuzzy,omitempty"`
+
+	// States records the review state of keys ("draft", "reviewed",
+	// "approved"); keys without one are approved. Dictionaries keep it, so
+	// production can serve approved values only (see SetMinState).
+	States map[string]State `json:"states,omitempty"`
+
+	// Nested writes dotted keys as nested objects ("auth": {"login": ...})
+	// when the file is encoded. Decoding sets it if the file has object values.
+	Nested bool `json:"-"`
+
+	// Indent is the indentation MarshalCatalog writes the file with,
+	// DefaultIndent if empty. Decoding sets it to the file's own, so
+	// rewriting a catalog keeps it.
+	Indent string `json:"-"`
+}
+
+// UnmarshalJSON decodes a translation file. Besides strings, translation values
+// may be JSON numbers or booleans, which are stored as written so locale-dependent
+// configuration ("items_per_page": 20) can live in the catalog, arrays of
+// strings, which are stored in Lists, and objects, whose fields are flattened
+// into "key.field" entries (see GetInto).
+func (tf *TranslationFile) UnmarshalJSON(data []byte) error {
+	type plain TranslationFile
+	aux := struct {
+		*plain
+		Translations map[string]json.RawMessage `json:"translations"`
+	}{plain: (*plain)(tf)}
+
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	tf.Indent = fileIndent(bytes.TrimSpace(data))
+
+	if aux.Translations == nil {
+		tf.Translations = nil
+		return nil
+	}
+
+	tf.Translations = make(map[string]string, len(aux.Translations))
+	tf.Lists = nil
+	tf.Nested = false
+	for key, raw := range aux.Translations {
+		if trimmed := bytes.TrimSpace(raw); len(trimmed) > 0 && trimmed[0] == '{' {
+			tf.Nested = true
+		}
+		if err := tf.decodeValue(key, raw); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// decodeValue stores a raw JSON translation value under key, flattening objects
+func (tf *TranslationFile) decodeValue(key string, raw json.RawMessage) error {
+	trimmed := bytes.TrimSpace(raw)
+	if len(trimmed) == 0 {
+		return fileError("", key, "translation key '%s' has missing value", key)
+	}
+
+	switch trimmed[0] {
+	case '[':
+		var list []string
+		if err := json.Unmarshal(trimmed, &list); err != nil {
+			return fileError("", key, "translation key '%s': arrays may only contain strings", key)
+		}
+		if tf.Lists == nil {
+			tf.Lists = make(map[string][]string)
+		}
+		tf.Lists[key] = list
+
+	case '{':
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(trimmed, &fields); err != nil {
+			fe := fileError("", key, "translation key '%s' has invalid object", key)
+			fe.Err = err
+			return fe
+		}
+		if len(fields) == 0 {
+			return fileError("", key, "translation key '%s' has empty object", key)
+		}
+		for name, value := range fields {
+			if err := tf.decodeValue(key+KeySeparator+name, value); err != nil {
+				return err
+			}
+		}
+
+	default:
+		value, err := decodeTranslationValue(trimmed)
+		if err != nil {
+			fe := fileError("", key, "translation key '%s' has invalid value", key)
+			fe.Err = err
+			return fe
+		}
+		tf.Translations[key] = value
+	}
+	return nil
+}
+
+// MarshalJSON encodes a translation file, writing Lists back into the
+// "translations" object alongside the string values, nested by key segment
+// if Nested is set
+func (tf TranslationFile) MarshalJSON() ([]byte, error) {
+	type plain TranslationFile
+	if len(tf.Lists) == 0 && !tf.Nested && len(tf.Groups) == 0 {
+		return marshalUnescaped(plain(tf))
+	}
+
+	translations := make(map[string]any, len(tf.Translations)+len(tf.Lists))
+	for k, v := range tf.Translations {
+		translations[k] = v
+	}
+	for k, v := range tf.Lists {
+		translations[k] = v
+	}
+	if tf.Nested {
+		translations = nestKeys(translations)
+	} else if len(tf.Groups) > 0 {
+		return marshalUnescaped(struct {
+			plain
+			Translations groupedTranslations `json:"translations"`
+		}{plain(tf), groupedTranslations{translations, tf.Groups}})
+	}
+
+	return marshalUnescaped(struct {
+		plain
+		Translations map[string]any `json:"translations"`
+	}{plain(tf), translations})
+}
+
+// groupedTranslations encodes translation values in the order of their
+// groups, sorted by group name, then the keys of no group, each sorted
+type groupedTranslations struct {
+	values map[string]any
+	groups map[string][]string
+}
+
+func (g groupedTranslations) MarshalJSON() ([]byte, error) {
+	var order []string
+	seen := make(map[string]bool, len(g.values))
+	for _, group := range sortedKeys(g.groups) {
+		keys := slices.Clone(g.groups[group])
+		sort.Strings(keys)
+		for _, key := range keys {
+			if _, ok := g.values[key]; ok && !seen[key] {
+				seen[key] = true
+				order = append(order, key)
+			}
+		}
+	}
+	for _, key := range sortedKeys(g.values) {
+		if !seen[key] {
+			order = append(order, key)
+		}
+	}
+
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range order {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		k, err := marshalUnescaped(key)
+		if err != nil {
+			return nil, err
+		}
+		v, err := marshalUnescaped(g.values[key])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(k)
+		buf.WriteByte(':')
+		buf.Write(v)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// nestKeys turns dotted keys into nested objects: "auth.login.title" becomes
+// {"auth": {"login": {"title": ...}}}. A key whose prefix is already a value
+// ("auth" and "auth.login") stays flat at that level, which loads the same.
+func nestKeys(flat map[string]any) map[string]any {
+	root := make(map[string]any)
+	for _, key := range sortedKeys(flat) {
+		node := root
+		parts := strings.Split(key, KeySeparator)
+		for i, part := range parts {
+			if i == len(parts)-1 {
+				if _, taken := node[part]; taken {
+					node[part+KeySeparator] = flat[key]
+				} else {
+					node[part] = flat[key]
+				}
+				break
+			}
+
+			child, ok := node[part]
+			if !ok {
+				child = make(map[string]any)
+				node[part] = child
+			}
+			group, ok := child.(map[string]any)
+			if !ok {
+				node[strings.Join(parts[i:], KeySeparator)] = flat[key]
+				break
+			}
+			node = group
+		}
+	}
+	return root
+}
+
+// decodeTranslationValue converts a raw JSON translation value to its string form
+func decodeTranslationValue(raw json.RawMessage) (string, error) {
+	var value any
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return "", err
+	}
+
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case float64, bool:
+		return strings.TrimSpace(string(raw)), nil
+	default:
+		return "", fmt.Errorf("unsupported value type %T", v)
+	}
 }
 
 // Dictionary represents one language's translations
 type Dictionary struct {
 	Lang         string
 	Translations map[string]string
+	Lists        map[string][]string
+	Schedule     map[string][]ScheduledValue
+	meta         Meta
+	bundle       *Bundle
+	index        []string         // sorted keys of Translations, see keyIndex
+	fuzzy        map[string]bool  // keys whose value is outdated, see Fuzzy
+	states       map[string]State // review states, see SetState
 	mu           sync.RWMutex
 }
 
@@ -72,39 +378,96 @@ type Dictionary struct {
 // Registry management
 // -----------------------------------------------------------------------------
 
-var (
-	dictionaries  = map[string]*Dictionary{}
-	muDicts       sync.RWMutex
-	currentLang   = DefaultLang
-	muDefaultLang sync.RWMutex
-)
-
 // SetDefaultLanguage sets the fallback language code
 func SetDefaultLanguage(lang string) {
-	muDefaultLang.Lock()
-	defer muDefaultLang.Unlock()
-	currentLang = lang
+	defaultBundle.SetDefaultLanguage(lang)
 }
 
 // DefaultLanguage returns the current fallback language
 func DefaultLanguage() string {
-	muDefaultLang.RLock()
-	defer muDefaultLang.RUnlock()
-	return currentLang
+	return defaultBundle.DefaultLanguage()
 }
 
 // Register adds a dictionary to the global registry
 func Register(dict *Dictionary) {
-	muDicts.Lock()
-	defer muDicts.Unlock()
-	dictionaries[dict.Lang] = dict
+	defaultBundle.Register(dict)
+}
+
+// RegisterNew adds a dictionary to the global registry unless its language
+// already has one, see Bundle.RegisterNew
+func RegisterNew(dict *Dictionary) error {
+	return defaultBundle.RegisterNew(dict)
+}
+
+// MustRegister is like RegisterNew but panics if the language already has a
+// dictionary
+func MustRegister(dict *Dictionary) {
+	defaultBundle.MustRegister(dict)
+}
+
+// RegisterMerged merges a dictionary into the one the global registry has for
+// its language, or registers it, see Bundle.RegisterMerged
+func RegisterMerged(dict *Dictionary) {
+	defaultBundle.RegisterMerged(dict)
+}
+
+// OnReplace registers fn to be called with the language of every dictionary
+// Register replaces in the global registry
+func OnReplace(fn func(lang string)) {
+	defaultBundle.OnReplace(fn)
+}
+
+// RegisterStrict validates every template of a dictionary and adds it to the
+// global registry only if all of them are well-formed
+func RegisterStrict(dict *Dictionary) error {
+	return defaultBundle.RegisterStrict(dict)
+}
+
+// ReplaceAll swaps the whole global registry for dicts in one step
+func ReplaceAll(dicts map[string]*Dictionary) {
+	defaultBundle.ReplaceAll(dicts)
+}
+
+// Unregister removes the dictionary of a language from the global registry
+func Unregister(lang string) {
+	defaultBundle.Unregister(lang)
+}
+
+// ResetRegistry restores the global registry to its initial state: no
+// dictionaries, DefaultLang as default language and no fallbacks, base
+// catalogs, lazy loading, Forward or OnChange, OnMissing and OnReplace
+// listeners. It is meant for tests.
+func ResetRegistry() {
+	defaultBundle.Reset()
+	Forward(nil)
+}
+
+// OnChange registers fn to be called with the language of every dictionary a
+// reload of the global registry actually changes
+func OnChange(fn func(lang string)) {
+	defaultBundle.OnChange(fn)
+}
+
+// OnMissing registers fn to be called with the locale and key of every
+// translation missing from the global registry, see Bundle.OnMissing
+//
+// Example:
+//
+//	i18n.OnMissing(func(locale, key string) {
+//		slog.Warn("missing translation", "locale", locale, "key", key)
+//	})
+func OnMissing(fn func(locale, key string)) {
+	defaultBundle.OnMissing(fn)
 }
 
 // GetDictionary returns a dictionary by language code
 func GetDictionary(lang string) *Dictionary {
-	muDicts.RLock()
-	defer muDicts.RUnlock()
-	return dictionaries[lang]
+	return defaultBundle.GetDictionary(lang)
+}
+
+// Languages returns the sorted language codes of all registered dictionaries
+func Languages() []string {
+	return defaultBundle.Languages()
 }
 
 // -----------------------------------------------------------------------------
@@ -121,70 +484,197 @@ func NewDictionary(lang string) *Dictionary {
 
 // LoadDictionaryFile loads a single dictionary file
 func LoadDictionaryFile(path string) (*Dictionary, error) {
+	tf, err := readTranslationFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return newDictionaryFromFile(tf), nil
+}
+
+// LoadDictionaryReader loads a dictionary from r, such as an HTTP response
+// body or an embedded file, with the same validation as LoadDictionaryFile.
+// The dictionary is not registered.
+func LoadDictionaryReader(r io.Reader) (*Dictionary, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, &FileError{Reason: "failed to read dictionary", Err: err}
+	}
+	return LoadDictionaryBytes(data)
+}
+
+// LoadDictionaryBytes loads a dictionary from the content of a translation
+// file, with the same validation as LoadDictionaryFile. The dictionary is not
+// registered.
+//
+//	//go:embed locales/default.fr.json
+//	var fr []byte
+//
+//	dict, err := i18n.LoadDictionaryBytes(fr)
+func LoadDictionaryBytes(data []byte) (*Dictionary, error) {
+	tf, err := decodeTranslationFile(data)
+	if err != nil {
+		return nil, err
+	}
+	return newDictionaryFromFile(tf), nil
+}
+
+// newDictionaryFromFile creates a dictionary with the entries of a decoded file
+func newDictionaryFromFile(tf *TranslationFile) *Dictionary {
+	dict := NewDictionary(tf.Meta.Lang)
+	dict.meta = tf.Meta
+	dict.AddAll(tf.Translations)
+	for key, list := range tf.Lists {
+		dict.AddSlice(key, list)
+	}
+	for key, values := range tf.Schedule {
+		for _, value := range values {
+			dict.AddScheduled(key, value)
+		}
+	}
+	for _, key := range tf.Fuzzy {
+		dict.SetFuzzy(key, true)
+	}
+	for key, state := range tf.States {
+		dict.SetState(key, state)
+	}
+	return dict
+}
+
+// readTranslationFile reads, decodes and validates a translation file
+func readTranslationFile(path string) (*TranslationFile, error) {
 	data, err := os.ReadFile(filepath.Clean(path))
 	if err != nil {
-		return nil, fmt.Errorf("failed to read file %s: %w", path, err)
+		logger().Warn("failed to read translation file", "path", path, "error", err)
+		return nil, &FileError{Path: path, Reason: "failed to read file", Err: err}
+	}
+
+	tf, err := decodeTranslationFile(data)
+	if err != nil {
+		err = withPath(err, path, "invalid translation file")
+		logger().Warn("invalid translation file", "path", path, "error", err)
+		return nil, err
+	}
+	logger().Debug("translation file loaded", "path", path, "lang", tf.Meta.Lang, "name", tf.Meta.Name, "keys", len(tf.Translations))
+	return tf, nil
+}
+
+// decodeTranslationFile migrates, decodes and validates the content of a
+// translation file
+func decodeTranslationFile(data []byte) (*TranslationFile, error) {
+	data, err := migrateCatalog(data)
+	if err != nil {
+		return nil, err
 	}
 
 	var tf TranslationFile
 	if err := json.Unmarshal(data, &tf); err != nil {
-		return nil, fmt.Errorf("invalid translation file %s: %w", path, err)
+		return nil, withPosition(withPath(err, "", "invalid translation file"), data)
 	}
 
 	// Validate translation file structure
 	if err := validateTranslationFile(&tf); err != nil {
-		return nil, fmt.Errorf("validation failed for %s: %w", path, err)
+		return nil, withPosition(err, data)
 	}
 
-	dict := NewDictionary(tf.Meta.Lang)
-	dict.AddAll(tf.Translations)
-	return dict, nil
+	return &tf, nil
 }
 
-// validateTranslationFile validates the structure and content of a translation file
+// validateTranslationFile validates the structure and content of a translation file.
+// Problems are reported as *FileError carrying the language and offending key.
 func validateTranslationFile(tf *TranslationFile) error {
+	lang := tf.Meta.Lang
+
 	// Check required meta fields
 	if tf.Meta.Lang == "" {
-		return fmt.Errorf("missing required 'meta.lang' field")
+		return fileError(lang, "", "missing required 'meta.lang' field")
 	}
 
 	if tf.Meta.Name == "" {
-		return fmt.Errorf("missing required 'meta.name' field")
+		return fileError(lang, "", "missing required 'meta.name' field")
 	}
 
 	// Validate language code format (basic validation)
 	if len(tf.Meta.Lang) < 2 || len(tf.Meta.Lang) > 5 {
-		return fmt.Errorf("invalid language code '%s': must be 2-5 characters", tf.Meta.Lang)
+		return fileError(lang, "", "invalid language code '%s': must be 2-5 characters", lang)
 	}
 
 	// Check for valid characters in language code (letters, numbers, hyphens)
 	for _, r := range tf.Meta.Lang {
 		if !((r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '-') {
-			return fmt.Errorf("invalid language code '%s': contains invalid character '%c'", tf.Meta.Lang, r)
+			return fileError(lang, "", "invalid language code '%s': contains invalid character '%c'", lang, r)
 		}
 	}
 
 	// Validate translations
 	if tf.Translations == nil {
-		return fmt.Errorf("missing 'translations' field")
+		return fileError(lang, "", "missing 'translations' field")
 	}
 
 	// Check for empty keys or values
 	for key, value := range tf.Translations {
 		if key == "" {
-			return fmt.Errorf("translation has empty key")
+			return fileError(lang, key, "translation has empty key")
 		}
 		if value == "" {
-			return fmt.Errorf("translation key '%s' has empty value", key)
+			return fileError(lang, key, "translation key '%s' has empty value", key)
 		}
 
 		// Validate placeholder consistency in ICU plural forms
 		if err := validatePluralTemplate(key, value); err != nil {
-			return fmt.Errorf("invalid plural template for key '%s': %w", key, err)
+			fe := fileError(lang, key, "invalid plural template for key '%s'", key)
+			fe.Err = err
+			return fe
+		}
+		if err := validateSelectTemplate(value); err != nil {
+			fe := fileError(lang, key, "invalid select template for key '%s'", key)
+			fe.Err = err
+			return fe
 		}
 	}
 
-	return nil
+	// Check list values
+	for key, list := range tf.Lists {
+		if key == "" {
+			return fileError(lang, key, "translation has empty key")
+		}
+		if len(list) == 0 {
+			return fileError(lang, key, "translation key '%s' has empty list", key)
+		}
+		for i, value := range list {
+			if value == "" {
+				return fileError(lang, key, "translation key '%s' has empty value at index %d", key, i)
+			}
+		}
+	}
+
+	if err := validateSchedule(tf); err != nil {
+		return err
+	}
+	return validateStates(tf)
+}
+
+// validateTemplate checks that a translation value is a well-formed template:
+// placeholder braces must balance and plural and select templates must be valid
+func validateTemplate(key, template string) error {
+	depth := 0
+	for _, r := range template {
+		if r == '{' {
+			depth++
+		} else if r == '}' {
+			depth--
+			if depth < 0 {
+				return fmt.Errorf("unbalanced braces: too many closing braces")
+			}
+		}
+	}
+	if depth != 0 {
+		return fmt.Errorf("unbalanced braces: missing %d closing brace(s)", depth)
+	}
+
+	if err := validatePluralTemplate(key, template); err != nil {
+		return err
+	}
+	return validateSelectTemplate(template)
 }
 
 // validatePluralTemplate validates ICU-style plural templates
@@ -210,6 +700,10 @@ func validatePluralTemplate(key, template string) error {
 		return fmt.Errorf("unbalanced braces: missing %d closing brace(s)", braceCount)
 	}
 
+	if _, ok := pluralOffset(template); !ok {
+		return fmt.Errorf("invalid offset: expected a non-negative integer")
+	}
+
 	// Validate that it contains at least one valid plural form
 	validForms := []string{"zero", "one", "two", "few", "many", "other"}
 	foundValidForm := false
@@ -230,23 +724,24 @@ func validatePluralTemplate(key, template string) error {
 
 // Load auto-loads the default dictionary from locales/default.en.json
 func Load() error {
-	return LoadFrom(DefaultFilePath)
+	return defaultBundle.Load()
 }
 
 // LoadFrom loads and registers a dictionary from a specific path
 func LoadFrom(path string) error {
-	dict, err := LoadDictionaryFile(path)
-	if err != nil {
-		return err
-	}
-	Register(dict)
-	return nil
+	return defaultBundle.LoadFrom(path)
 }
 
 // LoadLanguage loads a dictionary for a specific language from locales/default.{lang}.json
 func LoadLanguage(lang string) error {
-	path := filepath.Join(DefaultFolder, fmt.Sprintf("%s.%s.json", DefaultDictionary, lang))
-	return LoadFrom(path)
+	return defaultBundle.LoadLanguage(lang)
+}
+
+// ReloadLanguage reloads every locales/*.{lang}.json file and swaps the
+// merged dictionary into the global registry, keeping the current one if a
+// file fails to load
+func ReloadLanguage(lang string) error {
+	return defaultBundle.ReloadLanguage(lang)
 }
 
 // -----------------------------------------------------------------------------
@@ -260,6 +755,9 @@ func (d *Dictionary) Add(key, value string) {
 	if d.Translations == nil {
 		d.Translations = make(map[string]string)
 	}
+	if _, ok := d.Translations[key]; !ok {
+		d.indexAdd(key)
+	}
 	d.Translations[key] = value
 }
 
@@ -273,29 +771,222 @@ func (d *Dictionary) AddAll(translations map[string]string) {
 	for k, v := range translations {
 		d.Translations[k] = v
 	}
+	d.index = nil // rebuilt once on the next query rather than per key
 }
 
-// Get retrieves a translation with fallback to default language
-func (d *Dictionary) Get(key string) string {
+// Remove deletes a key, with its list and scheduled values, from the dictionary
+func (d *Dictionary) Remove(key string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if _, ok := d.Translations[key]; ok {
+		d.indexRemove(key)
+	}
+	delete(d.Translations, key)
+	delete(d.Lists, key)
+	delete(d.Schedule, key)
+	delete(d.fuzzy, key)
+	delete(d.states, key)
+}
+
+// Clear deletes every translation, list and scheduled value of the dictionary
+func (d *Dictionary) Clear() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.Translations = make(map[string]string)
+	d.Lists = nil
+	d.Schedule = nil
+	d.fuzzy = nil
+	d.states = nil
+	d.index = nil
+}
+
+// Meta returns the meta of the file the dictionary was loaded from. When
+// several files of a language are loaded together, it is the meta of the
+// default dictionary file. Dictionaries created with NewDictionary only have
+// Lang set.
+//
+// Example:
+//
+//	dict := i18n.GetDictionary("fr")
+//	fmt.Printf("catalog %s, updated %s\n", dict.Meta().Version, dict.Meta().Updated)
+func (d *Dictionary) Meta() Meta {
 	d.mu.RLock()
 	defer d.mu.RUnlock()
+	meta := d.meta
+	meta.Lang = d.Lang
+	return meta
+}
 
-	// Try to get from this dictionary first
-	if value, ok := d.Translations[key]; ok {
+// Direction returns the text direction declared in the dictionary's meta,
+// "ltr" or "rtl", or "" if the file does not declare one
+func (d *Dictionary) Direction() string {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.meta.Direction
+}
+
+// Get retrieves a translation with fallback along the locale's fallback
+// chain. A missing key is reported to the OnMissing listeners of the
+// dictionary's bundle.
+func (d *Dictionary) Get(key string) string {
+	if value, ok := d.lookup(key); ok {
 		return value
 	}
 
-	// Fallback to default language dictionary if this isn't the default
-	if d.Lang != DefaultLanguage() {
-		if defaultDict := GetDictionary(DefaultLanguage()); defaultDict != nil && defaultDict != d {
-			return defaultDict.Get(key)
-		}
+	d.mu.RLock()
+	b := d.bundle
+	d.mu.RUnlock()
+	if b == nil {
+		b = defaultBundle
 	}
+	b.notifyMissing(d.Lang, key)
 
 	// Return key if not found
 	return key
 }
 
+// lookup finds a translation in this dictionary or its fallback dictionaries
+func (d *Dictionary) lookup(key string) (string, bool) {
+	return d.lookupFirst(key)
+}
+
+// lookupFirst finds the first of several candidate keys, trying all of them in
+// this dictionary before walking the fallback chain (e.g. pt-BR → pt → en)
+func (d *Dictionary) lookupFirst(keys ...string) (string, bool) {
+	for _, key := range keys {
+		if value, ok := d.translation(key); ok {
+			return value, true
+		}
+	}
+
+	for _, dict := range d.fallbackDictionaries() {
+		for _, key := range keys {
+			if value, ok := dict.translation(key); ok {
+				return value, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// translation returns a translation of this dictionary only, without
+// fallback, preferring a scheduled value whose window is active. Fuzzy values
+// and values below the least state served are left out as the dictionary's
+// bundle asks.
+func (d *Dictionary) translation(key string) (string, bool) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	if d.fuzzy[key] && d.excludesFuzzy() || d.belowMinState(key) {
+		return "", false
+	}
+	if value, ok := d.scheduled(key); ok {
+		return value, true
+	}
+	value, ok := d.Translations[key]
+	return value, ok
+}
+
+// AddSlice inserts or updates a list value
+func (d *Dictionary) AddSlice(key string, values []string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.Lists == nil {
+		d.Lists = make(map[string][]string)
+	}
+	d.Lists[key] = append([]string(nil), values...)
+}
+
+// GetSlice retrieves a list value with fallback along the locale's fallback
+// chain. It returns nil if no dictionary in the chain has a list value.
+func (d *Dictionary) GetSlice(key string) []string {
+	if list, ok := d.list(key); ok {
+		return list
+	}
+
+	for _, dict := range d.fallbackDictionaries() {
+		if list, ok := dict.list(key); ok {
+			return list
+		}
+	}
+
+	return nil
+}
+
+// list returns a copy of a list value of this dictionary only, unless its
+// state is below the least state served
+func (d *Dictionary) list(key string) ([]string, bool) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	list, ok := d.Lists[key]
+	if !ok || d.belowMinState(key) {
+		return nil, false
+	}
+	return append([]string(nil), list...), true
+}
+
+// fallbackDictionaries returns the dictionaries consulted, in order, when a
+// key is missing from this one: the base catalog of its language, then the
+// registered dictionary and base catalog of each language of its fallback
+// chain, from the bundle it is registered in
+func (d *Dictionary) fallbackDictionaries() []*Dictionary {
+	d.mu.RLock()
+	b := d.bundle
+	d.mu.RUnlock()
+	if b == nil {
+		b = defaultBundle
+	}
+
+	candidates := []*Dictionary{b.baseDictionary(d.Lang)}
+	for _, lang := range b.FallbackChain(d.Lang) {
+		candidates = append(candidates, b.GetDictionary(lang), b.baseDictionary(lang))
+	}
+
+	var dicts []*Dictionary
+	for _, dict := range candidates {
+		if dict != nil && dict != d {
+			dicts = append(dicts, dict)
+		}
+	}
+	return dicts
+}
+
+// Validate checks every translation of the dictionary the way catalog files
+// are checked at load, and additionally rejects templates with unbalanced
+// braces and values with invalid UTF-8 or invisible characters pasted from
+// documents (zero width spaces, right-to-left overrides, ...). Values added
+// at runtime with Add bypass file validation, so call Validate (or register
+// with RegisterStrict) to catch malformed templates before they render at
+// request time. Every problem is reported as a *FileError carrying the key;
+// the returned error joins them in key order.
+func (d *Dictionary) Validate() error {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	var errs []error
+	for _, key := range sortedKeys(d.Translations) {
+		value := d.Translations[key]
+		switch {
+		case key == "":
+			errs = append(errs, fileError(d.Lang, key, "translation has empty key"))
+		case value == "":
+			errs = append(errs, fileError(d.Lang, key, "translation key '%s' has empty value", key))
+		default:
+			if err := validateTemplate(key, value); err != nil {
+				fe := fileError(d.Lang, key, "invalid template for key '%s'", key)
+				fe.Err = err
+				errs = append(errs, fe)
+			}
+			if err := validateCharacters(value); err != nil {
+				fe := fileError(d.Lang, key, "suspicious characters in key '%s'", key)
+				fe.Err = err
+				errs = append(errs, fe)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
 // Has checks if a translation key exists
 func (d *Dictionary) Has(key string) bool {
 	d.mu.RLock()
@@ -315,6 +1006,31 @@ func (d *Dictionary) Keys() []string {
 	return keys
 }
 
+// Range calls fn for each translation in key order, stopping early if fn
+// returns false. It iterates over a snapshot taken when it is called, so fn
+// may read and modify the dictionary; changes made meanwhile are not seen.
+// Scheduled values and lists are not included.
+//
+// Example:
+//
+//	dict.Range(func(key, value string) bool {
+//		if strings.Contains(strings.ToLower(value), query) {
+//			matches = append(matches, key)
+//		}
+//		return len(matches) < 20
+//	})
+func (d *Dictionary) Range(fn func(key, value string) bool) {
+	d.mu.RLock()
+	snapshot := maps.Clone(d.Translations)
+	d.mu.RUnlock()
+
+	for _, key := range sortedKeys(snapshot) {
+		if !fn(key, snapshot[key]) {
+			return
+		}
+	}
+}
+
 // Count returns the number of translations
 func (d *Dictionary) Count() int {
 	d.mu.RLock()
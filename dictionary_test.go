@@ -2,12 +2,15 @@ package i18n
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"sync"
 	"testing"
+	"testing/iotest"
 )
 
 func TestNewDictionary(t *testing.T) {
@@ -78,9 +81,9 @@ func TestDictionaryGet_Fallback(t *testing.T) {
 	}
 
 	// Cleanup
-	muDicts.Lock()
-	dictionaries = make(map[string]*Dictionary)
-	muDicts.Unlock()
+	defaultBundle.mu.Lock()
+	defaultBundle.dictionaries = make(map[string]*Dictionary)
+	defaultBundle.mu.Unlock()
 }
 
 func TestDictionaryGet_ReturnKeyIfNotFound(t *testing.T) {
@@ -94,9 +97,9 @@ func TestDictionaryGet_ReturnKeyIfNotFound(t *testing.T) {
 	}
 
 	// Cleanup
-	muDicts.Lock()
-	dictionaries = make(map[string]*Dictionary)
-	muDicts.Unlock()
+	defaultBundle.mu.Lock()
+	defaultBundle.dictionaries = make(map[string]*Dictionary)
+	defaultBundle.mu.Unlock()
 }
 
 func TestDictionaryHas(t *testing.T) {
@@ -156,6 +159,58 @@ func TestDictionaryCount(t *testing.T) {
 	}
 }
 
+func TestDictionaryRange(t *testing.T) {
+	dict := NewDictionary("en")
+	dict.AddAll(map[string]string{"c": "C", "a": "A", "b": "B"})
+
+	var visited []string
+	dict.Range(func(key, value string) bool {
+		visited = append(visited, key+"="+value)
+		// Writing from the callback must not deadlock or affect the iteration
+		dict.Add("z", "Z")
+		dict.Remove("c")
+		return true
+	})
+	if want := []string{"a=A", "b=B", "c=C"}; !reflect.DeepEqual(visited, want) {
+		t.Errorf("Expected %v, got %v", want, visited)
+	}
+
+	visited = nil
+	dict.Range(func(key, value string) bool {
+		visited = append(visited, key)
+		return key != "b"
+	})
+	if want := []string{"a", "b"}; !reflect.DeepEqual(visited, want) {
+		t.Errorf("Expected iteration to stop after b, got %v", visited)
+	}
+}
+
+func TestDictionaryRemoveAndClear(t *testing.T) {
+	dict := NewDictionary("en")
+	dict.AddAll(map[string]string{"a": "A", "b": "B"})
+	dict.AddSlice("days", []string{"Mon", "Tue"})
+	dict.AddScheduled("a", ScheduledValue{Value: "Scheduled A"})
+
+	dict.Remove("a")
+	dict.Remove("days")
+	dict.Remove("missing")
+	if dict.Has("a") || dict.Count() != 1 {
+		t.Errorf("Expected only b to remain, got %v", dict.Keys())
+	}
+	if len(dict.GetSlice("days")) != 0 || len(dict.Schedule["a"]) != 0 {
+		t.Error("Expected the list and scheduled values to be removed")
+	}
+
+	dict.Clear()
+	if dict.Count() != 0 {
+		t.Errorf("Expected an empty dictionary, got %v", dict.Keys())
+	}
+	dict.Add("c", "C")
+	if dict.Get("c") != "C" {
+		t.Error("Expected a cleared dictionary to accept new keys")
+	}
+}
+
 func TestSetDefaultLanguage(t *testing.T) {
 	original := DefaultLanguage()
 	defer SetDefaultLanguage(original) // Restore original
@@ -186,9 +241,9 @@ func TestRegisterAndGetDictionary(t *testing.T) {
 	}
 
 	// Cleanup
-	muDicts.Lock()
-	delete(dictionaries, "test")
-	muDicts.Unlock()
+	defaultBundle.mu.Lock()
+	delete(defaultBundle.dictionaries, "test")
+	defaultBundle.mu.Unlock()
 }
 
 func TestLoadDictionaryFile(t *testing.T) {
@@ -198,12 +253,13 @@ func TestLoadDictionaryFile(t *testing.T) {
 
 	testData := TranslationFile{
 		Meta: struct {
-			Lang      string `json:"lang"`
-			Name      string `json:"name"`
-			Version   string `json:"version,omitempty"`
-			Author    string `json:"author,omitempty"`
-			Updated   string `json:"updated,omitempty"`
-			Direction string `json:"direction,omitempty"`
+			Lang          string `json:"lang"`
+			Name          string `json:"name"`
+			Version       string `json:"version,omitempty"`
+			Author        string `json:"author,omitempty"`
+			Updated       string `json:"updated,omitempty"`
+			Direction     string `json:"direction,omitempty"`
+			SchemaVersion int    `json:"schema_version,omitempty"`
 		}{
 			Lang: "en",
 			Name: "test",
@@ -255,6 +311,95 @@ func TestLoadDictionaryFile_InvalidJSON(t *testing.T) {
 	}
 }
 
+func TestLoadDictionaryReader(t *testing.T) {
+	content := `{
+  "meta": {"lang": "fr", "name": "default"},
+  "translations": {"hello": "Bonjour", "days": ["lun", "mar"]}
+}`
+
+	dict, err := LoadDictionaryReader(strings.NewReader(content))
+	if err != nil {
+		t.Fatalf("Failed to load dictionary: %v", err)
+	}
+	if dict.Lang != "fr" || dict.Get("hello") != "Bonjour" || len(dict.GetSlice("days")) != 2 {
+		t.Errorf("Unexpected dictionary: %+v", dict)
+	}
+
+	dict, err = LoadDictionaryBytes([]byte(content))
+	if err != nil {
+		t.Fatalf("Failed to load dictionary from bytes: %v", err)
+	}
+	if dict.Get("hello") != "Bonjour" {
+		t.Errorf("Expected 'Bonjour', got '%s'", dict.Get("hello"))
+	}
+
+	_, err = LoadDictionaryBytes([]byte(`{"meta": {"name": "default"}, "translations": {}}`))
+	var fe *FileError
+	if !errors.As(err, &fe) || fe.Path != "" {
+		t.Errorf("Expected a FileError without path for missing lang, got %v", err)
+	}
+
+	_, err = LoadDictionaryReader(iotest.ErrReader(errors.New("connection reset")))
+	if err == nil || !strings.Contains(err.Error(), "connection reset") {
+		t.Errorf("Expected read error, got %v", err)
+	}
+}
+
+func TestDictionaryMeta(t *testing.T) {
+	dict, err := LoadDictionaryBytes([]byte(`{
+  "meta": {"lang": "ar", "name": "default", "version": "2.4.0", "author": "L10n team", "updated": "2026-10-01", "direction": "rtl"},
+  "translations": {"hello": "مرحبا"}
+}`))
+	if err != nil {
+		t.Fatalf("Failed to load dictionary: %v", err)
+	}
+	meta := dict.Meta()
+	if meta.Lang != "ar" || meta.Name != "default" || meta.Version != "2.4.0" || meta.Author != "L10n team" || meta.Updated != "2026-10-01" {
+		t.Errorf("Unexpected meta: %+v", meta)
+	}
+	if dict.Direction() != "rtl" {
+		t.Errorf("Expected direction 'rtl', got '%s'", dict.Direction())
+	}
+
+	var buf strings.Builder
+	if err := dict.Export(&buf, FormatJSON); err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), `"version": "2.4.0"`) || !strings.Contains(buf.String(), `"direction": "rtl"`) {
+		t.Errorf("Expected export to keep the meta, got %s", buf.String())
+	}
+
+	created := NewDictionary("fr")
+	if meta := created.Meta(); meta != (Meta{Lang: "fr"}) {
+		t.Errorf("Expected only Lang to be set, got %+v", meta)
+	}
+	if created.Direction() != "" {
+		t.Errorf("Expected no direction, got '%s'", created.Direction())
+	}
+}
+
+func TestDictionaryMeta_MergedFiles(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"auth.he.json":    `{"meta": {"lang": "he", "name": "auth", "version": "1.0.0"}, "translations": {"login": "כניסה"}}`,
+		"default.he.json": `{"meta": {"lang": "he", "name": "default", "version": "3.1.0", "direction": "rtl"}, "translations": {"hello": "שלום"}}`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write test file: %v", err)
+		}
+	}
+
+	b := NewBundle()
+	if err := b.LoadDir(dir); err != nil {
+		t.Fatalf("LoadDir failed: %v", err)
+	}
+	dict := b.GetDictionary("he")
+	if meta := dict.Meta(); meta.Name != "default" || meta.Version != "3.1.0" || dict.Direction() != "rtl" {
+		t.Errorf("Expected the default dictionary's meta, got %+v", meta)
+	}
+}
+
 func TestLoadDictionaryFile_MissingLang(t *testing.T) {
 	tempDir := t.TempDir()
 	filePath := filepath.Join(tempDir, "nolang.json")
@@ -289,12 +434,13 @@ func TestValidateTranslationFile(t *testing.T) {
 			name: "valid file",
 			tf: TranslationFile{
 				Meta: struct {
-					Lang      string `json:"lang"`
-					Name      string `json:"name"`
-					Version   string `json:"version,omitempty"`
-					Author    string `json:"author,omitempty"`
-					Updated   string `json:"updated,omitempty"`
-					Direction string `json:"direction,omitempty"`
+					Lang          string `json:"lang"`
+					Name          string `json:"name"`
+					Version       string `json:"version,omitempty"`
+					Author        string `json:"author,omitempty"`
+					Updated       string `json:"updated,omitempty"`
+					Direction     string `json:"direction,omitempty"`
+					SchemaVersion int    `json:"schema_version,omitempty"`
 				}{
 					Lang: "en",
 					Name: "test",
@@ -310,12 +456,13 @@ func TestValidateTranslationFile(t *testing.T) {
 			name: "missing lang",
 			tf: TranslationFile{
 				Meta: struct {
-					Lang      string `json:"lang"`
-					Name      string `json:"name"`
-					Version   string `json:"version,omitempty"`
-					Author    string `json:"author,omitempty"`
-					Updated   string `json:"updated,omitempty"`
-					Direction string `json:"direction,omitempty"`
+					Lang          string `json:"lang"`
+					Name          string `json:"name"`
+					Version       string `json:"version,omitempty"`
+					Author        string `json:"author,omitempty"`
+					Updated       string `json:"updated,omitempty"`
+					Direction     string `json:"direction,omitempty"`
+					SchemaVersion int    `json:"schema_version,omitempty"`
 				}{
 					Name: "test",
 				},
@@ -328,12 +475,13 @@ func TestValidateTranslationFile(t *testing.T) {
 			name: "missing name",
 			tf: TranslationFile{
 				Meta: struct {
-					Lang      string `json:"lang"`
-					Name      string `json:"name"`
-					Version   string `json:"version,omitempty"`
-					Author    string `json:"author,omitempty"`
-					Updated   string `json:"updated,omitempty"`
-					Direction string `json:"direction,omitempty"`
+					Lang          string `json:"lang"`
+					Name          string `json:"name"`
+					Version       string `json:"version,omitempty"`
+					Author        string `json:"author,omitempty"`
+					Updated       string `json:"updated,omitempty"`
+					Direction     string `json:"direction,omitempty"`
+					SchemaVersion int    `json:"schema_version,omitempty"`
 				}{
 					Lang: "en",
 				},
@@ -346,12 +494,13 @@ func TestValidateTranslationFile(t *testing.T) {
 			name: "invalid lang code - too short",
 			tf: TranslationFile{
 				Meta: struct {
-					Lang      string `json:"lang"`
-					Name      string `json:"name"`
-					Version   string `json:"version,omitempty"`
-					Author    string `json:"author,omitempty"`
-					Updated   string `json:"updated,omitempty"`
-					Direction string `json:"direction,omitempty"`
+					Lang          string `json:"lang"`
+					Name          string `json:"name"`
+					Version       string `json:"version,omitempty"`
+					Author        string `json:"author,omitempty"`
+					Updated       string `json:"updated,omitempty"`
+					Direction     string `json:"direction,omitempty"`
+					SchemaVersion int    `json:"schema_version,omitempty"`
 				}{
 					Lang: "e",
 					Name: "test",
@@ -365,12 +514,13 @@ func TestValidateTranslationFile(t *testing.T) {
 			name: "invalid lang code - too long",
 			tf: TranslationFile{
 				Meta: struct {
-					Lang      string `json:"lang"`
-					Name      string `json:"name"`
-					Version   string `json:"version,omitempty"`
-					Author    string `json:"author,omitempty"`
-					Updated   string `json:"updated,omitempty"`
-					Direction string `json:"direction,omitempty"`
+					Lang          string `json:"lang"`
+					Name          string `json:"name"`
+					Version       string `json:"version,omitempty"`
+					Author        string `json:"author,omitempty"`
+					Updated       string `json:"updated,omitempty"`
+					Direction     string `json:"direction,omitempty"`
+					SchemaVersion int    `json:"schema_version,omitempty"`
 				}{
 					Lang: "toolong",
 					Name: "test",
@@ -384,12 +534,13 @@ func TestValidateTranslationFile(t *testing.T) {
 			name: "invalid lang code - invalid characters",
 			tf: TranslationFile{
 				Meta: struct {
-					Lang      string `json:"lang"`
-					Name      string `json:"name"`
-					Version   string `json:"version,omitempty"`
-					Author    string `json:"author,omitempty"`
-					Updated   string `json:"updated,omitempty"`
-					Direction string `json:"direction,omitempty"`
+					Lang          string `json:"lang"`
+					Name          string `json:"name"`
+					Version       string `json:"version,omitempty"`
+					Author        string `json:"author,omitempty"`
+					Updated       string `json:"updated,omitempty"`
+					Direction     string `json:"direction,omitempty"`
+					SchemaVersion int    `json:"schema_version,omitempty"`
 				}{
 					Lang: "en@US",
 					Name: "test",
@@ -403,12 +554,13 @@ func TestValidateTranslationFile(t *testing.T) {
 			name: "empty key",
 			tf: TranslationFile{
 				Meta: struct {
-					Lang      string `json:"lang"`
-					Name      string `json:"name"`
-					Version   string `json:"version,omitempty"`
-					Author    string `json:"author,omitempty"`
-					Updated   string `json:"updated,omitempty"`
-					Direction string `json:"direction,omitempty"`
+					Lang          string `json:"lang"`
+					Name          string `json:"name"`
+					Version       string `json:"version,omitempty"`
+					Author        string `json:"author,omitempty"`
+					Updated       string `json:"updated,omitempty"`
+					Direction     string `json:"direction,omitempty"`
+					SchemaVersion int    `json:"schema_version,omitempty"`
 				}{
 					Lang: "en",
 					Name: "test",
@@ -425,12 +577,13 @@ func TestValidateTranslationFile(t *testing.T) {
 			name: "empty value",
 			tf: TranslationFile{
 				Meta: struct {
-					Lang      string `json:"lang"`
-					Name      string `json:"name"`
-					Version   string `json:"version,omitempty"`
-					Author    string `json:"author,omitempty"`
-					Updated   string `json:"updated,omitempty"`
-					Direction string `json:"direction,omitempty"`
+					Lang          string `json:"lang"`
+					Name          string `json:"name"`
+					Version       string `json:"version,omitempty"`
+					Author        string `json:"author,omitempty"`
+					Updated       string `json:"updated,omitempty"`
+					Direction     string `json:"direction,omitempty"`
+					SchemaVersion int    `json:"schema_version,omitempty"`
 				}{
 					Lang: "en",
 					Name: "test",
@@ -447,12 +600,13 @@ func TestValidateTranslationFile(t *testing.T) {
 			name: "valid plural template",
 			tf: TranslationFile{
 				Meta: struct {
-					Lang      string `json:"lang"`
-					Name      string `json:"name"`
-					Version   string `json:"version,omitempty"`
-					Author    string `json:"author,omitempty"`
-					Updated   string `json:"updated,omitempty"`
-					Direction string `json:"direction,omitempty"`
+					Lang          string `json:"lang"`
+					Name          string `json:"name"`
+					Version       string `json:"version,omitempty"`
+					Author        string `json:"author,omitempty"`
+					Updated       string `json:"updated,omitempty"`
+					Direction     string `json:"direction,omitempty"`
+					SchemaVersion int    `json:"schema_version,omitempty"`
 				}{
 					Lang: "en",
 					Name: "test",
@@ -467,12 +621,13 @@ func TestValidateTranslationFile(t *testing.T) {
 			name: "invalid plural template - unbalanced braces",
 			tf: TranslationFile{
 				Meta: struct {
-					Lang      string `json:"lang"`
-					Name      string `json:"name"`
-					Version   string `json:"version,omitempty"`
-					Author    string `json:"author,omitempty"`
-					Updated   string `json:"updated,omitempty"`
-					Direction string `json:"direction,omitempty"`
+					Lang          string `json:"lang"`
+					Name          string `json:"name"`
+					Version       string `json:"version,omitempty"`
+					Author        string `json:"author,omitempty"`
+					Updated       string `json:"updated,omitempty"`
+					Direction     string `json:"direction,omitempty"`
+					SchemaVersion int    `json:"schema_version,omitempty"`
 				}{
 					Lang: "en",
 					Name: "test",
@@ -488,12 +643,13 @@ func TestValidateTranslationFile(t *testing.T) {
 			name: "invalid plural template - no valid forms",
 			tf: TranslationFile{
 				Meta: struct {
-					Lang      string `json:"lang"`
-					Name      string `json:"name"`
-					Version   string `json:"version,omitempty"`
-					Author    string `json:"author,omitempty"`
-					Updated   string `json:"updated,omitempty"`
-					Direction string `json:"direction,omitempty"`
+					Lang          string `json:"lang"`
+					Name          string `json:"name"`
+					Version       string `json:"version,omitempty"`
+					Author        string `json:"author,omitempty"`
+					Updated       string `json:"updated,omitempty"`
+					Direction     string `json:"direction,omitempty"`
+					SchemaVersion int    `json:"schema_version,omitempty"`
 				}{
 					Lang: "en",
 					Name: "test",
@@ -572,6 +728,19 @@ func TestValidatePluralTemplate(t *testing.T) {
 			template: "{count, plural, zero {no messages} one {# message} few {# messages} other {# messages}}",
 			wantErr:  false,
 		},
+		{
+			name:     "exact matches and offset",
+			key:      "liked",
+			template: "{count, plural, offset:1 =0 {nobody} =1 {you} one {you and # other} other {you and # others}}",
+			wantErr:  false,
+		},
+		{
+			name:     "malformed offset",
+			key:      "liked",
+			template: "{count, plural, offset:-1 one {# item} other {# items}}",
+			wantErr:  true,
+			errMsg:   "invalid offset",
+		},
 	}
 
 	for _, tt := range tests {
@@ -592,6 +761,40 @@ func TestValidatePluralTemplate(t *testing.T) {
 	}
 }
 
+func TestDictionaryValidate(t *testing.T) {
+	dict := NewDictionary("en")
+	dict.AddAll(map[string]string{
+		"hello-0": "Hello {0}",
+		"items":   "{count, plural, one {# item} other {# items}}",
+	})
+	if err := dict.Validate(); err != nil {
+		t.Fatalf("Expected valid dictionary, got %v", err)
+	}
+
+	dict.Add("greeting", "Hello {name")
+	dict.Add("broken-plural", "{count, plural, single {# item}}")
+	dict.Add("empty", "")
+
+	err := dict.Validate()
+	if err == nil {
+		t.Fatal("Expected validation errors, got nil")
+	}
+
+	var fe *FileError
+	if !errors.As(err, &fe) || fe.Key != "broken-plural" || fe.Lang != "en" {
+		t.Errorf("Expected first error for 'broken-plural', got %v", err)
+	}
+	for _, expected := range []string{
+		"translation key 'empty' has empty value",
+		"invalid template for key 'greeting': unbalanced braces",
+		"no valid plural forms found",
+	} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("Expected error to contain %q, got: %v", expected, err)
+		}
+	}
+}
+
 func TestDictionaryConcurrency(t *testing.T) {
 	dict := NewDictionary("en")
 
@@ -0,0 +1,121 @@
+package i18n
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+)
+
+// TranslationDiff is the drift between the keys a source tree uses and the
+// keys of one catalog, see DiffTranslations
+type TranslationDiff struct {
+	Lang    string   `json:"lang"`
+	Path    string   `json:"path"`
+	Missing []string `json:"missing,omitempty"` // keys the code uses that the catalog has no value for
+	Unused  []string `json:"unused,omitempty"`  // keys of the catalog the code no longer uses
+}
+
+// Empty reports whether the catalog matches the code
+func (d TranslationDiff) Empty() bool {
+	return len(d.Missing) == 0 && len(d.Unused) == 0
+}
+
+// DiffTranslations compares the keys used by the i18n calls under root (and
+// found by the registered extractors) with those of the catalog at
+// localeFile, without writing anything, so CI can fail when a string would
+// ship untranslated. A missing catalog has every key missing. List values
+// count as translations but are not reported as unused, since no extracted
+// call reads them.
+//
+// Example:
+//
+//	diff, err := i18n.DiffTranslations(".", "locales/default.fr.json")
+//	if err == nil && !diff.Empty() {
+//		log.Fatalf("missing: %v, unused: %v", diff.Missing, diff.Unused)
+//	}
+func DiffTranslations(root, localeFile string) (TranslationDiff, error) {
+	return DiffTranslationsContext(context.Background(), root, localeFile)
+}
+
+// DiffTranslationsContext is like DiffTranslations but stops scanning and
+// returns ctx's error once ctx is done
+func DiffTranslationsContext(ctx context.Context, root, localeFile string) (TranslationDiff, error) {
+	results, err := extractTranslations(ctx, []string{root}, nil)
+	if err != nil {
+		return TranslationDiff{}, err
+	}
+	return diffCatalog(results, localeFile)
+}
+
+// DiffCatalogs compares the configured sources with the catalog of every
+// configured locale, in order, like DiffTranslations
+func (c *Config) DiffCatalogs(ctx context.Context) ([]TranslationDiff, error) {
+	sources := c.Sources
+	if len(sources) == 0 {
+		sources = []string{"."}
+	}
+	results, err := extractTranslations(ctx, sources, c.Exclude)
+	if err != nil {
+		return nil, err
+	}
+
+	diffs := make([]TranslationDiff, 0, len(c.Locales))
+	for _, locale := range c.Locales {
+		diff, err := diffCatalog(results, filepath.Join(c.Dir(), fmt.Sprintf("%s.%s.json", DefaultDictionary, locale)))
+		if err != nil {
+			return nil, err
+		}
+		diffs = append(diffs, diff)
+	}
+	return diffs, nil
+}
+
+// diffCatalog compares extracted keys with the catalog at path
+func diffCatalog(results extraction, path string) (TranslationDiff, error) {
+	tf, err := readCatalog(path)
+	if err != nil {
+		return TranslationDiff{}, err
+	}
+	diff := TranslationDiff{Lang: tf.Meta.Lang, Path: path}
+	if diff.Lang == "" {
+		diff.Lang = fileLanguage(path)
+	}
+
+	used := make(map[string]bool, len(results.texts)+len(results.keys))
+	for key := range results.texts {
+		used[key] = true
+	}
+	for key := range results.keys {
+		used[key] = true
+	}
+
+	for key := range used {
+		_, ok := tf.Translations[key]
+		if _, isList := tf.Lists[key]; !ok && !isList {
+			diff.Missing = append(diff.Missing, key)
+		}
+	}
+	// Object values are used through their fields
+	found := make(map[string]bool, len(results.blocks))
+	for key := range tf.Translations {
+		if block, ok := results.blockOf(key); ok {
+			found[block] = true
+		} else if !used[key] {
+			diff.Unused = append(diff.Unused, key)
+		}
+	}
+	for key := range tf.Lists {
+		if block, ok := results.blockOf(key); ok {
+			found[block] = true
+		}
+	}
+	for block := range results.blocks {
+		if !found[block] {
+			diff.Missing = append(diff.Missing, block)
+		}
+	}
+	sort.Strings(diff.Missing)
+	sort.Strings(diff.Unused)
+	return diff, nil
+}
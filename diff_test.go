@@ -0,0 +1,80 @@
+package i18n
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestDiffTranslations(t *testing.T) {
+	root := t.TempDir()
+	writeTestFile(t, filepath.Join(root, "app.go"), `package app
+
+import "github.com/nyxstack/i18n"
+
+var (
+	title   = i18n.S("Dashboard")
+	welcome = i18n.T("welcome", "Anne")
+	days    = i18n.T("days")
+	items   = i18n.P("items", 2)
+)
+`)
+	path := filepath.Join(root, "locales", "default.fr.json")
+	writeTestFile(t, path, `{
+  "meta": {"lang": "fr", "name": "default"},
+  "translations": {
+    "dashboard": "Tableau de bord",
+    "welcome": "Bienvenue {0}",
+    "days": ["lun", "mar"],
+    "old.banner": "Soldes",
+    "weekdays": ["lun", "mar"]
+  }
+}`)
+
+	diff, err := DiffTranslations(root, path)
+	if err != nil {
+		t.Fatalf("DiffTranslations failed: %v", err)
+	}
+	want := TranslationDiff{Lang: "fr", Path: path, Missing: []string{"items"}, Unused: []string{"old.banner"}}
+	if !reflect.DeepEqual(diff, want) {
+		t.Errorf("Expected %+v, got %+v", want, diff)
+	}
+	if diff.Empty() {
+		t.Error("Expected drift to be reported")
+	}
+
+	// A missing catalog lacks every key
+	diff, err = DiffTranslations(root, filepath.Join(root, "locales", "default.de.json"))
+	if err != nil {
+		t.Fatalf("DiffTranslations failed: %v", err)
+	}
+	if diff.Lang != "de" || len(diff.Missing) != 4 || len(diff.Unused) != 0 {
+		t.Errorf("Expected every key to be missing, got %+v", diff)
+	}
+}
+
+func TestConfigDiffCatalogs(t *testing.T) {
+	root := t.TempDir()
+	writeTestFile(t, filepath.Join(root, "app.go"), "package app\n\nimport \"github.com/nyxstack/i18n\"\n\nvar title = i18n.S(\"Dashboard\")\n")
+	cfg, err := LoadConfig(writeConfig(t, root, "locales: [en, fr]\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cfg.GenerateCatalogs(context.Background()); err != nil {
+		t.Fatalf("GenerateCatalogs failed: %v", err)
+	}
+
+	diffs, err := cfg.DiffCatalogs(context.Background())
+	if err != nil {
+		t.Fatalf("DiffCatalogs failed: %v", err)
+	}
+	if len(diffs) != 2 || !diffs[0].Empty() || !diffs[1].Empty() || diffs[1].Lang != "fr" {
+		t.Errorf("Expected generated catalogs to match the code, got %+v", diffs)
+	}
+
+	writeTestFile(t, filepath.Join(root, "app.go"), "package app\n\nimport \"github.com/nyxstack/i18n\"\n\nvar title = i18n.S(\"Overview\")\n")
+	if diffs, _ = cfg.DiffCatalogs(context.Background()); diffs[1].Empty() || diffs[1].Missing[0] != "overview" {
+		t.Errorf("Expected the changed text to be reported, got %+v", diffs)
+	}
+}
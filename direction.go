@@ -0,0 +1,83 @@
+package i18n
+
+import "strings"
+
+// Text directions returned by Direction
+const (
+	LTR = "ltr"
+	RTL = "rtl"
+)
+
+// rtlLanguages are the languages written right to left by default
+var rtlLanguages = map[string]bool{
+	"ar": true, "arc": true, "ckb": true, "dv": true, "fa": true, "he": true,
+	"iw": true, "ji": true, "ks": true, "ps": true, "sd": true, "syr": true,
+	"ug": true, "ur": true, "yi": true,
+}
+
+// rtlScripts are the right-to-left ISO 15924 scripts, lowercased, for tags
+// that name their script ("az-Arab", "pa-Arab")
+var rtlScripts = map[string]bool{
+	"adlm": true, "arab": true, "hebr": true, "nkoo": true, "rohg": true,
+	"syrc": true, "thaa": true,
+}
+
+// Direction returns the text direction of locale, "ltr" or "rtl", for the
+// dir attribute of HTML pages. The direction field of the dictionary the
+// locale resolves to wins; otherwise it is looked up in a built-in table of
+// right-to-left languages (Arabic, Hebrew, Persian, Urdu, ...) and scripts.
+//
+// Example:
+//
+//	<html lang="{{ .Locale }}" dir="{{ .Dir }}">
+//	page.Dir = i18n.Direction(locale)
+func Direction(locale string) string {
+	return defaultBundle.Direction(locale)
+}
+
+// Direction is like the package-level Direction but uses the bundle's
+// dictionaries
+func (b *Bundle) Direction(locale string) string {
+	lang := locale
+	if dict := b.dictionaryFor(locale); dict != nil {
+		switch strings.ToLower(dict.Direction()) {
+		case LTR:
+			return LTR
+		case RTL:
+			return RTL
+		}
+		lang = dict.Lang
+	}
+	return tagDirection(lang)
+}
+
+// IsRTL reports whether locale is written right to left, see Direction
+func IsRTL(locale string) bool {
+	return defaultBundle.IsRTL(locale)
+}
+
+// IsRTL is like the package-level IsRTL but uses the bundle's dictionaries
+func (b *Bundle) IsRTL(locale string) bool {
+	return b.Direction(locale) == RTL
+}
+
+// tagDirection returns the built-in direction of a language tag. A script
+// subtag decides over the language ("ku-Arab" is rtl, "sd-Deva" ltr).
+func tagDirection(tag string) string {
+	subtags := strings.Split(normalizeTag(tag), "-")
+	for _, sub := range subtags[1:] {
+		if len(sub) == 1 {
+			break // extensions and private use follow
+		}
+		if len(sub) == 4 && sub[0] >= 'a' && sub[0] <= 'z' {
+			if rtlScripts[sub] {
+				return RTL
+			}
+			return LTR
+		}
+	}
+	if rtlLanguages[subtags[0]] {
+		return RTL
+	}
+	return LTR
+}
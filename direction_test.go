@@ -0,0 +1,60 @@
+package i18n
+
+import "testing"
+
+func TestDirection_BuiltIn(t *testing.T) {
+	tests := []struct {
+		locale string
+		want   string
+	}{
+		{"en", LTR},
+		{"ar", RTL},
+		{"ar-EG", RTL},
+		{"he_IL", RTL},
+		{"fa", RTL},
+		{"ur-PK", RTL},
+		{"ckb", RTL},
+		{"pa-Arab", RTL},
+		{"az-Arab-IR", RTL},
+		{"sd-Deva", LTR},
+		{"ku-Latn", LTR},
+		{"en-x-arab", LTR},
+		{"", LTR},
+	}
+
+	b := NewBundle()
+	for _, tt := range tests {
+		if got := b.Direction(tt.locale); got != tt.want {
+			t.Errorf("Direction(%q) = %q, want %q", tt.locale, got, tt.want)
+		}
+		if got := b.IsRTL(tt.locale); got != (tt.want == RTL) {
+			t.Errorf("IsRTL(%q) = %v", tt.locale, got)
+		}
+	}
+}
+
+func TestDirection_FromDictionary(t *testing.T) {
+	b := NewBundle()
+
+	// A constructed-script locale declared rtl in its catalog
+	xx, err := LoadDictionaryBytes([]byte(`{"meta": {"lang": "xx", "name": "default", "direction": "RTL"}, "translations": {"hello": "olleh"}}`))
+	if err != nil {
+		t.Fatalf("Failed to load dictionary: %v", err)
+	}
+	b.Register(xx)
+	en := NewDictionary("en")
+	en.Add("hello", "Hello")
+	b.Register(en)
+
+	if !b.IsRTL("xx") {
+		t.Error("Expected the file's direction to be used")
+	}
+	if !b.IsRTL("xx-YY") {
+		t.Error("Expected a regional locale to use its parent's direction")
+	}
+
+	// Arabic without a catalog renders the English fallback, left to right
+	if got := b.Direction("ar"); got != LTR {
+		t.Errorf("Expected the fallback dictionary's direction, got %q", got)
+	}
+}
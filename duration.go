@@ -0,0 +1,186 @@
+package i18n
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// durationUnit is one unit of a formatted duration
+type durationUnit struct {
+	name string
+	size time.Duration
+}
+
+// durationUnits lists the units of a formatted duration, largest first
+var durationUnits = []durationUnit{
+	{"day", 24 * time.Hour},
+	{"hour", time.Hour},
+	{"minute", time.Minute},
+	{"second", time.Second},
+}
+
+// unitNames holds the localized names of a unit: long names by plural form,
+// and invariant short and narrow abbreviations
+type unitNames struct {
+	long   map[string]string
+	short  string
+	narrow string // defaults to short
+}
+
+// durationLocale holds the built-in duration vocabulary of a language
+type durationLocale struct {
+	units map[string]unitNames
+	and   string // joins the last two units of long durations
+}
+
+// durationLocales are the built-in duration vocabularies. Locales without an
+// entry use the vocabulary of their parent language, then English.
+var durationLocales = map[string]durationLocale{
+	"en": {and: "and", units: map[string]unitNames{
+		"day":         {long: map[string]string{"one": "day", "other": "days"}, short: "d", narrow: "d"},
+		"hour":        {long: map[string]string{"one": "hour", "other": "hours"}, short: "hr", narrow: "h"},
+		"minute":      {long: map[string]string{"one": "minute", "other": "minutes"}, short: "min", narrow: "m"},
+		"second":      {long: map[string]string{"one": "second", "other": "seconds"}, short: "sec", narrow: "s"},
+		"millisecond": {long: map[string]string{"one": "millisecond", "other": "milliseconds"}, short: "ms"},
+	}},
+	"fr": {and: "et", units: map[string]unitNames{
+		"day":         {long: map[string]string{"zero": "jour", "one": "jour", "other": "jours"}, short: "j"},
+		"hour":        {long: map[string]string{"zero": "heure", "one": "heure", "other": "heures"}, short: "h"},
+		"minute":      {long: map[string]string{"zero": "minute", "one": "minute", "other": "minutes"}, short: "min"},
+		"second":      {long: map[string]string{"zero": "seconde", "one": "seconde", "other": "secondes"}, short: "s"},
+		"millisecond": {long: map[string]string{"zero": "milliseconde", "one": "milliseconde", "other": "millisecondes"}, short: "ms"},
+	}},
+	"de": {and: "und", units: map[string]unitNames{
+		"day":         {long: map[string]string{"one": "Tag", "other": "Tage"}, short: "T.", narrow: "T"},
+		"hour":        {long: map[string]string{"one": "Stunde", "other": "Stunden"}, short: "Std.", narrow: "h"},
+		"minute":      {long: map[string]string{"one": "Minute", "other": "Minuten"}, short: "Min.", narrow: "min"},
+		"second":      {long: map[string]string{"one": "Sekunde", "other": "Sekunden"}, short: "Sek.", narrow: "s"},
+		"millisecond": {long: map[string]string{"one": "Millisekunde", "other": "Millisekunden"}, short: "ms"},
+	}},
+	"es": {and: "y", units: map[string]unitNames{
+		"day":         {long: map[string]string{"one": "día", "other": "días"}, short: "d"},
+		"hour":        {long: map[string]string{"one": "hora", "other": "horas"}, short: "h"},
+		"minute":      {long: map[string]string{"one": "minuto", "other": "minutos"}, short: "min"},
+		"second":      {long: map[string]string{"one": "segundo", "other": "segundos"}, short: "s"},
+		"millisecond": {long: map[string]string{"one": "milisegundo", "other": "milisegundos"}, short: "ms"},
+	}},
+	"it": {and: "e", units: map[string]unitNames{
+		"day":         {long: map[string]string{"one": "giorno", "other": "giorni"}, short: "g"},
+		"hour":        {long: map[string]string{"one": "ora", "other": "ore"}, short: "h"},
+		"minute":      {long: map[string]string{"one": "minuto", "other": "minuti"}, short: "min"},
+		"second":      {long: map[string]string{"one": "secondo", "other": "secondi"}, short: "s"},
+		"millisecond": {long: map[string]string{"one": "millisecondo", "other": "millisecondi"}, short: "ms"},
+	}},
+	"pt": {and: "e", units: map[string]unitNames{
+		"day":         {long: map[string]string{"one": "dia", "other": "dias"}, short: "d"},
+		"hour":        {long: map[string]string{"one": "hora", "other": "horas"}, short: "h"},
+		"minute":      {long: map[string]string{"one": "minuto", "other": "minutos"}, short: "min"},
+		"second":      {long: map[string]string{"one": "segundo", "other": "segundos"}, short: "s"},
+		"millisecond": {long: map[string]string{"one": "milissegundo", "other": "milissegundos"}, short: "ms"},
+	}},
+	"nl": {and: "en", units: map[string]unitNames{
+		"day":         {long: map[string]string{"one": "dag", "other": "dagen"}, short: "d"},
+		"hour":        {long: map[string]string{"one": "uur", "other": "uur"}, short: "u"},
+		"minute":      {long: map[string]string{"one": "minuut", "other": "minuten"}, short: "min"},
+		"second":      {long: map[string]string{"one": "seconde", "other": "seconden"}, short: "s"},
+		"millisecond": {long: map[string]string{"one": "milliseconde", "other": "milliseconden"}, short: "ms"},
+	}},
+	"ru": {and: "и", units: map[string]unitNames{
+		"day":         {long: map[string]string{"one": "день", "few": "дня", "other": "дней"}, short: "дн"},
+		"hour":        {long: map[string]string{"one": "час", "few": "часа", "other": "часов"}, short: "ч"},
+		"minute":      {long: map[string]string{"one": "минута", "few": "минуты", "other": "минут"}, short: "мин"},
+		"second":      {long: map[string]string{"one": "секунда", "few": "секунды", "other": "секунд"}, short: "с"},
+		"millisecond": {long: map[string]string{"one": "миллисекунда", "few": "миллисекунды", "other": "миллисекунд"}, short: "мс"},
+	}},
+	"pl": {and: "i", units: map[string]unitNames{
+		"day":         {long: map[string]string{"one": "dzień", "few": "dni", "other": "dni"}, short: "d"},
+		"hour":        {long: map[string]string{"one": "godzina", "few": "godziny", "other": "godzin"}, short: "godz.", narrow: "h"},
+		"minute":      {long: map[string]string{"one": "minuta", "few": "minuty", "other": "minut"}, short: "min"},
+		"second":      {long: map[string]string{"one": "sekunda", "few": "sekundy", "other": "sekund"}, short: "s"},
+		"millisecond": {long: map[string]string{"one": "milisekunda", "few": "milisekundy", "other": "milisekund"}, short: "ms"},
+	}},
+}
+
+// FormatDuration formats a duration in a locale, with each unit correctly
+// pluralized and joined the way the language does:
+//
+//	i18n.FormatDuration("en", 150*time.Minute, i18n.Long)   // "2 hours and 30 minutes"
+//	i18n.FormatDuration("en", 150*time.Minute, i18n.Short)  // "2 hr, 30 min"
+//	i18n.FormatDuration("en", 150*time.Minute, i18n.Narrow) // "2h 30m"
+//	i18n.FormatDuration("de", 150*time.Minute, i18n.Long)   // "2 Stunden und 30 Minuten"
+//
+// Days, hours, minutes and seconds are shown when non-zero; durations under a
+// second are shown in milliseconds. Locales without built-in vocabulary use
+// their parent language, then English.
+func FormatDuration(locale string, d time.Duration, style UnitStyle) string {
+	lang, vocab := builtinLocale(durationLocales, locale)
+
+	sign := ""
+	if d < 0 {
+		sign = "-"
+		d = -d
+	}
+
+	var parts []string
+	if d < time.Second {
+		parts = append(parts, vocab.format(lang, "millisecond", int64(d/time.Millisecond), style))
+	} else {
+		for _, unit := range durationUnits {
+			if n := d / unit.size; n > 0 {
+				parts = append(parts, vocab.format(lang, unit.name, int64(n), style))
+				d -= n * unit.size
+			}
+		}
+	}
+
+	return sign + vocab.join(parts, style)
+}
+
+// format renders a count of a unit in a style
+func (l durationLocale) format(lang, unit string, n int64, style UnitStyle) string {
+	names := l.units[unit]
+	count := strconv.FormatInt(n, 10)
+
+	switch style {
+	case Short:
+		return count + " " + names.short
+	case Narrow:
+		if names.narrow != "" {
+			return count + names.narrow
+		}
+		return count + names.short
+	default:
+		form := determinePluralForm(lang, pluralOperand(n))
+		name, ok := names.long[form]
+		if !ok {
+			name = names.long["other"]
+		}
+		return count + " " + name
+	}
+}
+
+// join combines formatted units: "a, b and c" (Long), "a, b, c" (Short) or
+// "a b c" (Narrow)
+func (l durationLocale) join(parts []string, style UnitStyle) string {
+	switch {
+	case style == Narrow:
+		return strings.Join(parts, " ")
+	case style == Short || len(parts) < 2:
+		return strings.Join(parts, ", ")
+	default:
+		last := len(parts) - 1
+		return strings.Join(parts[:last], ", ") + " " + l.and + " " + parts[last]
+	}
+}
+
+// builtinLocale finds the built-in data of a locale, trying its parent tags
+// (pt-BR → pt) before falling back to English
+func builtinLocale[T any](table map[string]T, locale string) (string, T) {
+	for tag := normalizeTag(locale); tag != ""; tag = truncateTag(tag) {
+		if data, ok := table[tag]; ok {
+			return tag, data
+		}
+	}
+	return DefaultLang, table[DefaultLang]
+}
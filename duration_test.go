@@ -0,0 +1,58 @@
+package i18n
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatDuration(t *testing.T) {
+	d := 2*time.Hour + 30*time.Minute
+
+	tests := []struct {
+		locale   string
+		d        time.Duration
+		style    UnitStyle
+		expected string
+	}{
+		{"en", d, Long, "2 hours and 30 minutes"},
+		{"en", d, Short, "2 hr, 30 min"},
+		{"en", d, Narrow, "2h 30m"},
+		{"en", time.Hour, Long, "1 hour"},
+		{"en", 26*time.Hour + time.Minute + 5*time.Second, Long, "1 day, 2 hours, 1 minute and 5 seconds"},
+		{"en", 1500 * time.Millisecond, Long, "1 second"},
+		{"en", 250 * time.Millisecond, Long, "250 milliseconds"},
+		{"en", 0, Long, "0 milliseconds"},
+		{"en", -d, Narrow, "-2h 30m"},
+		{"fr", d, Long, "2 heures et 30 minutes"},
+		{"fr", time.Minute, Long, "1 minute"},
+		{"fr", d, Narrow, "2h 30min"},
+		{"de", d, Long, "2 Stunden und 30 Minuten"},
+		{"de", d, Short, "2 Std., 30 Min."},
+		{"pt-BR", d, Long, "2 horas e 30 minutos"},
+		{"ru", 3*time.Hour + 5*time.Minute, Long, "3 часа и 5 минут"},
+		{"pl", 24 * time.Hour, Long, "1 dzień"},
+		{"ja", d, Long, "2 hours and 30 minutes"},
+	}
+
+	for _, tt := range tests {
+		if result := FormatDuration(tt.locale, tt.d, tt.style); result != tt.expected {
+			t.Errorf("FormatDuration(%q, %v, %s) = %q, expected %q", tt.locale, tt.d, tt.style, result, tt.expected)
+		}
+	}
+}
+
+func TestBuiltinLocale(t *testing.T) {
+	table := map[string]string{"en": "English", "pt": "Portuguese", "zh-hant": "Traditional Chinese"}
+
+	tests := map[string]string{
+		"pt-BR":      "pt",
+		"zh_Hant_TW": "zh-hant",
+		"en":         "en",
+		"ja":         "en",
+	}
+	for locale, expected := range tests {
+		if lang, _ := builtinLocale(table, locale); lang != expected {
+			t.Errorf("builtinLocale(%q) = %q, expected %q", locale, lang, expected)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package i18n
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// DefaultIndent is the indentation of the catalogs written without one of
+// their own, see TranslationFile.Indent
+const DefaultIndent = "  "
+
+// MarshalCatalog encodes a translation file the way the generators, the
+// importers and Export write catalogs, so the same content always gives the
+// same bytes and catalogs diff cleanly: keys sorted at every level (or in
+// group order, see TranslationFile.Groups), meta fields in a fixed order,
+// "<", ">" and "&" written as-is instead of as \u003c escapes, lines
+// indented with tf.Indent (DefaultIndent if empty) and a final newline.
+//
+// Example:
+//
+//	tf.Indent = "\t"
+//	data, err := i18n.MarshalCatalog(tf)
+func MarshalCatalog(tf *TranslationFile) ([]byte, error) {
+	indent := tf.Indent
+	if indent == "" {
+		indent = DefaultIndent
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", indent)
+	if err := enc.Encode(tf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// marshalUnescaped is json.Marshal without the escaping of "<", ">" and
+// "&", which MarshalCatalog leaves out. Callers of json.Marshal still get
+// them escaped, as it escapes the output of MarshalJSON methods again.
+func marshalUnescaped(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// fileIndent returns the indentation of the first indented line of an
+// encoded JSON object, or "" if it has none
+func fileIndent(data []byte) string {
+	_, rest, ok := bytes.Cut(data, []byte("\n"))
+	if !ok {
+		return ""
+	}
+	line := rest[:len(rest)-len(bytes.TrimLeft(rest, " \t"))]
+	return string(line)
+}
@@ -0,0 +1,90 @@
+package i18n
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMarshalCatalog(t *testing.T) {
+	tf := newCatalogFile("fr", map[string]string{
+		"zebra":   "Zèbre",
+		"welcome": "Bienvenue <b>{0}</b> & co",
+		"apple":   "Pomme",
+	})
+	tf.Lists = map[string][]string{"days": {"lun", "mar"}}
+
+	data, err := MarshalCatalog(tf)
+	if err != nil {
+		t.Fatalf("MarshalCatalog failed: %v", err)
+	}
+	out := string(data)
+	if !strings.HasSuffix(out, "}\n") || strings.HasSuffix(out, "\n\n") {
+		t.Errorf("Expected one final newline, got %q", out)
+	}
+	if !strings.Contains(out, `"welcome": "Bienvenue <b>{0}</b> & co"`) {
+		t.Errorf("Expected markup written as-is, got:\n%s", out)
+	}
+	if a, d, w, z := strings.Index(out, `"apple"`), strings.Index(out, `"days"`), strings.Index(out, `"welcome"`), strings.Index(out, `"zebra"`); !(a < d && d < w && w < z) {
+		t.Errorf("Expected sorted keys, got:\n%s", out)
+	}
+	if !strings.Contains(out, "\n  \"meta\"") {
+		t.Errorf("Expected DefaultIndent, got:\n%s", out)
+	}
+
+	// The same content always gives the same bytes
+	for range 20 {
+		again, err := MarshalCatalog(tf)
+		if err != nil || string(again) != out {
+			t.Fatalf("Expected stable output, got:\n%s", again)
+		}
+	}
+
+	// Decoding keeps the file's indentation for the next write
+	tabbed := strings.ReplaceAll(out, "  ", "\t")
+	decoded := &TranslationFile{}
+	if err := json.Unmarshal([]byte(tabbed), decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Indent != "\t" {
+		t.Errorf("Expected a tab indent, got %q", decoded.Indent)
+	}
+	if data, _ := MarshalCatalog(decoded); string(data) != tabbed {
+		t.Errorf("Expected the tabbed file back, got:\n%s", data)
+	}
+}
+
+func TestConfigGenerateCatalogs_Indent(t *testing.T) {
+	root := t.TempDir()
+	writeTestFile(t, filepath.Join(root, "app.go"), "package app\n\nimport \"github.com/nyxstack/i18n\"\n\nvar hello = i18n.S(\"Hello\")\n")
+	cfg, err := LoadConfig(writeConfig(t, root, "locales: [en, fr]\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A catalog keeps its own indentation
+	writeTestFile(t, filepath.Join(cfg.Dir(), "default.fr.json"), "{\n\t\"meta\": {\"lang\": \"fr\", \"name\": \"default\"},\n\t\"translations\": {\"hello\": \"Bonjour\"}\n}\n")
+	if err := cfg.GenerateCatalogs(context.Background()); err != nil {
+		t.Fatalf("GenerateCatalogs failed: %v", err)
+	}
+	en, _ := os.ReadFile(filepath.Join(cfg.Dir(), "default.en.json"))
+	fr, _ := os.ReadFile(filepath.Join(cfg.Dir(), "default.fr.json"))
+	if !strings.HasPrefix(string(en), "{\n  \"meta\"") || !strings.HasPrefix(string(fr), "{\n\t\"meta\"") {
+		t.Errorf("Expected en with DefaultIndent and fr with tabs, got:\n%s\n%s", en, fr)
+	}
+
+	// ... unless the config sets one
+	cfg.Indent = 4
+	if err := cfg.GenerateCatalogs(context.Background()); err != nil {
+		t.Fatalf("GenerateCatalogs failed: %v", err)
+	}
+	for _, lang := range []string{"en", "fr"} {
+		data, _ := os.ReadFile(filepath.Join(cfg.Dir(), "default."+lang+".json"))
+		if !strings.HasPrefix(string(data), "{\n    \"meta\"") {
+			t.Errorf("%s: expected 4 spaces, got:\n%s", lang, data)
+		}
+	}
+}
@@ -0,0 +1,224 @@
+package i18n
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+)
+
+// EnumValue is a constant of an enum type and its catalog key
+type EnumValue struct {
+	Name  string `json:"name"`  // constant name, e.g. "StatusOnHold"
+	Key   string `json:"key"`   // catalog key, e.g. "status.on-hold"
+	Label string `json:"label"` // default label derived from the name, e.g. "On hold"
+}
+
+// FindEnum returns the constants of type typeName declared in the Go package
+// in dir, in declaration order. Keys combine the type and constant names:
+// StatusOnHold of type Status becomes "status.on-hold".
+func FindEnum(dir, typeName string) (string, []EnumValue, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return "", nil, err
+	}
+
+	fset := token.NewFileSet()
+	var pkg string
+	var values []EnumValue
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+
+		node, err := parser.ParseFile(fset, file, nil, 0)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to parse %s: %w", file, err)
+		}
+		pkg = node.Name.Name
+
+		for _, decl := range node.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.CONST {
+				continue
+			}
+
+			// Constants without type or value repeat the previous spec (iota)
+			current := ""
+			for _, spec := range gen.Specs {
+				vs := spec.(*ast.ValueSpec)
+				switch {
+				case vs.Type != nil:
+					current = ""
+					if ident, ok := vs.Type.(*ast.Ident); ok {
+						current = ident.Name
+					}
+				case len(vs.Values) > 0:
+					current = ""
+				}
+
+				if current != typeName {
+					continue
+				}
+				for _, name := range vs.Names {
+					if name.Name != "_" {
+						values = append(values, enumValue(typeName, name.Name))
+					}
+				}
+			}
+		}
+	}
+
+	if len(values) == 0 {
+		return "", nil, fmt.Errorf("no constants of type %s found in %s", typeName, dir)
+	}
+	return pkg, values, nil
+}
+
+// enumValue derives the key and default label of an enum constant
+func enumValue(typeName, name string) EnumValue {
+	rest := strings.TrimPrefix(name, typeName)
+	if rest == "" {
+		rest = name
+	}
+
+	words := splitCamel(rest)
+	label := strings.ToLower(strings.Join(words, " "))
+	if label != "" {
+		r := []rune(label)
+		label = string(unicode.ToUpper(r[0])) + string(r[1:])
+	}
+
+	return EnumValue{
+		Name:  name,
+		Key:   slugify(strings.Join(splitCamel(typeName), " ")) + KeySeparator + slugify(strings.Join(words, " ")),
+		Label: label,
+	}
+}
+
+// splitCamel splits a camel case identifier into words, keeping acronyms
+// together: "HTTPStatusOK" → [HTTP Status OK]
+func splitCamel(s string) []string {
+	runes := []rune(s)
+	var words []string
+	start := 0
+	for i := 1; i < len(runes); i++ {
+		prev, cur := runes[i-1], runes[i]
+		nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+		if unicode.IsUpper(cur) && (unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower)) ||
+			cur == '_' {
+			if word := strings.Trim(string(runes[start:i]), "_"); word != "" {
+				words = append(words, word)
+			}
+			start = i
+		}
+	}
+	if word := strings.Trim(string(runes[start:]), "_"); word != "" {
+		words = append(words, word)
+	}
+	return words
+}
+
+// GenerateEnum writes a LocalizedString(locale) method for the enum type
+// typeName declared in dir, translating each constant by its catalog key and
+// falling back to the value's String form. The output defaults to
+// <type>_i18n.go in dir. It returns the enum values so callers can add the
+// keys to their catalogs.
+func GenerateEnum(dir, typeName, outputPath string) ([]EnumValue, error) {
+	pkg, values, err := FindEnum(dir, typeName)
+	if err != nil {
+		return nil, err
+	}
+
+	if outputPath == "" {
+		outputPath = filepath.Join(dir, strings.ToLower(typeName)+"_i18n.go")
+	}
+
+	keys := unexportedName(typeName) + "LabelKeys"
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by extract-i18n enum; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package %s\n\n", pkg)
+	fmt.Fprintf(&buf, "import (\n\t\"fmt\"\n\n\t\"github.com/nyxstack/i18n\"\n)\n\n")
+	fmt.Fprintf(&buf, "// %s maps each %s to its catalog key\n", keys, typeName)
+	fmt.Fprintf(&buf, "var %s = map[%s]string{\n", keys, typeName)
+	for _, v := range values {
+		fmt.Fprintf(&buf, "\t%s: %q,\n", v.Name, v.Key)
+	}
+	fmt.Fprintf(&buf, "}\n\n")
+	fmt.Fprintf(&buf, "// LocalizedString returns the localized label of a %s, or its String\n", typeName)
+	fmt.Fprintf(&buf, "// form if the catalog has no translation\n")
+	fmt.Fprintf(&buf, "func (v %s) LocalizedString(locale string) string {\n", typeName)
+	fmt.Fprintf(&buf, "\tif key, ok := %s[v]; ok {\n", keys)
+	fmt.Fprintf(&buf, "\t\tif label := i18n.T(key)(locale); label != key {\n\t\t\treturn label\n\t\t}\n\t}\n")
+	fmt.Fprintf(&buf, "\treturn fmt.Sprint(v)\n}\n")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format generated code: %w", err)
+	}
+
+	if err := os.WriteFile(filepath.Clean(outputPath), src, 0644); err != nil {
+		return nil, fmt.Errorf("failed to write %s: %w", outputPath, err)
+	}
+
+	logger().Info("enum labels generated", "type", typeName, "values", len(values), "path", outputPath)
+	return values, nil
+}
+
+// unexportedName lowercases the first letter of an identifier
+func unexportedName(name string) string {
+	r := []rune(name)
+	r[0] = unicode.ToLower(r[0])
+	return string(r)
+}
+
+// PlanEnumKeys returns the keys of values missing from the catalog at path,
+// as the additions AddEnumKeys would make
+func PlanEnumKeys(path string, values []EnumValue) (LocaleChanges, error) {
+	tf, err := readTranslationFile(path)
+	if err != nil {
+		return LocaleChanges{}, err
+	}
+
+	changes := LocaleChanges{Lang: tf.Meta.Lang}
+	for _, v := range values {
+		if _, ok := tf.Translations[v.Key]; !ok {
+			changes.Added = append(changes.Added, ChangeEntry{Key: v.Key, New: v.Label})
+		}
+	}
+	return changes, nil
+}
+
+// AddEnumKeys adds the keys of values missing from the catalog at path, using
+// their default labels, and returns how many were added. Existing
+// translations are left untouched.
+func AddEnumKeys(path string, values []EnumValue) (int, error) {
+	changes, err := PlanEnumKeys(path, values)
+	if err != nil || len(changes.Added) == 0 {
+		return 0, err
+	}
+
+	tf, err := readTranslationFile(path)
+	if err != nil {
+		return 0, err
+	}
+	for _, e := range changes.Added {
+		tf.Translations[e.Key] = e.New
+	}
+
+	data, err := MarshalCatalog(tf)
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal dictionary: %w", err)
+	}
+
+	if err := os.WriteFile(filepath.Clean(path), data, 0644); err != nil {
+		return 0, fmt.Errorf("failed to save dictionary: %w", err)
+	}
+	return len(changes.Added), nil
+}
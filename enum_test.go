@@ -0,0 +1,165 @@
+package i18n
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const enumSource = `package orders
+
+type Status int
+
+const (
+	StatusActive Status = iota
+	StatusOnHold
+	_
+	StatusHTTPError
+)
+
+const Other = 5
+
+type Kind string
+
+const KindDigital Kind = "digital"
+
+func (s Status) String() string { return "status" }
+`
+
+func writeEnumPackage(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "orders.go"), []byte(enumSource), 0644); err != nil {
+		t.Fatalf("Failed to write package: %v", err)
+	}
+	return dir
+}
+
+func TestFindEnum(t *testing.T) {
+	dir := writeEnumPackage(t)
+
+	pkg, values, err := FindEnum(dir, "Status")
+	if err != nil {
+		t.Fatalf("FindEnum failed: %v", err)
+	}
+	if pkg != "orders" {
+		t.Errorf("Expected package 'orders', got %q", pkg)
+	}
+
+	expected := []EnumValue{
+		{"StatusActive", "status.active", "Active"},
+		{"StatusOnHold", "status.on-hold", "On hold"},
+		{"StatusHTTPError", "status.http-error", "Http error"},
+	}
+	if !reflect.DeepEqual(values, expected) {
+		t.Errorf("Expected %v, got %v", expected, values)
+	}
+
+	if _, values, _ := FindEnum(dir, "Kind"); len(values) != 1 || values[0].Key != "kind.digital" {
+		t.Errorf("Expected kind.digital, got %v", values)
+	}
+
+	if _, _, err := FindEnum(dir, "Missing"); err == nil {
+		t.Error("Expected error for unknown type")
+	}
+}
+
+func TestSplitCamel(t *testing.T) {
+	tests := map[string][]string{
+		"OnHold":       {"On", "Hold"},
+		"HTTPStatusOK": {"HTTP", "Status", "OK"},
+		"Level2Ready":  {"Level2", "Ready"},
+		"snake_case":   {"snake", "case"},
+		"A":            {"A"},
+	}
+
+	for input, expected := range tests {
+		if result := splitCamel(input); !reflect.DeepEqual(result, expected) {
+			t.Errorf("splitCamel(%q) = %v, expected %v", input, result, expected)
+		}
+	}
+}
+
+func TestGenerateEnum(t *testing.T) {
+	dir := writeEnumPackage(t)
+
+	if _, err := GenerateEnum(dir, "Status", ""); err != nil {
+		t.Fatalf("GenerateEnum failed: %v", err)
+	}
+
+	path := filepath.Join(dir, "status_i18n.go")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Generated file missing: %v", err)
+	}
+	if _, err := parser.ParseFile(token.NewFileSet(), path, data, 0); err != nil {
+		t.Fatalf("Generated file does not parse: %v", err)
+	}
+
+	src := string(data)
+	for _, want := range []string{
+		"DO NOT EDIT",
+		"package orders",
+		`StatusOnHold:    "status.on-hold"`,
+		"func (v Status) LocalizedString(locale string) string",
+	} {
+		if !strings.Contains(src, want) {
+			t.Errorf("Generated file missing %q:\n%s", want, src)
+		}
+	}
+
+	// Regenerating ignores the generated file's own declarations
+	if values, err := GenerateEnum(dir, "Status", ""); err != nil || len(values) != 3 {
+		t.Errorf("Expected 3 values on regeneration, got %v (%v)", values, err)
+	}
+}
+
+func TestAddEnumKeys(t *testing.T) {
+	dir := t.TempDir()
+	path := writeTestCatalog(t, dir, "default.en.json", "en", map[string]string{
+		"status.active": "Live",
+	})
+
+	values := []EnumValue{
+		{"StatusActive", "status.active", "Active"},
+		{"StatusOnHold", "status.on-hold", "On hold"},
+	}
+
+	added, err := AddEnumKeys(path, values)
+	if err != nil {
+		t.Fatalf("AddEnumKeys failed: %v", err)
+	}
+	if added != 1 {
+		t.Errorf("Expected 1 key added, got %d", added)
+	}
+
+	tf, err := readTranslationFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read catalog: %v", err)
+	}
+	if tf.Translations["status.active"] != "Live" || tf.Translations["status.on-hold"] != "On hold" {
+		t.Errorf("Unexpected translations: %v", tf.Translations)
+	}
+}
+
+func TestPlanEnumKeys(t *testing.T) {
+	dir := t.TempDir()
+	path := writeTestCatalog(t, dir, "default.en.json", "en", map[string]string{
+		"status.active": "Live",
+	})
+
+	changes, err := PlanEnumKeys(path, []EnumValue{
+		{"StatusActive", "status.active", "Active"},
+		{"StatusOnHold", "status.on-hold", "On hold"},
+	})
+	if err != nil {
+		t.Fatalf("PlanEnumKeys failed: %v", err)
+	}
+	if len(changes.Added) != 1 || changes.Added[0] != (ChangeEntry{Key: "status.on-hold", New: "On hold"}) {
+		t.Errorf("Expected status.on-hold to be added, got %v", changes.Added)
+	}
+}
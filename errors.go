@@ -0,0 +1,183 @@
+package i18n
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// FileError describes a problem loading or validating a translation file.
+// Loader and validation functions return it (possibly joined or wrapped), so
+// callers can extract precise diagnostics with errors.As:
+//
+//	var fe *i18n.FileError
+//	if errors.As(err, &fe) {
+//		annotate(fe.Path, fe.Key, fe.Reason)
+//	}
+type FileError struct {
+	Path   string // file the problem was found in, empty if not read from disk
+	Lang   string // language of the file, empty if not known yet
+	Key    string // offending translation key, empty for file-level problems
+	Line   int    // 1-based line of the problem in the file, 0 if unknown
+	Column int    // 1-based column of the problem in the file, 0 if unknown
+	Reason string // human-readable description of the problem
+	Err    error  // underlying error, if any
+}
+
+// Error formats the problem as "path:line:column: reason: err", leaving out
+// the parts that are not known.
+func (e *FileError) Error() string {
+	var b strings.Builder
+	if e.Path != "" {
+		b.WriteString(e.Path)
+		if e.Line > 0 {
+			b.WriteString(":" + strconv.Itoa(e.Line))
+			if e.Column > 0 {
+				b.WriteString(":" + strconv.Itoa(e.Column))
+			}
+		}
+		b.WriteString(": ")
+	}
+	b.WriteString(e.Reason)
+	if e.Err != nil {
+		b.WriteString(": ")
+		b.WriteString(e.Err.Error())
+	}
+	return b.String()
+}
+
+func (e *FileError) Unwrap() error {
+	return e.Err
+}
+
+// fileError creates a FileError for a problem found while decoding or validating
+func fileError(lang, key, format string, args ...any) *FileError {
+	return &FileError{Lang: lang, Key: key, Reason: fmt.Sprintf(format, args...)}
+}
+
+// withPath attaches a file path to err, turning it into a FileError if needed
+func withPath(err error, path, reason string) error {
+	var fe *FileError
+	if errors.As(err, &fe) {
+		fe.Path = path
+		return err
+	}
+	return &FileError{Path: path, Reason: reason, Err: err}
+}
+
+// withPosition sets the line and column of a FileError found in data: the
+// offending key for key-level problems, or the offset of a JSON decode error
+func withPosition(err error, data []byte) error {
+	var fe *FileError
+	if !errors.As(err, &fe) {
+		return err
+	}
+
+	offset := int64(-1)
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case fe.Key != "":
+		offset = keyOffset(data, fe.Key)
+	case errors.As(fe.Err, &syntaxErr):
+		offset = syntaxErr.Offset - 1
+	case errors.As(fe.Err, &typeErr):
+		offset = typeErr.Offset - 1
+	}
+
+	if offset >= 0 {
+		fe.Line, fe.Column = position(data, offset)
+	}
+	return err
+}
+
+// position converts a byte offset in data to a 1-based line and column.
+// Columns count characters, not bytes.
+func position(data []byte, offset int64) (line, column int) {
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+	before := data[:offset]
+	start := bytes.LastIndexByte(before, '\n') + 1
+	return 1 + bytes.Count(before, []byte("\n")), 1 + utf8.RuneCount(before[start:])
+}
+
+// keyOffset returns the byte offset of a translation key in a catalog file,
+// or -1 if it cannot be found. Keys of object values are matched by their
+// flattened "key.field" name.
+func keyOffset(data []byte, key string) int64 {
+	dec := json.NewDecoder(bytes.NewReader(data))
+
+	// Walk the token stream keeping the stack of object keys leading to the
+	// current value, so nested translation keys can be matched by full name.
+	type frame struct {
+		object bool
+		key    string
+		expect bool // next string token in this object is a key
+	}
+	var stack []frame
+
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return -1
+		}
+
+		if len(stack) > 0 {
+			top := &stack[len(stack)-1]
+			if s, ok := tok.(string); ok && top.object && top.expect {
+				top.key = s
+				top.expect = false
+
+				if len(stack) >= 2 && stack[0].key == "translations" {
+					names := make([]string, 0, len(stack)-1)
+					for _, f := range stack[1:] {
+						names = append(names, f.key)
+					}
+					if strings.Join(names, KeySeparator) == key {
+						return openingQuote(data, dec.InputOffset())
+					}
+				}
+				continue
+			}
+		}
+
+		switch tok {
+		case json.Delim('{'):
+			stack = append(stack, frame{object: true, expect: true})
+			continue
+		case json.Delim('['):
+			stack = append(stack, frame{})
+			continue
+		case json.Delim('}'), json.Delim(']'):
+			stack = stack[:len(stack)-1]
+		}
+
+		// A value completed: the enclosing object expects a key next
+		if len(stack) > 0 && stack[len(stack)-1].object {
+			stack[len(stack)-1].expect = true
+		}
+	}
+}
+
+// openingQuote returns the offset of the quote opening the JSON string that
+// ends at end
+func openingQuote(data []byte, end int64) int64 {
+	for i := end - 2; i >= 0; i-- {
+		if data[i] != '"' {
+			continue
+		}
+		backslashes := 0
+		for j := i - 1; j >= 0 && data[j] == '\\'; j-- {
+			backslashes++
+		}
+		if backslashes%2 == 0 {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,197 @@
+package i18n
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFileError_Validation(t *testing.T) {
+	dir := t.TempDir()
+	path := writeTestCatalog(t, dir, "default.fr.json", "fr", map[string]string{"hello": "Bonjour", "bye": ""})
+
+	_, err := LoadDictionaryFile(path)
+
+	var fe *FileError
+	if !errors.As(err, &fe) {
+		t.Fatalf("Expected *FileError, got %T: %v", err, err)
+	}
+	if fe.Path != path || fe.Lang != "fr" || fe.Key != "bye" {
+		t.Errorf("Unexpected error metadata: %+v", fe)
+	}
+	if !strings.Contains(fe.Reason, "has empty value") {
+		t.Errorf("Unexpected reason: %s", fe.Reason)
+	}
+	if !strings.HasPrefix(err.Error(), path+":") {
+		t.Errorf("Expected error message to start with the path, got: %v", err)
+	}
+}
+
+func TestFileError_PluralTemplate(t *testing.T) {
+	dir := t.TempDir()
+	path := writeTestCatalog(t, dir, "default.en.json", "en", map[string]string{
+		"items": "{count, plural, one {# item}",
+	})
+
+	_, err := LoadDictionaryFile(path)
+
+	var fe *FileError
+	if !errors.As(err, &fe) {
+		t.Fatalf("Expected *FileError, got %T: %v", err, err)
+	}
+	if fe.Key != "items" || fe.Err == nil || !strings.Contains(fe.Err.Error(), "unbalanced braces") {
+		t.Errorf("Unexpected error: %+v", fe)
+	}
+}
+
+func TestFileError_Read(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing.json")
+
+	_, err := LoadDictionaryFile(path)
+
+	var fe *FileError
+	if !errors.As(err, &fe) || fe.Path != path {
+		t.Fatalf("Expected *FileError with path, got %v", err)
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Expected error to wrap fs.ErrNotExist, got %v", err)
+	}
+}
+
+func TestFileError_Decode(t *testing.T) {
+	dir := t.TempDir()
+
+	invalidJSON := filepath.Join(dir, "invalid.json")
+	if err := os.WriteFile(invalidJSON, []byte("{"), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+	_, err := LoadDictionaryFile(invalidJSON)
+	var fe *FileError
+	if !errors.As(err, &fe) || fe.Path != invalidJSON || fe.Key != "" {
+		t.Errorf("Expected file-level *FileError, got %v", err)
+	}
+
+	badValue := filepath.Join(dir, "bad.json")
+	content := `{"meta":{"lang":"en","name":"x"},"translations":{"days":["Mon", 2]}}`
+	if err := os.WriteFile(badValue, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+	_, err = LoadDictionaryFile(badValue)
+	if !errors.As(err, &fe) || fe.Path != badValue || fe.Key != "days" {
+		t.Errorf("Expected *FileError for key 'days', got %v", err)
+	}
+}
+
+func TestFileError_LoadDirStrict(t *testing.T) {
+	dir := t.TempDir()
+	writeTestCatalog(t, dir, "default.en.json", "en", map[string]string{"a": "A", "b": "B"})
+	writeTestCatalog(t, dir, "default.de.json", "de", map[string]string{"a": "A"})
+
+	err := NewBundle().LoadDirStrict(dir, 1)
+
+	var fe *FileError
+	if !errors.As(err, &fe) || fe.Lang != "de" {
+		t.Errorf("Expected *FileError for de, got %v", err)
+	}
+}
+
+func TestFileError_Position(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "default.fr.json")
+	content := `{
+  "meta": {"lang": "fr", "name": "default", "hello": "not a translation"},
+  "translations": {
+    "days": ["Lun", "Mar"],
+    "h\"i": "Salut",
+    "nested": {"deep": {"hello": ""}},
+    "hello": "Bonjour"
+  }
+}`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	_, err := LoadDictionaryFile(path)
+
+	var fe *FileError
+	if !errors.As(err, &fe) {
+		t.Fatalf("Expected *FileError, got %T: %v", err, err)
+	}
+	if fe.Line != 6 || fe.Column != 25 {
+		t.Errorf("Expected position 6:25, got %d:%d", fe.Line, fe.Column)
+	}
+	if !strings.HasPrefix(err.Error(), path+":6:25: translation key 'nested.deep.hello'") {
+		t.Errorf("Expected position in error message, got: %v", err)
+	}
+}
+
+func TestFileError_SyntaxPosition(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "broken.json")
+	content := "{\n  \"meta\": {\"lang\": \"fr\",}\n}"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	_, err := LoadDictionaryFile(path)
+
+	var fe *FileError
+	if !errors.As(err, &fe) || fe.Line != 2 || fe.Column != 25 {
+		t.Errorf("Expected syntax error at 2:25, got %v", err)
+	}
+}
+
+func TestKeyOffset(t *testing.T) {
+	data := []byte(`{
+  "meta": {"lang": "en", "hello": "not a translation"},
+  "translations": {
+    "days": ["hello", "bye"],
+    "say \"hi\"": "Hi",
+    "nested": {"deep": {"hello": "Hi"}},
+    "hello": "Hello"
+  }
+}`)
+
+	tests := map[string]struct{ line, column int }{
+		"days":              {4, 5},
+		`say "hi"`:          {5, 5},
+		"nested.deep.hello": {6, 25},
+		"hello":             {7, 5},
+	}
+	for key, expected := range tests {
+		offset := keyOffset(data, key)
+		if offset < 0 {
+			t.Errorf("keyOffset(%q) not found", key)
+			continue
+		}
+		if line, column := position(data, offset); line != expected.line || column != expected.column {
+			t.Errorf("keyOffset(%q) at %d:%d, expected %d:%d", key, line, column, expected.line, expected.column)
+		}
+	}
+
+	if offset := keyOffset(data, "missing"); offset != -1 {
+		t.Errorf("Expected -1 for missing key, got %d", offset)
+	}
+}
+
+func TestPosition(t *testing.T) {
+	data := []byte("ab\nçd\n")
+
+	tests := []struct {
+		offset       int64
+		line, column int
+	}{
+		{0, 1, 1},
+		{1, 1, 2},
+		{3, 2, 1},
+		{5, 2, 2},
+		{100, 3, 1},
+	}
+	for _, tt := range tests {
+		if line, column := position(data, tt.offset); line != tt.line || column != tt.column {
+			t.Errorf("position(%d) = %d:%d, expected %d:%d", tt.offset, line, column, tt.line, tt.column)
+		}
+	}
+}
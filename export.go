@@ -0,0 +1,131 @@
+package i18n
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+)
+
+// Format is a file format a dictionary can be exported to
+type Format int
+
+const (
+	FormatJSON       Format = iota // translation file with flat keys
+	FormatNestedJSON               // translation file with keys nested by segment
+	FormatCSV                      // key and language columns, as ExportCSV
+	FormatTSV                      // key and language columns, as ExportTSV
+	FormatYAML                     // translation file as YAML, with the structure of JSON
+	FormatPO                       // gettext PO file, with keys as msgid
+	FormatXLIFF                    // XLIFF 1.2 document, with keys as trans-unit ids
+)
+
+// Export writes the dictionary to w in format. JSON and YAML formats write a
+// complete translation file, including the meta it was loaded with, lists
+// and scheduled values, that loads back into an identical dictionary; CSV
+// and TSV write string values only, and their readers turn "\r\n" line
+// breaks in values into "\n". PO and XLIFF write string values, and PO also
+// fuzzy marks. A dictionary that would fail to load is not written.
+func (d *Dictionary) Export(w io.Writer, format Format) error {
+	tf := d.translationFile()
+	if err := validateTranslationFile(tf); err != nil {
+		return err
+	}
+
+	switch format {
+	case FormatJSON, FormatNestedJSON:
+		tf.Nested = format == FormatNestedJSON
+		data, err := MarshalCatalog(tf)
+		if err != nil {
+			return fmt.Errorf("failed to marshal dictionary: %w", err)
+		}
+		_, err = w.Write(data)
+		return err
+	case FormatCSV, FormatTSV:
+		comma := ','
+		if format == FormatTSV {
+			comma = '\t'
+		}
+		return exportColumns(w, comma, map[string]map[string]string{tf.Meta.Lang: tf.Translations}, []string{tf.Meta.Lang})
+	case FormatYAML, FormatPO, FormatXLIFF:
+		data, err := marshalCatalogAs(tf, format)
+		if err != nil {
+			return fmt.Errorf("failed to marshal dictionary: %w", err)
+		}
+		_, err = w.Write(data)
+		return err
+	}
+	return fmt.Errorf("unknown format %d", format)
+}
+
+// marshalCatalogAs encodes a translation file in a format other than the
+// spreadsheets
+func marshalCatalogAs(tf *TranslationFile, format Format) ([]byte, error) {
+	switch format {
+	case FormatYAML:
+		return marshalCatalogYAML(tf)
+	case FormatPO:
+		return marshalPO(tf), nil
+	case FormatXLIFF:
+		return marshalXLIFF(tf)
+	}
+	return MarshalCatalog(tf)
+}
+
+// Save writes the dictionary to path, creating its directory if needed. The
+// format follows the extension, as for ConvertCatalog: .csv and .tsv write
+// spreadsheets, .yaml, .po and .xliff their formats, and anything else a
+// JSON translation file.
+//
+// Example:
+//
+//	dict := i18n.GetDictionary("fr")
+//	dict.Add("welcome", "Bienvenue")
+//	err := dict.Save("locales/default.fr.json")
+func (d *Dictionary) Save(path string) error {
+	format, err := catalogFormat(path)
+	if err != nil {
+		format = FormatJSON
+	}
+
+	var buf bytes.Buffer
+	if err := d.Export(&buf, format); err != nil {
+		return withPath(err, path, "invalid dictionary")
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+	if err := os.WriteFile(filepath.Clean(path), buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to save dictionary: %w", err)
+	}
+	return nil
+}
+
+// translationFile returns a snapshot of the dictionary as a file with its
+// meta, named after the default dictionary if it has no name
+func (d *Dictionary) translationFile() *TranslationFile {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	tf := newCatalogFile(d.Lang, maps.Clone(d.Translations))
+	if d.meta.Name != "" {
+		tf.Meta.Name = d.meta.Name
+	}
+	tf.Meta.Version = d.meta.Version
+	tf.Meta.Author = d.meta.Author
+	tf.Meta.Updated = d.meta.Updated
+	tf.Meta.Direction = d.meta.Direction
+	if tf.Translations == nil {
+		tf.Translations = make(map[string]string)
+	}
+	tf.Lists = maps.Clone(d.Lists)
+	tf.Schedule = maps.Clone(d.Schedule)
+	if len(d.fuzzy) > 0 {
+		tf.Fuzzy = sortedKeys(d.fuzzy)
+	}
+	tf.States = maps.Clone(d.states)
+	return tf
+}
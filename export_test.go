@@ -0,0 +1,165 @@
+package i18n
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDictionarySave_RoundTrip(t *testing.T) {
+	dict := NewDictionary("fr")
+	dict.AddAll(map[string]string{
+		"auth.login.title": "Connexion",
+		"items":            "{count, plural, one {# élément} other {# éléments}}",
+	})
+	dict.AddSlice("days", []string{"lun", "mar"})
+	dict.AddScheduled("auth.login.title", ScheduledValue{
+		Value:      "Joyeuses fêtes",
+		ValidFrom:  time.Date(2026, 12, 20, 0, 0, 0, 0, time.UTC),
+		ValidUntil: time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC),
+	})
+
+	path := filepath.Join(t.TempDir(), "locales", "default.fr.json")
+	if err := dict.Save(path); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	loaded, err := LoadDictionaryFile(path)
+	if err != nil {
+		t.Fatalf("Failed to load saved dictionary: %v", err)
+	}
+	if loaded.Lang != "fr" || loaded.Hash() != dict.Hash() {
+		t.Errorf("Round trip changed the dictionary: %+v", loaded)
+	}
+	if !reflect.DeepEqual(loaded.Translations, dict.Translations) {
+		t.Errorf("Expected %v, got %v", dict.Translations, loaded.Translations)
+	}
+}
+
+func TestDictionaryExport(t *testing.T) {
+	dict := NewDictionary("en")
+	dict.AddAll(map[string]string{"auth.login": "Sign in", "title": "Dashboard"})
+
+	var buf bytes.Buffer
+	if err := dict.Export(&buf, FormatNestedJSON); err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), `"login": "Sign in"`) || !strings.Contains(buf.String(), `"schema_version": 1`) {
+		t.Errorf("Expected a nested translation file, got %s", buf.String())
+	}
+
+	buf.Reset()
+	if err := dict.Export(&buf, FormatCSV); err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	if want := "key,en\nauth.login,Sign in\ntitle,Dashboard\n"; buf.String() != want {
+		t.Errorf("Expected %q, got %q", want, buf.String())
+	}
+
+	if err := NewDictionary("english").Export(&buf, FormatJSON); err == nil {
+		t.Error("Expected an invalid language code to be rejected")
+	}
+	if err := dict.Export(&buf, Format(99)); err == nil {
+		t.Error("Expected an unknown format to be rejected")
+	}
+}
+
+func TestDictionarySave_Extension(t *testing.T) {
+	dict := NewDictionary("de")
+	dict.Add("title", "Übersicht")
+
+	path := filepath.Join(t.TempDir(), "de.tsv")
+	if err := dict.Save(path); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read file: %v", err)
+	}
+	if string(data) != "key\tde\ntitle\tÜbersicht\n" {
+		t.Errorf("Unexpected TSV: %q", data)
+	}
+}
+
+// randomDictionary returns a dictionary of random keys and values that
+// exercise the formats: dotted keys, placeholders, plurals, lists, quotes,
+// separators, line breaks and non-ASCII text
+func randomDictionary(r *rand.Rand, lang string) *Dictionary {
+	words := []string{"Connexion", "élément", "日本語", "مرحبا", "a,b", "tab\there", "line\nbreak", `"quoted"`, "it's", "{0}", "{name}", "100%", "emoji 🎉", "  padded  ", "#", "crlf\r\nline"}
+	parents := []string{"auth", "login", "nav", "errors", "x1"}
+	leaves := []string{"title", "items", "home", "not_found", "label"}
+
+	dict := NewDictionary(lang)
+	for range 1 + r.IntN(30) {
+		parts := make([]string, r.IntN(3), 3)
+		for i := range parts {
+			parts[i] = parents[r.IntN(len(parents))]
+		}
+		key := strings.Join(append(parts, leaves[r.IntN(len(leaves))]), ".")
+
+		var value string
+		switch r.IntN(4) {
+		case 0:
+			value = fmt.Sprintf("{count, plural, one {# %s} other {# %s}}", words[r.IntN(len(words))], words[r.IntN(len(words))])
+		case 1:
+			value = fmt.Sprintf("%s {0} %s {1}", words[r.IntN(len(words))], words[r.IntN(len(words))])
+		default:
+			value = words[r.IntN(len(words))] + " " + words[r.IntN(len(words))]
+		}
+		dict.Add(key, value)
+	}
+	if r.IntN(2) == 0 {
+		dict.AddSlice("days", []string{"lun", "mar, mer", "jeu\tven"})
+	}
+	return dict
+}
+
+// TestExport_RoundTripProperty checks that every format loads back what it
+// exported, for many random dictionaries: JSON formats the whole dictionary,
+// CSV and TSV its string values
+func TestExport_RoundTripProperty(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	for i := range 300 {
+		dict := randomDictionary(r, "fr")
+		for _, format := range []Format{FormatJSON, FormatNestedJSON, FormatCSV, FormatTSV} {
+			var buf bytes.Buffer
+			if err := dict.Export(&buf, format); err != nil {
+				t.Fatalf("#%d: Export(%d) failed: %v\n%v", i, format, err, dict.Translations)
+			}
+
+			switch format {
+			case FormatJSON, FormatNestedJSON:
+				loaded, err := LoadDictionaryBytes(buf.Bytes())
+				if err != nil {
+					t.Fatalf("#%d: format %d does not load back: %v\n%s", i, format, err, buf.String())
+				}
+				if !reflect.DeepEqual(loaded.Translations, dict.Translations) || !reflect.DeepEqual(loaded.Lists, dict.Lists) || loaded.Hash() != dict.Hash() {
+					t.Fatalf("#%d: format %d changed the dictionary:\n%v\n%v", i, format, dict.Translations, loaded.Translations)
+				}
+			default:
+				read := ReadCSV
+				if format == FormatTSV {
+					read = ReadTSV
+				}
+				catalogs, err := read(&buf)
+				if err != nil {
+					t.Fatalf("#%d: format %d does not read back: %v", i, format, err)
+				}
+				// Spreadsheet formats end lines with \n, inside values too
+				want := make(map[string]string, len(dict.Translations))
+				for key, value := range dict.Translations {
+					want[key] = strings.ReplaceAll(value, "\r\n", "\n")
+				}
+				if !reflect.DeepEqual(catalogs["fr"], want) {
+					t.Fatalf("#%d: format %d changed the translations:\n%v\n%v", i, format, want, catalogs["fr"])
+				}
+			}
+		}
+	}
+}
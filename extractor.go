@@ -0,0 +1,417 @@
+package i18n
+
+import (
+	"context"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	pathpkg "path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Entry is a translatable string found in a source file
+type Entry struct {
+	// Key is the catalog key. If empty, it is derived from Text the way F and
+	// S derive theirs.
+	Key string
+
+	// Text is the source text, in the printf style F accepts. Entries without
+	// text are keys used as-is, like the first argument of T, whose text is
+	// kept from the catalog or listed under "needs_source".
+	Text string
+
+	// Block marks Key as the key of an object value, such as the key GetInto
+	// decodes: the catalog keys under "Key." are its fields.
+	Block bool
+
+	// Pos locates the entry, such as "file.go:12:5", and Func names the
+	// construct it was found in, such as "i18n.T". Both are only logged.
+	// An entry with neither Key nor Text is a call whose argument is not a
+	// constant: the generators warn about it and extract nothing.
+	Pos  string
+	Func string
+
+	// Comment is a note for translators, such as what a placeholder stands
+	// for or where the text appears. It is written to the catalogs'
+	// "comments" section.
+	Comment string
+}
+
+// Extractor finds translatable strings in a source file. Register
+// extractors for file types the Go extractor does not understand (SQL seed
+// files, YAML workflows, proto options, ...) and their entries are merged
+// into the catalogs GenerateTranslations and GenerateCatalogs write.
+type Extractor interface {
+	Extract(path string, src []byte) ([]Entry, error)
+}
+
+// ExtractorFunc adapts a function to the Extractor interface
+type ExtractorFunc func(path string, src []byte) ([]Entry, error)
+
+// Extract calls f(path, src)
+func (f ExtractorFunc) Extract(path string, src []byte) ([]Entry, error) {
+	return f(path, src)
+}
+
+// registeredExtractor is an extractor with the file name pattern it handles
+type registeredExtractor struct {
+	pattern   string
+	extractor Extractor
+}
+
+var (
+	extractorsMu sync.RWMutex
+	extractors   = []registeredExtractor{
+		{pattern: "*.go", extractor: GoExtractor{}},
+		{pattern: "*.proto", extractor: ProtoExtractor{}},
+		{pattern: "*.html", extractor: TemplateExtractor{}},
+		{pattern: "*.tmpl", extractor: TemplateExtractor{}},
+		{pattern: "*.gotmpl", extractor: TemplateExtractor{}},
+	}
+)
+
+// RegisterExtractor runs e on the files whose base name matches pattern
+// (filepath.Match syntax, e.g. "*.sql" or "*.workflow.yaml") when a source
+// tree is scanned. GoExtractor is registered for "*.go", ProtoExtractor
+// for "*.proto" and TemplateExtractor for "*.html", "*.tmpl" and
+// "*.gotmpl"; a file matching several patterns is passed to every
+// extractor.
+//
+// Example:
+//
+//	i18n.RegisterExtractor("*.sql", i18n.ExtractorFunc(func(path string, src []byte) ([]i18n.Entry, error) {
+//		var entries []i18n.Entry
+//		for _, m := range labelPattern.FindAllSubmatch(src, -1) {
+//			entries = append(entries, i18n.Entry{Text: string(m[1]), Pos: path})
+//		}
+//		return entries, nil
+//	}))
+func RegisterExtractor(pattern string, e Extractor) error {
+	if _, err := filepath.Match(pattern, ""); err != nil {
+		return fmt.Errorf("invalid extractor pattern %q: %w", pattern, err)
+	}
+	if e == nil {
+		return fmt.Errorf("nil extractor for pattern %q", pattern)
+	}
+
+	extractorsMu.Lock()
+	defer extractorsMu.Unlock()
+	extractors = append(extractors, registeredExtractor{pattern: pattern, extractor: e})
+	return nil
+}
+
+// extractorsFor returns the extractors registered for a file
+func extractorsFor(path string) []Extractor {
+	extractorsMu.RLock()
+	defer extractorsMu.RUnlock()
+
+	name := filepath.Base(path)
+	var matched []Extractor
+	for _, r := range extractors {
+		if ok, _ := filepath.Match(r.pattern, name); ok {
+			matched = append(matched, r.extractor)
+		}
+	}
+	return matched
+}
+
+// ExtractDir runs the registered extractors on the files under root and
+// returns their entries in file order. It stops at the first extractor error,
+// or with ctx's error once ctx is done.
+func ExtractDir(ctx context.Context, root string) ([]Entry, error) {
+	return extractDir(ctx, root, nil)
+}
+
+// extractDir is ExtractDir skipping the files and directories that match
+// one of the exclude patterns, see excluded
+func extractDir(ctx context.Context, root string, exclude []string) ([]Entry, error) {
+	var entries []Entry
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		if err != nil {
+			return nil
+		}
+		if path != root && excluded(root, path, exclude) {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if info.IsDir() {
+			return nil
+		}
+
+		matched := extractorsFor(path)
+		if len(matched) == 0 {
+			return nil
+		}
+
+		src, err := os.ReadFile(filepath.Clean(path))
+		if err != nil {
+			return nil
+		}
+		for _, e := range matched {
+			found, err := e.Extract(path, src)
+			if err != nil {
+				return fmt.Errorf("%s: %w", path, err)
+			}
+			entries = append(entries, found...)
+		}
+		return nil
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("error walking files: %w", err)
+	}
+	return entries, nil
+}
+
+// excluded reports whether path, found under root, matches one of the
+// patterns (filepath.Match syntax) by its slash-separated path relative to
+// root or by its base name, so "vendor", "*_test.go" and "internal/legacy"
+// all work
+func excluded(root, path string, patterns []string) bool {
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return false
+	}
+	rel = filepath.ToSlash(rel)
+	name := filepath.Base(path)
+	for _, pattern := range patterns {
+		if ok, _ := filepath.Match(pattern, rel); ok {
+			return true
+		}
+		if ok, _ := filepath.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// ImportPath is the import path of this package, which GoExtractor resolves
+// import aliases against
+const ImportPath = "github.com/nyxstack/i18n"
+
+// GoExtractor finds the constant first arguments of calls to the i18n
+// functions (F, S, T, P, P64, Pf, Sel, O, Errorf, THTML, TRandom) in Go
+// source, and the key arguments of the value accessors (GetInt, GetBool,
+// GetDuration, GetSlice, GetInto), which follow the locale: string literals,
+// constants declared in the same file, and concatenations of both. Other
+// arguments, such as variables or constants of other files, are returned as
+// entries with neither Key nor Text, so they are reported. Calls are matched
+// through the file's imports of ImportPath, so aliased (tr.T) and dot (T)
+// imports are found, while other packages named i18n are left out; in a file
+// that imports no package named i18n, i18n.X is assumed to be this package.
+// Calls on bundles and namespaces need type information, see
+// contrib/i18npackages. A comment starting with "i18n:" on the line before a
+// call is its Comment:
+//
+//	// i18n: {0} is the name of the invited user
+//	i18n.T("invite_sent", name)
+//
+// Files that do not parse yield no entries.
+type GoExtractor struct{}
+
+// Extract returns the i18n calls of a Go file
+func (GoExtractor) Extract(path string, src []byte) ([]Entry, error) {
+	fs := token.NewFileSet()
+	node, err := parser.ParseFile(fs, path, src, parser.AllErrors|parser.ParseComments)
+	if err != nil {
+		return nil, nil
+	}
+
+	names, dot := importNames(node)
+	consts := fileConsts(node)
+	comments := translatorComments(fs, node)
+
+	var entries []Entry
+	ast.Inspect(node, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+
+		var qualifier, funcName string
+		switch fun := call.Fun.(type) {
+		case *ast.SelectorExpr:
+			pkg, ok := fun.X.(*ast.Ident)
+			if !ok || !names[pkg.Name] {
+				return true
+			}
+			qualifier, funcName = pkg.Name+".", fun.Sel.Name
+		case *ast.Ident:
+			if !dot {
+				return true
+			}
+			funcName = fun.Name
+		default:
+			return true
+		}
+		if funcName != "F" && funcName != "S" && !keyFuncs[funcName] && !localeKeyFuncs[funcName] {
+			return true
+		}
+
+		arg := 0
+		if localeKeyFuncs[funcName] {
+			arg = 1
+		}
+		if len(call.Args) <= arg {
+			return true
+		}
+
+		entry := Entry{
+			Pos:     fs.Position(call.Args[arg].Pos()).String(),
+			Func:    qualifier + funcName,
+			Comment: comments[fs.Position(call.Pos()).Line-1],
+			Block:   blockFuncs[funcName],
+		}
+		raw, ok := consts.fold(call.Args[arg])
+		switch {
+		case !ok:
+		case keyFuncs[funcName] || localeKeyFuncs[funcName]:
+			entry.Key = raw
+		default:
+			entry.Text = raw
+		}
+		entries = append(entries, entry)
+		return true
+	})
+	return entries, nil
+}
+
+// translatorComment marks the comments GoExtractor passes on to translators
+const translatorComment = "i18n:"
+
+// translatorComments returns the translator comments of a file by the line
+// they end on, their lines joined with spaces
+func translatorComments(fs *token.FileSet, file *ast.File) map[int]string {
+	comments := make(map[int]string)
+	for _, group := range file.Comments {
+		text, ok := strings.CutPrefix(strings.TrimSpace(group.Text()), translatorComment)
+		if !ok {
+			continue
+		}
+		comments[fs.Position(group.End()).Line] = strings.Join(strings.Fields(text), " ")
+	}
+	return comments
+}
+
+// stringConsts maps the constants of a file to their value expressions. A
+// name declared twice, in different scopes, maps to nil, as the file alone
+// does not tell which one a use refers to.
+type stringConsts map[string]ast.Expr
+
+// fileConsts returns the constants declared in a file, at package level or
+// in functions
+func fileConsts(file *ast.File) stringConsts {
+	consts := make(stringConsts)
+	ast.Inspect(file, func(n ast.Node) bool {
+		decl, ok := n.(*ast.GenDecl)
+		if !ok || decl.Tok != token.CONST {
+			return true
+		}
+		var values []ast.Expr // repeated by specs without values
+		for _, spec := range decl.Specs {
+			s := spec.(*ast.ValueSpec)
+			if len(s.Values) > 0 {
+				values = s.Values
+			}
+			for i, name := range s.Names {
+				var value ast.Expr
+				if i < len(values) {
+					value = values[i]
+				}
+				if _, dup := consts[name.Name]; dup {
+					value = nil
+				}
+				consts[name.Name] = value
+			}
+		}
+		return true
+	})
+	return consts
+}
+
+// fold returns the value of a constant string expression: a literal, a
+// constant of the file, or a concatenation of those
+func (c stringConsts) fold(expr ast.Expr) (string, bool) {
+	return c.foldSeen(expr, nil)
+}
+
+// foldSeen is fold, guarding against constants defined in terms of
+// themselves, which do not compile anyway
+func (c stringConsts) foldSeen(expr ast.Expr, seen map[string]bool) (string, bool) {
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		if e.Kind != token.STRING {
+			return "", false
+		}
+		value, err := strconv.Unquote(e.Value)
+		return value, err == nil
+	case *ast.ParenExpr:
+		return c.foldSeen(e.X, seen)
+	case *ast.BinaryExpr:
+		if e.Op != token.ADD {
+			return "", false
+		}
+		x, ok := c.foldSeen(e.X, seen)
+		if !ok {
+			return "", false
+		}
+		y, ok := c.foldSeen(e.Y, seen)
+		return x + y, ok
+	case *ast.Ident:
+		value := c[e.Name]
+		if value == nil || seen[e.Name] {
+			return "", false
+		}
+		if seen == nil {
+			seen = make(map[string]bool)
+		}
+		seen[e.Name] = true
+		defer delete(seen, e.Name)
+		return c.foldSeen(value, seen)
+	}
+	return "", false
+}
+
+// importNames returns the names a file refers to this package by, and
+// whether it dot-imports it. A file that imports no package named i18n
+// refers to it as i18n.
+func importNames(file *ast.File) (names map[string]bool, dot bool) {
+	names = make(map[string]bool)
+	imported, other := false, false
+	for _, imp := range file.Imports {
+		path, err := strconv.Unquote(imp.Path.Value)
+		if err != nil {
+			continue
+		}
+		name := pathpkg.Base(path)
+		if imp.Name != nil {
+			name = imp.Name.Name
+		}
+		if path != ImportPath {
+			other = other || name == "i18n"
+			continue
+		}
+		imported = true
+		switch name {
+		case ".":
+			dot = true
+		case "_": // not usable
+		default:
+			names[name] = true
+		}
+	}
+	if !imported && !other {
+		names["i18n"] = true
+	}
+	return names, dot
+}
@@ -0,0 +1,235 @@
+package i18n
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestGoExtractor(t *testing.T) {
+	src := `package main
+
+import "github.com/nyxstack/i18n"
+
+func main() {
+	i18n.F("Hello %s", "World")
+	i18n.T("goodbye_message")
+	fmt.Println("not translated")
+}
+`
+	entries, err := GoExtractor{}.Extract("main.go", []byte(src))
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	want := []Entry{
+		{Text: "Hello %s", Pos: "main.go:6:9", Func: "i18n.F"},
+		{Key: "goodbye_message", Pos: "main.go:7:9", Func: "i18n.T"},
+	}
+	if !reflect.DeepEqual(entries, want) {
+		t.Errorf("Expected %+v, got %+v", want, entries)
+	}
+
+	if entries, err := (GoExtractor{}).Extract("broken.go", []byte("package")); err != nil || entries != nil {
+		t.Errorf("Expected no entries for an invalid file, got %v, %v", entries, err)
+	}
+}
+
+func TestGoExtractor_Constants(t *testing.T) {
+	src := `package main
+
+import "github.com/nyxstack/i18n"
+
+const welcomeKey = "welcome"
+
+const (
+	prefix = "errors."
+	quota  = prefix + "quota"
+	raw    = ` + "`Line\\one`" + `
+)
+
+func main() {
+	const local = "Saved"
+	name := "dynamic"
+	i18n.T(welcomeKey)
+	i18n.T(quota)
+	i18n.S("Hello " + ("World"))
+	i18n.S(local)
+	i18n.F("Tab\there")
+	i18n.S(raw)
+	i18n.T(name)
+	i18n.T(prefix + name)
+	i18n.T(otherFileKey)
+}
+`
+	entries, err := GoExtractor{}.Extract("main.go", []byte(src))
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	want := []Entry{
+		{Key: "welcome", Pos: "main.go:16:9", Func: "i18n.T"},
+		{Key: "errors.quota", Pos: "main.go:17:9", Func: "i18n.T"},
+		{Text: "Hello World", Pos: "main.go:18:9", Func: "i18n.S"},
+		{Text: "Saved", Pos: "main.go:19:9", Func: "i18n.S"},
+		{Text: "Tab\there", Pos: "main.go:20:9", Func: "i18n.F"},
+		{Text: "Line\\one", Pos: "main.go:21:9", Func: "i18n.S"},
+		{Pos: "main.go:22:9", Func: "i18n.T"},
+		{Pos: "main.go:23:9", Func: "i18n.T"},
+		{Pos: "main.go:24:9", Func: "i18n.T"},
+	}
+	if !reflect.DeepEqual(entries, want) {
+		t.Errorf("Expected %+v, got %+v", want, entries)
+	}
+}
+
+func TestGenerateTranslations_DynamicArguments(t *testing.T) {
+	logs := captureLogs(t)
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "main.go"), `package main
+
+import "github.com/nyxstack/i18n"
+
+func label(key string) string { return i18n.T(key)("en") }
+`)
+	if err := GenerateTranslations("en", dir, filepath.Join(dir, "locales", "default.en.json")); err != nil {
+		t.Fatalf("GenerateTranslations failed: %v", err)
+	}
+	want := `msg="argument is not a constant, not extracted" pos=` + filepath.Join(dir, "main.go") + `:5:47 func=i18n.T`
+	if !strings.Contains(logs.String(), want) {
+		t.Errorf("Expected %q in logs:\n%s", want, logs.String())
+	}
+}
+
+func TestGoExtractor_Imports(t *testing.T) {
+	for _, tt := range []struct {
+		name, src string
+		want      []string
+	}{
+		{"alias", `package main
+
+import tr "github.com/nyxstack/i18n"
+
+var _, _ = tr.S("Aliased"), tr.T("aliased.key")
+`, []string{"tr.S Aliased", "tr.T aliased.key"}},
+		{"dot import", `package main
+
+import . "github.com/nyxstack/i18n"
+
+var _, _ = S("Dotted"), fmt.Sprint("no")
+`, []string{"S Dotted"}},
+		{"other i18n package", `package main
+
+import (
+	"example.com/legacy/i18n"
+	nyx "github.com/nyxstack/i18n"
+)
+
+var _, _ = i18n.S("Legacy"), nyx.S("Ours")
+`, []string{"nyx.S Ours"}},
+		{"other i18n package only", `package main
+
+import "example.com/vendor/i18n"
+
+var _ = i18n.T("theirs")
+`, nil},
+		{"blank import", `package main
+
+import _ "github.com/nyxstack/i18n"
+
+var _ = i18n.T("unresolved")
+`, nil},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, err := GoExtractor{}.Extract("main.go", []byte(tt.src))
+			if err != nil {
+				t.Fatalf("Extract failed: %v", err)
+			}
+			var got []string
+			for _, e := range entries {
+				got = append(got, e.Func+" "+e.Text+e.Key)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestRegisterExtractor(t *testing.T) {
+	// Seed files list one translatable label per "-- label:" comment
+	err := RegisterExtractor("*.seed.sql", ExtractorFunc(func(path string, src []byte) ([]Entry, error) {
+		var entries []Entry
+		for i, line := range strings.Split(string(src), "\n") {
+			if label, ok := strings.CutPrefix(line, "-- label: "); ok {
+				entries = append(entries, Entry{Text: label, Pos: fmt.Sprintf("%s:%d", path, i+1)})
+			}
+			if key, ok := strings.CutPrefix(line, "-- key: "); ok {
+				entries = append(entries, Entry{Key: key})
+			}
+		}
+		return entries, nil
+	}))
+	if err != nil {
+		t.Fatalf("RegisterExtractor failed: %v", err)
+	}
+
+	dir := t.TempDir()
+	files := map[string]string{
+		"main.go":        "package main\n\nfunc main() { i18n.S(\"Dashboard\") }\n",
+		"roles.seed.sql": "-- label: Administrator\n-- key: roles.viewer\nINSERT INTO roles VALUES ('admin');\n",
+		"roles.sql":      "-- label: Ignored\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write test file: %v", err)
+		}
+	}
+
+	output := filepath.Join(dir, "locales", "default.en.json")
+	if err := GenerateTranslations("en", dir, output); err != nil {
+		t.Fatalf("GenerateTranslations failed: %v", err)
+	}
+	tf, err := readTranslationFile(output)
+	if err != nil {
+		t.Fatalf("Failed to read catalog: %v", err)
+	}
+	want := map[string]string{"dashboard": "Dashboard", "administrator": "Administrator"}
+	if !reflect.DeepEqual(tf.Translations, want) {
+		t.Errorf("Expected %v, got %v", want, tf.Translations)
+	}
+	if !reflect.DeepEqual(tf.NeedsSource, []string{"roles.viewer"}) {
+		t.Errorf("Expected roles.viewer to need source text, got %v", tf.NeedsSource)
+	}
+}
+
+func TestRegisterExtractor_Invalid(t *testing.T) {
+	if err := RegisterExtractor("[", GoExtractor{}); err == nil {
+		t.Error("Expected an invalid pattern to be rejected")
+	}
+	if err := RegisterExtractor("*.yaml", nil); err == nil {
+		t.Error("Expected a nil extractor to be rejected")
+	}
+}
+
+func TestExtractDir_ExtractorError(t *testing.T) {
+	failure := errors.New("unterminated block")
+	err := RegisterExtractor("*.broken-workflow", ExtractorFunc(func(string, []byte) ([]Entry, error) {
+		return nil, failure
+	}))
+	if err != nil {
+		t.Fatalf("RegisterExtractor failed: %v", err)
+	}
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "deploy.broken-workflow"), []byte("steps:"), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+	_, err = ExtractDir(context.Background(), dir)
+	if !errors.Is(err, failure) || !strings.Contains(err.Error(), "deploy.broken-workflow") {
+		t.Errorf("Expected the extractor error with the file path, got %v", err)
+	}
+}
@@ -0,0 +1,63 @@
+package i18n
+
+// SetFallbacks configures the languages consulted, in order, when a key is
+// missing for locale in the default bundle
+func SetFallbacks(locale string, chain ...string) {
+	defaultBundle.SetFallbacks(locale, chain...)
+}
+
+// FallbackChain returns the languages consulted when a key is missing for
+// locale in the default bundle
+func FallbackChain(locale string) []string {
+	return defaultBundle.FallbackChain(locale)
+}
+
+// SetFallbacks configures the languages consulted, in order, when a key is
+// missing for locale. The default language always ends the chain, even when
+// it is not listed. Calling SetFallbacks without a chain restores the
+// implicit BCP 47 chain.
+//
+// Example:
+//
+//	bundle.SetFallbacks("pt-BR", "pt", "es")
+//	bundle.FallbackChain("pt-BR") // [pt es en]
+func (b *Bundle) SetFallbacks(locale string, chain ...string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if len(chain) == 0 {
+		delete(b.fallbacks, locale)
+		return
+	}
+	b.fallbacks[locale] = append([]string(nil), chain...)
+}
+
+// FallbackChain returns the languages consulted, in order, when a key is
+// missing for locale. Without a configured chain, the locale falls back to
+// its parent tags by removing subtags (zh-Hant-TW → zh-Hant → zh), then to
+// the default language. The locale itself is never part of its chain.
+func (b *Bundle) FallbackChain(locale string) []string {
+	b.mu.RLock()
+	configured, ok := b.fallbacks[locale]
+	defaultLang := b.defaultLang
+	b.mu.RUnlock()
+
+	var candidates []string
+	if ok {
+		candidates = configured
+	} else {
+		for tag := truncateTag(locale); tag != ""; tag = truncateTag(tag) {
+			candidates = append(candidates, tag)
+		}
+	}
+	candidates = append(candidates, defaultLang)
+
+	seen := map[string]bool{locale: true}
+	chain := make([]string, 0, len(candidates))
+	for _, lang := range candidates {
+		if !seen[lang] {
+			seen[lang] = true
+			chain = append(chain, lang)
+		}
+	}
+	return chain
+}
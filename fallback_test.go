@@ -0,0 +1,94 @@
+package i18n
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFallbackChain(t *testing.T) {
+	b := NewBundle()
+
+	tests := []struct {
+		locale   string
+		expected []string
+	}{
+		{"pt-BR", []string{"pt", "en"}},
+		{"zh-Hant-TW", []string{"zh-Hant", "zh", "en"}},
+		{"fr", []string{"en"}},
+		{"en", []string{}},
+		{"en-GB", []string{"en"}},
+	}
+
+	for _, tt := range tests {
+		if chain := b.FallbackChain(tt.locale); !reflect.DeepEqual(chain, tt.expected) {
+			t.Errorf("FallbackChain(%q) = %v, expected %v", tt.locale, chain, tt.expected)
+		}
+	}
+
+	b.SetFallbacks("pt-BR", "pt", "es", "pt")
+	if chain := b.FallbackChain("pt-BR"); !reflect.DeepEqual(chain, []string{"pt", "es", "en"}) {
+		t.Errorf("Expected configured chain [pt es en], got %v", chain)
+	}
+
+	b.SetFallbacks("pt-BR")
+	if chain := b.FallbackChain("pt-BR"); !reflect.DeepEqual(chain, []string{"pt", "en"}) {
+		t.Errorf("Expected implicit chain after reset, got %v", chain)
+	}
+}
+
+func TestFallbackLookup(t *testing.T) {
+	b := NewBundle()
+
+	en := NewDictionary("en")
+	en.AddAll(map[string]string{"hello": "Hello", "bus": "Bus", "train": "Train"})
+	en.AddSlice("days", []string{"Mon", "Tue"})
+	b.Register(en)
+
+	pt := NewDictionary("pt")
+	pt.AddAll(map[string]string{"hello": "Olá", "bus": "Autocarro"})
+	pt.AddSlice("days", []string{"Seg", "Ter"})
+	b.Register(pt)
+
+	ptBR := NewDictionary("pt-BR")
+	ptBR.Add("bus", "Ônibus")
+	b.Register(ptBR)
+
+	tests := []struct {
+		key      string
+		expected string
+	}{
+		{"bus", "Ônibus"},
+		{"hello", "Olá"},
+		{"train", "Train"},
+		{"missing", "missing"},
+	}
+	for _, tt := range tests {
+		if result := ptBR.Get(tt.key); result != tt.expected {
+			t.Errorf("Get(%q) = %q, expected %q", tt.key, result, tt.expected)
+		}
+	}
+
+	if days := ptBR.GetSlice("days"); !reflect.DeepEqual(days, []string{"Seg", "Ter"}) {
+		t.Errorf("Expected pt list through fallback, got %v", days)
+	}
+
+	// Unregistered regional locales resolve to their parent language
+	if result := b.S("Hello")("pt-PT"); result != "Olá" {
+		t.Errorf("Expected 'Olá' for pt-PT, got %q", result)
+	}
+
+	// A configured chain skips the implicit parent
+	b.SetFallbacks("pt-BR", "en")
+	if result := ptBR.Get("hello"); result != "Hello" {
+		t.Errorf("Expected configured fallback 'Hello', got %q", result)
+	}
+}
+
+func TestSetFallbacks_DefaultBundle(t *testing.T) {
+	defer SetFallbacks("de-AT")
+
+	SetFallbacks("de-AT", "de-DE")
+	if chain := FallbackChain("de-AT"); !reflect.DeepEqual(chain, []string{"de-DE", DefaultLanguage()}) {
+		t.Errorf("Expected [de-DE %s], got %v", DefaultLanguage(), chain)
+	}
+}
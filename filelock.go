@@ -0,0 +1,155 @@
+package i18n
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// LockFile is the file the generators create in a catalog directory while
+// they read, merge and rewrite its catalogs, so that parallel CI jobs or a
+// watcher and a manual run take turns instead of interleaving their writes
+const LockFile = ".i18n.lock"
+
+// ErrLocked is returned, wrapped, when a catalog directory stays locked by
+// another process longer than the generators wait
+var ErrLocked = errors.New("catalog directory is locked")
+
+var (
+	// lockTimeout is how long LockCatalogDir waits for another lock
+	lockTimeout = time.Minute
+
+	// lockRetry is how often LockCatalogDir checks whether a lock is gone
+	lockRetry = 50 * time.Millisecond
+
+	// staleLockAge is the age after which a lock is taken to be left behind
+	// by a process that crashed, and removed
+	staleLockAge = 10 * time.Minute
+)
+
+// LockCatalogDir takes the advisory lock of a catalog directory, creating the
+// directory if needed, and returns the function that releases it. If another
+// process holds the lock, it waits until the lock is released, ctx is done
+// or a minute has passed, whichever comes first; the last returns ErrLocked.
+// Locks older than ten minutes are considered stale and broken.
+//
+// GenerateCatalogs, GenerateWorkspace, ImportCatalogs and the other functions
+// that rewrite catalogs take the lock themselves; use LockCatalogDir to keep
+// them out while editing catalogs some other way.
+//
+// Example:
+//
+//	unlock, err := i18n.LockCatalogDir(ctx, "locales")
+//	if err != nil {
+//		return err
+//	}
+//	defer unlock()
+func LockCatalogDir(ctx context.Context, dir string) (func() error, error) {
+	if dir == "" {
+		dir = DefaultFolder
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	path := filepath.Join(dir, LockFile)
+	deadline := time.Now().Add(lockTimeout)
+	for {
+		f, err := os.OpenFile(filepath.Clean(path), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+		if err == nil {
+			host, _ := os.Hostname()
+			_, err = fmt.Fprintf(f, "pid %d on %s since %s\n", os.Getpid(), host, time.Now().UTC().Format(time.RFC3339))
+			if cerr := f.Close(); err == nil {
+				err = cerr
+			}
+			if err != nil {
+				os.Remove(path)
+				return nil, fmt.Errorf("failed to write lock file: %w", err)
+			}
+			return func() error { return unlockCatalogDir(path) }, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, fmt.Errorf("failed to create lock file: %w", err)
+		}
+
+		if info, err := os.Stat(path); err == nil && time.Since(info.ModTime()) > staleLockAge {
+			logger().Warn("removing stale catalog lock", "path", path, "age", time.Since(info.ModTime()).Round(time.Second))
+			os.Remove(path)
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("%w: %s", ErrLocked, lockHolder(path))
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(lockRetry):
+		}
+	}
+}
+
+// unlockCatalogDir removes the lock file at path
+func unlockCatalogDir(path string) error {
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove lock file: %w", err)
+	}
+	return nil
+}
+
+// lockHolder describes the process holding the lock file at path
+func lockHolder(path string) string {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil || len(data) == 0 {
+		return path
+	}
+	return fmt.Sprintf("%s (held by %s)", path, strings.TrimSpace(string(data)))
+}
+
+// withCatalogLock runs fn holding the lock of a catalog directory
+func withCatalogLock(ctx context.Context, dir string, fn func() error) (err error) {
+	unlock, err := LockCatalogDir(ctx, dir)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if uerr := unlock(); err == nil {
+			err = uerr
+		}
+	}()
+	return fn()
+}
+
+// writeFileAtomic writes data to a temporary file next to path and renames
+// it over path, so readers see either the old or the new content, never a
+// partial write
+func writeFileAtomic(path string, data []byte) error {
+	path = filepath.Clean(path)
+	f, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	tmp := f.Name()
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	if err := os.Chmod(tmp, 0644); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package i18n
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestLockCatalogDir(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "locales")
+
+	unlock, err := LockCatalogDir(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("LockCatalogDir failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, LockFile)); err != nil {
+		t.Fatalf("Expected the lock file to exist: %v", err)
+	}
+
+	// A second writer waits until ctx is done
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if _, err := LockCatalogDir(ctx, dir); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the lock to be held, got %v", err)
+	}
+
+	// ... or until the lock timeout, naming the holder
+	defer func(timeout time.Duration) { lockTimeout = timeout }(lockTimeout)
+	lockTimeout = 100 * time.Millisecond
+	_, err = LockCatalogDir(context.Background(), dir)
+	if !errors.Is(err, ErrLocked) || !strings.Contains(err.Error(), fmt.Sprintf("pid %d", os.Getpid())) {
+		t.Errorf("Expected ErrLocked naming this process, got %v", err)
+	}
+
+	if err := unlock(); err != nil {
+		t.Fatalf("unlock failed: %v", err)
+	}
+	unlock, err = LockCatalogDir(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("Expected the released lock to be taken again, got %v", err)
+	}
+	unlock()
+}
+
+func TestLockCatalogDir_Stale(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, LockFile)
+	writeTestFile(t, path, "pid 1 on crashed-runner\n")
+	old := time.Now().Add(-time.Hour)
+	if err := os.Chtimes(path, old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	unlock, err := LockCatalogDir(ctx, dir)
+	if err != nil {
+		t.Fatalf("Expected the stale lock to be broken, got %v", err)
+	}
+	unlock()
+}
+
+func TestImportCatalogs_Concurrent(t *testing.T) {
+	dir := t.TempDir()
+
+	// Without the lock, parallel read-merge-write cycles lose each other's keys
+	var wg sync.WaitGroup
+	errs := make(chan error, 20)
+	for i := range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- ImportCatalogs(dir, map[string]map[string]string{
+				"fr": {fmt.Sprintf("key%d", i): "valeur"},
+			})
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("ImportCatalogs failed: %v", err)
+		}
+	}
+
+	fr, err := LoadDictionaryFile(filepath.Join(dir, "default.fr.json"))
+	if err != nil {
+		t.Fatalf("Failed to load fr: %v", err)
+	}
+	if n := len(fr.Keys()); n != 20 {
+		t.Errorf("Expected all 20 imported keys, got %d", n)
+	}
+
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Errorf("Expected only the catalog to be left behind, got %v", entries)
+	}
+}
@@ -0,0 +1,96 @@
+package i18n
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"slices"
+)
+
+// SetExcludeFuzzy leaves fuzzy translations out when exclude is set: a key a
+// catalog lists as fuzzy, whose source text changed since it was translated,
+// is looked up along the fallback chain instead, so users see the current
+// source text rather than an outdated translation.
+//
+// Example:
+//
+//	i18n.SetExcludeFuzzy(os.Getenv("ENV") == "production")
+func SetExcludeFuzzy(exclude bool) {
+	defaultBundle.SetExcludeFuzzy(exclude)
+}
+
+// SetExcludeFuzzy is like the package-level SetExcludeFuzzy but applies to
+// the bundle's dictionaries
+func (b *Bundle) SetExcludeFuzzy(exclude bool) {
+	b.excludeFuzzy.Store(exclude)
+}
+
+// excludesFuzzy reports whether the dictionary's bundle leaves fuzzy
+// translations out. The caller must hold d.mu.
+func (d *Dictionary) excludesFuzzy() bool {
+	b := d.bundle
+	if b == nil {
+		b = defaultBundle
+	}
+	return b.excludeFuzzy.Load()
+}
+
+// SetFuzzy marks the translation of key as fuzzy, outdated by a change of its
+// source text, or clears the mark
+func (d *Dictionary) SetFuzzy(key string, fuzzy bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if !fuzzy {
+		delete(d.fuzzy, key)
+		return
+	}
+	if d.fuzzy == nil {
+		d.fuzzy = make(map[string]bool)
+	}
+	d.fuzzy[key] = true
+}
+
+// Fuzzy returns the keys whose translation is marked fuzzy, sorted
+func (d *Dictionary) Fuzzy() []string {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	if len(d.fuzzy) == 0 {
+		return nil
+	}
+	return sortedKeys(d.fuzzy)
+}
+
+// sourceHash returns the hash of a source text recorded in SourceHashes
+func sourceHash(text string) string {
+	sum := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(sum[:6])
+}
+
+// trackSource returns the source hashes and fuzzy keys of a catalog of
+// translations of source: keys that keep their mark, and keys whose recorded
+// source hash differs from that of the current source text, which are also
+// returned as marked. Keys without a recorded hash are taken as translating
+// the current source text; keys without source text keep theirs.
+func trackSource(existing *TranslationFile, source, translations map[string]string) (hashes map[string]string, fuzzy, marked []string) {
+	hashes = make(map[string]string, len(translations))
+	for _, key := range sortedKeys(translations) {
+		text, ok := source[key]
+		if !ok {
+			if old, ok := existing.SourceHashes[key]; ok {
+				hashes[key] = old
+			}
+			continue
+		}
+		hash := sourceHash(text)
+		if old, ok := existing.SourceHashes[key]; ok && old != hash && !slices.Contains(existing.Fuzzy, key) {
+			marked = append(marked, key)
+		}
+		hashes[key] = hash
+	}
+	if len(hashes) == 0 {
+		hashes = nil
+	}
+
+	fuzzy = append(usedKeys(existing.Fuzzy, translations), marked...)
+	slices.Sort(fuzzy)
+	return hashes, fuzzy, marked
+}
@@ -0,0 +1,246 @@
+package i18n
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestGenerateCatalogs_Fuzzy(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "main.go"), `package main
+
+import "github.com/nyxstack/i18n"
+
+func main() {
+	_ = i18n.T("welcome")
+	_ = i18n.T("goodbye")
+}
+`)
+	localesDir := filepath.Join(dir, "locales")
+	if err := os.MkdirAll(localesDir, 0755); err != nil {
+		t.Fatalf("Failed to create locales dir: %v", err)
+	}
+	writeTestCatalog(t, localesDir, "default.en.json", "en", map[string]string{"welcome": "Welcome", "goodbye": "Goodbye"})
+	frPath := writeTestCatalog(t, localesDir, "default.fr.json", "fr", map[string]string{"welcome": "Bienvenue", "goodbye": "Au revoir"})
+
+	generate := func() {
+		t.Helper()
+		if err := GenerateCatalogs(context.Background(), dir, localesDir, "en", "fr"); err != nil {
+			t.Fatalf("GenerateCatalogs failed: %v", err)
+		}
+	}
+	generate()
+
+	fr, err := readTranslationFile(frPath)
+	if err != nil {
+		t.Fatalf("Failed to read fr catalog: %v", err)
+	}
+	want := map[string]string{"welcome": sourceHash("Welcome"), "goodbye": sourceHash("Goodbye")}
+	if !reflect.DeepEqual(fr.SourceHashes, want) || fr.Fuzzy != nil {
+		t.Fatalf("Expected source hashes %v and no fuzzy keys, got %v and %v", want, fr.SourceHashes, fr.Fuzzy)
+	}
+
+	// The source text of welcome changes
+	writeTestCatalog(t, localesDir, "default.en.json", "en", map[string]string{"welcome": "Welcome back", "goodbye": "Goodbye"})
+	changes, err := PlanCatalogs(context.Background(), dir, localesDir, "en", "fr")
+	if err != nil {
+		t.Fatalf("PlanCatalogs failed: %v", err)
+	}
+	if !reflect.DeepEqual(changes[1].Fuzzy, []string{"welcome"}) || changes[1].Empty() {
+		t.Errorf("Expected the plan to mark welcome fuzzy, got %+v", changes[1])
+	}
+	generate()
+
+	if fr, err = readTranslationFile(frPath); err != nil {
+		t.Fatalf("Failed to read fr catalog: %v", err)
+	}
+	if !reflect.DeepEqual(fr.Fuzzy, []string{"welcome"}) {
+		t.Errorf("Expected welcome to be fuzzy, got %v", fr.Fuzzy)
+	}
+	if fr.Translations["welcome"] != "Bienvenue" || fr.SourceHashes["welcome"] != sourceHash("Welcome back") {
+		t.Errorf("Expected the translation kept with the new source hash, got %q and %q", fr.Translations["welcome"], fr.SourceHashes["welcome"])
+	}
+
+	// The mark stays until a reviewer removes it, and is not reported again
+	generate()
+	if fr, err = readTranslationFile(frPath); err != nil {
+		t.Fatalf("Failed to read fr catalog: %v", err)
+	}
+	if !reflect.DeepEqual(fr.Fuzzy, []string{"welcome"}) {
+		t.Errorf("Expected welcome to stay fuzzy, got %v", fr.Fuzzy)
+	}
+	if changes, _ := PlanCatalogs(context.Background(), dir, localesDir, "en", "fr"); !changes[1].Empty() {
+		t.Errorf("Expected no further changes, got %+v", changes[1])
+	}
+}
+
+func TestTrackSource(t *testing.T) {
+	existing := &TranslationFile{
+		SourceHashes: map[string]string{"a": sourceHash("A"), "b": sourceHash("old B"), "c": sourceHash("old C"), "gone": "x", "todo": "y"},
+		Fuzzy:        []string{"c", "gone"},
+	}
+	source := map[string]string{"a": "A", "b": "B", "c": "C", "new": "New"}
+	translations := map[string]string{"a": "a", "b": "b", "c": "c", "new": "New", "todo": "todo"}
+
+	hashes, fuzzy, marked := trackSource(existing, source, translations)
+	wantHashes := map[string]string{"a": sourceHash("A"), "b": sourceHash("B"), "c": sourceHash("C"), "new": sourceHash("New"), "todo": "y"}
+	if !reflect.DeepEqual(hashes, wantHashes) {
+		t.Errorf("Expected hashes %v, got %v", wantHashes, hashes)
+	}
+	if !reflect.DeepEqual(fuzzy, []string{"b", "c"}) {
+		t.Errorf("Expected fuzzy b and c, got %v", fuzzy)
+	}
+	if !reflect.DeepEqual(marked, []string{"b"}) {
+		t.Errorf("Expected b newly marked, got %v", marked)
+	}
+}
+
+func TestSetExcludeFuzzy(t *testing.T) {
+	b := NewBundle()
+	en := NewDictionary("en")
+	en.Add("welcome", "Welcome back")
+	b.Register(en)
+
+	fr, err := LoadDictionaryBytes([]byte(`{
+  "meta": {"lang": "fr", "name": "default"},
+  "translations": {"welcome": "Bienvenue", "goodbye": "Au revoir"},
+  "fuzzy": ["welcome"]
+}`))
+	if err != nil {
+		t.Fatalf("LoadDictionaryBytes failed: %v", err)
+	}
+	b.Register(fr)
+
+	if got := fr.Fuzzy(); !reflect.DeepEqual(got, []string{"welcome"}) {
+		t.Errorf("Expected welcome to be fuzzy, got %v", got)
+	}
+	if got := b.T("welcome")("fr"); got != "Bienvenue" {
+		t.Errorf("Expected the fuzzy translation by default, got %q", got)
+	}
+
+	b.SetExcludeFuzzy(true)
+	if got := b.T("welcome")("fr"); got != "Welcome back" {
+		t.Errorf("Expected the source text for a fuzzy key, got %q", got)
+	}
+	if got := b.T("goodbye")("fr"); got != "Au revoir" {
+		t.Errorf("Expected other keys to be translated, got %q", got)
+	}
+	if got := b.Clone().T("welcome")("fr"); got != "Welcome back" {
+		t.Errorf("Expected a clone to exclude fuzzy values too, got %q", got)
+	}
+
+	if report := b.CoverageReport("en"); len(report) != 2 || !reflect.DeepEqual(report[1].Fuzzy, []string{"welcome"}) {
+		t.Errorf("Expected the fr statistics to list welcome as fuzzy, got %+v", report)
+	}
+
+	fr.SetFuzzy("welcome", false)
+	if got := b.T("welcome")("fr"); got != "Bienvenue" {
+		t.Errorf("Expected the translation once reviewed, got %q", got)
+	}
+}
+
+func TestDictionary_FuzzyExport(t *testing.T) {
+	dict := NewDictionary("fr")
+	dict.Add("welcome", "Bienvenue")
+	dict.SetFuzzy("welcome", true)
+
+	data, err := MarshalCatalog(dict.translationFile())
+	if err != nil {
+		t.Fatalf("MarshalCatalog failed: %v", err)
+	}
+	if !strings.Contains(string(data), `"fuzzy": [`) {
+		t.Errorf("Expected the fuzzy keys to be written, got %s", data)
+	}
+
+	dict.Remove("welcome")
+	if dict.Fuzzy() != nil {
+		t.Errorf("Expected no fuzzy keys after Remove, got %v", dict.Fuzzy())
+	}
+}
+
+func TestBundleReloadLanguage_Fuzzy(t *testing.T) {
+	t.Chdir(t.TempDir())
+	path := filepath.Join(DefaultFolder, "default.fr.json")
+	writeTestFile(t, path, `{"meta": {"lang": "fr", "name": "default"}, "translations": {"welcome": "Bienvenue"}, "fuzzy": ["welcome"]}`)
+
+	b := NewBundle()
+	b.SetExcludeFuzzy(true)
+	if err := b.ReloadLanguage("fr"); err != nil {
+		t.Fatalf("ReloadLanguage failed: %v", err)
+	}
+	if got := b.T("welcome")("fr"); got != "welcome" {
+		t.Errorf("Expected the fuzzy value left out, got %q", got)
+	}
+
+	// A reviewer only removes the mark
+	writeTestFile(t, path, `{"meta": {"lang": "fr", "name": "default"}, "translations": {"welcome": "Bienvenue"}}`)
+	if err := b.ReloadLanguage("fr"); err != nil {
+		t.Fatalf("ReloadLanguage failed: %v", err)
+	}
+	if got := b.T("welcome")("fr"); got != "Bienvenue" {
+		t.Errorf("Expected the translation once reviewed, got %q", got)
+	}
+}
+
+func TestGenerateCatalogs_NewKeysNotTranslated(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "main.go"), `package main
+
+import "github.com/nyxstack/i18n"
+
+func main() {
+	_ = i18n.T("welcome")
+	_ = i18n.T("goodbye")
+}
+`)
+	localesDir := filepath.Join(dir, "locales")
+	if err := os.MkdirAll(localesDir, 0755); err != nil {
+		t.Fatalf("Failed to create locales dir: %v", err)
+	}
+	writeTestCatalog(t, localesDir, "default.en.json", "en", map[string]string{"welcome": "Welcome", "goodbye": "Goodbye"})
+	frPath := writeTestCatalog(t, localesDir, "default.fr.json", "fr", map[string]string{"welcome": "Bienvenue"})
+
+	for range 2 {
+		if err := GenerateCatalogs(context.Background(), dir, localesDir, "en", "fr"); err != nil {
+			t.Fatalf("GenerateCatalogs failed: %v", err)
+		}
+		fr, err := readTranslationFile(frPath)
+		if err != nil {
+			t.Fatalf("Failed to read fr catalog: %v", err)
+		}
+		if fr.Translations["goodbye"] != "Goodbye" || !reflect.DeepEqual(fr.Fuzzy, []string{"goodbye"}) {
+			t.Fatalf("Expected the new key copied and fuzzy, got %v and %v", fr.Translations, fr.Fuzzy)
+		}
+	}
+
+	b := NewBundle()
+	err := b.LoadDirStrict(localesDir, 0.6)
+	if err == nil || !strings.Contains(err.Error(), "coverage 50.0% is below 60.0%") {
+		t.Errorf("Expected the copied key not to count towards coverage, got %v", err)
+	}
+	if err := b.LoadDir(localesDir); err != nil {
+		t.Fatalf("LoadDir failed: %v", err)
+	}
+	report := b.CoverageReport("en")
+	if len(report) != 2 || report[1].Lang != "fr" || report[1].Translated != 1 || report[1].Identical != 0 {
+		t.Errorf("Expected fr to translate 1 key, got %+v", report)
+	}
+}
+
+func TestBundleReset_ExcludeFuzzy(t *testing.T) {
+	b := NewBundle()
+	fr := NewDictionary("fr")
+	fr.Add("welcome", "Bienvenue")
+	fr.SetFuzzy("welcome", true)
+	b.SetExcludeFuzzy(true)
+	b.Reset()
+
+	b.Register(fr)
+	if got := b.T("welcome")("fr"); got != "Bienvenue" {
+		t.Errorf("Expected Reset to serve fuzzy values again, got %q", got)
+	}
+}
@@ -1,126 +1,707 @@
 package i18n
 
 import (
-	"encoding/json"
+	"context"
+	"errors"
 	"fmt"
-	"go/ast"
-	"go/parser"
-	"go/token"
+	"maps"
 	"os"
 	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
 )
 
-// GenerateTranslations scans a Go codebase for i18n function calls (F, S, T, P)
-// and generates translation keys + source strings into a dictionary file in the locales/ folder.
+// GenerateTranslations scans a Go codebase for i18n function calls (F, S, T, P, Pf, Sel, O),
+// and other files with registered extractors (see RegisterExtractor), and generates
+// translation keys + source strings into a dictionary file in the locales/ folder.
+// F and S texts are stored under slugified keys. Keys passed to T, P and the
+// other key-based functions are kept as-is with their existing value in the
+// catalog, or listed under "needs_source" until someone writes the source text.
 func GenerateTranslations(locale, root, outputPath string) error {
-	results := make(map[string]string)
+	return GenerateTranslationsContext(context.Background(), locale, root, outputPath)
+}
+
+// GenerateTranslationsContext is like GenerateTranslations but stops scanning
+// and returns ctx's error, without writing the output, once ctx is done
+func GenerateTranslationsContext(ctx context.Context, locale, root, outputPath string) error {
+	results, err := extractTranslations(ctx, []string{root}, nil)
+	if err != nil {
+		return err
+	}
 
-	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
-		if err != nil || info.IsDir() || filepath.Ext(path) != ".go" {
-			return nil
+	if results.empty() {
+		logger().Info("no i18n calls found", "root", root)
+		return nil
+	}
+
+	outputPath = CatalogPath(locale, outputPath)
+	var translations map[string]string
+	var needsSource []string
+	err = withCatalogLock(ctx, filepath.Dir(outputPath), func() error {
+		existing, err := readCatalog(outputPath)
+		if err != nil {
+			return err
 		}
 
-		fs := token.NewFileSet()
-		node, err := parser.ParseFile(fs, path, nil, parser.AllErrors)
+		translations, needsSource = results.resolve(existing.known(), existing.Lists)
+		tf := newCatalogFile(locale, translations)
+		tf.keepMeta(existing.Meta)
+		tf.Lists = results.lists(existing.Lists)
+		tf.Schedule = usedSchedule(existing.Schedule, translations)
+		tf.Nested = existing.Nested
+		tf.Indent = existing.Indent
+		tf.NeedsSource = needsSource
+		tf.Placeholders = results.placeholders(translations)
+		tf.Comments = results.comments
+		tf.MachineTranslated = usedKeys(existing.MachineTranslated, translations)
+		tf.SourceHashes = usedHashes(existing.SourceHashes, translations)
+		tf.Fuzzy = usedKeys(existing.Fuzzy, translations)
+		tf.States = usedStates(existing.States, translations)
+		return writeCatalog(outputPath, tf)
+	})
+	if err != nil {
+		return err
+	}
+
+	logger().Info("catalog written", "lang", locale, "entries", len(translations)+len(needsSource), "path", outputPath)
+	if len(needsSource) > 0 {
+		logger().Warn("keys need source text", "lang", locale, "keys", needsSource)
+	}
+	return nil
+}
+
+// PlanTranslations reports the changes GenerateTranslationsContext would make
+// to the catalog at outputPath (keys added, changed and removed) without
+// writing anything. A missing catalog counts as empty.
+func PlanTranslations(ctx context.Context, locale, root, outputPath string) (LocaleChanges, error) {
+	results, err := extractTranslations(ctx, []string{root}, nil)
+	if err != nil {
+		return LocaleChanges{}, err
+	}
+	if results.empty() {
+		return LocaleChanges{Lang: locale}, nil
+	}
+
+	existing, err := readCatalog(CatalogPath(locale, outputPath))
+	if err != nil {
+		return LocaleChanges{}, err
+	}
+	translations, _ := results.resolve(existing.known(), existing.Lists)
+	return compareTranslations(locale, existing.Translations, translations), nil
+}
+
+// GenerateCatalogs extracts translations once and writes the catalog of
+// every locale into dir (locales/ if empty). The first locale is the source
+// language and gets the source strings; the other catalogs keep their
+// existing translations, start new keys as untranslated copies of the source
+// text and drop keys no longer used, so all files stay consistent. It holds
+// the lock of dir while it does (see LockCatalogDir).
+//
+//	i18n.GenerateCatalogs(ctx, ".", "", "en", "fr", "de")
+func GenerateCatalogs(ctx context.Context, root, dir string, locales ...string) error {
+	return withCatalogLock(ctx, dir, func() error {
+		catalogs, err := planCatalogs(ctx, []string{root}, nil, dir, DefaultDictionary, locales)
 		if err != nil {
-			return nil
+			return err
 		}
+		return writeCatalogs(catalogs)
+	})
+}
 
-		ast.Inspect(node, func(n ast.Node) bool {
-			call, ok := n.(*ast.CallExpr)
-			if !ok {
-				return true
-			}
+// GenerateAll is like GenerateCatalogs into locales/, but also synchronizes
+// every default catalog already there (see DiscoverLocales) with the keys
+// found under root, so one run updates all locales. locales lists the
+// source language first and any locales to create; the discovered ones
+// follow in alphabetical order.
+//
+//	i18n.GenerateAll(".", []string{"en"}) // updates locales/default.{en,de,fr,...}.json
+func GenerateAll(root string, locales []string) error {
+	return GenerateAllContext(context.Background(), root, locales)
+}
 
-			sel, ok := call.Fun.(*ast.SelectorExpr)
-			if !ok {
-				return true
-			}
+// GenerateAllContext is like GenerateAll but stops scanning and returns
+// ctx's error, without writing anything, once ctx is done
+func GenerateAllContext(ctx context.Context, root string, locales []string) error {
+	all, err := ExpandLocales(DefaultFolder, locales)
+	if err != nil {
+		return err
+	}
+	return GenerateCatalogs(ctx, root, DefaultFolder, all...)
+}
 
-			pkg, ok := sel.X.(*ast.Ident)
-			if !ok || pkg.Name != "i18n" {
-				return true
-			}
+// DiscoverLocales returns the languages of the default catalogs in dir
+// ({DefaultDictionary}.{lang}.json), sorted. A missing dir has none.
+func DiscoverLocales(dir string) ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(dir, DefaultDictionary+".*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list catalogs in %s: %w", dir, err)
+	}
+	var locales []string
+	for _, path := range files {
+		if lang := fileLanguage(path); lang != "" && filepath.Base(path) == DefaultDictionary+"."+lang+".json" {
+			locales = append(locales, lang)
+		}
+	}
+	sort.Strings(locales)
+	return locales, nil
+}
 
-			funcName := sel.Sel.Name
-			if funcName != "F" && funcName != "S" && funcName != "T" && funcName != "P" {
-				return true
-			}
+// ExpandLocales returns locales followed by the other locales DiscoverLocales
+// finds in dir. locales must name at least the source language.
+func ExpandLocales(dir string, locales []string) ([]string, error) {
+	if len(locales) == 0 {
+		return nil, fmt.Errorf("no source language given")
+	}
+	found, err := DiscoverLocales(dir)
+	if err != nil {
+		return nil, err
+	}
+	all := slices.Clone(locales)
+	for _, lang := range found {
+		if !slices.Contains(all, lang) {
+			all = append(all, lang)
+		}
+	}
+	return all, nil
+}
 
-			if len(call.Args) == 0 {
-				return true
-			}
+// writeCatalogs writes planned catalogs
+func writeCatalogs(catalogs []plannedCatalog) error {
+	for _, c := range catalogs {
+		tf := newCatalogFile(c.changes.Lang, c.translations)
+		tf.keepMeta(c.meta)
+		tf.Meta.Name = c.name
+		tf.Lists = c.lists
+		tf.Schedule = c.schedule
+		tf.NeedsSource = c.needsSource
+		tf.Placeholders = c.placeholders
+		tf.Comments = c.comments
+		tf.Groups = c.groups
+		tf.MachineTranslated = c.machine
+		tf.SourceHashes = c.sourceHashes
+		tf.Fuzzy = c.fuzzy
+		tf.States = c.states
+		tf.Nested = c.nested
+		tf.Indent = c.indent
+		if c.keepRemoved {
+			tf.Obsolete = c.removed
+		}
+		if c.writeReferences {
+			tf.References = c.references
+		}
+		if err := writeCatalog(c.path, tf); err != nil {
+			return err
+		}
+		logger().Info("catalog written", "lang", c.changes.Lang, "entries", len(c.translations),
+			"added", len(c.changes.Added), "removed", len(c.changes.Removed), "path", c.path)
+		if len(c.changes.Fuzzy) > 0 {
+			logger().Warn("source text changed, translations marked fuzzy", "lang", c.changes.Lang, "keys", c.changes.Fuzzy)
+		}
+	}
+	return nil
+}
+
+// PlanCatalogs reports the changes GenerateCatalogs would make to the catalog
+// of each locale, in the order given, without writing anything
+func PlanCatalogs(ctx context.Context, root, dir string, locales ...string) ([]LocaleChanges, error) {
+	catalogs, err := planCatalogs(ctx, []string{root}, nil, dir, DefaultDictionary, locales)
+	if err != nil {
+		return nil, err
+	}
+	return catalogChanges(catalogs), nil
+}
+
+// catalogChanges returns the changes of planned catalogs
+func catalogChanges(catalogs []plannedCatalog) []LocaleChanges {
+	changes := make([]LocaleChanges, 0, len(catalogs))
+	for _, c := range catalogs {
+		changes = append(changes, c.changes)
+	}
+	return changes
+}
+
+// plannedCatalog is the new content of one locale's catalog
+type plannedCatalog struct {
+	name            string
+	path            string
+	meta            Meta // metadata of the existing catalog
+	translations    map[string]string
+	lists           map[string][]string         // list values of the keys still used
+	schedule        map[string][]ScheduledValue // scheduled values of the keys still used
+	needsSource     []string
+	placeholders    map[string][]string
+	comments        map[string]string   // translator comments
+	groups          map[string][]string // see Config.Group
+	files           map[string]string   // key → source file it was first found in
+	references      map[string][]string // key → locations it is used at
+	writeReferences bool                // write references, see Config.References
+	removed         map[string]string   // last values of the keys no longer used
+	keepRemoved     bool                // write removed as the obsolete section
+	machine         []string            // keys still marked as machine-translated
+	sourceHashes    map[string]string   // see TranslationFile.SourceHashes
+	fuzzy           []string            // keys marked fuzzy, see TranslationFile.Fuzzy
+	states          map[string]State    // review states of the keys still used
+	nested          bool
+	indent          string
+	changes         LocaleChanges
+}
+
+// planCatalogs computes the catalogs GenerateCatalogs writes from the
+// source trees under roots, leaving out excluded paths, as the dictionary
+// name of each locale. It returns nil if they have no i18n calls.
+func planCatalogs(ctx context.Context, roots, exclude []string, dir, name string, locales []string) ([]plannedCatalog, error) {
+	if len(locales) == 0 {
+		return nil, fmt.Errorf("no locales given")
+	}
+	if dir == "" {
+		dir = DefaultFolder
+	}
+
+	results, err := extractTranslations(ctx, roots, exclude)
+	if err != nil {
+		return nil, err
+	}
+	if results.empty() {
+		logger().Info("no i18n calls found", "root", strings.Join(roots, ","))
+		return nil, nil
+	}
+
+	var source map[string]string
+	var sourceLists map[string][]string
+	var needsSource []string
+	catalogs := make([]plannedCatalog, 0, len(locales))
+	for i, locale := range locales {
+		path := filepath.Join(dir, fmt.Sprintf("%s.%s.json", name, locale))
+		existing, err := readCatalog(path)
+		if err != nil {
+			return nil, err
+		}
+		current := existing.known()
 
-			firstArg, ok := call.Args[0].(*ast.BasicLit)
-			if !ok || firstArg.Kind != token.STRING {
-				return true
+		var translations, hashes map[string]string
+		var lists map[string][]string
+		var fuzzy, marked []string
+		if i == 0 {
+			source, needsSource = results.resolve(current, existing.Lists)
+			translations = source
+			sourceLists = results.lists(existing.Lists)
+			lists = sourceLists
+		} else {
+			// Lists start as copies of the source language's, like translations
+			lists = results.lists(existing.Lists)
+			for key, list := range sourceLists {
+				if _, ok := lists[key]; ok {
+					continue
+				}
+				if lists == nil {
+					lists = make(map[string][]string, len(sourceLists))
+				}
+				lists[key] = list
 			}
+			// New keys start as a copy of the source text, marked fuzzy so
+			// they do not count as translated
+			var copied []string
+			translations = make(map[string]string, len(source))
+			for key, text := range source {
+				if value, ok := current[key]; ok {
+					text = value
+				} else {
+					copied = append(copied, key)
+				}
+				translations[key] = text
+			}
+			// Keys without source text keep their existing translation
+			for _, key := range needsSource {
+				if value, ok := current[key]; ok {
+					translations[key] = value
+				}
+			}
+			hashes, fuzzy, marked = trackSource(existing, source, translations)
+			fuzzy = append(fuzzy, copied...)
+			slices.Sort(fuzzy)
+			fuzzy = slices.Compact(fuzzy)
+		}
+		changes := compareTranslations(locale, existing.Translations, translations)
+		changes.Fuzzy = marked
+
+		catalogs = append(catalogs, plannedCatalog{
+			name:         name,
+			path:         path,
+			meta:         existing.Meta,
+			translations: translations,
+			lists:        lists,
+			schedule:     usedSchedule(existing.Schedule, translations),
+			needsSource:  missingKeys(needsSource, translations),
+			placeholders: results.placeholders(translations),
+			comments:     results.comments,
+			files:        results.files,
+			references:   results.refs,
+			removed:      removedValues(current, translations),
+			machine:      usedKeys(existing.MachineTranslated, translations),
+			sourceHashes: hashes,
+			fuzzy:        fuzzy,
+			states:       usedStates(existing.States, translations),
+			nested:       existing.Nested,
+			indent:       existing.Indent,
+			changes:      changes,
+		})
+	}
+	return catalogs, nil
+}
+
+// known returns the translations of a catalog along with its obsolete
+// values, so keys used again get their last value back
+func (tf *TranslationFile) known() map[string]string {
+	if len(tf.Obsolete) == 0 {
+		return tf.Translations
+	}
+	known := make(map[string]string, len(tf.Translations)+len(tf.Obsolete))
+	maps.Copy(known, tf.Obsolete)
+	maps.Copy(known, tf.Translations)
+	return known
+}
+
+// removedValues returns the values of the keys of known that translations
+// no longer has
+func removedValues(known, translations map[string]string) map[string]string {
+	var removed map[string]string
+	for key, value := range known {
+		if _, ok := translations[key]; ok {
+			continue
+		}
+		if removed == nil {
+			removed = make(map[string]string)
+		}
+		removed[key] = value
+	}
+	return removed
+}
+
+// usedHashes returns the source hashes of the keys that still have a
+// translation
+func usedHashes(hashes, translations map[string]string) map[string]string {
+	var used map[string]string
+	for key, hash := range hashes {
+		if _, ok := translations[key]; !ok {
+			continue
+		}
+		if used == nil {
+			used = make(map[string]string)
+		}
+		used[key] = hash
+	}
+	return used
+}
+
+// usedSchedule returns the scheduled values of the keys that still have a
+// translation
+func usedSchedule(schedule map[string][]ScheduledValue, translations map[string]string) map[string][]ScheduledValue {
+	var used map[string][]ScheduledValue
+	for key, values := range schedule {
+		if _, ok := translations[key]; !ok {
+			continue
+		}
+		if used == nil {
+			used = make(map[string][]ScheduledValue)
+		}
+		used[key] = values
+	}
+	return used
+}
+
+// usedStates returns the states of the keys that still have a translation
+func usedStates(states map[string]State, translations map[string]string) map[string]State {
+	var used map[string]State
+	for key, state := range states {
+		if _, ok := translations[key]; !ok {
+			continue
+		}
+		if used == nil {
+			used = make(map[string]State)
+		}
+		used[key] = state
+	}
+	return used
+}
+
+// missingKeys returns the keys that have no translation
+func missingKeys(keys []string, translations map[string]string) []string {
+	var missing []string
+	for _, key := range keys {
+		if _, ok := translations[key]; !ok {
+			missing = append(missing, key)
+		}
+	}
+	return missing
+}
+
+// usedKeys returns the keys that still have a translation
+func usedKeys(keys []string, translations map[string]string) []string {
+	var used []string
+	for _, key := range keys {
+		if _, ok := translations[key]; ok {
+			used = append(used, key)
+		}
+	}
+	return used
+}
 
-			// Clean up the string literal quotes
-			raw := firstArg.Value
-			if len(raw) >= 2 && raw[0] == '"' && raw[len(raw)-1] == '"' {
-				raw = raw[1 : len(raw)-1]
+// readCatalog returns the catalog at path, or an empty one if the file does
+// not exist
+func readCatalog(path string) (*TranslationFile, error) {
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		return &TranslationFile{}, nil
+	}
+	return readTranslationFile(path)
+}
+
+// keyFuncs are the i18n functions whose first argument is a catalog key
+// rather than source text
+var keyFuncs = map[string]bool{"T": true, "P": true, "P64": true, "Pf": true, "Sel": true, "O": true, "Errorf": true, "THTML": true, "TRandom": true}
+
+// localeKeyFuncs are the typed value accessors, whose second argument, after
+// the locale, is a catalog key
+var localeKeyFuncs = map[string]bool{"GetInt": true, "GetBool": true, "GetDuration": true, "GetSlice": true, "GetInto": true}
+
+// blockFuncs are the functions whose key names an object value, read field
+// by field from the keys under it
+var blockFuncs = map[string]bool{"GetInto": true}
+
+// extraction holds the strings found in a source tree
+type extraction struct {
+	texts  map[string]string   // slugified key → source text, from F and S
+	verbs  map[string][]string // slugified key → printf verbs of its text, in order
+	keys   map[string]bool     // keys used as-is, from T, P, Sel, ...
+	blocks map[string]bool     // keys of object values, from GetInto
+	files  map[string]string   // key → slash path of the file it was first found in, relative to its root
+	refs   map[string][]string // key → "file:line" of every use, relative to its root, sorted
+
+	comments map[string]string // key → translator comment, the first found
+}
+
+// empty reports whether no i18n calls were found
+func (e extraction) empty() bool {
+	return len(e.texts) == 0 && len(e.keys) == 0 && len(e.blocks) == 0
+}
+
+// blockOf returns the object value used that key is a field of, if any
+func (e extraction) blockOf(key string) (string, bool) {
+	for block := range e.blocks {
+		if strings.HasPrefix(key, block+KeySeparator) {
+			return block, true
+		}
+	}
+	return "", false
+}
+
+// placeholders returns the printf verbs of the texts among translations,
+// recorded so translations can be checked against the argument types
+func (e extraction) placeholders(translations map[string]string) map[string][]string {
+	var verbs map[string][]string
+	for key, v := range e.verbs {
+		if _, ok := translations[key]; !ok {
+			continue
+		}
+		if verbs == nil {
+			verbs = make(map[string][]string)
+		}
+		verbs[key] = v
+	}
+	return verbs
+}
+
+// resolve returns the translations of a source catalog: the source texts,
+// plus the value in current of every key used as-is and of every field of
+// the object values used. Keys without a value, string or list, are returned
+// as needsSource, as the key itself is not display text.
+func (e extraction) resolve(current map[string]string, lists map[string][]string) (translations map[string]string, needsSource []string) {
+	translations = make(map[string]string, len(e.texts)+len(e.keys))
+	for key, text := range e.texts {
+		translations[key] = text
+	}
+	for _, key := range sortedKeys(e.keys) {
+		if _, ok := translations[key]; ok {
+			continue
+		}
+		if value, ok := current[key]; ok {
+			translations[key] = value
+		} else if _, ok := lists[key]; !ok {
+			needsSource = append(needsSource, key)
+		}
+	}
+	// Object values keep all their fields
+	for _, block := range sortedKeys(e.blocks) {
+		found := false
+		for key, value := range current {
+			if strings.HasPrefix(key, block+KeySeparator) {
+				translations[key] = value
+				found = true
+			}
+		}
+		for key := range lists {
+			if strings.HasPrefix(key, block+KeySeparator) {
+				found = true
 			}
+		}
+		if !found {
+			needsSource = append(needsSource, block)
+		}
+	}
+	return translations, needsSource
+}
 
-			key := slugify(raw)
-			results[key] = raw
+// lists returns the list values of the keys used as-is, such as the
+// alternatives of TRandom, and of the fields of the object values used, or
+// nil if there are none
+func (e extraction) lists(existing map[string][]string) map[string][]string {
+	var lists map[string][]string
+	for key, list := range existing {
+		if _, ok := e.blockOf(key); !ok && !e.keys[key] {
+			continue
+		}
+		if lists == nil {
+			lists = make(map[string][]string)
+		}
+		lists[key] = list
+	}
+	return lists
+}
 
-			pos := fs.Position(firstArg.Pos())
-			fmt.Printf("[%s] %s.%s → %s → key: %s\n",
-				pos, pkg.Name, funcName, raw, key)
+// extractTranslations collects the entries of the registered extractors
+// under roots, leaving out excluded paths
+func extractTranslations(ctx context.Context, roots, exclude []string) (extraction, error) {
+	results := extraction{
+		texts:  make(map[string]string),
+		verbs:  make(map[string][]string),
+		keys:   make(map[string]bool),
+		blocks: make(map[string]bool),
+		files:  make(map[string]string),
+		refs:   make(map[string][]string),
 
-			return true
-		})
-		return nil
-	})
+		comments: make(map[string]string),
+	}
+	for _, root := range roots {
+		entries, err := extractDir(ctx, root, exclude)
+		if err != nil {
+			return extraction{}, err
+		}
+		results.add(root, entries)
+	}
+	return results, nil
+}
 
-	if err != nil {
-		return fmt.Errorf("error walking files: %w", err)
+// add folds the entries found under root into the extraction
+func (e extraction) add(root string, entries []Entry) {
+	for _, entry := range entries {
+		key, raw := entry.Key, entry.Text
+		switch {
+		case raw != "":
+			// Store the {0}-style template F renders, with the verbs it replaced
+			if key == "" {
+				key = slugify(raw)
+			}
+			text, verbs := normalize(raw)
+			e.texts[key] = text
+			if len(verbs) > 0 {
+				e.verbs[key] = verbs
+			}
+		case key != "" && entry.Block:
+			raw = key
+			e.blocks[key] = true
+		case key != "":
+			raw = key
+			e.keys[key] = true
+		default:
+			logger().Warn("argument is not a constant, not extracted", "pos", entry.Pos, "func", entry.Func)
+			continue
+		}
+
+		if _, ok := e.files[key]; !ok {
+			e.files[key] = entryFile(root, entry.Pos)
+		}
+		if ref := entryReference(root, entry.Pos); !slices.Contains(e.refs[key], ref) {
+			e.refs[key] = append(e.refs[key], ref)
+			slices.Sort(e.refs[key])
+		}
+		if _, ok := e.comments[key]; !ok && entry.Comment != "" {
+			e.comments[key] = entry.Comment
+		}
+		logger().Debug("found translatable string", "pos", entry.Pos, "func", entry.Func, "text", raw, "key", key)
 	}
+}
 
-	if len(results) == 0 {
-		fmt.Println("no i18n calls found")
-		return nil
+// entryPos matches the line and column that end an entry position
+var entryPos = regexp.MustCompile(`:\d+(:\d+)?$`)
+
+// entryReference returns an entry position without its column, the file
+// relative to root as entryFile does: "dir/file.go:12"
+func entryReference(root, pos string) string {
+	file := entryFile(root, pos)
+	line, _, _ := strings.Cut(strings.TrimPrefix(entryPos.FindString(pos), ":"), ":")
+	if line == "" {
+		return file
+	}
+	return file + ":" + line
+}
+
+// entryFile returns the file of an entry position ("dir/file.go:12:5") as
+// a slash path relative to root when it is under root
+func entryFile(root, pos string) string {
+	file := entryPos.ReplaceAllString(pos, "")
+	if rel, err := filepath.Rel(root, file); err == nil && !strings.HasPrefix(rel, "..") {
+		file = rel
 	}
+	return filepath.ToSlash(file)
+}
 
-	// Use default output path if empty
+// CatalogPath returns outputPath, or the default catalog path of locale if it is empty
+func CatalogPath(locale, outputPath string) string {
 	if outputPath == "" {
-		outputPath = filepath.Join(DefaultFolder, fmt.Sprintf("%s.%s.json", DefaultDictionary, locale))
+		return filepath.Join(DefaultFolder, fmt.Sprintf("%s.%s.json", DefaultDictionary, locale))
 	}
+	return outputPath
+}
 
+// newCatalogFile returns the default dictionary file of locale
+func newCatalogFile(locale string, translations map[string]string) *TranslationFile {
+	tf := &TranslationFile{Translations: translations}
+	tf.Meta.Lang = locale
+	tf.Meta.Name = DefaultDictionary
+	tf.Meta.SchemaVersion = SchemaVersion
+	return tf
+}
+
+// keepMeta copies the details of meta, the metadata of the catalog tf
+// replaces, such as its version and text direction. The language and schema
+// version stay those of tf, and so does the name if meta has none.
+func (tf *TranslationFile) keepMeta(meta Meta) {
+	meta.Lang = tf.Meta.Lang
+	meta.SchemaVersion = tf.Meta.SchemaVersion
+	if meta.Name == "" {
+		meta.Name = tf.Meta.Name
+	}
+	tf.Meta = meta
+}
+
+// writeCatalog saves a generated catalog file
+func writeCatalog(outputPath string, tf *TranslationFile) error {
 	// Ensure output directory exists
 	dir := filepath.Dir(outputPath)
 	if err := os.MkdirAll(dir, 0755); err != nil {
 		return fmt.Errorf("failed to create output directory: %w", err)
 	}
 
-	// Create TranslationFile structure for saving
-	tf := TranslationFile{
-		Meta: struct {
-			Lang      string `json:"lang"`
-			Name      string `json:"name"`
-			Version   string `json:"version,omitempty"`
-			Author    string `json:"author,omitempty"`
-			Updated   string `json:"updated,omitempty"`
-			Direction string `json:"direction,omitempty"`
-		}{
-			Lang: locale,
-			Name: DefaultDictionary,
-		},
-		Translations: results,
-	}
-
 	// Save to JSON file
-	data, err := json.MarshalIndent(tf, "", "  ")
+	data, err := MarshalCatalog(tf)
 	if err != nil {
 		return fmt.Errorf("failed to marshal dictionary: %w", err)
 	}
 
-	if err := os.WriteFile(filepath.Clean(outputPath), data, 0644); err != nil {
+	if err := writeFileAtomic(outputPath, data); err != nil {
 		return fmt.Errorf("failed to save dictionary: %w", err)
 	}
-
-	fmt.Printf("✅ Extracted %d i18n entries → %s\n", len(results), outputPath)
 	return nil
 }
 
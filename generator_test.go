@@ -1,9 +1,12 @@
 package i18n
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
 )
 
@@ -65,10 +68,17 @@ func main() {
 
 	// Verify translations were extracted
 	expectedTranslations := map[string]string{
-		"hello-0":         "Hello %s",
-		"welcome":         "Welcome",
-		"goodbye-message": "goodbye_message", // T() uses the key as-is
-		"item-count":      "item_count",      // P() uses the key as-is
+		"hello-0": "Hello {0}", // F() texts are stored as the template F renders
+		"welcome": "Welcome",
+	}
+
+	if verbs := tf.Placeholders["hello-0"]; len(verbs) != 1 || verbs[0] != "%s" {
+		t.Errorf("Expected the %%s verb of hello-0 to be recorded, got %v", tf.Placeholders)
+	}
+
+	// T() and P() keys are not display text: they wait for a source value
+	if len(tf.NeedsSource) != 2 || tf.NeedsSource[0] != "goodbye_message" || tf.NeedsSource[1] != "item_count" {
+		t.Errorf("Expected T and P keys to need source text, got %v", tf.NeedsSource)
 	}
 
 	for expectedKey, expectedValue := range expectedTranslations {
@@ -307,3 +317,432 @@ func main() {
 		t.Fatalf("Output directory was not created: %s", filepath.Dir(outputPath))
 	}
 }
+
+func TestGenerateTranslationsContext_Canceled(t *testing.T) {
+	tempDir := t.TempDir()
+	content := "package main\n\nimport \"github.com/nyxstack/i18n\"\n\nvar _ = i18n.S(\"Welcome\")\n"
+	if err := os.WriteFile(filepath.Join(tempDir, "test.go"), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to create test Go file: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	outputPath := filepath.Join(tempDir, "locales", "default.en.json")
+	err := GenerateTranslationsContext(ctx, "en", tempDir, outputPath)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	if _, err := os.Stat(outputPath); !os.IsNotExist(err) {
+		t.Error("Expected no output file after cancellation")
+	}
+}
+
+func TestPlanTranslations(t *testing.T) {
+	tempDir := t.TempDir()
+	content := "package main\n\nimport \"github.com/nyxstack/i18n\"\n\nvar (\n\t_ = i18n.S(\"Welcome\")\n\t_ = i18n.S(\"Dashboard\")\n)\n"
+	if err := os.WriteFile(filepath.Join(tempDir, "test.go"), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to create test Go file: %v", err)
+	}
+
+	localesDir := filepath.Join(tempDir, "locales")
+	if err := os.MkdirAll(localesDir, 0755); err != nil {
+		t.Fatalf("Failed to create locales dir: %v", err)
+	}
+	outputPath := writeTestCatalog(t, localesDir, "default.en.json", "en", map[string]string{
+		"welcome": "Welcome!",
+		"stale":   "Stale",
+	})
+	before, _ := os.ReadFile(outputPath)
+
+	changes, err := PlanTranslations(context.Background(), "en", tempDir, outputPath)
+	if err != nil {
+		t.Fatalf("PlanTranslations failed: %v", err)
+	}
+
+	if len(changes.Added) != 1 || changes.Added[0].Key != "dashboard" {
+		t.Errorf("Expected dashboard to be added, got %v", changes.Added)
+	}
+	if len(changes.Changed) != 1 || changes.Changed[0].Key != "welcome" || changes.Changed[0].New != "Welcome" {
+		t.Errorf("Expected welcome to be changed, got %v", changes.Changed)
+	}
+	if len(changes.Removed) != 1 || changes.Removed[0].Key != "stale" {
+		t.Errorf("Expected stale to be removed, got %v", changes.Removed)
+	}
+
+	after, _ := os.ReadFile(outputPath)
+	if string(before) != string(after) {
+		t.Error("Expected the catalog to be left untouched")
+	}
+
+	// A missing catalog counts as empty
+	changes, err = PlanTranslations(context.Background(), "fr", tempDir, filepath.Join(localesDir, "default.fr.json"))
+	if err != nil || len(changes.Added) != 2 {
+		t.Errorf("Expected 2 additions for a new catalog, got %v (%v)", changes, err)
+	}
+}
+
+func TestPlanTranslations_Obsolete(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "main.go"), `package main
+
+import "github.com/nyxstack/i18n"
+
+var _ = i18n.T("welcome")
+`)
+	outputPath := filepath.Join(dir, "locales", "default.en.json")
+	writeTestFile(t, outputPath, `{
+  "meta": {"lang": "en", "name": "default"},
+  "translations": {"stale": "Stale"},
+  "obsolete": {"welcome": "Welcome back"}
+}`)
+
+	changes, err := PlanTranslations(context.Background(), "en", dir, outputPath)
+	if err != nil {
+		t.Fatalf("PlanTranslations failed: %v", err)
+	}
+	if want := []ChangeEntry{{Key: "welcome", New: "Welcome back"}}; !reflect.DeepEqual(changes.Added, want) {
+		t.Errorf("Expected welcome to get its obsolete value back, got %v", changes.Added)
+	}
+
+	if err := GenerateTranslations("en", dir, outputPath); err != nil {
+		t.Fatalf("GenerateTranslations failed: %v", err)
+	}
+	tf, err := readTranslationFile(outputPath)
+	if err != nil {
+		t.Fatalf("Failed to read generated catalog: %v", err)
+	}
+	if got := tf.Translations["welcome"]; got != "Welcome back" {
+		t.Errorf("Expected the plan to match the written catalog, got %q", got)
+	}
+}
+
+func TestGenerateCatalogs(t *testing.T) {
+	tempDir := t.TempDir()
+	content := "package main\n\nimport \"github.com/nyxstack/i18n\"\n\nvar (\n\t_ = i18n.S(\"Welcome\")\n\t_ = i18n.S(\"Dashboard\")\n)\n"
+	if err := os.WriteFile(filepath.Join(tempDir, "test.go"), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to create test Go file: %v", err)
+	}
+
+	localesDir := filepath.Join(tempDir, "locales")
+	if err := os.MkdirAll(localesDir, 0755); err != nil {
+		t.Fatalf("Failed to create locales dir: %v", err)
+	}
+	writeTestCatalog(t, localesDir, "default.fr.json", "fr", map[string]string{
+		"welcome": "Bienvenue",
+		"stale":   "Périmé",
+	})
+
+	changes, err := PlanCatalogs(context.Background(), tempDir, localesDir, "en", "fr", "de")
+	if err != nil {
+		t.Fatalf("PlanCatalogs failed: %v", err)
+	}
+	if len(changes) != 3 || changes[0].Lang != "en" || changes[1].Lang != "fr" || changes[2].Lang != "de" {
+		t.Fatalf("Expected changes for en, fr and de in order, got %v", changes)
+	}
+	if len(changes[1].Added) != 1 || len(changes[1].Removed) != 1 || len(changes[1].Changed) != 0 {
+		t.Errorf("Expected fr to add dashboard and remove stale, got %+v", changes[1])
+	}
+
+	if err := GenerateCatalogs(context.Background(), tempDir, localesDir, "en", "fr", "de"); err != nil {
+		t.Fatalf("GenerateCatalogs failed: %v", err)
+	}
+
+	expected := map[string]map[string]string{
+		"en": {"welcome": "Welcome", "dashboard": "Dashboard"},
+		"fr": {"welcome": "Bienvenue", "dashboard": "Dashboard"},
+		"de": {"welcome": "Welcome", "dashboard": "Dashboard"},
+	}
+	for lang, want := range expected {
+		tf, err := readTranslationFile(filepath.Join(localesDir, "default."+lang+".json"))
+		if err != nil {
+			t.Fatalf("Failed to read %s catalog: %v", lang, err)
+		}
+		if tf.Meta.Lang != lang || len(tf.Translations) != len(want) {
+			t.Errorf("Unexpected %s catalog: %+v", lang, tf)
+		}
+		for key, value := range want {
+			if tf.Translations[key] != value {
+				t.Errorf("%s: expected %s = %q, got %q", lang, key, value, tf.Translations[key])
+			}
+		}
+	}
+
+	if err := GenerateCatalogs(context.Background(), tempDir, localesDir); err == nil {
+		t.Error("Expected error without locales")
+	}
+}
+
+func TestGenerateCatalogs_Comments(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "main.go"), `package main
+
+import "github.com/nyxstack/i18n"
+
+func main() {
+	// i18n: {0} is the name of the invited user,
+	// shown in a toast
+	_ = i18n.T("invite_sent", "Anne")
+	// Not for translators
+	_ = i18n.S("Dashboard")
+	_ = i18n.S("Settings")
+}
+`)
+	localesDir := filepath.Join(dir, "locales")
+	if err := GenerateCatalogs(context.Background(), dir, localesDir, "en", "fr"); err != nil {
+		t.Fatalf("GenerateCatalogs failed: %v", err)
+	}
+
+	want := map[string]string{"invite_sent": "{0} is the name of the invited user, shown in a toast"}
+	for _, lang := range []string{"en", "fr"} {
+		tf, err := readTranslationFile(filepath.Join(localesDir, "default."+lang+".json"))
+		if err != nil {
+			t.Fatalf("Failed to read %s catalog: %v", lang, err)
+		}
+		if !reflect.DeepEqual(tf.Comments, want) {
+			t.Errorf("%s: expected comments %v, got %v", lang, want, tf.Comments)
+		}
+	}
+}
+
+func TestGenerateCatalogs_KeepsMeta(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "main.go"), `package main
+
+import "github.com/nyxstack/i18n"
+
+var (
+	_ = i18n.T("welcome")
+	_ = i18n.TRandom("weekdays")
+)
+`)
+	localesDir := filepath.Join(dir, "locales")
+	if err := os.MkdirAll(localesDir, 0755); err != nil {
+		t.Fatalf("Failed to create locales dir: %v", err)
+	}
+	writeTestCatalog(t, localesDir, "default.en.json", "en", map[string]string{"welcome": "Welcome"})
+	arPath := filepath.Join(localesDir, "default.ar.json")
+	writeTestFile(t, arPath, `{
+  "meta": {"lang": "ar", "name": "default", "version": "3", "direction": "rtl"},
+  "translations": {
+    "welcome": "مرحبا",
+    "weekdays": ["الاثنين", "الثلاثاء"],
+    "stale": "قديم"
+  },
+  "schedule": {
+    "welcome": [{"value": "رمضان كريم", "valid_from": "2026-02-18", "valid_until": "2026-03-19"}],
+    "stale": [{"value": "قديم جدا", "valid_from": "2026-01-01"}]
+  }
+}`)
+
+	check := func(name string) {
+		t.Helper()
+		tf, err := readTranslationFile(arPath)
+		if err != nil {
+			t.Fatalf("%s: failed to read ar catalog: %v", name, err)
+		}
+		if tf.Meta.Version != "3" || tf.Meta.Direction != "rtl" || tf.Meta.Lang != "ar" || tf.Meta.SchemaVersion != SchemaVersion {
+			t.Errorf("%s: expected the meta kept, got %+v", name, tf.Meta)
+		}
+		if len(tf.Schedule) != 1 || len(tf.Schedule["welcome"]) != 1 || tf.Schedule["welcome"][0].Value != "رمضان كريم" {
+			t.Errorf("%s: expected the schedule of welcome kept, got %v", name, tf.Schedule)
+		}
+		if want := map[string][]string{"weekdays": {"الاثنين", "الثلاثاء"}}; !reflect.DeepEqual(tf.Lists, want) {
+			t.Errorf("%s: expected the weekdays list kept, got %v", name, tf.Lists)
+		}
+	}
+
+	if err := GenerateCatalogs(context.Background(), dir, localesDir, "en", "ar"); err != nil {
+		t.Fatalf("GenerateCatalogs failed: %v", err)
+	}
+	check("GenerateCatalogs")
+
+	if err := GenerateTranslations("ar", dir, arPath); err != nil {
+		t.Fatalf("GenerateTranslations failed: %v", err)
+	}
+	check("GenerateTranslations")
+}
+
+func TestGenerateTranslations_KeepsSourceValues(t *testing.T) {
+	tempDir := t.TempDir()
+	content := "package main\n\nimport \"github.com/nyxstack/i18n\"\n\nvar (\n\t_ = i18n.T(\"auth.login_title\")\n\t_ = i18n.P(\"item_count\", 2)\n)\n"
+	if err := os.WriteFile(filepath.Join(tempDir, "test.go"), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to create test Go file: %v", err)
+	}
+
+	localesDir := filepath.Join(tempDir, "locales")
+	if err := os.MkdirAll(localesDir, 0755); err != nil {
+		t.Fatalf("Failed to create locales dir: %v", err)
+	}
+	outputPath := writeTestCatalog(t, localesDir, "default.en.json", "en", map[string]string{
+		"auth.login_title": "Sign in",
+	})
+
+	if err := GenerateTranslations("en", tempDir, outputPath); err != nil {
+		t.Fatalf("GenerateTranslations failed: %v", err)
+	}
+
+	tf, err := readTranslationFile(outputPath)
+	if err != nil {
+		t.Fatalf("Failed to read generated catalog: %v", err)
+	}
+	if tf.Translations["auth.login_title"] != "Sign in" {
+		t.Errorf("Expected existing source value to be kept verbatim, got %v", tf.Translations)
+	}
+	if _, ok := tf.Translations["item_count"]; ok || len(tf.NeedsSource) != 1 || tf.NeedsSource[0] != "item_count" {
+		t.Errorf("Expected item_count to need source text, got %v / %v", tf.Translations, tf.NeedsSource)
+	}
+
+	// Target locales keep their translations of keys without source text
+	frPath := writeTestCatalog(t, localesDir, "default.fr.json", "fr", map[string]string{
+		"item_count": "{count, plural, one {# article} other {# articles}}",
+	})
+	if err := GenerateCatalogs(context.Background(), tempDir, localesDir, "en", "fr"); err != nil {
+		t.Fatalf("GenerateCatalogs failed: %v", err)
+	}
+	fr, err := readTranslationFile(frPath)
+	if err != nil {
+		t.Fatalf("Failed to read fr catalog: %v", err)
+	}
+	if fr.Translations["auth.login_title"] != "Sign in" || fr.Translations["item_count"] == "" || len(fr.NeedsSource) != 0 {
+		t.Errorf("Unexpected fr catalog: %v / %v", fr.Translations, fr.NeedsSource)
+	}
+}
+
+func TestGenerateTranslations_KeyFuncs(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "main.go"), `package main
+
+import "github.com/nyxstack/i18n"
+
+func check() error {
+	return i18n.Errorf("quota_exceeded", "Pro")
+}
+
+func terms() any {
+	return i18n.THTML("terms_html", i18n.AllowHTML)
+}
+
+var inbox = i18n.TRandom("empty_inbox")
+`)
+	localesDir := filepath.Join(dir, "locales")
+	if err := os.MkdirAll(localesDir, 0755); err != nil {
+		t.Fatalf("Failed to create locales dir: %v", err)
+	}
+	outputPath := filepath.Join(localesDir, "default.en.json")
+	writeTestFile(t, outputPath, `{
+  "meta": {"lang": "en", "name": "default"},
+  "translations": {
+    "quota_exceeded": "Plan {0} has no requests left",
+    "terms_html": "Read the <a href=\"/terms\">terms</a>",
+    "empty_inbox": ["Inbox zero!", "Nothing to see here"],
+    "unused": "Unused",
+    "unused_list": ["a", "b"]
+  }
+}`)
+
+	if err := GenerateTranslations("en", dir, outputPath); err != nil {
+		t.Fatalf("GenerateTranslations failed: %v", err)
+	}
+	tf, err := readTranslationFile(outputPath)
+	if err != nil {
+		t.Fatalf("Failed to read generated catalog: %v", err)
+	}
+	want := map[string]string{
+		"quota_exceeded": "Plan {0} has no requests left",
+		"terms_html":     "Read the <a href=\"/terms\">terms</a>",
+	}
+	if !reflect.DeepEqual(tf.Translations, want) {
+		t.Errorf("Expected the keys of the key functions kept, got %v", tf.Translations)
+	}
+	if lists := map[string][]string{"empty_inbox": {"Inbox zero!", "Nothing to see here"}}; !reflect.DeepEqual(tf.Lists, lists) {
+		t.Errorf("Expected the lists of the keys still used kept, got %v", tf.Lists)
+	}
+	if tf.NeedsSource != nil {
+		t.Errorf("Expected no keys without source text, got %v", tf.NeedsSource)
+	}
+}
+
+func TestGenerateTranslations_ValueAccessors(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "main.go"), `package main
+
+import "github.com/nyxstack/i18n"
+
+type Banner struct{ Title, Body string }
+
+func settings(locale string) {
+	_, _ = i18n.GetInt(locale, "items_per_page")
+	var banner Banner
+	_ = i18n.GetInto(locale, "empty_state", &banner)
+	_ = i18n.GetInto(locale, "promo", &banner)
+}
+`)
+	outputPath := filepath.Join(dir, "locales", "default.en.json")
+	writeTestFile(t, outputPath, `{
+  "meta": {"lang": "en", "name": "default"},
+  "translations": {
+    "items_per_page": 20,
+    "empty_state": {"title": "Nothing here yet", "tips": ["Import a file", "Invite your team"]},
+    "old_title": "Old title"
+  }
+}`)
+
+	if err := GenerateTranslations("en", dir, outputPath); err != nil {
+		t.Fatalf("GenerateTranslations failed: %v", err)
+	}
+	tf, err := readTranslationFile(outputPath)
+	if err != nil {
+		t.Fatalf("Failed to read generated catalog: %v", err)
+	}
+	want := map[string]string{"items_per_page": "20", "empty_state.title": "Nothing here yet"}
+	if !reflect.DeepEqual(tf.Translations, want) {
+		t.Errorf("Expected the values of the accessors kept, got %v", tf.Translations)
+	}
+	if lists := map[string][]string{"empty_state.tips": {"Import a file", "Invite your team"}}; !reflect.DeepEqual(tf.Lists, lists) {
+		t.Errorf("Expected the list fields kept, got %v", tf.Lists)
+	}
+	if want := []string{"promo"}; !reflect.DeepEqual(tf.NeedsSource, want) {
+		t.Errorf("Expected the object without fields to need source text, got %v", tf.NeedsSource)
+	}
+}
+
+func TestGenerateAll(t *testing.T) {
+	root := t.TempDir()
+	t.Chdir(root)
+	writeTestFile(t, "app.go", "package app\n\nimport \"github.com/nyxstack/i18n\"\n\nvar title = i18n.S(\"Dashboard\")\n")
+	writeTestFile(t, filepath.Join(DefaultFolder, "default.fr.json"), `{"meta": {"lang": "fr", "name": "default"}, "translations": {"dashboard": "Tableau de bord", "old": "Ancien"}}`)
+	writeTestFile(t, filepath.Join(DefaultFolder, "default.ja.json"), `{"meta": {"lang": "ja", "name": "default"}, "translations": {}}`)
+	writeTestFile(t, filepath.Join(DefaultFolder, "auth.de.json"), `{"meta": {"lang": "de", "name": "auth"}, "translations": {}}`)
+
+	locales, err := ExpandLocales(DefaultFolder, []string{"en", "ja"})
+	if err != nil {
+		t.Fatalf("ExpandLocales failed: %v", err)
+	}
+	if !reflect.DeepEqual(locales, []string{"en", "ja", "fr"}) {
+		t.Errorf("Expected the given locales, then the other default catalogs, got %v", locales)
+	}
+	if _, err := ExpandLocales(DefaultFolder, nil); err == nil {
+		t.Error("Expected a source language to be required")
+	}
+
+	if err := GenerateAll(".", []string{"en"}); err != nil {
+		t.Fatalf("GenerateAll failed: %v", err)
+	}
+	for lang, want := range map[string]map[string]string{
+		"en": {"dashboard": "Dashboard"},
+		"fr": {"dashboard": "Tableau de bord"},
+		"ja": {"dashboard": "Dashboard"},
+	} {
+		tf, err := readTranslationFile(filepath.Join(DefaultFolder, "default."+lang+".json"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(tf.Translations, want) {
+			t.Errorf("%s: expected %v, got %v", lang, want, tf.Translations)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(DefaultFolder, "default.de.json")); err == nil {
+		t.Error("Expected other dictionaries' catalogs not to create locales")
+	}
+}
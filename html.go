@@ -0,0 +1,206 @@
+package i18n
+
+import (
+	"fmt"
+	"html"
+	"html/template"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// EscapeMode decides how THTML treats markup in translations. Placeholder
+// values are escaped in every mode, unless they are template.HTML.
+type EscapeMode int
+
+const (
+	// EscapeHTML escapes the whole translation, so it renders as text
+	EscapeHTML EscapeMode = iota
+
+	// AllowHTML keeps the markup of translations as-is. Use it only for
+	// catalogs as trusted as the templates themselves.
+	AllowHTML
+
+	// SanitizeHTML keeps the formatting tags of translations (b, strong, i,
+	// em, u, s, small, sub, sup, code, span, p, br and a with an http,
+	// https, mailto or relative href) and escapes everything else
+	SanitizeHTML
+)
+
+// HTMLFunc returns a translation that is safe to insert into an HTML
+// template when called with a locale, see THTML
+type HTMLFunc func(locale string) template.HTML
+
+// SetEscapeMode sets how THTML treats markup in the translations of the
+// default bundle. The default is EscapeHTML; pass a mode among the
+// arguments of THTML to override it for one call.
+func SetEscapeMode(mode EscapeMode) {
+	defaultBundle.SetEscapeMode(mode)
+}
+
+// SetEscapeMode is like the package-level SetEscapeMode but applies to the bundle
+func (b *Bundle) SetEscapeMode(mode EscapeMode) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.escape = mode
+}
+
+// escapeMode returns the bundle's escape mode
+func (b *Bundle) escapeMode() EscapeMode {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.escape
+}
+
+// THTML is like T but returns template.HTML for html/template, which
+// inserts it without escaping it again. The translation is escaped,
+// sanitized or kept according to the escape mode (see SetEscapeMode); an
+// EscapeMode among args overrides it for this call. Placeholder values are
+// always escaped, unless they are template.HTML, and with SanitizeHTML they
+// are sanitized along with the translation.
+//
+// Example:
+//
+//	i18n.SetEscapeMode(i18n.SanitizeHTML)
+//	msg := i18n.THTML("welcome_html", user.Name) // "Welcome <b>{0}</b>!"
+//	tmpl.Execute(w, map[string]any{"Welcome": msg(locale)})
+func THTML(key string, args ...any) HTMLFunc {
+	return defaultBundle.THTML(key, args...)
+}
+
+// THTML is like the package-level THTML but uses the bundle's dictionaries
+func (b *Bundle) THTML(key string, args ...any) HTMLFunc {
+	mode := b.escapeMode()
+	for _, arg := range args {
+		if m, ok := arg.(EscapeMode); ok {
+			mode = m
+		}
+	}
+
+	fn := b.debugged(func(locale string) string {
+		text := key
+		if tr, _, ok := b.find(locale, key); ok && tr != "" {
+			text = tr
+		}
+		return mode.render(text, newPlaceholderArgs(escapeArgs(localizeArgs(locale, args))))
+	}, key)
+
+	return func(locale string) template.HTML {
+		return template.HTML(fn(locale))
+	}
+}
+
+// render escapes, sanitizes or keeps the markup of a translation and fills
+// in its escaped placeholder values. Sanitizing happens last, so links are
+// checked with the values in place.
+func (m EscapeMode) render(text string, values *placeholderArgs) string {
+	switch m {
+	case AllowHTML:
+		return values.replace(text)
+	case SanitizeHTML:
+		return sanitizeHTML(values.replace(text))
+	default:
+		return values.replace(html.EscapeString(text))
+	}
+}
+
+// escapeArgs escapes placeholder values, including those of Args, leaving
+// template.HTML values and options alone
+func escapeArgs(args []any) []any {
+	escaped := make([]any, len(args))
+	for i, arg := range args {
+		if a, ok := arg.(Args); ok {
+			values := make(Args, len(a))
+			for name, value := range a {
+				values[name] = escapeArg(value)
+			}
+			escaped[i] = values
+			continue
+		}
+		escaped[i] = escapeArg(arg)
+	}
+	return escaped
+}
+
+// escapeArg escapes one placeholder value
+func escapeArg(arg any) any {
+	switch a := arg.(type) {
+	case template.HTML:
+		return string(a)
+	case EscapeMode, StyleOption, RandomOption:
+		return arg
+	}
+	return html.EscapeString(fmt.Sprint(arg))
+}
+
+var (
+	// htmlTag matches an opening, closing or self-closing tag
+	htmlTag = regexp.MustCompile(`<(/?)([a-zA-Z][a-zA-Z0-9]*)((?:\s+[^>]*)?)/?>`)
+
+	// htmlAttr matches one attribute of a tag
+	htmlAttr = regexp.MustCompile(`([a-zA-Z_:][-a-zA-Z0-9_:.]*)\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s"'=<>` + "`" + `]+))`)
+
+	// safeHref matches the link targets SanitizeHTML keeps
+	safeHref = regexp.MustCompile(`(?i)^(?:https?:|mailto:|[^:]*$)`)
+)
+
+// sanitizeTags are the formatting tags SanitizeHTML keeps, with the
+// attributes they may keep
+var sanitizeTags = map[string][]string{
+	"a": {"href", "title"}, "b": nil, "br": nil, "code": nil, "em": nil, "i": nil,
+	"p": nil, "s": nil, "small": nil, "span": {"class"}, "strong": nil, "sub": nil,
+	"sup": nil, "u": nil,
+}
+
+// sanitizeHTML keeps the allowed tags of text and escapes the rest. The
+// content of script and style elements is dropped along with them.
+func sanitizeHTML(text string) string {
+	var out strings.Builder
+	for text != "" {
+		loc := htmlTag.FindStringSubmatchIndex(text)
+		if loc == nil {
+			out.WriteString(escapeText(text))
+			break
+		}
+		out.WriteString(escapeText(text[:loc[0]]))
+
+		closing := loc[3] > loc[2]
+		name := strings.ToLower(text[loc[4]:loc[5]])
+		attrs := text[loc[6]:loc[7]]
+		text = text[loc[1]:]
+
+		if (name == "script" || name == "style") && !closing {
+			end := strings.Index(strings.ToLower(text), "</"+name)
+			if end < 0 {
+				break
+			}
+			text = text[end:]
+			continue
+		}
+
+		allowed, ok := sanitizeTags[name]
+		if !ok {
+			continue
+		}
+		if closing {
+			out.WriteString("</" + name + ">")
+			continue
+		}
+		out.WriteString("<" + name)
+		for _, m := range htmlAttr.FindAllStringSubmatch(attrs, -1) {
+			attr := strings.ToLower(m[1])
+			value := html.UnescapeString(m[2] + m[3] + m[4])
+			if !slices.Contains(allowed, attr) || (attr == "href" && !safeHref.MatchString(strings.TrimSpace(value))) {
+				continue
+			}
+			out.WriteString(" " + attr + `="` + html.EscapeString(value) + `"`)
+		}
+		out.WriteString(">")
+	}
+	return out.String()
+}
+
+// escapeText escapes text between tags, keeping the entities it already has
+func escapeText(text string) string {
+	return html.EscapeString(html.UnescapeString(text))
+}
@@ -0,0 +1,79 @@
+package i18n
+
+import (
+	htmltemplate "html/template"
+	"strings"
+	"testing"
+)
+
+func newHTMLBundle() *Bundle {
+	b := NewBundle()
+	en := NewDictionary("en")
+	en.AddAll(map[string]string{
+		"welcome": "Welcome <b>{0}</b>!",
+		"terms":   `Read the <a href="https://example.com/terms" onclick="track()">terms</a><script>alert(1)</script>`,
+		"evil":    `<a href="javascript:alert(1)">click</a> & <img src=x onerror=alert(1)>`,
+		"link":    `<a href="{url}">{label}</a>`,
+	})
+	b.Register(en)
+	return b
+}
+
+func TestTHTML(t *testing.T) {
+	b := newHTMLBundle()
+
+	tests := []struct {
+		name string
+		mode EscapeMode
+		key  string
+		args []any
+		want string
+	}{
+		{"escape", EscapeHTML, "welcome", []any{"<i>Ann</i>"}, "Welcome &lt;b&gt;&lt;i&gt;Ann&lt;/i&gt;&lt;/b&gt;!"},
+		{"allow", AllowHTML, "welcome", []any{"<i>Ann</i>"}, "Welcome <b>&lt;i&gt;Ann&lt;/i&gt;</b>!"},
+		{"allow trusted arg", AllowHTML, "welcome", []any{htmltemplate.HTML("<i>Ann</i>")}, "Welcome <b><i>Ann</i></b>!"},
+		{"sanitize", SanitizeHTML, "terms", nil, `Read the <a href="https://example.com/terms">terms</a>`},
+		{"sanitize unsafe", SanitizeHTML, "evil", nil, `<a>click</a> &amp; `},
+		{"sanitize args", SanitizeHTML, "link", []any{Args{"url": "javascript:alert(1)", "label": "<b>x</b>"}}, `<a>&lt;b&gt;x&lt;/b&gt;</a>`},
+		{"sanitize safe args", SanitizeHTML, "link", []any{Args{"url": "/help?q=a&b", "label": "Help"}}, `<a href="/help?q=a&amp;b">Help</a>`},
+		{"missing key", EscapeHTML, "<b>missing</b>", nil, "&lt;b&gt;missing&lt;/b&gt;"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b.SetEscapeMode(tt.mode)
+			if got := string(b.THTML(tt.key, tt.args...)("en")); got != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestTHTML_Override(t *testing.T) {
+	b := newHTMLBundle()
+	if got := string(b.THTML("welcome", "Ann", AllowHTML)("en")); got != "Welcome <b>Ann</b>!" {
+		t.Errorf("Expected the per-call mode to apply, got %q", got)
+	}
+	if got := string(b.THTML("welcome", "Ann")("en")); got != "Welcome &lt;b&gt;Ann&lt;/b&gt;!" {
+		t.Errorf("Expected the bundle to keep escaping, got %q", got)
+	}
+
+	b.SetEscapeMode(AllowHTML)
+	b.Reset()
+	if b.escapeMode() != EscapeHTML {
+		t.Error("Expected Reset to restore EscapeHTML")
+	}
+}
+
+func TestTHTML_Template(t *testing.T) {
+	b := newHTMLBundle()
+	b.SetEscapeMode(SanitizeHTML)
+
+	tmpl := htmltemplate.Must(htmltemplate.New("page").Funcs(b.TemplateFuncs("en")).Parse(`<p>{{ thtml "welcome" .Name }}</p>`))
+	var out strings.Builder
+	if err := tmpl.Execute(&out, map[string]string{"Name": "<Ann>"}); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if got := out.String(); got != "<p>Welcome <b>&lt;Ann&gt;</b>!</p>" {
+		t.Errorf("Expected the sanitized translation to render once-escaped, got %q", got)
+	}
+}
@@ -0,0 +1,97 @@
+package i18n
+
+import "net/http"
+
+// MiddlewareConfig configures locale detection in NewMiddleware
+type MiddlewareConfig struct {
+	// QueryParam is the query parameter that selects a locale ("lang" if empty)
+	QueryParam string
+
+	// CookieName is the cookie that stores the preferred locale ("lang" if empty)
+	CookieName string
+
+	// Bundle provides the available locales and the default language
+	// (the default bundle if nil)
+	Bundle *Bundle
+
+	// Cache optionally memoizes the Accept-Language negotiation per client
+	Cache *LocaleCache
+
+	// ClientKey identifies the client for Cache (the remote address if nil)
+	ClientKey func(r *http.Request) string
+}
+
+// Middleware detects the request locale with the default configuration and
+// stores it in the request context (see FromContext).
+//
+// Example:
+//
+//	mux := http.NewServeMux()
+//	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+//		fmt.Fprintln(w, i18n.SCtx(r.Context(), "Dashboard"))
+//	})
+//	http.ListenAndServe(":8080", i18n.Middleware(mux))
+func Middleware(next http.Handler) http.Handler {
+	return NewMiddleware(MiddlewareConfig{})(next)
+}
+
+// NewMiddleware returns a middleware that resolves the request locale from,
+// in order, the query parameter, the cookie and the Accept-Language header.
+// Each candidate is negotiated against the registered dictionaries and the
+// languages the bundle loads lazily (see LoadLazily); the default language
+// is used when nothing matches.
+func NewMiddleware(config MiddlewareConfig) func(http.Handler) http.Handler {
+	if config.QueryParam == "" {
+		config.QueryParam = "lang"
+	}
+	if config.CookieName == "" {
+		config.CookieName = "lang"
+	}
+	if config.Bundle == nil {
+		config.Bundle = defaultBundle
+	}
+	if config.ClientKey == nil {
+		config.ClientKey = func(r *http.Request) string { return r.RemoteAddr }
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			locale := config.detect(r)
+			next.ServeHTTP(w, r.WithContext(WithLocale(r.Context(), locale)))
+		})
+	}
+}
+
+// detect resolves the locale of a request
+func (c MiddlewareConfig) detect(r *http.Request) string {
+	available := c.Bundle.servedLanguages()
+
+	if lang := r.URL.Query().Get(c.QueryParam); lang != "" {
+		if locale := lookupLocale([]string{lang}, available); locale != "" {
+			return locale
+		}
+	}
+
+	if cookie, err := r.Cookie(c.CookieName); err == nil && cookie.Value != "" {
+		if locale := lookupLocale([]string{cookie.Value}, available); locale != "" {
+			return locale
+		}
+	}
+
+	header := r.Header.Get("Accept-Language")
+	if header != "" {
+		match := func() string { return MatchLocale(header, available) }
+
+		var locale string
+		if c.Cache != nil {
+			locale = c.Cache.Resolve(c.ClientKey(r)+"\x00"+header, match)
+		} else {
+			locale = match()
+		}
+		if locale != "" {
+			return locale
+		}
+	}
+
+	return c.Bundle.DefaultLanguage()
+}
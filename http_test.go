@@ -0,0 +1,169 @@
+package i18n
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func newTestBundle(langs ...string) *Bundle {
+	b := NewBundle()
+	for _, lang := range langs {
+		b.Register(NewDictionary(lang))
+	}
+	return b
+}
+
+// serveLocale runs a request through the middleware and returns the detected locale
+func serveLocale(t *testing.T, mw func(http.Handler) http.Handler, r *http.Request) string {
+	t.Helper()
+
+	var locale string
+	handler := mw(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		locale = FromContext(r.Context())
+	}))
+	handler.ServeHTTP(httptest.NewRecorder(), r)
+	return locale
+}
+
+func TestMiddleware_Detection(t *testing.T) {
+	mw := NewMiddleware(MiddlewareConfig{Bundle: newTestBundle("en", "fr", "de")})
+
+	tests := []struct {
+		name     string
+		url      string
+		cookie   string
+		header   string
+		expected string
+	}{
+		{"default", "/", "", "", "en"},
+		{"accept-language", "/", "", "de-DE,de;q=0.9,en;q=0.5", "de"},
+		{"quality order", "/", "", "en;q=0.3, fr;q=0.8", "fr"},
+		{"region fallback", "/", "", "fr-CA", "fr"},
+		{"unavailable", "/", "", "ja, zh;q=0.9", "en"},
+		{"cookie beats header", "/", "fr", "de", "fr"},
+		{"query beats cookie", "/?lang=de", "fr", "en", "de"},
+		{"invalid query ignored", "/?lang=xx", "fr", "", "fr"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			if tt.cookie != "" {
+				r.AddCookie(&http.Cookie{Name: "lang", Value: tt.cookie})
+			}
+			if tt.header != "" {
+				r.Header.Set("Accept-Language", tt.header)
+			}
+
+			if locale := serveLocale(t, mw, r); locale != tt.expected {
+				t.Errorf("Expected locale '%s', got '%s'", tt.expected, locale)
+			}
+		})
+	}
+}
+
+func TestMiddleware_LoadLazily(t *testing.T) {
+	dir := t.TempDir()
+	writeTestCatalog(t, dir, "default.en.json", "en", map[string]string{"title": "Dashboard"})
+	writeTestCatalog(t, dir, "default.de.json", "de", map[string]string{"title": "Übersicht"})
+	writeTestCatalog(t, dir, "default.fr.json", "fr", map[string]string{"title": "Tableau de bord"})
+
+	b := NewBundle()
+	if err := b.LoadLazily(dir, nil); err != nil {
+		t.Fatalf("LoadLazily failed: %v", err)
+	}
+	b.GetDictionary("en") // the default language, loaded up front
+	mw := NewMiddleware(MiddlewareConfig{Bundle: b})
+
+	tests := []struct {
+		name     string
+		url      string
+		header   string
+		expected string
+	}{
+		{"accept-language", "/", "de-DE,de;q=0.9", "de"},
+		{"query", "/?lang=fr", "", "fr"},
+		{"unavailable", "/", "ja", "en"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			if tt.header != "" {
+				r.Header.Set("Accept-Language", tt.header)
+			}
+			if locale := serveLocale(t, mw, r); locale != tt.expected {
+				t.Errorf("Expected locale '%s', got '%s'", tt.expected, locale)
+			}
+		})
+	}
+
+	if got := b.T("title")("de"); got != "Übersicht" {
+		t.Errorf("Expected the negotiated language to load, got %q", got)
+	}
+}
+
+func TestMiddleware_CustomNames(t *testing.T) {
+	mw := NewMiddleware(MiddlewareConfig{
+		Bundle:     newTestBundle("en", "fr", "de"),
+		QueryParam: "locale",
+		CookieName: "ui_lang",
+	})
+
+	r := httptest.NewRequest(http.MethodGet, "/?lang=de&locale=fr", nil)
+	if locale := serveLocale(t, mw, r); locale != "fr" {
+		t.Errorf("Expected 'fr' from custom query param, got '%s'", locale)
+	}
+
+	r = httptest.NewRequest(http.MethodGet, "/", nil)
+	r.AddCookie(&http.Cookie{Name: "ui_lang", Value: "de"})
+	if locale := serveLocale(t, mw, r); locale != "de" {
+		t.Errorf("Expected 'de' from custom cookie, got '%s'", locale)
+	}
+}
+
+func TestMiddleware_DefaultBundle(t *testing.T) {
+	setupTestDictionaries()
+	defer func() {
+		defaultBundle.mu.Lock()
+		defaultBundle.dictionaries = make(map[string]*Dictionary)
+		defaultBundle.mu.Unlock()
+	}()
+
+	var body string
+	handler := Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body = SCtx(r.Context(), "Dashboard")
+	}))
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Accept-Language", "fr-FR")
+	handler.ServeHTTP(httptest.NewRecorder(), r)
+
+	if body != "Tableau de bord" {
+		t.Errorf("Expected 'Tableau de bord', got '%s'", body)
+	}
+}
+
+func TestMiddleware_Cache(t *testing.T) {
+	cache := NewLocaleCache(time.Minute, 0)
+	mw := NewMiddleware(MiddlewareConfig{Bundle: newTestBundle("en", "fr"), Cache: cache})
+
+	for i := 0; i < 3; i++ {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set("Accept-Language", "fr")
+		if locale := serveLocale(t, mw, r); locale != "fr" {
+			t.Errorf("Expected 'fr', got '%s'", locale)
+		}
+	}
+	if cache.Len() != 1 {
+		t.Errorf("Expected 1 cached client, got %d", cache.Len())
+	}
+
+	// A different header from the same client is negotiated again
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Accept-Language", "en")
+	if locale := serveLocale(t, mw, r); locale != "en" {
+		t.Errorf("Expected 'en', got '%s'", locale)
+	}
+}
@@ -0,0 +1,109 @@
+package i18n
+
+import (
+	"slices"
+	"sort"
+	"strings"
+)
+
+// KeysWithPrefix returns the keys that start with prefix, sorted. It uses
+// a sorted index of the keys, built on the first query and kept up to date
+// by Add and Remove, so it costs a binary search plus the keys returned
+// rather than a scan of the whole dictionary. The index is rebuilt when the
+// number of keys changes; edit Translations directly only before querying.
+//
+// Example:
+//
+//	results := dict.KeysWithPrefix("billing.invoice.") // admin search as the user types
+func (d *Dictionary) KeysWithPrefix(prefix string) []string {
+	index := d.keyIndex()
+	start := sort.SearchStrings(index, prefix)
+	end := start
+	for end < len(index) && strings.HasPrefix(index[end], prefix) {
+		end++
+	}
+	return slices.Clone(index[start:end])
+}
+
+// Namespaces returns the distinct first segments of the dotted keys, such
+// as "auth" for "auth.login-title", sorted. These are the names of the
+// dictionaries merged into d (see NS) as well as of nested key groups.
+func (d *Dictionary) Namespaces() []string {
+	index := d.keyIndex()
+	var names []string
+	for i := 0; i < len(index); {
+		name, _, ok := strings.Cut(index[i], KeySeparator)
+		if !ok {
+			i++
+			continue
+		}
+		names = append(names, name)
+
+		// Skip every other key of the namespace; "\xff" sorts after any UTF-8
+		i += sort.SearchStrings(index[i:], name+KeySeparator+"\xff")
+	}
+	return names
+}
+
+// Keys returns the keys of the namespace in lang's dictionary, without the
+// namespace prefix, sorted. It returns nil if lang has no dictionary.
+func (n Namespace) Keys(lang string) []string {
+	dict := n.bundle.GetDictionary(lang)
+	if dict == nil {
+		return nil
+	}
+	prefix := n.Key("")
+	keys := dict.KeysWithPrefix(prefix)
+	for i, key := range keys {
+		keys[i] = key[len(prefix):]
+	}
+	return keys
+}
+
+// keyIndex returns the sorted keys of the dictionary, building the index if
+// needed. The slice is never modified afterwards, so callers may keep it.
+func (d *Dictionary) keyIndex() []string {
+	d.mu.RLock()
+	index := d.index
+	fresh := index != nil && len(index) == len(d.Translations)
+	d.mu.RUnlock()
+	if fresh {
+		return index
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.index == nil || len(d.index) != len(d.Translations) {
+		d.index = sortedKeys(d.Translations)
+	}
+	return d.index
+}
+
+// indexAdd inserts a new key into the index, if built. The caller holds
+// d.mu for writing. The index is copied, as queries may still hold it.
+func (d *Dictionary) indexAdd(key string) {
+	if d.index == nil || len(d.index) != len(d.Translations) {
+		d.index = nil
+		return
+	}
+	i, _ := slices.BinarySearch(d.index, key)
+	index := make([]string, 0, len(d.index)+1)
+	index = append(index, d.index[:i]...)
+	index = append(index, key)
+	d.index = append(index, d.index[i:]...)
+}
+
+// indexRemove deletes a key from the index, if built. The caller holds
+// d.mu for writing.
+func (d *Dictionary) indexRemove(key string) {
+	if d.index == nil || len(d.index) != len(d.Translations) {
+		d.index = nil
+		return
+	}
+	i, found := slices.BinarySearch(d.index, key)
+	if !found {
+		d.index = nil
+		return
+	}
+	d.index = slices.Delete(slices.Clone(d.index), i, i+1)
+}
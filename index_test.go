@@ -0,0 +1,105 @@
+package i18n
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestDictionaryKeysWithPrefix(t *testing.T) {
+	dict := NewDictionary("en")
+	dict.AddAll(map[string]string{
+		"auth.login.title":  "Sign in",
+		"auth.login.submit": "Continue",
+		"auth.logout":       "Sign out",
+		"authors":           "Authors",
+		"billing.invoice":   "Invoice",
+		"title":             "Dashboard",
+	})
+
+	if got, want := dict.KeysWithPrefix("auth.login."), []string{"auth.login.submit", "auth.login.title"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("KeysWithPrefix(auth.login.) = %v, want %v", got, want)
+	}
+	if got := dict.KeysWithPrefix("auth"); len(got) != 4 {
+		t.Errorf("Expected 4 keys starting with auth, got %v", got)
+	}
+	if got := dict.KeysWithPrefix("zzz"); len(got) != 0 {
+		t.Errorf("Expected no keys, got %v", got)
+	}
+
+	// The index follows Add and Remove, and results are not affected later
+	before := dict.KeysWithPrefix("auth.")
+	dict.Add("auth.login.help", "Forgot your password?")
+	dict.Add("auth.login.help", "Need help?") // existing key
+	dict.Remove("auth.logout")
+	dict.Remove("missing")
+	if got, want := dict.KeysWithPrefix("auth."), []string{"auth.login.help", "auth.login.submit", "auth.login.title"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("KeysWithPrefix(auth.) after changes = %v, want %v", got, want)
+	}
+	if want := []string{"auth.login.submit", "auth.login.title", "auth.logout"}; !reflect.DeepEqual(before, want) {
+		t.Errorf("Expected earlier results to be left alone, got %v", before)
+	}
+
+	if got, want := dict.Namespaces(), []string{"auth", "billing"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Namespaces() = %v, want %v", got, want)
+	}
+
+	dict.Clear()
+	if got := dict.KeysWithPrefix(""); len(got) != 0 {
+		t.Errorf("Expected no keys after Clear, got %v", got)
+	}
+}
+
+func TestNamespaceKeys(t *testing.T) {
+	b := NewBundle()
+	dict := NewDictionary("en")
+	dict.AddAll(map[string]string{"auth.login-title": "Sign in", "auth.logout": "Sign out", "title": "Dashboard"})
+	b.Register(dict)
+
+	if got, want := b.NS("auth").Keys("en"), []string{"login-title", "logout"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Keys(en) = %v, want %v", got, want)
+	}
+	if got := b.NS("auth").Keys("fr"); got != nil {
+		t.Errorf("Expected nil without a dictionary, got %v", got)
+	}
+}
+
+func TestDictionaryKeysWithPrefix_Concurrent(t *testing.T) {
+	dict := NewDictionary("en")
+	var wg sync.WaitGroup
+	for w := 0; w < 4; w++ {
+		wg.Add(2)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				dict.Add(fmt.Sprintf("ns%d.key%d", w, i), "value")
+			}
+		}(w)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				dict.KeysWithPrefix(fmt.Sprintf("ns%d.", w))
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	if got := dict.KeysWithPrefix("ns2."); len(got) != 100 {
+		t.Errorf("Expected 100 keys, got %d", len(got))
+	}
+}
+
+func BenchmarkKeysWithPrefix(b *testing.B) {
+	dict := NewDictionary("en")
+	translations := make(map[string]string, 100000)
+	for i := 0; i < 100000; i++ {
+		translations[fmt.Sprintf("ns%d.key%d", i%100, i)] = "value"
+	}
+	dict.AddAll(translations)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		dict.KeysWithPrefix("ns42.")
+	}
+}
@@ -0,0 +1,90 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/nyxstack/i18n"
+)
+
+// budgetFlag collects repeated -locale lang=size flags into a size budget
+type budgetFlag i18n.SizeBudget
+
+func (b budgetFlag) String() string { return "" }
+
+func (b budgetFlag) Set(value string) error {
+	lang, size, ok := strings.Cut(value, "=")
+	if !ok {
+		return fmt.Errorf("expected lang=size, got '%s'", value)
+	}
+	n, err := i18n.ParseSize(size)
+	if err != nil {
+		return err
+	}
+	b[lang] = n
+	return nil
+}
+
+// runBudget fails (or warns) when a catalog exceeds its byte budget
+func runBudget(args []string) int {
+	budget := i18n.SizeBudget{}
+	fs := newFlagSet("budget")
+	max := fs.String("max", "", "byte budget for every locale (e.g. 64KB)")
+	fs.Var(budgetFlag(budget), "locale", "per-locale budget as lang=size (repeatable)")
+	warn := fs.Bool("warn", false, "report violations without failing")
+	r := newReport(fs)
+	if code, ok := r.parse(fs, args); !ok {
+		return code
+	}
+
+	if *max != "" {
+		n, err := i18n.ParseSize(*max)
+		if err != nil {
+			return r.fail(err)
+		}
+		budget[i18n.AllLocales] = n
+	}
+
+	dir := r.dir(fs)
+
+	violations, err := i18n.CheckBudget(dir, budget)
+	if err != nil {
+		return r.fail(err)
+	}
+
+	result := map[string]any{"violations": violations}
+	if len(violations) == 0 {
+		r.printf("✅ All catalogs are within budget\n")
+		return r.done(exitOK, result)
+	}
+
+	prefix := "❌"
+	if *warn {
+		prefix = "⚠️ "
+	}
+	for _, v := range violations {
+		switch {
+		case r.json():
+		case r.annotations():
+			level := i18n.AnnotationError
+			if *warn {
+				level = i18n.AnnotationWarning
+			}
+			fmt.Println(i18n.Annotation{
+				Level:   level,
+				File:    filepath.Join(dir, v.File),
+				Title:   "Catalog size budget",
+				Message: v.String(),
+			})
+		default:
+			fmt.Fprintf(os.Stderr, "%s %s\n", prefix, v)
+		}
+	}
+
+	if *warn {
+		return r.done(exitOK, result)
+	}
+	return r.done(exitValidation, result)
+}
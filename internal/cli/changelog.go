@@ -0,0 +1,96 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/nyxstack/i18n"
+)
+
+// runChangelog prints the translation changes between two catalog snapshots
+func runChangelog(args []string) int {
+	fs := newFlagSet("changelog")
+	from := fs.String("from", "", "snapshot to compare from: git ref, directory or file (required)")
+	to := fs.String("to", "", "snapshot to compare to: git ref, directory or file (default: working tree)")
+	r := newReport(fs)
+	if code, ok := r.parse(fs, args); !ok {
+		return code
+	}
+
+	if *from == "" {
+		return r.fail(fmt.Errorf("usage: %s changelog --from <ref|path> [--to <ref|path>] [locales_dir]", prog))
+	}
+
+	dir := r.dir(fs)
+
+	fromCatalogs, err := readSnapshot(*from, dir)
+	if err != nil {
+		return r.fail(err)
+	}
+
+	toSpec := *to
+	if toSpec == "" {
+		toSpec = dir
+	}
+	toCatalogs, err := readSnapshot(toSpec, dir)
+	if err != nil {
+		return r.fail(err)
+	}
+
+	changes := i18n.CompareCatalogs(fromCatalogs, toCatalogs)
+	if !r.json() {
+		if err := i18n.WriteChangelog(os.Stdout, changes); err != nil {
+			return r.fail(err)
+		}
+	}
+	return r.done(exitOK, map[string]any{"changes": changes})
+}
+
+// readSnapshot reads catalogs from a path if it exists, otherwise from the
+// locales directory as it was at the given git ref
+func readSnapshot(spec, dir string) (map[string]map[string]string, error) {
+	if _, err := os.Stat(spec); err == nil {
+		return i18n.ReadCatalogs(spec)
+	}
+
+	tmp, err := os.MkdirTemp("", "i18n-changelog-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmp)
+
+	if err := checkoutCatalogs(spec, dir, tmp); err != nil {
+		return nil, err
+	}
+	return i18n.ReadCatalogs(tmp)
+}
+
+// checkoutCatalogs copies the JSON catalogs of dir at a git ref into dest
+func checkoutCatalogs(ref, dir, dest string) error {
+	prefix := path.Clean(filepath.ToSlash(dir)) + "/"
+	out, err := exec.Command("git", "ls-tree", "--name-only", ref, prefix).Output()
+	if err != nil {
+		return fmt.Errorf("failed to list %s at %s: %w", dir, ref, err)
+	}
+
+	for _, name := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		if !strings.HasSuffix(name, ".json") {
+			continue
+		}
+
+		// git show resolves paths relative to the repository root unless prefixed with ./
+		content, err := exec.Command("git", "show", ref+":./"+name).Output()
+		if err != nil {
+			return fmt.Errorf("failed to read %s at %s: %w", name, ref, err)
+		}
+
+		if err := os.WriteFile(filepath.Join(dest, path.Base(name)), content, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+
+	"github.com/nyxstack/i18n"
+)
+
+// runCheck compares the keys of the code with those of the catalogs, for
+// the same arguments as extraction, and exits with exitValidation on drift.
+// With -all, every default catalog of the output directory is checked too.
+func runCheck(r *report, args []string, list string, all bool) int {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	var diffs []i18n.TranslationDiff
+	switch {
+	case len(args) == 0 && list == "" && r.config != nil:
+		if len(r.config.Locales) == 0 {
+			return r.fail(errors.New("i18n.yaml lists no locales"))
+		}
+		cfg := *r.config
+		if all {
+			locales, err := i18n.ExpandLocales(cfg.Dir(), cfg.Locales)
+			if err != nil {
+				return r.fail(err)
+			}
+			cfg.Locales = locales
+		}
+		var err error
+		if diffs, err = cfg.DiffCatalogs(ctx); err != nil {
+			return r.fail(err)
+		}
+
+	case all || list != "" || (len(args) >= 2 && strings.Contains(args[1], ",")):
+		sourceDir, dir, locales, ok := localeArgs(args, list)
+		if !ok {
+			usage()
+			return exitValidation
+		}
+		if dir == "" {
+			dir = i18n.DefaultFolder
+		}
+		if all {
+			var err error
+			if locales, err = i18n.ExpandLocales(dir, locales); err != nil {
+				return r.fail(err)
+			}
+		}
+		for _, locale := range locales {
+			path := filepath.Join(dir, fmt.Sprintf("%s.%s.json", i18n.DefaultDictionary, locale))
+			diff, err := i18n.DiffTranslationsContext(ctx, sourceDir, path)
+			if err != nil {
+				return r.fail(err)
+			}
+			diffs = append(diffs, diff)
+		}
+
+	case len(args) >= 2:
+		var outputPath string
+		if len(args) > 2 {
+			outputPath = args[2]
+		}
+		diff, err := i18n.DiffTranslationsContext(ctx, args[0], i18n.CatalogPath(args[1], outputPath))
+		if err != nil {
+			return r.fail(err)
+		}
+		diffs = append(diffs, diff)
+
+	default:
+		usage()
+		return exitValidation
+	}
+
+	code := exitOK
+	for _, d := range diffs {
+		if d.Empty() {
+			r.printf("✅ %s matches the code\n", d.Path)
+			continue
+		}
+		code = exitValidation
+		for _, problem := range []struct {
+			keys    []string
+			title   string
+			message string
+		}{
+			{d.Missing, "Missing translation", "%s is used in the code but missing from the catalog"},
+			{d.Unused, "Unused translation", "%s is no longer used in the code"},
+		} {
+			for _, key := range problem.keys {
+				switch {
+				case r.json():
+				case r.annotations():
+					fmt.Println(i18n.Annotation{
+						Level:   i18n.AnnotationError,
+						File:    d.Path,
+						Title:   problem.title,
+						Message: fmt.Sprintf(problem.message, key),
+					})
+				default:
+					fmt.Fprintf(os.Stderr, "❌ %s: "+problem.message+"\n", d.Path, key)
+				}
+			}
+		}
+		if !r.json() {
+			fmt.Fprintf(os.Stderr, "%s: %d missing, %d unused key(s); run %s extract to update it\n", d.Path, len(d.Missing), len(d.Unused), prog)
+		}
+	}
+	return r.done(code, map[string]any{"diffs": diffs})
+}
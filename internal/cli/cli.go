@@ -0,0 +1,425 @@
+// Package cli implements the i18n command line tool, installed as i18n and,
+// for existing scripts, as extract-i18n
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+
+	"github.com/nyxstack/i18n"
+)
+
+// prog is the name the tool was run as, used in its messages
+var prog = "i18n"
+
+// commands maps subcommand names to their handlers. Each handler receives the
+// remaining arguments and returns the process exit code.
+var commands map[string]func(args []string) int
+
+func init() {
+	commands = map[string]func(args []string) int{
+		"extract":           runExtract,
+		"merge":             runMerge,
+		"lock":              runLock,
+		"verify":            runVerify,
+		"changelog":         runChangelog,
+		"budget":            runBudget,
+		"enum":              runEnum,
+		"csv":               runCSV,
+		"layout":            runLayout,
+		"workspace":         runWorkspace,
+		"prune":             runPrune,
+		"validate":          runValidate,
+		"lint":              runLint,
+		"stats":             runStats,
+		"convert":           runConvert,
+		"machine-translate": runMachineTranslate,
+		"gen-go":            runGenGo,
+		"keys":              runKeys,
+		"help":              runHelp,
+	}
+}
+
+// Run runs the command named by args[0] with the rest of args, as the tool
+// named name, and returns the process exit code. Arguments that do not
+// start with a command are those of extract, as before commands existed.
+func Run(name string, args []string) int {
+	prog = name
+	if len(args) > 0 {
+		if run, ok := commands[args[0]]; ok {
+			return run(args[1:])
+		}
+	}
+	return runExtract(args)
+}
+
+// runHelp prints the usage
+func runHelp([]string) int {
+	usage()
+	return exitOK
+}
+
+func usage() {
+	p := func(format string, args ...any) { fmt.Printf(format+"\n", args...) }
+	p("Usage: %s <command> [flags] [args]", prog)
+	p("")
+	p("Commands:")
+	p("  extract [-src dir] [-locales en,fr] [-o path] [-dry-run|-check] [-all] [-keep-removed]")
+	p("                                     Extract the strings of the code into the catalogs (the default command)")
+	p("  merge [-keep-existing] [-dry-run] [-o locales_dir] file.json...")
+	p("                                     Merge translated catalogs into the default catalogs")
+	p("  machine-translate -to fr[,de] [-from en] [-provider deepl|google|openai] [-only-missing] [-dry-run] [locales_dir]")
+	p("                                     Pre-fill missing translations with a translation service")
+	p("  prune [-keep pattern] [-remove] [source_dir] [locales_dir]")
+	p("                                     List (or -remove) catalog keys the code no longer uses")
+	p("  validate [-source lang] [-format f] [locales_dir]")
+	p("                                     Report every invalid catalog and placeholder mismatch")
+	p("  lint [-source lang] [-format f] [locales_dir]")
+	p("                                     Flag placeholder mismatches, plurals without other and stray #")
+	p("  stats [-source lang] [-fuzzy] [-format f] [locales_dir]")
+	p("                                     Print the coverage and value statistics of every locale")
+	p("  lock [-o file] [locales_dir]       Write a lockfile of catalog content hashes")
+	p("  verify [-lock file] [-format f] [locales_dir]")
+	p("                                     Check catalogs against the lockfile")
+	p("  changelog --from <ref|path> [--to <ref|path>] [locales_dir]")
+	p("                                     Summarize translation changes between snapshots")
+	p("  budget [-max size] [-locale lang=size] [-warn] [-format f] [locales_dir]")
+	p("                                     Check served catalog sizes against a byte budget")
+	p("  csv export [-o file] [-tsv] [-locales en,fr] [locales_dir]")
+	p("                                     Export catalogs as a spreadsheet (key, en, fr, ... columns)")
+	p("  csv import -i file [-tsv] [locales_dir]")
+	p("                                     Merge a reviewed spreadsheet back into the catalogs")
+	p("  convert [-lang code] <in> <out>    Convert a catalog between .json, .yaml, .po, .xliff, .csv and .tsv")
+	p("  layout -nested|-flat [locales_dir]")
+	p("                                     Rewrite catalogs with nested objects or flat dotted keys")
+	p("  workspace [-locales en,fr] [-o dir] [-dry-run] [root]")
+	p("                                     Extract every Go module (go.work aware) into its own namespace")
+	p("  gen-go [-catalog file] [-package name] [-o file] [locales_dir]")
+	p("                                     Generate a typed accessor function for every catalog key")
+	p("  keys [-catalog file] [-package name] [-output file] [-prefix Key] [-group] [locales_dir]")
+	p("                                     Generate a string constant for every catalog key")
+	p("  enum -type T [-o file] [-catalog file] [-dry-run] [package_dir]")
+	p("                                     Generate T.LocalizedString and its catalog keys")
+	p("  help                               Print this message")
+	p("")
+	p("Extract flags:")
+	p("  -src:      Directory to scan for Go, template (.html, .tmpl, .gotmpl) and .proto files")
+	p("  -locales:  Comma-separated language codes (e.g. 'en,fr,es'), the source language first")
+	p("  -o:        Output catalog, or directory for several locales (default: %s/default.<locale>.json)", i18n.DefaultFolder)
+	p("  -dry-run:  Print the keys that would be added, changed or removed")
+	p("  -all:      Also update every default.*.json catalog already in the output directory;")
+	p("             the locales given (at least the source language) come first")
+	p("  -check:    Report keys the code uses that a catalog lacks, and keys it no longer uses,")
+	p("             without writing anything; exits 1 on any drift")
+	p("  -keep-removed: Move keys no longer used to each catalog's \"obsolete\" section instead of")
+	p("             deleting them (existing translations are always kept)")
+	p("  Without flags or arguments, extract reads i18n.yaml; the positional form")
+	p("  %s extract <source_dir> <locale[,locale...]> [output_path] is still accepted.", prog)
+	p("")
+	p("Flags of every command:")
+	p("  -json:     Print a JSON summary (same as -format json)")
+	p("  -v:        Also print every file loaded and string found")
+	p("  -config:   Project config (default: i18n.yaml here or in a parent directory); its")
+	p("             output directory is every command's default locales_dir")
+	p("")
+	p("Exit codes:")
+	p("  0  success")
+	p("  1  invalid catalogs or usage, or a failed check")
+	p("  2  locale coverage below the required threshold")
+	p("  3  a file could not be read or written")
+	p("")
+	p("Examples:")
+	p("  %s extract", prog)
+	p("  %s extract -src . -locales en", prog)
+	p("  %s extract -src ./src -locales fr -o ./translations/fr.json", prog)
+	p("  %s extract -dry-run -src . -locales en", prog)
+	p("  %s extract -check -src . -locales en,fr,de", prog)
+	p("  %s extract -all -src . -locales en", prog)
+	p("  %s merge -o locales translated/default.fr.json", prog)
+	p("  %s validate locales", prog)
+	p("  %s lint -format annotations locales", prog)
+	p("  %s stats -json locales", prog)
+	p("  %s lock locales", prog)
+	p("  %s changelog --from v1.2.0", prog)
+	p("  %s budget -max 64KB -locale de=80KB locales", prog)
+	p("  %s verify -format annotations locales", prog)
+	p("  %s csv export -o review.csv locales", prog)
+	p("  %s convert locales/default.fr.json fr.tsv", prog)
+	p("  DEEPL_AUTH_KEY=... %s machine-translate -to fr,de -only-missing locales", prog)
+	p("  %s prune -keep 'errors.*' -remove .", prog)
+	p("  %s workspace -locales en,fr,de .", prog)
+	p("  %s gen-go -o internal/messages/messages.go locales", prog)
+	p("  %s keys -package keys -output internal/keys/keys.go -prefix '' -group locales", prog)
+	p("  %s enum -type Status -catalog locales/default.en.json ./orders", prog)
+}
+
+// runExtract writes the catalog of translation keys found in a source tree,
+// or with -dry-run prints the changes it would make
+func runExtract(args []string) int {
+	fs := newFlagSet("extract")
+	fs.Usage = usage
+	src := fs.String("src", "", "directory to scan (default: the first argument, else .)")
+	output := fs.String("o", "", "output directory, or catalog file when extracting one locale given as an argument")
+	dryRun := fs.Bool("dry-run", false, "print the changes without writing the catalog")
+	locales := fs.String("locales", "", "comma-separated locales to extract at once, source language first")
+	all := fs.Bool("all", false, "also update every default.*.json catalog already in the output directory")
+	check := fs.Bool("check", false, "report drift between the code and the catalogs without writing them, exiting 1 if any")
+	keepRemoved := fs.Bool("keep-removed", false, "move keys no longer used to the catalogs' \"obsolete\" section instead of deleting them")
+	r := newReport(fs)
+	if code, ok := r.parse(fs, args); !ok {
+		return code
+	}
+
+	// Without arguments, i18n.yaml says what to extract
+	if *src == "" && fs.NArg() == 0 && *locales == "" && r.config != nil {
+		if *output != "" {
+			abs, err := filepath.Abs(*output)
+			if err != nil {
+				return r.fail(err)
+			}
+			cfg := *r.config
+			cfg.Output = abs
+			r.config = &cfg
+		}
+		if *check {
+			return runCheck(r, nil, "", *all)
+		}
+		if *all {
+			return extractAll(r, nil, "", *dryRun, *keepRemoved)
+		}
+		cfg := *r.config
+		cfg.KeepRemoved = cfg.KeepRemoved || *keepRemoved
+		return extractConfig(r, &cfg, *dryRun)
+	}
+
+	args = extractArgs(fs.Args(), *src, *output, *locales)
+	if *check {
+		return runCheck(r, args, *locales, *all)
+	}
+	if *all {
+		return extractAll(r, args, *locales, *dryRun, *keepRemoved)
+	}
+
+	// Several locales ("en,fr,de" or -locales) are extracted into a directory
+	if *locales != "" || (len(args) >= 2 && strings.Contains(args[1], ",")) {
+		sourceDir, outputDir, list, ok := localeArgs(args, *locales)
+		if !ok {
+			usage()
+			return exitValidation
+		}
+		return extractLocales(r, sourceDir, outputDir, list, *dryRun, *keepRemoved)
+	}
+
+	if len(args) < 2 {
+		usage()
+		return exitValidation
+	}
+
+	sourceDir := args[0]
+	locale := args[1]
+	outputPath := ""
+	if len(args) > 2 {
+		outputPath = args[2]
+	}
+	catalog := i18n.CatalogPath(locale, outputPath)
+
+	// Obsolete keys are kept by the config-driven extraction, which writes
+	// default catalogs only
+	if *keepRemoved {
+		if filepath.Base(catalog) != fmt.Sprintf("%s.%s.json", i18n.DefaultDictionary, locale) {
+			return r.fail(fmt.Errorf("-keep-removed needs the catalog to be named %s.%s.json", i18n.DefaultDictionary, locale))
+		}
+		cfg := &i18n.Config{Sources: []string{sourceDir}, Locales: []string{locale}, Output: filepath.Dir(catalog), KeepRemoved: true}
+		return extractConfig(r, cfg, *dryRun)
+	}
+
+	// Stop scanning on Ctrl-C instead of leaving a half-written catalog
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	// The plan is the dry run's output and the JSON summary of a real run
+	var changes i18n.LocaleChanges
+	if *dryRun || r.json() {
+		var err error
+		changes, err = i18n.PlanTranslations(ctx, locale, sourceDir, outputPath)
+		if err != nil {
+			return r.fail(err)
+		}
+	}
+
+	result := map[string]any{"catalog": catalog, "changes": changes, "dry_run": *dryRun}
+	if *dryRun {
+		if !r.json() {
+			printPlan(catalog, changes)
+		}
+		return r.done(exitOK, result)
+	}
+
+	if err := i18n.GenerateTranslationsContext(ctx, locale, sourceDir, outputPath); err != nil {
+		return r.fail(err)
+	}
+	return r.done(exitOK, result)
+}
+
+// extractArgs returns the positional arguments of extract with the -src
+// and -o flags in their place: <source_dir> [locales] [output]. The source
+// directory defaults to . when -locales names the locales.
+func extractArgs(args []string, src, output, locales string) []string {
+	if src == "" && len(args) == 0 && locales != "" {
+		src = "."
+	}
+	if src != "" {
+		args = append([]string{src}, args...)
+	}
+	if output != "" {
+		args = append(args, output)
+	}
+	return args
+}
+
+// localeArgs parses the arguments of a run over several locales:
+// <source_dir> [output_dir] with -locales, else <source_dir> <locales> [output_dir]
+func localeArgs(args []string, list string) (sourceDir, outputDir string, locales []string, ok bool) {
+	if list == "" {
+		if len(args) < 2 {
+			return "", "", nil, false
+		}
+		list, args = args[1], append(args[:1:1], args[2:]...)
+	}
+	if len(args) < 1 {
+		return "", "", nil, false
+	}
+
+	sourceDir = args[0]
+	if len(args) > 1 {
+		outputDir = args[1]
+	}
+	for _, locale := range strings.Split(list, ",") {
+		if locale = strings.TrimSpace(locale); locale != "" {
+			locales = append(locales, locale)
+		}
+	}
+	return sourceDir, outputDir, locales, len(locales) > 0
+}
+
+// extractAll extracts the given locales and every other default catalog
+// already in the output directory, see i18n.ExpandLocales
+func extractAll(r *report, args []string, list string, dryRun, keepRemoved bool) int {
+	if len(args) == 0 && list == "" && r.config != nil {
+		cfg := *r.config
+		locales, err := i18n.ExpandLocales(cfg.Dir(), cfg.Locales)
+		if err != nil {
+			return r.fail(err)
+		}
+		cfg.Locales = locales
+		cfg.KeepRemoved = cfg.KeepRemoved || keepRemoved
+		return extractConfig(r, &cfg, dryRun)
+	}
+
+	sourceDir, outputDir, locales, ok := localeArgs(args, list)
+	if !ok {
+		usage()
+		return exitValidation
+	}
+	dir := outputDir
+	if dir == "" {
+		dir = i18n.DefaultFolder
+	}
+	locales, err := i18n.ExpandLocales(dir, locales)
+	if err != nil {
+		return r.fail(err)
+	}
+	return extractLocales(r, sourceDir, outputDir, locales, dryRun, keepRemoved)
+}
+
+// extractLocales writes the catalogs of several locales at once
+func extractLocales(r *report, sourceDir, outputDir string, locales []string, dryRun, keepRemoved bool) int {
+	if keepRemoved {
+		cfg := &i18n.Config{Sources: []string{sourceDir}, Locales: locales, Output: outputDir, KeepRemoved: true}
+		return extractConfig(r, cfg, dryRun)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	var changes []i18n.LocaleChanges
+	if dryRun || r.json() {
+		var err error
+		changes, err = i18n.PlanCatalogs(ctx, sourceDir, outputDir, locales...)
+		if err != nil {
+			return r.fail(err)
+		}
+	}
+
+	result := map[string]any{"locales": locales, "changes": changes, "dry_run": dryRun}
+	if dryRun {
+		if !r.json() {
+			printChanges(changes)
+		}
+		return r.done(exitOK, result)
+	}
+
+	if err := i18n.GenerateCatalogs(ctx, sourceDir, outputDir, locales...); err != nil {
+		return r.fail(err)
+	}
+	return r.done(exitOK, result)
+}
+
+// extractConfig writes the catalogs of the locales of the project config
+func extractConfig(r *report, cfg *i18n.Config, dryRun bool) int {
+	if len(cfg.Locales) == 0 {
+		return r.fail(errors.New("i18n.yaml lists no locales"))
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	var changes []i18n.LocaleChanges
+	if dryRun || r.json() {
+		var err error
+		changes, err = cfg.PlanCatalogs(ctx)
+		if err != nil {
+			return r.fail(err)
+		}
+	}
+
+	result := map[string]any{"locales": cfg.Locales, "changes": changes, "dry_run": dryRun}
+	if dryRun {
+		if !r.json() {
+			printChanges(changes)
+		}
+		return r.done(exitOK, result)
+	}
+
+	if err := cfg.GenerateCatalogs(ctx); err != nil {
+		return r.fail(err)
+	}
+	return r.done(exitOK, result)
+}
+
+// printChanges prints the changes a dry run would make to several catalogs
+func printChanges(changes []i18n.LocaleChanges) {
+	var changed []i18n.LocaleChanges
+	for _, c := range changes {
+		if !c.Empty() {
+			changed = append(changed, c)
+		}
+	}
+	fmt.Println("📝 dry run, nothing written")
+	i18n.WriteChangelog(os.Stdout, changed)
+}
+
+// printPlan prints the changes a dry run would make to a catalog file
+func printPlan(path string, changes i18n.LocaleChanges) {
+	fmt.Printf("📝 %s (dry run, nothing written)\n", path)
+	if changes.Empty() {
+		fmt.Println("No changes.")
+		return
+	}
+	i18n.WriteChangelog(os.Stdout, []i18n.LocaleChanges{changes})
+}
@@ -0,0 +1,314 @@
+package cli
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// mainSource is a program using the welcome and goodbye keys
+const mainSource = `package main
+
+import "github.com/nyxstack/i18n"
+
+var (
+	_ = i18n.T("welcome")
+	_ = i18n.T("goodbye")
+)
+`
+
+// cliCase is a run of the tool in a fresh working directory holding files
+type cliCase struct {
+	name       string
+	files      map[string]string
+	args       []string
+	wantCode   int
+	wantStdout string
+	wantStderr string
+	check      func(t *testing.T, stdout string)
+}
+
+// runCases runs every case with stdout and stderr captured
+func runCases(t *testing.T, tests []cliCase) {
+	t.Helper()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Chdir(t.TempDir())
+			for path, content := range tt.files {
+				writeFile(t, path, content)
+			}
+
+			code, stdout, stderr := runCLI(t, tt.args...)
+			if code != tt.wantCode {
+				t.Errorf("Expected exit code %d, got %d\nstdout: %s\nstderr: %s", tt.wantCode, code, stdout, stderr)
+			}
+			if !strings.Contains(stdout, tt.wantStdout) {
+				t.Errorf("Expected stdout to contain %q, got %q", tt.wantStdout, stdout)
+			}
+			if !strings.Contains(stderr, tt.wantStderr) {
+				t.Errorf("Expected stderr to contain %q, got %q", tt.wantStderr, stderr)
+			}
+			if tt.check != nil {
+				tt.check(t, stdout)
+			}
+		})
+	}
+}
+
+// runCLI runs the tool with args and returns its exit code and output
+func runCLI(t *testing.T, args ...string) (int, string, string) {
+	t.Helper()
+	stdout, stderr := captureFile(t), captureFile(t)
+	oldStdout, oldStderr := os.Stdout, os.Stderr
+	os.Stdout, os.Stderr = stdout, stderr
+	defer func() { os.Stdout, os.Stderr = oldStdout, oldStderr }()
+
+	code := Run("i18n", args)
+	return code, readCapture(t, stdout), readCapture(t, stderr)
+}
+
+func captureFile(t *testing.T) *os.File {
+	t.Helper()
+	f, err := os.CreateTemp(t.TempDir(), "output")
+	if err != nil {
+		t.Fatalf("Failed to create output file: %v", err)
+	}
+	t.Cleanup(func() { f.Close() })
+	return f
+}
+
+func readCapture(t *testing.T, f *os.File) string {
+	t.Helper()
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		t.Fatalf("Failed to rewind output: %v", err)
+	}
+	data, err := io.ReadAll(f)
+	if err != nil {
+		t.Fatalf("Failed to read output: %v", err)
+	}
+	return string(data)
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write %s: %v", path, err)
+	}
+}
+
+// testCatalog is the part of a catalog file the tests look at
+type testCatalog struct {
+	Translations map[string]any `json:"translations"`
+	NeedsSource  []string       `json:"needs_source"`
+}
+
+// readCatalog decodes the catalog at path
+func readCatalog(t *testing.T, path string) testCatalog {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read %s: %v", path, err)
+	}
+	var tf testCatalog
+	if err := json.Unmarshal(data, &tf); err != nil {
+		t.Fatalf("Failed to decode %s: %v", path, err)
+	}
+	return tf
+}
+
+// decodeSummary decodes the JSON summary a command printed
+func decodeSummary(t *testing.T, stdout string) summary {
+	t.Helper()
+	var s summary
+	if err := json.Unmarshal([]byte(stdout), &s); err != nil {
+		t.Fatalf("Expected a JSON summary, got %q: %v", stdout, err)
+	}
+	return s
+}
+
+func TestRun_Extract(t *testing.T) {
+	enCatalog := filepath.Join("locales", "default.en.json")
+	runCases(t, []cliCase{
+		{
+			name:  "positional",
+			files: map[string]string{"main.go": mainSource},
+			args:  []string{".", "en"},
+			check: func(t *testing.T, _ string) {
+				got := readCatalog(t, enCatalog)
+				if want := []string{"goodbye", "welcome"}; !slices.Equal(got.NeedsSource, want) {
+					t.Errorf("Expected %v to need source text, got %v", want, got.NeedsSource)
+				}
+			},
+		},
+		{
+			name:  "locales flag",
+			files: map[string]string{"main.go": mainSource},
+			args:  []string{"extract", "-locales", "en,fr"},
+			check: func(t *testing.T, _ string) {
+				for _, path := range []string{enCatalog, filepath.Join("locales", "default.fr.json")} {
+					if got := readCatalog(t, path); len(got.NeedsSource) != 2 {
+						t.Errorf("Expected 2 keys in %s, got %v", path, got.NeedsSource)
+					}
+				}
+			},
+		},
+		{
+			name:       "dry run",
+			files:      map[string]string{"main.go": mainSource},
+			args:       []string{"extract", "-dry-run", ".", "en"},
+			wantStdout: "dry run, nothing written",
+			check: func(t *testing.T, _ string) {
+				if _, err := os.Stat(enCatalog); !os.IsNotExist(err) {
+					t.Errorf("Expected no catalog written, got %v", err)
+				}
+			},
+		},
+		{
+			name:  "json summary",
+			files: map[string]string{"main.go": mainSource},
+			args:  []string{"extract", "-json", ".", "en"},
+			check: func(t *testing.T, stdout string) {
+				s := decodeSummary(t, stdout)
+				if s.Command != "extract" || s.ExitCode != exitOK || s.Result == nil {
+					t.Errorf("Unexpected summary %+v", s)
+				}
+			},
+		},
+		{
+			name: "check reports drift",
+			files: map[string]string{
+				"main.go": mainSource,
+				enCatalog: `{"meta": {"lang": "en", "name": "default"}, "translations": {"welcome": "Welcome"}}`,
+			},
+			args:     []string{"extract", "-check", "-locales", "en"},
+			wantCode: exitValidation,
+		},
+		{
+			name:       "unknown flag",
+			args:       []string{"extract", "-bogus"},
+			wantCode:   exitValidation,
+			wantStderr: "flag provided but not defined: -bogus",
+		},
+		{
+			name:       "unknown format",
+			args:       []string{"extract", "-format", "xml", ".", "en"},
+			wantCode:   exitValidation,
+			wantStderr: "unknown format 'xml'",
+		},
+		{
+			name:       "missing locale",
+			args:       []string{"extract", "."},
+			wantCode:   exitValidation,
+			wantStdout: "Usage: i18n <command>",
+		},
+		{
+			name:       "help",
+			args:       []string{"help"},
+			wantStdout: "Exit codes:",
+		},
+	})
+}
+
+func TestRun_Merge(t *testing.T) {
+	frCatalog := filepath.Join("locales", "default.fr.json")
+	files := map[string]string{
+		frCatalog:       `{"meta": {"lang": "fr", "name": "default"}, "translations": {"welcome": "Bienvenue", "goodbye": "Au revoir"}}`,
+		"delivery.json": `{"meta": {"lang": "fr", "name": "default"}, "translations": {"welcome": "Bienvenue !", "dashboard": "Tableau de bord"}}`,
+	}
+	runCases(t, []cliCase{
+		{
+			name:       "merge",
+			files:      files,
+			args:       []string{"merge", "delivery.json"},
+			wantStdout: "fr: 2 key(s)",
+			check: func(t *testing.T, _ string) {
+				got := readCatalog(t, frCatalog)
+				if len(got.Translations) != 3 || got.Translations["welcome"] != "Bienvenue !" {
+					t.Errorf("Expected the delivery merged, got %v", got.Translations)
+				}
+			},
+		},
+		{
+			name:       "dry run",
+			files:      files,
+			args:       []string{"merge", "-dry-run", "delivery.json"},
+			wantStdout: frCatalog + " (dry run, nothing written)",
+			check: func(t *testing.T, stdout string) {
+				if !strings.Contains(stdout, "dashboard") || !strings.Contains(stdout, "welcome") {
+					t.Errorf("Expected the added and changed keys listed, got %q", stdout)
+				}
+				if got := readCatalog(t, frCatalog); len(got.Translations) != 2 || got.Translations["welcome"] != "Bienvenue" {
+					t.Errorf("Expected the catalog left as is, got %v", got.Translations)
+				}
+			},
+		},
+		{
+			name:  "dry run json",
+			files: files,
+			args:  []string{"merge", "-dry-run", "-json", "delivery.json"},
+			check: func(t *testing.T, stdout string) {
+				result := decodeSummary(t, stdout).Result.(map[string]any)
+				changes := result["changes"].([]any)[0].(map[string]any)
+				if result["dry_run"] != true || len(changes["added"].([]any)) != 1 || len(changes["changed"].([]any)) != 1 {
+					t.Errorf("Unexpected dry run summary %v", result)
+				}
+			},
+		},
+		{
+			name:       "no files",
+			args:       []string{"merge"},
+			wantCode:   exitValidation,
+			wantStderr: "no catalog files to merge",
+		},
+	})
+}
+
+func TestRun_MachineTranslate(t *testing.T) {
+	frCatalog := filepath.Join("locales", "default.fr.json")
+	files := map[string]string{
+		filepath.Join("locales", "default.en.json"): `{"meta": {"lang": "en", "name": "default"}, "translations": {"welcome": "Welcome", "goodbye": "Goodbye", "items": "{count, plural, one {# item} other {# items}}"}}`,
+		frCatalog: `{"meta": {"lang": "fr", "name": "default"}, "translations": {"welcome": "Bienvenue", "goodbye": "Goodbye"}}`,
+	}
+	runCases(t, []cliCase{
+		{
+			name:       "dry run",
+			files:      files,
+			args:       []string{"machine-translate", "-to", "fr,de", "-dry-run", "locales"},
+			wantStdout: frCatalog + " (dry run, nothing written): 1 key(s) to translate\n  + goodbye\n  - items: plural",
+			check: func(t *testing.T, stdout string) {
+				if !strings.Contains(stdout, filepath.Join("locales", "default.de.json")+" (dry run, nothing written): 2 key(s)") {
+					t.Errorf("Expected the new de catalog planned, got %q", stdout)
+				}
+				if _, err := os.Stat(filepath.Join("locales", "default.de.json")); !os.IsNotExist(err) {
+					t.Errorf("Expected no catalog written, got %v", err)
+				}
+			},
+		},
+		{
+			name:  "dry run json",
+			files: files,
+			args:  []string{"machine-translate", "-to", "fr", "-dry-run", "-json", "locales"},
+			check: func(t *testing.T, stdout string) {
+				result := decodeSummary(t, stdout).Result.(map[string]any)
+				catalogs := result["catalogs"].([]any)
+				translated := catalogs[0].(map[string]any)["translated"].([]any)
+				if result["dry_run"] != true || len(translated) != 1 || translated[0] != "goodbye" {
+					t.Errorf("Unexpected dry run summary %v", result)
+				}
+			},
+		},
+		{
+			name:       "no target",
+			args:       []string{"machine-translate", "-dry-run"},
+			wantCode:   exitValidation,
+			wantStderr: "Usage: i18n machine-translate",
+		},
+	})
+}
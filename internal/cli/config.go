@@ -0,0 +1,47 @@
+package cli
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+
+	"github.com/nyxstack/i18n"
+)
+
+// loadConfig reads the -config file, or the i18n.yaml found in the current
+// directory or its parents. It returns nil if there is none, and with
+// -config none.
+func (r *report) loadConfig() (*i18n.Config, error) {
+	path := r.configPath
+	switch path {
+	case "none":
+		return nil, nil
+	case "":
+		found, err := i18n.FindConfig(".")
+		if err != nil || found == "" {
+			return nil, err
+		}
+		path = found
+	}
+	return i18n.LoadConfig(path)
+}
+
+// dir returns the locales_dir argument, else the configured catalog
+// directory, else the default one
+func (r *report) dir(fs *flag.FlagSet) string {
+	if fs.NArg() > 0 {
+		return fs.Arg(0)
+	}
+	if r.config == nil {
+		return i18n.DefaultFolder
+	}
+
+	// Keep the directory relative, as git resolves paths at a ref from it
+	dir := r.config.Dir()
+	if wd, err := os.Getwd(); err == nil {
+		if rel, err := filepath.Rel(wd, dir); err == nil {
+			dir = rel
+		}
+	}
+	return dir
+}
@@ -0,0 +1,41 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/nyxstack/i18n"
+)
+
+// runConvert converts a catalog between JSON, YAML, PO, XLIFF, CSV and TSV,
+// by extension
+func runConvert(args []string) int {
+	fs := newFlagSet("convert")
+	lang := fs.String("lang", "", "language column to convert when a spreadsheet has several, or language of a PO or XLIFF file that names none")
+	r := newReport(fs)
+	if code, ok := r.parse(fs, args); !ok {
+		return code
+	}
+	if fs.NArg() != 2 {
+		fmt.Fprintf(os.Stderr, "Usage: %s convert [-lang code] <in> <out>\n", prog)
+		return exitValidation
+	}
+	src, dst := fs.Arg(0), fs.Arg(1)
+
+	warnings, err := i18n.ConvertCatalog(src, dst, *lang)
+	if err != nil {
+		return r.fail(err)
+	}
+	for _, w := range warnings {
+		switch {
+		case r.json():
+		case r.annotations():
+			fmt.Println(i18n.Annotation{Level: i18n.AnnotationWarning, File: src, Title: "Conversion", Message: w})
+		default:
+			fmt.Fprintf(os.Stderr, "⚠️  %s\n", w)
+		}
+	}
+
+	r.printf("✅ Converted %s to %s\n", src, dst)
+	return r.done(exitOK, map[string]any{"input": src, "output": dst, "warnings": warnings})
+}
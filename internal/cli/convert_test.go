@@ -0,0 +1,70 @@
+package cli
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestRun_Convert(t *testing.T) {
+	files := map[string]string{
+		"default.fr.json": `{"meta": {"lang": "fr", "name": "default"}, "translations": {"welcome": "Bienvenue"}}`,
+	}
+	runCases(t, []cliCase{
+		{
+			name:       "json to po",
+			files:      files,
+			args:       []string{"convert", "default.fr.json", "fr.po"},
+			wantStdout: "Converted default.fr.json to fr.po",
+			check: func(t *testing.T, _ string) {
+				data, err := os.ReadFile("fr.po")
+				if err != nil {
+					t.Fatalf("Failed to read fr.po: %v", err)
+				}
+				if !strings.Contains(string(data), "msgid \"welcome\"\nmsgstr \"Bienvenue\"") {
+					t.Errorf("Expected the translation in the PO file, got %s", data)
+				}
+			},
+		},
+		{
+			name:  "po with lang",
+			files: map[string]string{"fr.po": "msgid \"welcome\"\nmsgstr \"Bienvenue\"\n"},
+			args:  []string{"convert", "-lang", "fr", "fr.po", "default.fr.json"},
+			check: func(t *testing.T, _ string) {
+				if got := readCatalog(t, "default.fr.json"); got.Translations["welcome"] != "Bienvenue" {
+					t.Errorf("Expected the translation back, got %v", got.Translations)
+				}
+			},
+		},
+		{
+			name:  "json summary",
+			files: files,
+			args:  []string{"convert", "-json", "default.fr.json", "fr.yaml"},
+			check: func(t *testing.T, stdout string) {
+				s := decodeSummary(t, stdout)
+				if s.Command != "convert" || s.Result.(map[string]any)["output"] != "fr.yaml" {
+					t.Errorf("Unexpected summary %+v", s)
+				}
+			},
+		},
+		{
+			name:       "missing argument",
+			files:      files,
+			args:       []string{"convert", "default.fr.json"},
+			wantCode:   exitValidation,
+			wantStderr: "Usage: i18n convert",
+		},
+		{
+			name:       "unsupported format",
+			files:      files,
+			args:       []string{"convert", "default.fr.json", "fr.strings"},
+			wantCode:   exitValidation,
+			wantStderr: "unsupported format '.strings'",
+		},
+		{
+			name:     "missing input",
+			args:     []string{"convert", "missing.json", "fr.po"},
+			wantCode: exitIO,
+		},
+	})
+}
@@ -0,0 +1,113 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/nyxstack/i18n"
+)
+
+// runCSV converts catalogs to and from CSV/TSV for spreadsheet review
+func runCSV(args []string) int {
+	if len(args) > 0 {
+		switch args[0] {
+		case "export":
+			return runCSVExport(args[1:])
+		case "import":
+			return runCSVImport(args[1:])
+		}
+	}
+	fmt.Fprintf(os.Stderr, "Usage: %s csv export|import [flags] [locales_dir]\n", prog)
+	return exitValidation
+}
+
+// runCSVExport writes every catalog as one key column plus a column per language
+func runCSVExport(args []string) int {
+	fs := newFlagSet("csv export")
+	output := fs.String("o", "", "output file (default stdout)")
+	tsv := fs.Bool("tsv", false, "write tab-separated values")
+	locales := fs.String("locales", "", "comma-separated language columns, in order (default all)")
+	r := newReport(fs)
+	if code, ok := r.parse(fs, args); !ok {
+		return code
+	}
+
+	dir := r.dir(fs)
+
+	catalogs, err := i18n.ReadCatalogs(dir)
+	if err != nil {
+		return r.fail(err)
+	}
+
+	var langs []string
+	if *locales != "" {
+		langs = strings.Split(*locales, ",")
+	}
+
+	var w io.Writer = os.Stdout
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			return r.fail(err)
+		}
+		defer f.Close()
+		w = f
+	} else if r.json() {
+		return r.fail(errors.New("-json requires -o, as the CSV is written to stdout"))
+	}
+
+	export := i18n.ExportCSV
+	if *tsv {
+		export = i18n.ExportTSV
+	}
+	if err := export(w, catalogs, langs...); err != nil {
+		return r.fail(err)
+	}
+
+	if *output != "" {
+		r.printf("✅ Exported %d locales → %s\n", len(catalogs), *output)
+	}
+	return r.done(exitOK, map[string]any{"output": *output, "locales": len(catalogs)})
+}
+
+// runCSVImport merges a reviewed CSV back into the catalogs
+func runCSVImport(args []string) int {
+	fs := newFlagSet("csv import")
+	input := fs.String("i", "", "CSV file to import (required)")
+	tsv := fs.Bool("tsv", false, "read tab-separated values")
+	r := newReport(fs)
+	if code, ok := r.parse(fs, args); !ok {
+		return code
+	}
+
+	if *input == "" {
+		return r.fail(errors.New("-i is required"))
+	}
+
+	dir := r.dir(fs)
+
+	f, err := os.Open(*input)
+	if err != nil {
+		return r.fail(err)
+	}
+	defer f.Close()
+
+	read := i18n.ReadCSV
+	if *tsv {
+		read = i18n.ReadTSV
+	}
+	catalogs, err := read(f)
+	if err != nil {
+		return r.fail(err)
+	}
+
+	if err := i18n.ImportCatalogs(dir, catalogs); err != nil {
+		return r.fail(err)
+	}
+
+	r.printf("✅ Imported %d locales → %s\n", len(catalogs), dir)
+	return r.done(exitOK, map[string]any{"input": *input, "locales": len(catalogs)})
+}
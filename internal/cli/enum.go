@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"errors"
+
+	"github.com/nyxstack/i18n"
+)
+
+// runEnum generates a LocalizedString method for an enum type and
+// optionally adds its keys to a catalog
+func runEnum(args []string) int {
+	fs := newFlagSet("enum")
+	typeName := fs.String("type", "", "enum type name (required)")
+	output := fs.String("o", "", "output file (default <type>_i18n.go in the package)")
+	catalog := fs.String("catalog", "", "catalog to add missing keys to")
+	dryRun := fs.Bool("dry-run", false, "print the keys that would be added without writing anything")
+	r := newReport(fs)
+	if code, ok := r.parse(fs, args); !ok {
+		return code
+	}
+
+	if *typeName == "" {
+		return r.fail(errors.New("-type is required"))
+	}
+
+	dir := "."
+	if fs.NArg() > 0 {
+		dir = fs.Arg(0)
+	}
+
+	if *dryRun {
+		_, values, err := i18n.FindEnum(dir, *typeName)
+		if err != nil {
+			return r.fail(err)
+		}
+		r.printf("📝 Would generate %s.LocalizedString for %d values\n", *typeName, len(values))
+
+		result := map[string]any{"type": *typeName, "values": values, "dry_run": true}
+		if *catalog != "" {
+			changes, err := i18n.PlanEnumKeys(*catalog, values)
+			if err != nil {
+				return r.fail(err)
+			}
+			if !r.json() {
+				printPlan(*catalog, changes)
+			}
+			result["catalog"] = *catalog
+			result["changes"] = changes
+		}
+		return r.done(exitOK, result)
+	}
+
+	values, err := i18n.GenerateEnum(dir, *typeName, *output)
+	if err != nil {
+		return r.fail(err)
+	}
+
+	result := map[string]any{"type": *typeName, "values": values}
+	if *catalog != "" {
+		added, err := i18n.AddEnumKeys(*catalog, values)
+		if err != nil {
+			return r.fail(err)
+		}
+		r.printf("✅ Added %d keys → %s\n", added, *catalog)
+		result["catalog"] = *catalog
+		result["added"] = added
+	}
+	return r.done(exitOK, result)
+}
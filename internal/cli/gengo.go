@@ -0,0 +1,35 @@
+package cli
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/nyxstack/i18n"
+)
+
+// runGenGo generates a typed accessor function for every key of the source
+// language's catalog
+func runGenGo(args []string) int {
+	fs := newFlagSet("gen-go")
+	catalog := fs.String("catalog", "", "catalog to generate from (default: the source language's default catalog in locales_dir)")
+	pkg := fs.String("package", "", "package clause (default: the package of the output directory)")
+	output := fs.String("o", "messages.go", "output file")
+	r := newReport(fs)
+	if code, ok := r.parse(fs, args); !ok {
+		return code
+	}
+
+	if *catalog == "" {
+		lang := "en"
+		if r.config != nil && r.config.SourceLanguage() != "" {
+			lang = r.config.SourceLanguage()
+		}
+		*catalog = filepath.Join(r.dir(fs), fmt.Sprintf("%s.%s.json", i18n.DefaultDictionary, lang))
+	}
+
+	accessors, err := i18n.GenerateAccessors(*catalog, *pkg, *output)
+	if err != nil {
+		return r.fail(err)
+	}
+	return r.done(exitOK, map[string]any{"catalog": *catalog, "output": *output, "accessors": accessors})
+}
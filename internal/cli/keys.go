@@ -0,0 +1,39 @@
+package cli
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/nyxstack/i18n"
+)
+
+// runKeys generates a string constant for every key of the source
+// language's catalog
+func runKeys(args []string) int {
+	fs := newFlagSet("keys")
+	catalog := fs.String("catalog", "", "catalog to generate from (default: the source language's default catalog in locales_dir)")
+	pkg := fs.String("package", "", "package clause (default: the package of the output directory)")
+	var output string
+	fs.StringVar(&output, "output", "keys.go", "output file")
+	fs.StringVar(&output, "o", "keys.go", "output file (shorthand for -output)")
+	prefix := fs.String("prefix", "Key", "prefix of the constant names")
+	group := fs.Bool("group", false, "write one const block per namespace")
+	r := newReport(fs)
+	if code, ok := r.parse(fs, args); !ok {
+		return code
+	}
+
+	if *catalog == "" {
+		lang := "en"
+		if r.config != nil && r.config.SourceLanguage() != "" {
+			lang = r.config.SourceLanguage()
+		}
+		*catalog = filepath.Join(r.dir(fs), fmt.Sprintf("%s.%s.json", i18n.DefaultDictionary, lang))
+	}
+
+	constants, err := i18n.GenerateKeyConstants(*catalog, *pkg, *prefix, output, *group)
+	if err != nil {
+		return r.fail(err)
+	}
+	return r.done(exitOK, map[string]any{"catalog": *catalog, "output": output, "constants": constants})
+}
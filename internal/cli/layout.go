@@ -0,0 +1,35 @@
+package cli
+
+import (
+	"errors"
+
+	"github.com/nyxstack/i18n"
+)
+
+// runLayout rewrites the catalogs with nested or flat translation keys
+func runLayout(args []string) int {
+	fs := newFlagSet("layout")
+	nested := fs.Bool("nested", false, "write keys as nested objects")
+	flat := fs.Bool("flat", false, "write keys as flat dotted strings")
+	r := newReport(fs)
+	if code, ok := r.parse(fs, args); !ok {
+		return code
+	}
+
+	if *nested == *flat {
+		return r.fail(errors.New("exactly one of -nested and -flat is required"))
+	}
+
+	dir := r.dir(fs)
+
+	if err := i18n.ConvertLayout(dir, *nested); err != nil {
+		return r.fail(err)
+	}
+
+	layout := "flat"
+	if *nested {
+		layout = "nested"
+	}
+	r.printf("✅ Rewrote catalogs in %s with %s keys\n", dir, layout)
+	return r.done(exitOK, map[string]any{"dir": dir, "layout": layout})
+}
@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/nyxstack/i18n"
+)
+
+// lintTitles are the annotation titles of the lint rules
+var lintTitles = map[string]string{
+	i18n.RulePlaceholders: "Placeholder mismatch",
+	i18n.RulePluralOther:  "Plural without other",
+	i18n.RuleStrayHash:    "Stray #",
+}
+
+// runLint flags translations that load but render wrong: placeholders that
+// differ from the source language's, plural blocks without an "other" form
+// and "#" outside plural blocks
+func runLint(args []string) int {
+	fs := newFlagSet("lint")
+	source := fs.String("source", "", "language the others are compared with (default: the configured source language, else en)")
+	r := newReport(fs)
+	if code, ok := r.parse(fs, args); !ok {
+		return code
+	}
+
+	dir := r.dir(fs)
+
+	b := i18n.NewBundle()
+	switch {
+	case *source != "":
+		b.SetDefaultLanguage(*source)
+	case r.config != nil && r.config.SourceLanguage() != "":
+		b.SetDefaultLanguage(r.config.SourceLanguage())
+	}
+
+	issues, err := b.LintDir(dir)
+	if err != nil {
+		return r.fail(err)
+	}
+
+	result := map[string]any{"issues": issues}
+	if len(issues) == 0 {
+		r.printf("✅ No lint problems in %s\n", dir)
+		return r.done(exitOK, result)
+	}
+
+	for _, issue := range issues {
+		switch {
+		case r.json():
+		case r.annotations():
+			fmt.Println(i18n.Annotation{
+				Level:   i18n.AnnotationError,
+				File:    issue.File,
+				Line:    issue.Line,
+				Column:  issue.Column,
+				Title:   lintTitles[issue.Rule],
+				Message: issue.Key + ": " + issue.Message,
+			})
+		default:
+			fmt.Fprintf(os.Stderr, "❌ %s [%s]\n", issue, issue.Rule)
+		}
+	}
+	if !r.json() {
+		fmt.Fprintf(os.Stderr, "%d problem(s) in %s\n", len(issues), dir)
+	}
+	return r.done(exitValidation, result)
+}
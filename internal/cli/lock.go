@@ -0,0 +1,75 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/nyxstack/i18n"
+)
+
+// runLock writes a lockfile with the content hash of every catalog
+func runLock(args []string) int {
+	fs := newFlagSet("lock")
+	output := fs.String("o", i18n.DefaultLockFile, "lockfile path")
+	r := newReport(fs)
+	if code, ok := r.parse(fs, args); !ok {
+		return code
+	}
+
+	dir := r.dir(fs)
+
+	lf, err := i18n.LockDir(dir)
+	if err != nil {
+		return r.fail(err)
+	}
+
+	if err := i18n.WriteLockfile(*output, lf); err != nil {
+		return r.fail(err)
+	}
+
+	r.printf("🔒 Locked %d catalogs → %s\n", len(lf.Catalogs), *output)
+	return r.done(exitOK, map[string]any{"lockfile": *output, "catalogs": len(lf.Catalogs)})
+}
+
+// runVerify checks the catalogs against a previously written lockfile
+func runVerify(args []string) int {
+	fs := newFlagSet("verify")
+	lockPath := fs.String("lock", i18n.DefaultLockFile, "lockfile path")
+	r := newReport(fs)
+	if code, ok := r.parse(fs, args); !ok {
+		return code
+	}
+
+	dir := r.dir(fs)
+
+	mismatches, err := i18n.VerifyLock(dir, *lockPath)
+	if err != nil {
+		return r.fail(err)
+	}
+
+	result := map[string]any{"lockfile": *lockPath, "mismatches": mismatches}
+	if len(mismatches) > 0 {
+		for _, m := range mismatches {
+			switch {
+			case r.json():
+			case r.annotations():
+				fmt.Println(i18n.Annotation{
+					Level:   i18n.AnnotationError,
+					File:    filepath.Join(dir, m.File),
+					Title:   "Lockfile mismatch",
+					Message: m.Reason,
+				})
+			default:
+				fmt.Fprintf(os.Stderr, "❌ %s\n", m)
+			}
+		}
+		if !r.json() {
+			fmt.Fprintf(os.Stderr, "%d catalog(s) differ from %s\n", len(mismatches), *lockPath)
+		}
+		return r.done(exitValidation, result)
+	}
+
+	r.printf("✅ All catalogs match %s\n", *lockPath)
+	return r.done(exitOK, result)
+}
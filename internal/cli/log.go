@@ -0,0 +1,58 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+)
+
+// consoleHandler prints the library's events as one human-readable line each:
+// progress on out, warnings and errors on stderr
+type consoleHandler struct {
+	out   io.Writer
+	level slog.Level
+	attrs []slog.Attr
+}
+
+// Enabled reports whether events of level are printed
+func (h *consoleHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level
+}
+
+// Handle prints an event as "✅ message key=value ..."
+func (h *consoleHandler) Handle(_ context.Context, rec slog.Record) error {
+	var line strings.Builder
+	w, icon := h.out, "✅ "
+	switch {
+	case rec.Level >= slog.LevelWarn:
+		w, icon = os.Stderr, "⚠️  "
+	case rec.Level < slog.LevelInfo:
+		icon = "   "
+	}
+
+	line.WriteString(icon + rec.Message)
+	write := func(a slog.Attr) bool {
+		fmt.Fprintf(&line, " %s=%v", a.Key, a.Value)
+		return true
+	}
+	for _, a := range h.attrs {
+		write(a)
+	}
+	rec.Attrs(write)
+
+	_, err := fmt.Fprintln(w, line.String())
+	return err
+}
+
+// WithAttrs returns a handler that prints attrs with every event
+func (h *consoleHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &consoleHandler{out: h.out, level: h.level, attrs: append(h.attrs[:len(h.attrs):len(h.attrs)], attrs...)}
+}
+
+// WithGroup returns h; the library does not group attributes
+func (h *consoleHandler) WithGroup(string) slog.Handler {
+	return h
+}
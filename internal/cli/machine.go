@@ -0,0 +1,122 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+
+	"github.com/nyxstack/i18n"
+	"github.com/nyxstack/i18n/mt"
+)
+
+// runMachineTranslate pre-fills missing translations with a machine
+// translation service
+func runMachineTranslate(args []string) int {
+	fs := newFlagSet("machine-translate")
+	to := fs.String("to", "", "comma-separated languages to translate into")
+	from := fs.String("from", "", "language to translate from (default: the configured source language, else en)")
+	provider := fs.String("provider", "deepl", "translation service: deepl, google or openai, with its API key in "+
+		mt.Providers["deepl"]+", "+mt.Providers["google"]+" or "+mt.Providers["openai"])
+	onlyMissing := fs.Bool("only-missing", false, "leave keys a previous run filled in as they are")
+	dryRun := fs.Bool("dry-run", false, "print the keys each catalog would get translated, without calling the service or writing anything")
+	r := newReport(fs)
+	if code, ok := r.parse(fs, args); !ok {
+		return code
+	}
+
+	var targets []string
+	for _, lang := range strings.Split(*to, ",") {
+		if lang = strings.TrimSpace(lang); lang != "" {
+			targets = append(targets, lang)
+		}
+	}
+	if len(targets) == 0 {
+		fmt.Fprintf(os.Stderr, "Usage: %s machine-translate -to fr[,de] [-from en] [-provider p] [-only-missing] [-dry-run] [locales_dir]\n", prog)
+		return exitValidation
+	}
+	source := *from
+	if source == "" && r.config != nil {
+		source = r.config.SourceLanguage()
+	}
+	if source == "" {
+		source = "en"
+	}
+
+	dir := r.dir(fs)
+	if *dryRun {
+		var all []i18n.MachineTranslation
+		for _, lang := range targets {
+			results, err := i18n.PlanMachineTranslate(dir, source, lang, *onlyMissing)
+			if err != nil {
+				return r.fail(err)
+			}
+			all = append(all, results...)
+			if !r.json() {
+				printMachinePlan(results)
+			}
+		}
+		return r.done(exitOK, map[string]any{"from": source, "provider": *provider, "catalogs": all, "dry_run": true})
+	}
+
+	tr, err := mt.New(*provider, "")
+	if err != nil {
+		return r.fail(err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	var all []i18n.MachineTranslation
+	for _, lang := range targets {
+		results, err := i18n.MachineTranslate(ctx, tr, dir, source, lang, *onlyMissing)
+		all = append(all, results...)
+		if !r.json() {
+			printMachineTranslations(r, results)
+		}
+		if err != nil {
+			return r.fail(err)
+		}
+	}
+	return r.done(exitOK, map[string]any{"from": source, "provider": *provider, "catalogs": all})
+}
+
+// printMachineTranslations prints the keys translated and skipped in each catalog
+func printMachineTranslations(r *report, results []i18n.MachineTranslation) {
+	for _, m := range results {
+		for _, key := range sortedSkipped(m.Skipped) {
+			if r.annotations() {
+				fmt.Println(i18n.Annotation{Level: i18n.AnnotationWarning, File: m.Path, Title: "Machine translation skipped", Message: key + ": " + m.Skipped[key]})
+			} else {
+				fmt.Fprintf(os.Stderr, "⚠️  %s: %s: %s\n", m.Path, key, m.Skipped[key])
+			}
+		}
+		r.printf("✅ %d key(s) machine-translated in %s\n", len(m.Translated), m.Path)
+	}
+}
+
+// printMachinePlan prints the keys a dry run would translate and skip in
+// each catalog
+func printMachinePlan(results []i18n.MachineTranslation) {
+	for _, m := range results {
+		fmt.Printf("📝 %s (dry run, nothing written): %d key(s) to translate\n", m.Path, len(m.Translated))
+		for _, key := range m.Translated {
+			fmt.Printf("  + %s\n", key)
+		}
+		for _, key := range sortedSkipped(m.Skipped) {
+			fmt.Printf("  - %s: %s\n", key, m.Skipped[key])
+		}
+	}
+}
+
+// sortedSkipped returns the keys of skipped in order
+func sortedSkipped(skipped map[string]string) []string {
+	keys := make([]string, 0, len(skipped))
+	for key := range skipped {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,126 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/nyxstack/i18n"
+)
+
+// runMerge merges translated catalog files, such as those returned by a
+// translation agency or another branch, into the default catalogs of a
+// directory
+func runMerge(args []string) int {
+	fs := newFlagSet("merge")
+	output := fs.String("o", "", "catalog directory to merge into (default: i18n.yaml's output, else locales)")
+	keepExisting := fs.Bool("keep-existing", false, "only add keys the catalogs lack, keeping their current values")
+	dryRun := fs.Bool("dry-run", false, "print the changes to each catalog without writing them")
+	r := newReport(fs)
+	if code, ok := r.parse(fs, args); !ok {
+		return code
+	}
+	if fs.NArg() == 0 {
+		return r.fail(errors.New("no catalog files to merge"))
+	}
+
+	dir := *output
+	switch {
+	case dir != "":
+	case r.config != nil:
+		dir = r.config.Dir()
+	default:
+		dir = i18n.DefaultFolder
+	}
+
+	// Later files win over earlier ones of the same language
+	catalogs := make(map[string]map[string]string)
+	for _, path := range fs.Args() {
+		dict, err := i18n.LoadDictionaryFile(path)
+		if err != nil {
+			return r.fail(err)
+		}
+		if catalogs[dict.Lang] == nil {
+			catalogs[dict.Lang] = make(map[string]string)
+		}
+		maps.Copy(catalogs[dict.Lang], dict.Translations)
+	}
+
+	if *keepExisting {
+		existing, err := i18n.ReadCatalogs(dir)
+		if err != nil {
+			return r.fail(err)
+		}
+		for lang, translations := range catalogs {
+			for key := range existing[lang] {
+				delete(translations, key)
+			}
+		}
+	}
+
+	merged := make(map[string]int, len(catalogs))
+	for lang, translations := range catalogs {
+		merged[lang] = len(translations)
+	}
+
+	if *dryRun {
+		changes, err := mergePlan(dir, catalogs)
+		if err != nil {
+			return r.fail(err)
+		}
+		if !r.json() {
+			for _, c := range changes {
+				printPlan(mergePath(dir, c.Lang), c)
+			}
+		}
+		return r.done(exitOK, map[string]any{"dir": dir, "merged": merged, "changes": changes, "dry_run": true})
+	}
+
+	if err := i18n.ImportCatalogs(dir, catalogs); err != nil {
+		return r.fail(err)
+	}
+
+	r.printf("✅ Merged %d file(s) into %s\n", fs.NArg(), dir)
+	for _, lang := range slices.Sorted(maps.Keys(merged)) {
+		r.printf("  %s: %d key(s)\n", lang, merged[lang])
+	}
+	return r.done(exitOK, map[string]any{"dir": dir, "merged": merged})
+}
+
+// mergePath returns the catalog of lang that merge writes in dir
+func mergePath(dir, lang string) string {
+	return filepath.Join(dir, fmt.Sprintf("%s.%s.json", i18n.DefaultDictionary, lang))
+}
+
+// mergePlan returns the changes merging catalogs would make to the default
+// catalog of each language in dir, in language order
+func mergePlan(dir string, catalogs map[string]map[string]string) ([]i18n.LocaleChanges, error) {
+	var plan []i18n.LocaleChanges
+	for _, lang := range slices.Sorted(maps.Keys(catalogs)) {
+		current := make(map[string]string)
+		path := mergePath(dir, lang)
+		if _, err := os.Stat(path); err == nil {
+			existing, err := i18n.ReadCatalogs(path)
+			if err != nil {
+				return nil, err
+			}
+			current = existing[lang]
+		}
+
+		after := maps.Clone(current)
+		if after == nil {
+			after = make(map[string]string)
+		}
+		maps.Copy(after, catalogs[lang])
+
+		changes := i18n.LocaleChanges{Lang: lang}
+		if diff := i18n.CompareCatalogs(map[string]map[string]string{lang: current}, map[string]map[string]string{lang: after}); len(diff) > 0 {
+			changes = diff[0]
+		}
+		plan = append(plan, changes)
+	}
+	return plan, nil
+}
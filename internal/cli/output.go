@@ -0,0 +1,173 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"os/exec"
+
+	"github.com/nyxstack/i18n"
+)
+
+// Exit codes shared by every command. They are stable so pipelines can branch
+// on the result without parsing the output.
+const (
+	exitOK         = 0 // success
+	exitValidation = 1 // invalid catalogs or usage, or a failed check
+	exitCoverage   = 2 // a locale's coverage is below the required threshold
+	exitIO         = 3 // a file could not be read or written
+)
+
+// Output formats accepted by the -format flag
+const (
+	formatText        = "text"
+	formatAnnotations = "annotations"
+	formatJSON        = "json"
+)
+
+// summary is the machine-readable outcome of a command printed in JSON mode
+type summary struct {
+	Command  string            `json:"command"`
+	ExitCode int               `json:"exit_code"`
+	Error    string            `json:"error,omitempty"`
+	Problems []i18n.Annotation `json:"problems,omitempty"`
+	Result   any               `json:"result,omitempty"`
+}
+
+// report prints the outcome of a command in the format chosen with -format
+// or -json
+type report struct {
+	command    string
+	format     string
+	verbose    bool
+	configPath string
+	config     *i18n.Config // the project's i18n.yaml, nil if it has none
+}
+
+// newReport registers the -format, -json, -v and -config flags shared by
+// every command
+func newReport(fs *flag.FlagSet) *report {
+	r := &report{command: fs.Name(), format: formatText}
+	fs.StringVar(&r.format, "format", formatText, "output format: text, annotations (GitHub Actions) or json")
+	fs.BoolVar(&r.verbose, "v", false, "also print debug events, such as every file loaded and string found")
+	fs.StringVar(&r.configPath, "config", "", "project config file (default: i18n.yaml in this or a parent directory, \"none\" to ignore it)")
+	fs.BoolFunc("json", "print a JSON summary (same as -format json)", func(string) error {
+		r.format = formatJSON
+		return nil
+	})
+	return r
+}
+
+// newFlagSet returns a flag set that reports errors instead of exiting, so
+// invalid usage maps to exitValidation rather than the flag package's code
+func newFlagSet(name string) *flag.FlagSet {
+	return flag.NewFlagSet(name, flag.ContinueOnError)
+}
+
+// parse parses args, validates the output format and loads the project
+// config. It returns false with the exit code to use if the command should
+// stop.
+func (r *report) parse(fs *flag.FlagSet, args []string) (int, bool) {
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK, false
+		}
+		return exitValidation, false
+	}
+
+	switch r.format {
+	case formatText, formatAnnotations, formatJSON:
+	default:
+		fmt.Fprintf(os.Stderr, "Error: unknown format '%s' (expected %s, %s or %s)\n",
+			r.format, formatText, formatAnnotations, formatJSON)
+		return exitValidation, false
+	}
+
+	r.setLogger()
+	config, err := r.loadConfig()
+	if err != nil {
+		return r.fail(err), false
+	}
+	r.config = config
+	return exitOK, true
+}
+
+// json reports whether the command prints a JSON summary
+func (r *report) json() bool {
+	return r.format == formatJSON
+}
+
+// annotations reports whether problems are printed as GitHub Actions annotations
+func (r *report) annotations() bool {
+	return r.format == formatAnnotations
+}
+
+// printf prints human-readable progress, which JSON mode leaves out
+func (r *report) printf(format string, args ...any) {
+	if !r.json() {
+		fmt.Printf(format, args...)
+	}
+}
+
+// setLogger prints the library's events on stdout, or on stderr in JSON mode
+// so stdout only carries the summary
+func (r *report) setLogger() {
+	h := &consoleHandler{out: os.Stdout, level: slog.LevelInfo}
+	if r.json() {
+		h.out = os.Stderr
+	}
+	if r.verbose {
+		h.level = slog.LevelDebug
+	}
+	i18n.SetLogger(slog.New(h))
+}
+
+// fail reports err and returns its exit code. Annotations go to stdout,
+// where the Actions runner picks up workflow commands.
+func (r *report) fail(err error) int {
+	code := exitCode(err)
+	switch r.format {
+	case formatJSON:
+		r.print(summary{Command: r.command, ExitCode: code, Error: err.Error(), Problems: i18n.ErrorAnnotations(err)})
+	case formatAnnotations:
+		for _, a := range i18n.ErrorAnnotations(err) {
+			fmt.Println(a)
+		}
+	default:
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+	}
+	return code
+}
+
+// done prints the JSON summary of a finished command and returns code
+func (r *report) done(code int, result any) int {
+	if r.json() {
+		r.print(summary{Command: r.command, ExitCode: code, Result: result})
+	}
+	return code
+}
+
+// print writes a summary as indented JSON to stdout
+func (r *report) print(s summary) {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return
+	}
+	fmt.Println(string(data))
+}
+
+// exitCode maps an error to exitIO if a file or command could not be
+// accessed, and to exitValidation otherwise
+func exitCode(err error) int {
+	var pathErr *fs.PathError
+	var execErr *exec.ExitError
+	if errors.As(err, &pathErr) || errors.As(err, &execErr) {
+		return exitIO
+	}
+	return exitValidation
+}
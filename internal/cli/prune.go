@@ -0,0 +1,100 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+
+	"github.com/nyxstack/i18n"
+)
+
+// keepFlag collects repeated or comma-separated -keep patterns
+type keepFlag []string
+
+func (k *keepFlag) String() string { return strings.Join(*k, ",") }
+
+func (k *keepFlag) Set(value string) error {
+	for _, pattern := range strings.Split(value, ",") {
+		if pattern = strings.TrimSpace(pattern); pattern != "" {
+			*k = append(*k, pattern)
+		}
+	}
+	return nil
+}
+
+// runPrune lists the keys of the default catalogs that the code no longer
+// uses, or with -remove deletes them
+func runPrune(args []string) int {
+	var keep keepFlag
+	fs := newFlagSet("prune")
+	fs.Var(&keep, "keep", "keys to keep although the code does not use them literally, as path.Match patterns (repeatable, e.g. 'errors.*')")
+	remove := fs.Bool("remove", false, "delete the unused keys instead of listing them")
+	r := newReport(fs)
+	if code, ok := r.parse(fs, args); !ok {
+		return code
+	}
+
+	sourceDir := "."
+	if fs.NArg() > 0 {
+		sourceDir = fs.Arg(0)
+	}
+	var dir string
+	switch {
+	case fs.NArg() > 1:
+		dir = fs.Arg(1)
+	case r.config != nil:
+		dir = r.config.Dir()
+	default:
+		dir = filepath.Join(sourceDir, i18n.DefaultFolder)
+	}
+
+	locales, err := i18n.DiscoverLocales(dir)
+	if err != nil {
+		return r.fail(err)
+	}
+	if len(locales) == 0 {
+		return r.fail(fmt.Errorf("no %s.*.json catalogs in %s", i18n.DefaultDictionary, dir))
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	unused := make(map[string][]string, len(locales))
+	removed := 0
+	for _, locale := range locales {
+		path := filepath.Join(dir, fmt.Sprintf("%s.%s.json", i18n.DefaultDictionary, locale))
+		keys, err := i18n.FindUnusedKeysContext(ctx, sourceDir, path, keep...)
+		if err != nil {
+			return r.fail(err)
+		}
+		if len(keys) == 0 {
+			continue
+		}
+		unused[path] = keys
+
+		if *remove {
+			n, err := i18n.PruneKeys(path, keys...)
+			if err != nil {
+				return r.fail(err)
+			}
+			removed += n
+			r.printf("🗑️  %s: removed %d unused key(s)\n", path, n)
+			continue
+		}
+		for _, key := range keys {
+			r.printf("%s: %s\n", path, key)
+		}
+	}
+
+	result := map[string]any{"unused": unused, "removed": removed}
+	switch {
+	case len(unused) == 0:
+		r.printf("✅ No unused keys in %s\n", dir)
+	case !*remove:
+		r.printf("Run %s prune -remove to delete them, or -keep the keys built at run time\n", prog)
+	}
+	return r.done(exitOK, result)
+}
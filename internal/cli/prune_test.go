@@ -0,0 +1,75 @@
+package cli
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestRun_Prune(t *testing.T) {
+	enCatalog := filepath.Join("locales", "default.en.json")
+	files := map[string]string{
+		"main.go": mainSource,
+		enCatalog: `{"meta": {"lang": "en", "name": "default"}, "translations": {"welcome": "Welcome", "goodbye": "Goodbye", "stale": "Stale", "errors.timeout": "Timed out"}}`,
+	}
+	runCases(t, []cliCase{
+		{
+			name:       "lists unused keys",
+			files:      files,
+			args:       []string{"prune"},
+			wantStdout: enCatalog + ": stale",
+			check: func(t *testing.T, _ string) {
+				if got := readCatalog(t, enCatalog); len(got.Translations) != 4 {
+					t.Errorf("Expected the catalog left as is, got %v", got.Translations)
+				}
+			},
+		},
+		{
+			name:       "remove",
+			files:      files,
+			args:       []string{"prune", "-remove", ".", "locales"},
+			wantStdout: "removed 2 unused key(s)",
+			check: func(t *testing.T, _ string) {
+				got := readCatalog(t, enCatalog)
+				if _, ok := got.Translations["stale"]; ok || len(got.Translations) != 2 {
+					t.Errorf("Expected the unused keys removed, got %v", got.Translations)
+				}
+			},
+		},
+		{
+			name:       "keep patterns",
+			files:      files,
+			args:       []string{"prune", "-keep", "stale", "-keep", "errors.*", "-remove"},
+			wantStdout: "No unused keys in locales",
+			check: func(t *testing.T, _ string) {
+				if got := readCatalog(t, enCatalog); len(got.Translations) != 4 {
+					t.Errorf("Expected every key kept, got %v", got.Translations)
+				}
+			},
+		},
+		{
+			name:  "json summary",
+			files: files,
+			args:  []string{"prune", "-json"},
+			check: func(t *testing.T, stdout string) {
+				s := decodeSummary(t, stdout)
+				unused := s.Result.(map[string]any)["unused"].(map[string]any)
+				if keys := unused[enCatalog].([]any); len(keys) != 2 {
+					t.Errorf("Expected 2 unused keys in the summary, got %v", unused)
+				}
+			},
+		},
+		{
+			name:       "no catalogs",
+			files:      map[string]string{"main.go": mainSource},
+			args:       []string{"prune"},
+			wantCode:   exitValidation,
+			wantStderr: "no default.*.json catalogs in locales",
+		},
+		{
+			name:       "unknown flag",
+			args:       []string{"prune", "-delete"},
+			wantCode:   exitValidation,
+			wantStderr: "flag provided but not defined: -delete",
+		},
+	})
+}
@@ -0,0 +1,107 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/nyxstack/i18n"
+)
+
+// runStats prints the translation coverage and value statistics of every
+// locale of a catalog directory
+func runStats(args []string) int {
+	fs := newFlagSet("stats")
+	source := fs.String("source", "", "language to measure coverage against (default: the configured source language, else en)")
+	fuzzy := fs.Bool("fuzzy", false, "count and list the translations marked fuzzy, whose source text changed")
+	r := newReport(fs)
+	if code, ok := r.parse(fs, args); !ok {
+		return code
+	}
+
+	dir := r.dir(fs)
+
+	b := i18n.NewBundle()
+	switch {
+	case *source != "":
+		b.SetDefaultLanguage(*source)
+	case r.config != nil && r.config.SourceLanguage() != "":
+		b.SetDefaultLanguage(r.config.SourceLanguage())
+	}
+	if err := b.LoadDir(dir); err != nil {
+		return r.fail(err)
+	}
+
+	lang := b.DefaultLanguage()
+	report := b.CoverageReport(lang)
+	if report == nil {
+		return r.fail(fmt.Errorf("no catalog for the source language '%s' in %s", lang, dir))
+	}
+
+	// Check the coverage against i18n.yaml's thresholds, if any
+	code := exitOK
+	var below []i18n.LocaleStats
+	if r.config != nil {
+		for _, s := range report {
+			if s.Ratio() < r.config.MinCoverage(s.Lang) {
+				below = append(below, s)
+				code = exitCoverage
+			}
+		}
+	}
+
+	if !r.json() {
+		printStats(report, *fuzzy)
+	}
+	for _, s := range below {
+		switch {
+		case r.json():
+		case r.annotations():
+			fmt.Println(i18n.Annotation{
+				Level:   i18n.AnnotationError,
+				File:    filepath.Join(dir, fmt.Sprintf("%s.%s.json", i18n.DefaultDictionary, s.Lang)),
+				Title:   "Translation coverage",
+				Message: fmt.Sprintf("%s is %.1f%% translated, below %.1f%%", s.Lang, s.Ratio()*100, r.config.MinCoverage(s.Lang)*100),
+			})
+		default:
+			fmt.Fprintf(os.Stderr, "❌ %s coverage %.1f%% is below %.1f%%\n", s.Lang, s.Ratio()*100, r.config.MinCoverage(s.Lang)*100)
+		}
+	}
+	return r.done(code, map[string]any{"source": lang, "locales": report})
+}
+
+// printStats prints a table of the statistics of every locale, with a
+// column and then a list of the fuzzy keys if fuzzy is set
+func printStats(report []i18n.LocaleStats, fuzzy bool) {
+	fuzzyColumn := func(v any) string {
+		if !fuzzy {
+			return ""
+		}
+		return fmt.Sprintf("%-6v ", v)
+	}
+	fmt.Printf("%-8s %-18s %-10s %-6s %s%-24s %s\n", "LOCALE", "TRANSLATED", "IDENTICAL", "EXTRA", fuzzyColumn("FUZZY"), "LONGEST", "SHORTEST")
+	for _, s := range report {
+		fmt.Printf("%-8s %-18s %-10d %-6d %s%-24s %s\n", s.Lang,
+			fmt.Sprintf("%d/%d (%.1f%%)", s.Translated, s.Keys, s.Ratio()*100),
+			s.Identical, s.Extra, fuzzyColumn(len(s.Fuzzy)), valueLength(s.Longest), valueLength(s.Shortest))
+	}
+	if !fuzzy {
+		return
+	}
+	for _, s := range report {
+		if len(s.Fuzzy) > 0 {
+			fmt.Printf("\n%s fuzzy:\n", s.Lang)
+			for _, key := range s.Fuzzy {
+				fmt.Printf("  ? %s\n", key)
+			}
+		}
+	}
+}
+
+// valueLength formats a key and the length of its value, or "-" if none
+func valueLength(v *i18n.ValueLength) string {
+	if v == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%s (%d)", v.Key, v.Length)
+}
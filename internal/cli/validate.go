@@ -0,0 +1,61 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/nyxstack/i18n"
+)
+
+// runValidate reports every problem of the catalogs of a directory,
+// comparing their placeholders with those of the source language
+func runValidate(args []string) int {
+	fs := newFlagSet("validate")
+	source := fs.String("source", "", "language whose placeholders the others must use (default: the configured source language, else en)")
+	r := newReport(fs)
+	if code, ok := r.parse(fs, args); !ok {
+		return code
+	}
+
+	dir := r.dir(fs)
+
+	b := i18n.NewBundle()
+	switch {
+	case *source != "":
+		b.SetDefaultLanguage(*source)
+	case r.config != nil && r.config.SourceLanguage() != "":
+		b.SetDefaultLanguage(r.config.SourceLanguage())
+	}
+
+	issues, err := b.ValidateDir(dir)
+	if err != nil {
+		return r.fail(err)
+	}
+
+	result := map[string]any{"issues": issues}
+	if len(issues) == 0 {
+		r.printf("✅ All catalogs in %s are valid\n", dir)
+		return r.done(exitOK, result)
+	}
+
+	for _, issue := range issues {
+		switch {
+		case r.json():
+		case r.annotations():
+			fmt.Println(i18n.Annotation{
+				Level:   i18n.AnnotationError,
+				File:    issue.File,
+				Line:    issue.Line,
+				Column:  issue.Column,
+				Title:   "Invalid catalog",
+				Message: issue.Message,
+			})
+		default:
+			fmt.Fprintf(os.Stderr, "❌ %s\n", issue)
+		}
+	}
+	if !r.json() {
+		fmt.Fprintf(os.Stderr, "%d problem(s) in %s\n", len(issues), dir)
+	}
+	return r.done(exitValidation, result)
+}
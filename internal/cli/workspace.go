@@ -0,0 +1,126 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/nyxstack/i18n"
+)
+
+// runWorkspace extracts every module of a monorepo into its own namespace
+// of a shared locales directory and prints the coverage of each
+func runWorkspace(args []string) int {
+	fs := newFlagSet("workspace")
+	locales := fs.String("locales", "", "comma-separated locales, source language first (default: i18n.yaml's)")
+	output := fs.String("o", "", "catalog directory (default: i18n.yaml's output, else <root>/locales)")
+	dryRun := fs.Bool("dry-run", false, "print the changes without writing the catalogs")
+	r := newReport(fs)
+	if code, ok := r.parse(fs, args); !ok {
+		return code
+	}
+
+	root := "."
+	if fs.NArg() > 0 {
+		root = fs.Arg(0)
+	}
+
+	var list []string
+	for _, locale := range strings.Split(*locales, ",") {
+		if locale = strings.TrimSpace(locale); locale != "" {
+			list = append(list, locale)
+		}
+	}
+	if len(list) == 0 && r.config != nil {
+		list = r.config.Locales
+	}
+	if len(list) == 0 {
+		return r.fail(errors.New("no locales: pass -locales or list them in i18n.yaml"))
+	}
+
+	dir := *output
+	switch {
+	case dir != "":
+	case r.config != nil:
+		dir = r.config.Dir()
+	default:
+		dir = filepath.Join(root, i18n.DefaultFolder)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if *dryRun {
+		plan, err := i18n.PlanWorkspace(ctx, root, dir, list...)
+		if err != nil {
+			return r.fail(err)
+		}
+		if !r.json() {
+			for _, ns := range sortedNamespaces(plan) {
+				fmt.Printf("📦 %s\n", ns)
+				printChanges(plan[ns])
+			}
+		}
+		return r.done(exitOK, map[string]any{"locales": list, "changes": plan, "dry_run": true})
+	}
+
+	report, err := i18n.GenerateWorkspace(ctx, root, dir, list...)
+	if err != nil {
+		return r.fail(err)
+	}
+
+	// Check the coverage against i18n.yaml's thresholds, if any
+	code := exitOK
+	var below []i18n.ModuleCoverage
+	if r.config != nil {
+		for _, c := range report {
+			if c.Ratio() < r.config.MinCoverage(c.Lang) {
+				below = append(below, c)
+				code = exitCoverage
+			}
+		}
+	}
+
+	if !r.json() {
+		printCoverage(report)
+	}
+	for _, c := range below {
+		switch {
+		case r.json():
+		case r.annotations():
+			fmt.Println(i18n.Annotation{
+				Level:   i18n.AnnotationError,
+				File:    filepath.Join(dir, fmt.Sprintf("%s.%s.json", c.Module, c.Lang)),
+				Title:   "Translation coverage",
+				Message: fmt.Sprintf("%s is %.1f%% translated, below %.1f%%", c.Lang, c.Ratio()*100, r.config.MinCoverage(c.Lang)*100),
+			})
+		default:
+			fmt.Fprintf(os.Stderr, "❌ %s/%s coverage %.1f%% is below %.1f%%\n",
+				c.Module, c.Lang, c.Ratio()*100, r.config.MinCoverage(c.Lang)*100)
+		}
+	}
+	return r.done(code, map[string]any{"locales": list, "coverage": report})
+}
+
+// printCoverage prints a table of the coverage of every module and locale
+func printCoverage(report []i18n.ModuleCoverage) {
+	fmt.Printf("%-20s %-8s %s\n", "MODULE", "LOCALE", "TRANSLATED")
+	for _, c := range report {
+		fmt.Printf("%-20s %-8s %d/%d (%.1f%%)\n", c.Module, c.Lang, c.Translated, c.Keys, c.Ratio()*100)
+	}
+}
+
+// sortedNamespaces returns the namespaces of a workspace plan in order
+func sortedNamespaces(plan map[string][]i18n.LocaleChanges) []string {
+	names := make([]string, 0, len(plan))
+	for ns := range plan {
+		names = append(names, ns)
+	}
+	sort.Strings(names)
+	return names
+}
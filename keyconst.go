@@ -0,0 +1,109 @@
+package i18n
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// KeyConstant is a constant GenerateKeyConstants writes for a catalog key
+type KeyConstant struct {
+	Name string `json:"name"` // constant name, e.g. "KeyWelcome"
+	Key  string `json:"key"`
+}
+
+// GenerateKeyConstants writes a Go file to outputPath with a string constant
+// per key of the catalog at catalog, including list keys and keys still
+// waiting for source text, so code can write i18n.T(keys.Welcome) instead of
+// a string a typo would break:
+//
+//	const KeyWelcome = "welcome"
+//
+// Names are prefix followed by the key in camel case ("auth.login_button"
+// becomes KeyAuthLoginButton with prefix "Key"). With group set, the
+// constants are written in one block per namespace, the key's first
+// segment. pkg is the package clause; if empty, it is taken from the Go
+// files already in the output directory, or else the directory's name.
+// It returns the constants written, in key order.
+func GenerateKeyConstants(catalog, pkg, prefix, outputPath string, group bool) ([]KeyConstant, error) {
+	tf, err := readTranslationFile(catalog)
+	if err != nil {
+		return nil, err
+	}
+	if pkg == "" {
+		if pkg, err = outputPackage(filepath.Dir(outputPath)); err != nil {
+			return nil, err
+		}
+	}
+
+	keys := make(map[string]bool, len(tf.Translations)+len(tf.Lists)+len(tf.NeedsSource))
+	for key := range tf.Translations {
+		keys[key] = true
+	}
+	for key := range tf.Lists {
+		keys[key] = true
+	}
+	for _, key := range tf.NeedsSource {
+		keys[key] = true
+	}
+
+	var constants []KeyConstant
+	byName := make(map[string]string, len(keys))
+	blocks := make(map[string][]KeyConstant)
+	for _, key := range sortedKeys(keys) {
+		name := prefix + exportedIdent(key)
+		if !token.IsIdentifier(name) || !token.IsExported(name) {
+			return nil, fmt.Errorf("key '%s' makes no exported identifier with prefix '%s'", key, prefix)
+		}
+		if other, ok := byName[name]; ok {
+			return nil, fmt.Errorf("keys '%s' and '%s' both generate %s", other, key, name)
+		}
+		byName[name] = key
+
+		c := KeyConstant{Name: name, Key: key}
+		constants = append(constants, c)
+		namespace := ""
+		if group {
+			namespace, _, _ = strings.Cut(key, KeySeparator)
+			if namespace == key {
+				namespace = ""
+			}
+		}
+		blocks[namespace] = append(blocks[namespace], c)
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by i18n keys from %s; DO NOT EDIT.\n\n", filepath.Base(catalog))
+	fmt.Fprintf(&buf, "package %s\n", pkg)
+	for _, namespace := range sortedKeys(blocks) {
+		buf.WriteString("\n")
+		if namespace != "" {
+			fmt.Fprintf(&buf, "// Keys of the %s namespace\n", namespace)
+		} else if group {
+			buf.WriteString("// Keys outside any namespace\n")
+		}
+		buf.WriteString("const (\n")
+		for _, c := range blocks[namespace] {
+			fmt.Fprintf(&buf, "\t%s = %q\n", c.Name, c.Key)
+		}
+		buf.WriteString(")\n")
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format generated code: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(outputPath), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create output directory: %w", err)
+	}
+	if err := os.WriteFile(filepath.Clean(outputPath), src, 0644); err != nil {
+		return nil, fmt.Errorf("failed to write %s: %w", outputPath, err)
+	}
+
+	logger().Info("key constants generated", "catalog", catalog, "constants", len(constants), "path", outputPath)
+	return constants, nil
+}
@@ -0,0 +1,75 @@
+package i18n
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestGenerateKeyConstants(t *testing.T) {
+	dir := t.TempDir()
+	catalog := filepath.Join(dir, "default.en.json")
+	writeTestFile(t, catalog, `{
+  "meta": {"lang": "en", "name": "default"},
+  "translations": {"welcome": "Welcome", "auth.login": "Log in", "auth.logout": "Log out", "weekdays": ["Mon"]},
+  "needs_source": ["billing.invoice_due"]
+}`)
+	output := filepath.Join(dir, "keys", "keys.go")
+
+	constants, err := GenerateKeyConstants(catalog, "", "Key", output, true)
+	if err != nil {
+		t.Fatalf("GenerateKeyConstants failed: %v", err)
+	}
+	want := []KeyConstant{
+		{Name: "KeyAuthLogin", Key: "auth.login"},
+		{Name: "KeyAuthLogout", Key: "auth.logout"},
+		{Name: "KeyBillingInvoiceDue", Key: "billing.invoice_due"},
+		{Name: "KeyWeekdays", Key: "weekdays"},
+		{Name: "KeyWelcome", Key: "welcome"},
+	}
+	if !reflect.DeepEqual(constants, want) {
+		t.Errorf("Expected %+v, got %+v", want, constants)
+	}
+
+	data, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := parser.ParseFile(token.NewFileSet(), output, data, 0); err != nil {
+		t.Fatalf("Generated code does not parse: %v\n%s", err, data)
+	}
+	src := string(data)
+	for _, part := range []string{
+		"package keys",
+		"// Keys outside any namespace\nconst (\n\tKeyWeekdays = \"weekdays\"\n\tKeyWelcome  = \"welcome\"\n)",
+		"// Keys of the auth namespace\nconst (\n\tKeyAuthLogin  = \"auth.login\"\n\tKeyAuthLogout = \"auth.logout\"\n)",
+		"// Keys of the billing namespace\n",
+	} {
+		if !strings.Contains(src, part) {
+			t.Errorf("Expected %q in:\n%s", part, src)
+		}
+	}
+}
+
+func TestGenerateKeyConstants_Ungrouped(t *testing.T) {
+	dir := t.TempDir()
+	catalog := writeTestCatalog(t, dir, "default.en.json", "en", map[string]string{"welcome": "Welcome", "auth.login": "Log in"})
+	output := filepath.Join(dir, "keys.go")
+
+	if _, err := GenerateKeyConstants(catalog, "keys", "", output, false); err != nil {
+		t.Fatalf("GenerateKeyConstants failed: %v", err)
+	}
+	data, _ := os.ReadFile(output)
+	if want := "package keys\n\nconst (\n\tAuthLogin = \"auth.login\"\n\tWelcome   = \"welcome\"\n)\n"; !strings.HasSuffix(string(data), want) {
+		t.Errorf("Expected a single block, got:\n%s", data)
+	}
+
+	catalog = writeTestCatalog(t, dir, "default.en.json", "en", map[string]string{"404": "Not found"})
+	if _, err := GenerateKeyConstants(catalog, "keys", "", output, false); err == nil {
+		t.Error("Expected an error for a key that makes no identifier")
+	}
+}
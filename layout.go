@@ -0,0 +1,38 @@
+package i18n
+
+import (
+	"context"
+	"fmt"
+)
+
+// ConvertLayout rewrites every catalog in dir with nested translation keys
+// ("auth": {"login": {"title": ...}}) if nested is true, or with flat dotted
+// keys ("auth.login.title") otherwise. Both layouts load the same way; the
+// extractor and importers keep whichever layout a catalog already uses.
+func ConvertLayout(dir string, nested bool) error {
+	return withCatalogLock(context.Background(), dir, func() error {
+		files, err := catalogFiles(dir)
+		if err != nil {
+			return err
+		}
+		for _, path := range files {
+			tf, err := readTranslationFile(path)
+			if err != nil {
+				return err
+			}
+			if tf.Nested == nested {
+				continue
+			}
+			tf.Nested = nested
+
+			data, err := MarshalCatalog(tf)
+			if err != nil {
+				return fmt.Errorf("failed to marshal dictionary: %w", err)
+			}
+			if err := writeFileAtomic(path, data); err != nil {
+				return fmt.Errorf("failed to save dictionary: %w", err)
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,160 @@
+package i18n
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNestKeys(t *testing.T) {
+	got := nestKeys(map[string]any{
+		"auth.login.title":  "Sign in",
+		"auth.login.button": "Log in",
+		"auth.logout":       "Sign out",
+		"title":             "Dashboard",
+		"menu":              "Menu",
+		"menu.open":         "Open",
+	})
+
+	want := map[string]any{
+		"auth": map[string]any{
+			"login": map[string]any{
+				"title":  "Sign in",
+				"button": "Log in",
+			},
+			"logout": "Sign out",
+		},
+		"title":     "Dashboard",
+		"menu":      "Menu",
+		"menu.open": "Open",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("nestKeys() = %v, want %v", got, want)
+	}
+}
+
+func TestTranslationFile_NestedRoundTrip(t *testing.T) {
+	content := `{
+  "meta": {"lang": "en", "name": "default"},
+  "translations": {
+    "auth": {"login": {"title": "Sign in"}},
+    "menu": "Menu",
+    "menu.open": "Open",
+    "weekdays": ["Mon", "Tue"]
+  }
+}`
+
+	var tf TranslationFile
+	if err := json.Unmarshal([]byte(content), &tf); err != nil {
+		t.Fatalf("Failed to unmarshal: %v", err)
+	}
+	if !tf.Nested {
+		t.Error("Expected a file with object values to be marked nested")
+	}
+	if tf.Translations["auth.login.title"] != "Sign in" {
+		t.Errorf("Expected flattened key auth.login.title, got %v", tf.Translations)
+	}
+
+	data, err := json.Marshal(tf)
+	if err != nil {
+		t.Fatalf("Failed to marshal: %v", err)
+	}
+	if !strings.Contains(string(data), `"auth":{"login":{"title":"Sign in"}}`) {
+		t.Errorf("Expected nested output, got %s", data)
+	}
+
+	var decoded TranslationFile
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Failed to unmarshal marshaled file: %v", err)
+	}
+	if !reflect.DeepEqual(decoded.Translations, tf.Translations) || len(decoded.Lists["weekdays"]) != 2 {
+		t.Errorf("Round trip lost data: %+v", decoded)
+	}
+
+	tf.Nested = false
+	data, err = json.Marshal(tf)
+	if err != nil {
+		t.Fatalf("Failed to marshal: %v", err)
+	}
+	if !strings.Contains(string(data), `"auth.login.title":"Sign in"`) {
+		t.Errorf("Expected flat output, got %s", data)
+	}
+}
+
+func TestTranslationFile_GroupedOrder(t *testing.T) {
+	tf := TranslationFile{
+		Meta:         Meta{Lang: "en", Name: "default"},
+		Translations: map[string]string{"a-title": "Admin", "invoice": "Invoice", "login": "Sign in", "zz-manual": "Manual"},
+		Lists:        map[string][]string{"weekdays": {"Mon", "Tue"}},
+		Groups:       map[string][]string{"billing": {"invoice"}, "auth": {"weekdays", "login"}, "admin": {"a-title"}},
+	}
+	data, err := json.Marshal(tf)
+	if err != nil {
+		t.Fatalf("Failed to marshal: %v", err)
+	}
+	want := `"translations":{"a-title":"Admin","login":"Sign in","weekdays":["Mon","Tue"],"invoice":"Invoice","zz-manual":"Manual"}`
+	if !strings.Contains(string(data), want) {
+		t.Errorf("Expected translations in group order, then ungrouped keys, got %s", data)
+	}
+
+	var decoded TranslationFile
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Failed to unmarshal marshaled file: %v", err)
+	}
+	if !reflect.DeepEqual(decoded.Groups, tf.Groups) || !reflect.DeepEqual(decoded.Translations, tf.Translations) {
+		t.Errorf("Round trip lost data: %+v", decoded)
+	}
+}
+
+func TestConvertLayout(t *testing.T) {
+	dir := t.TempDir()
+	path := writeTestCatalog(t, dir, "default.en.json", "en", map[string]string{
+		"auth.login.title": "Sign in",
+		"title":            "Dashboard",
+	})
+
+	if err := ConvertLayout(dir, true); err != nil {
+		t.Fatalf("ConvertLayout(nested) failed: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read catalog: %v", err)
+	}
+	var raw struct {
+		Translations map[string]any `json:"translations"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		t.Fatalf("Failed to parse catalog: %v", err)
+	}
+	if _, ok := raw.Translations["auth"].(map[string]any); !ok {
+		t.Errorf("Expected nested auth object, got %s", data)
+	}
+
+	dict, err := LoadDictionaryFile(path)
+	if err != nil {
+		t.Fatalf("Failed to load nested catalog: %v", err)
+	}
+	if dict.Get("auth.login.title") != "Sign in" {
+		t.Errorf("Expected 'Sign in', got '%s'", dict.Get("auth.login.title"))
+	}
+
+	// Rewrites keep the nested layout
+	if err := ImportCatalogs(dir, map[string]map[string]string{"en": {"auth.logout": "Sign out"}}); err != nil {
+		t.Fatalf("ImportCatalogs failed: %v", err)
+	}
+	data, _ = os.ReadFile(path)
+	if !strings.Contains(string(data), `"logout": "Sign out"`) {
+		t.Errorf("Expected import to keep the nested layout, got %s", data)
+	}
+
+	if err := ConvertLayout(dir, false); err != nil {
+		t.Fatalf("ConvertLayout(flat) failed: %v", err)
+	}
+	data, _ = os.ReadFile(filepath.Clean(path))
+	if !strings.Contains(string(data), `"auth.login.title": "Sign in"`) {
+		t.Errorf("Expected flat keys, got %s", data)
+	}
+}
@@ -0,0 +1,97 @@
+package i18n
+
+import (
+	"maps"
+	"sync"
+)
+
+// lazyLoader loads the catalogs of a language the first time it is looked up
+type lazyLoader struct {
+	dir       string
+	available map[string]bool // languages with catalog files in dir
+	onError   func(lang string, err error)
+
+	mu    sync.Mutex
+	calls map[string]chan struct{} // closed once the load of a language is done
+}
+
+// LoadLazily makes the global registry load the catalogs of a language from
+// dir the first time it is looked up (see Bundle.LoadLazily)
+func LoadLazily(dir string, onError func(lang string, err error)) error {
+	return defaultBundle.LoadLazily(dir, onError)
+}
+
+// LoadLazily makes the bundle load the catalogs of a language from dir
+// ({name}.{lang}.json, merged) the first time GetDictionary or a translation
+// asks for it, instead of loading every language at startup. Concurrent
+// first lookups share a single load. Only languages with files in dir when
+// LoadLazily is called are loaded; lookups of other languages never touch
+// the disk. A language that fails to load is reported to onError, which may
+// be nil, and is not retried. Languages lists only loaded languages.
+//
+// Example:
+//
+//	i18n.Load() // the default language, needed for fallback
+//	i18n.LoadLazily("locales", func(lang string, err error) {
+//		log.Printf("failed to load %s: %v", lang, err)
+//	})
+func (b *Bundle) LoadLazily(dir string, onError func(lang string, err error)) error {
+	files, err := catalogFiles(dir)
+	if err != nil {
+		return err
+	}
+
+	available := make(map[string]bool)
+	for _, file := range files {
+		if lang := fileLanguage(file); lang != "" {
+			available[lang] = true
+		}
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.lazy = &lazyLoader{
+		dir:       dir,
+		available: available,
+		onError:   onError,
+		calls:     make(map[string]chan struct{}),
+	}
+	return nil
+}
+
+// servedLanguages returns the sorted languages the bundle serves: those
+// registered and those it loads on first use
+func (b *Bundle) servedLanguages() []string {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if b.lazy == nil {
+		return sortedKeys(b.dictionaries)
+	}
+	langs := maps.Clone(b.lazy.available)
+	for lang := range b.dictionaries {
+		langs[lang] = true
+	}
+	return sortedKeys(langs)
+}
+
+// load loads lang into b once; concurrent callers wait for the first one
+func (l *lazyLoader) load(b *Bundle, lang string) {
+	if !l.available[lang] {
+		return
+	}
+
+	l.mu.Lock()
+	if done, ok := l.calls[lang]; ok {
+		l.mu.Unlock()
+		<-done
+		return
+	}
+	done := make(chan struct{})
+	l.calls[lang] = done
+	l.mu.Unlock()
+	defer close(done)
+
+	if err := b.LoadDirLanguage(l.dir, lang); err != nil && l.onError != nil {
+		l.onError(lang, err)
+	}
+}
@@ -0,0 +1,73 @@
+package i18n
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestLoadLazily(t *testing.T) {
+	dir := t.TempDir()
+	writeTestCatalog(t, dir, "default.en.json", "en", map[string]string{"title": "Dashboard"})
+	writeTestCatalog(t, dir, "default.de.json", "de", map[string]string{"title": "Übersicht"})
+	writeTestCatalog(t, dir, "auth.de.json", "de", map[string]string{"login": "Anmelden"})
+
+	b := NewBundle()
+	var loads int
+	b.OnChange(func(lang string) { loads++ })
+	if err := b.LoadLazily(dir, nil); err != nil {
+		t.Fatalf("LoadLazily failed: %v", err)
+	}
+	if langs := b.Languages(); len(langs) != 0 {
+		t.Errorf("Expected nothing loaded up front, got %v", langs)
+	}
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if got := b.T("login")("de"); got != "Anmelden" {
+				t.Errorf("Expected 'Anmelden', got %q", got)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if loads != 1 {
+		t.Errorf("Expected de to be loaded once, got %d loads", loads)
+	}
+	if dict := b.GetDictionary("de"); dict == nil || dict.Count() != 2 {
+		t.Errorf("Expected merged de dictionary, got %v", dict)
+	}
+	if langs := b.Languages(); len(langs) != 1 || langs[0] != "de" {
+		t.Errorf("Expected only de loaded, got %v", langs)
+	}
+
+	// Languages without files, including ones that look like paths, are ignored
+	for _, lang := range []string{"ja", "../de", "*"} {
+		if b.GetDictionary(lang) != nil {
+			t.Errorf("Expected no dictionary for %q", lang)
+		}
+	}
+}
+
+func TestLoadLazily_Error(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "default.fr.json"), []byte("{"), 0644); err != nil {
+		t.Fatalf("Failed to write catalog: %v", err)
+	}
+
+	b := NewBundle()
+	var failed []string
+	if err := b.LoadLazily(dir, func(lang string, err error) { failed = append(failed, lang) }); err != nil {
+		t.Fatalf("LoadLazily failed: %v", err)
+	}
+
+	b.GetDictionary("fr")
+	b.GetDictionary("fr")
+	if len(failed) != 1 || failed[0] != "fr" {
+		t.Errorf("Expected one reported failure for fr, got %v", failed)
+	}
+}
@@ -0,0 +1,183 @@
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Lint rules, the Rule of the issues LintDir reports
+const (
+	RulePlaceholders = "placeholders" // placeholders differ from the default language's
+	RulePluralOther  = "plural-other" // a plural block has no "other" form
+	RuleStrayHash    = "stray-hash"   // "#" outside a plural or selectordinal block
+)
+
+// LintDir checks the translations of every catalog in dir for mistakes that
+// load fine but render wrong:
+//
+//   - placeholders: the placeholders of a translation differ from those of the
+//     default language's catalog of the same name ("fr" uses {1} where "en"
+//     only has {0})
+//   - plural-other: a {count, plural, ...} block has no "other" form, which
+//     counts without a form of their own fall back to
+//   - stray-hash: a translation uses "#" outside plural and selectordinal
+//     blocks, where it is not replaced by the number, while the default
+//     language's value does not
+//
+// The Rule of each issue is one of RulePlaceholders, RulePluralOther and
+// RuleStrayHash. Catalogs that cannot be read or decoded are an error; see
+// ValidateDir to report those.
+//
+// Example:
+//
+//	issues, err := i18n.LintDir("locales")
+//	for _, issue := range issues {
+//		log.Printf("%s [%s]", issue, issue.Rule)
+//	}
+func LintDir(dir string) ([]ValidationIssue, error) {
+	return defaultBundle.LintDir(dir)
+}
+
+// LintDir is like the package-level LintDir but compares translations with
+// the bundle's default language
+func (b *Bundle) LintDir(dir string) ([]ValidationIssue, error) {
+	files, err := catalogFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	catalogs := make([]catalogData, 0, len(files))
+	for _, file := range files {
+		c, err := readCatalogData(file)
+		if err != nil {
+			return nil, err
+		}
+		catalogs = append(catalogs, c)
+	}
+	return lintCatalogs(catalogs, b.DefaultLanguage(), placeholderRule, pluralOtherRule, strayHashRule), nil
+}
+
+// catalogData is a decoded catalog with the bytes it was decoded from, to
+// locate its keys
+type catalogData struct {
+	path string
+	data []byte
+	tf   *TranslationFile
+}
+
+// readCatalogData reads and decodes the catalog at path
+func readCatalogData(path string) (catalogData, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return catalogData{}, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	tf, err := decodeTranslationFile(data)
+	if err != nil {
+		return catalogData{}, withPath(err, path, "invalid translation file")
+	}
+	return catalogData{path: path, data: data, tf: tf}, nil
+}
+
+// lintRule checks one translation. source is the default language's value of
+// the key, nil in the default language's own catalogs and for keys it does
+// not have. check returns a message describing the problem, or "".
+type lintRule struct {
+	name  string
+	check func(value string, source *string, defaultLang string) string
+}
+
+var placeholderRule = lintRule{RulePlaceholders, func(value string, source *string, defaultLang string) string {
+	if source == nil {
+		return ""
+	}
+	return placeholderDifference(placeholderNames(*source), placeholderNames(value), defaultLang)
+}}
+
+var pluralOtherRule = lintRule{RulePluralOther, func(value string, _ *string, _ string) string {
+	for {
+		start, end, cases, ok := parseBlock(value, "plural")
+		if !ok {
+			return ""
+		}
+		if !hasOtherCase(cases) {
+			return "plural block has no 'other' form"
+		}
+		value = value[:start] + value[end:]
+	}
+}}
+
+var strayHashRule = lintRule{RuleStrayHash, func(value string, source *string, defaultLang string) string {
+	if source == nil || !strings.Contains(outsideCountBlocks(value), "#") || strings.Contains(outsideCountBlocks(*source), "#") {
+		return ""
+	}
+	return "'#' outside a plural block is not replaced by the count"
+}}
+
+// lintCatalogs checks every translation of catalogs with rules, in file and
+// key order. Translations are compared with those of the defaultLang catalog
+// of the same name.
+func lintCatalogs(catalogs []catalogData, defaultLang string, rules ...lintRule) []ValidationIssue {
+	// The default language's values, by catalog name and key
+	reference := make(map[string]map[string]string)
+	for _, c := range catalogs {
+		if c.tf.Meta.Lang != defaultLang {
+			continue
+		}
+		if reference[c.tf.Meta.Name] == nil {
+			reference[c.tf.Meta.Name] = make(map[string]string)
+		}
+		for key, value := range c.tf.Translations {
+			reference[c.tf.Meta.Name][key] = value
+		}
+	}
+
+	var issues []ValidationIssue
+	for _, c := range catalogs {
+		for _, key := range sortedKeys(c.tf.Translations) {
+			var source *string
+			if sourceValue, ok := reference[c.tf.Meta.Name][key]; ok && c.tf.Meta.Lang != defaultLang {
+				source = &sourceValue
+			}
+			for _, rule := range rules {
+				message := rule.check(c.tf.Translations[key], source, defaultLang)
+				if message == "" {
+					continue
+				}
+				issue := ValidationIssue{File: c.path, Lang: c.tf.Meta.Lang, Key: key, Rule: rule.name, Message: message}
+				if offset := keyOffset(c.data, key); offset >= 0 {
+					issue.Line, issue.Column = position(c.data, offset)
+				}
+				issues = append(issues, issue)
+			}
+		}
+	}
+	return issues
+}
+
+// hasOtherCase reports whether the branches of a plural block include
+// "other". The block's "offset:n" is read with its first branch.
+func hasOtherCase(cases map[string]string) bool {
+	for selector := range cases {
+		if fields := strings.Fields(selector); len(fields) > 0 && fields[len(fields)-1] == "other" {
+			return true
+		}
+	}
+	return false
+}
+
+// outsideCountBlocks returns a template without its plural and selectordinal
+// blocks, the text where "#" is literal
+func outsideCountBlocks(template string) string {
+	for _, kind := range []string{"plural", "selectordinal"} {
+		for {
+			start, end, _, ok := parseBlock(template, kind)
+			if !ok {
+				break
+			}
+			template = template[:start] + template[end:]
+		}
+	}
+	return template
+}
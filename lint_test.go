@@ -0,0 +1,81 @@
+package i18n
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLintDir(t *testing.T) {
+	dir := t.TempDir()
+	writeTestCatalog(t, dir, "default.en.json", "en", map[string]string{
+		"greeting":   "Hello {0}",
+		"items":      "{count, plural, one {# item} other {# items}}",
+		"order":      "Order #{0}",
+		"rank":       "You finished {pos, selectordinal, one {#st} other {#th}}",
+		"few_only":   "{count, plural, one {# file}}",
+		"nested":     "{gender, select, male {{count, plural, one {# his} other {# his}}} other {{count, plural, one {# their} other {# their}}}}",
+		"with_offst": "{count, plural, offset:1 =0 {nobody} other {# others}}",
+	})
+	writeTestCatalog(t, dir, "default.fr.json", "fr", map[string]string{
+		"greeting":   "Bonjour {1}",
+		"items":      "# éléments, {count, plural, one {# élément}}",
+		"order":      "Commande #{0}",
+		"rank":       "Vous avez fini {pos, selectordinal, one {#er} other {#e}}",
+		"nested":     "{gender, select, male {{count, plural, one {# le sien} other {# les siens}}} other {{count, plural, one {# le leur} other {# les leurs}}}}",
+		"with_offst": "{count, plural, offset:1 =0 {personne} other {# autres}}",
+	})
+
+	issues, err := LintDir(dir)
+	if err != nil {
+		t.Fatalf("LintDir failed: %v", err)
+	}
+
+	var got []string
+	for _, issue := range issues {
+		got = append(got, filepath.Base(issue.File)+" "+issue.Key+" "+issue.Rule+": "+issue.Message)
+	}
+	want := []string{
+		"default.en.json few_only plural-other: plural block has no 'other' form",
+		"default.fr.json greeting placeholders: placeholders differ from 'en': missing {0}; has {1}",
+		"default.fr.json items plural-other: plural block has no 'other' form",
+		"default.fr.json items stray-hash: '#' outside a plural block is not replaced by the count",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected issues\n%s\ngot\n%s", strings.Join(want, "\n"), strings.Join(got, "\n"))
+	}
+
+	for _, issue := range issues {
+		if issue.Lang == "" || issue.Line == 0 {
+			t.Errorf("Expected a language and line for %s", issue)
+		}
+	}
+}
+
+func TestLintDir_Bundle(t *testing.T) {
+	dir := t.TempDir()
+	writeTestCatalog(t, dir, "default.en.json", "en", map[string]string{"hello": "Hello {0}"})
+	writeTestCatalog(t, dir, "default.fr.json", "fr", map[string]string{"hello": "Bonjour {name}"})
+
+	b := NewBundle()
+	b.SetDefaultLanguage("fr")
+	issues, err := b.LintDir(dir)
+	if err != nil {
+		t.Fatalf("LintDir failed: %v", err)
+	}
+	if len(issues) != 1 || issues[0].Lang != "en" || issues[0].Rule != RulePlaceholders {
+		t.Errorf("Expected the en catalog compared with fr, got %v", issues)
+	}
+}
+
+func TestLintDir_InvalidCatalog(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "default.en.json")
+	writeTestFile(t, path, `{"meta": {"lang": "en"`)
+
+	_, err := LintDir(dir)
+	if err == nil || !strings.Contains(err.Error(), path) {
+		t.Errorf("Expected an error naming %s, got %v", path, err)
+	}
+}
@@ -0,0 +1,260 @@
+package i18n
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// LoadDir registers every catalog in dir ({name}.{lang}.json), merging files
+// that share a language, so callers need not know which languages exist.
+// Keys of dictionaries other than "default" are merged under their name, as
+// read by NS.
+// Valid catalogs are registered even if others fail; the returned error joins
+// every failure.
+//
+// Example:
+//
+//	if err := i18n.LoadDir("locales"); err != nil {
+//		log.Printf("some catalogs failed to load: %v", err)
+//	}
+func LoadDir(dir string) error {
+	return defaultBundle.LoadDir(dir)
+}
+
+// LoadDirContext is like LoadDir but stops reading files once ctx is done,
+// returning ctx's error without registering any catalog
+func LoadDirContext(ctx context.Context, dir string) error {
+	return defaultBundle.LoadDirContext(ctx, dir)
+}
+
+// LoadDir is like the package-level LoadDir but registers into the bundle
+func (b *Bundle) LoadDir(dir string) error {
+	return b.LoadDirContext(context.Background(), dir)
+}
+
+// LoadDirContext is like the package-level LoadDirContext but registers into the bundle
+func (b *Bundle) LoadDirContext(ctx context.Context, dir string) error {
+	dicts, errs := readDir(ctx, dir)
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	for _, lang := range sortedKeys(dicts) {
+		b.Register(dicts[lang])
+	}
+	return errors.Join(errs...)
+}
+
+// LoadRoots registers the catalogs of several locale directories layered in
+// precedence order: each root overrides the keys of the roots before it, so
+// list shared catalogs first and overrides last. Keys missing from a later
+// root keep their earlier value, and roots that do not exist are skipped.
+// Like LoadDir, valid catalogs are registered even if others fail.
+//
+// Example:
+//
+//	// vendor defaults < app catalogs < deployment overrides
+//	err := i18n.LoadRoots("vendor-locales", "locales", "/etc/app/locales")
+func LoadRoots(roots ...string) error {
+	return defaultBundle.LoadRoots(roots...)
+}
+
+// LoadRoots is like the package-level LoadRoots but registers into the bundle
+func (b *Bundle) LoadRoots(roots ...string) error {
+	var errs []error
+	layered := make(map[string]*Dictionary)
+	for _, root := range roots {
+		dicts, rootErrs := readDir(context.Background(), root)
+		errs = append(errs, rootErrs...)
+		for _, lang := range sortedKeys(dicts) {
+			if dict, ok := layered[lang]; ok {
+				dict.merge(dicts[lang])
+			} else {
+				layered[lang] = dicts[lang]
+			}
+		}
+	}
+
+	for _, lang := range sortedKeys(layered) {
+		b.Register(layered[lang])
+	}
+	return errors.Join(errs...)
+}
+
+// LoadDirLanguage registers the catalogs of one language in dir
+// ({name}.{lang}.json), merged, replacing the registered dictionary of that
+// language in one step. Other languages are left untouched, which makes it
+// suitable for reloading a language after its files change. Files whose
+// content is unchanged are not registered again (see OnChange).
+func LoadDirLanguage(dir, lang string) error {
+	return defaultBundle.LoadDirLanguage(dir, lang)
+}
+
+// LoadDirLanguage is like the package-level LoadDirLanguage but registers into the bundle
+func (b *Bundle) LoadDirLanguage(dir, lang string) error {
+	files, err := filepath.Glob(filepath.Join(dir, "*."+lang+".json"))
+	if err != nil {
+		return fmt.Errorf("failed to list catalogs in %s: %w", dir, err)
+	}
+	sort.Strings(files)
+
+	dicts, errs := readFiles(context.Background(), files)
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+	dict := dicts[lang]
+	if dict == nil {
+		return nil
+	}
+	if current := b.registered(lang); current != nil && current.Hash() == dict.Hash() {
+		return nil
+	}
+	b.set(dict)
+	b.notifyChange(lang)
+	return nil
+}
+
+// LoadDirStrict loads every catalog in dir but only registers a locale whose
+// coverage against the default language is at least minCoverage (0.8 = 80%).
+// Sparse locales are left out so they never appear in a language picker.
+// The returned error joins every rejected locale and unreadable file.
+func LoadDirStrict(dir string, minCoverage float64) error {
+	return defaultBundle.LoadDirStrict(dir, minCoverage)
+}
+
+// LoadDirStrict is like the package-level LoadDirStrict but registers into the bundle
+func (b *Bundle) LoadDirStrict(dir string, minCoverage float64) error {
+	dicts, errs := readDir(context.Background(), dir)
+
+	defaultLang := b.DefaultLanguage()
+	reference := dicts[defaultLang]
+	if reference == nil {
+		reference = b.GetDictionary(defaultLang)
+	}
+	if reference == nil {
+		errs = append(errs, fileError(defaultLang, "", "no dictionary for default language '%s' to measure coverage against", defaultLang))
+		return errors.Join(errs...)
+	}
+
+	for _, lang := range sortedKeys(dicts) {
+		dict := dicts[lang]
+		if lang != defaultLang {
+			if c := coverage(dict, reference); c < minCoverage {
+				errs = append(errs, fileError(lang, "", "locale '%s' not registered: coverage %.1f%% is below %.1f%%",
+					lang, c*100, minCoverage*100))
+				continue
+			}
+		}
+		b.Register(dict)
+	}
+
+	return errors.Join(errs...)
+}
+
+// readDir loads every catalog file in dir, merging files that share a
+// language. It stops early once ctx is done.
+func readDir(ctx context.Context, dir string) (map[string]*Dictionary, []error) {
+	files, err := catalogFiles(dir)
+	if err != nil {
+		return nil, []error{err}
+	}
+	return readFiles(ctx, files)
+}
+
+// readFiles loads catalog files, merging files that share a language. Keys
+// of dictionaries other than the default one are namespaced (see NS). It
+// stops early once ctx is done.
+func readFiles(ctx context.Context, files []string) (map[string]*Dictionary, []error) {
+	var errs []error
+	dicts := make(map[string]*Dictionary)
+	for _, file := range files {
+		if ctx.Err() != nil {
+			break
+		}
+
+		tf, err := readTranslationFile(file)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		namespaceKeys(tf)
+		loaded := newDictionaryFromFile(tf)
+
+		if lang := fileLanguage(file); lang != "" && lang != loaded.Lang {
+			fe := fileError(loaded.Lang, "", "file name language '%s' does not match meta.lang '%s'", lang, loaded.Lang)
+			fe.Path = file
+			errs = append(errs, fe)
+			continue
+		}
+
+		if dict, ok := dicts[loaded.Lang]; ok {
+			dict.merge(loaded)
+		} else {
+			dicts[loaded.Lang] = loaded
+		}
+	}
+	return dicts, errs
+}
+
+// fileLanguage returns the language of a {name}.{lang}.json file name, or ""
+// if the name has no language part
+func fileLanguage(path string) string {
+	parts := strings.Split(strings.TrimSuffix(filepath.Base(path), ".json"), ".")
+	if len(parts) < 2 {
+		return ""
+	}
+	return parts[len(parts)-1]
+}
+
+// merge copies all translations, lists, scheduled values, fuzzy marks and
+// states of other into d.
+// d keeps its meta unless other is the default dictionary.
+func (d *Dictionary) merge(other *Dictionary) {
+	other.mu.RLock()
+	defer other.mu.RUnlock()
+
+	if other.meta.Name == DefaultDictionary {
+		d.mu.Lock()
+		d.meta = other.meta
+		d.mu.Unlock()
+	}
+
+	d.AddAll(other.Translations)
+	for key, list := range other.Lists {
+		d.AddSlice(key, list)
+	}
+	for key, values := range other.Schedule {
+		for _, value := range values {
+			d.AddScheduled(key, value)
+		}
+	}
+	for key := range other.fuzzy {
+		d.SetFuzzy(key, true)
+	}
+	for key, state := range other.states {
+		d.SetState(key, state)
+	}
+}
+
+// coverage returns the fraction of the reference keys translated in dict.
+// Fuzzy values, such as new keys the extractor filled with the source text,
+// are not translated yet.
+func coverage(dict, reference *Dictionary) float64 {
+	keys := reference.Keys()
+	if len(keys) == 0 {
+		return 1
+	}
+
+	fuzzy := dict.Fuzzy()
+	translated := 0
+	for _, key := range keys {
+		if dict.Has(key) && !slices.Contains(fuzzy, key) {
+			translated++
+		}
+	}
+	return float64(translated) / float64(len(keys))
+}
@@ -0,0 +1,216 @@
+package i18n
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadDirStrict(t *testing.T) {
+	dir := t.TempDir()
+	writeTestCatalog(t, dir, "default.en.json", "en", map[string]string{"a": "A", "b": "B", "c": "C", "d": "D"})
+	writeTestCatalog(t, dir, "default.fr.json", "fr", map[string]string{"a": "A", "b": "B", "c": "C"})
+	writeTestCatalog(t, dir, "default.de.json", "de", map[string]string{"a": "A"})
+	writeTestCatalog(t, dir, "auth.de.json", "de", map[string]string{"b": "B"})
+
+	b := NewBundle()
+	err := b.LoadDirStrict(dir, 0.75)
+	if err == nil {
+		t.Fatal("Expected error for sparse locale, got nil")
+	}
+	if !strings.Contains(err.Error(), "'de'") || !strings.Contains(err.Error(), "50.0%") {
+		t.Errorf("Expected error to mention de at 50%%, got: %v", err)
+	}
+
+	if b.GetDictionary("en") == nil || b.GetDictionary("fr") == nil {
+		t.Error("Expected en and fr to be registered")
+	}
+	if b.GetDictionary("de") != nil {
+		t.Error("Expected de not to be registered")
+	}
+
+	// Lower threshold registers every locale, merging files of the same language
+	b = NewBundle()
+	if err := b.LoadDirStrict(dir, 0.5); err != nil {
+		t.Fatalf("LoadDirStrict failed: %v", err)
+	}
+	if de := b.GetDictionary("de"); de == nil || de.Count() != 2 {
+		t.Errorf("Expected merged de dictionary with 2 keys, got %v", de)
+	}
+}
+
+func TestLoadDirStrict_UsesRegisteredDefault(t *testing.T) {
+	dir := t.TempDir()
+	writeTestCatalog(t, dir, "default.fr.json", "fr", map[string]string{"a": "A"})
+
+	b := NewBundle()
+	if err := b.LoadDirStrict(dir, 0.5); err == nil {
+		t.Error("Expected error without a default language dictionary, got nil")
+	}
+
+	en := NewDictionary("en")
+	en.AddAll(map[string]string{"a": "A", "b": "B"})
+	b.Register(en)
+
+	if err := b.LoadDirStrict(dir, 0.5); err != nil {
+		t.Fatalf("LoadDirStrict failed: %v", err)
+	}
+	if b.GetDictionary("fr") == nil {
+		t.Error("Expected fr to be registered against the registered default")
+	}
+}
+
+func TestLoadDirStrict_InvalidFile(t *testing.T) {
+	dir := t.TempDir()
+	writeTestCatalog(t, dir, "default.en.json", "en", map[string]string{"a": "A"})
+	if err := os.WriteFile(filepath.Join(dir, "broken.fr.json"), []byte("not json"), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	b := NewBundle()
+	err := b.LoadDirStrict(dir, 1)
+	if err == nil || !strings.Contains(err.Error(), "broken.fr.json") {
+		t.Errorf("Expected error for broken file, got %v", err)
+	}
+	if b.GetDictionary("en") == nil {
+		t.Error("Expected valid catalogs to be registered despite errors")
+	}
+}
+
+func TestCoverage(t *testing.T) {
+	reference := NewDictionary("en")
+	dict := NewDictionary("fr")
+
+	if c := coverage(dict, reference); c != 1 {
+		t.Errorf("Expected full coverage against empty reference, got %f", c)
+	}
+
+	reference.AddAll(map[string]string{"a": "A", "b": "B", "c": "C", "d": "D"})
+	dict.AddAll(map[string]string{"a": "A", "extra": "X"})
+
+	if c := coverage(dict, reference); c != 0.25 {
+		t.Errorf("Expected coverage 0.25, got %f", c)
+	}
+}
+
+func TestLoadDir(t *testing.T) {
+	dir := t.TempDir()
+	writeTestCatalog(t, dir, "default.en.json", "en", map[string]string{"a": "A"})
+	writeTestCatalog(t, dir, "default.fr.json", "fr", map[string]string{"a": "A-fr"})
+	writeTestCatalog(t, dir, "auth.fr.json", "fr", map[string]string{"b": "B-fr"})
+	writeTestCatalog(t, dir, "default.de.json", "es", map[string]string{"a": "A-es"})
+	if err := os.WriteFile(filepath.Join(dir, "broken.it.json"), []byte("not json"), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	b := NewBundle()
+	err := b.LoadDir(dir)
+	if err == nil {
+		t.Fatal("Expected errors for broken and mismatched files, got nil")
+	}
+	for _, want := range []string{"broken.it.json", "default.de.json", "'de' does not match meta.lang 'es'"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected error to contain %q, got: %v", want, err)
+		}
+	}
+
+	if got := b.Languages(); len(got) != 2 || got[0] != "en" || got[1] != "fr" {
+		t.Errorf("Expected en and fr to be registered, got %v", got)
+	}
+	if fr := b.GetDictionary("fr"); fr == nil || fr.Count() != 2 {
+		t.Errorf("Expected merged fr dictionary with 2 keys, got %v", fr)
+	}
+}
+
+func TestLoadRoots(t *testing.T) {
+	vendor, app, override := t.TempDir(), t.TempDir(), t.TempDir()
+	writeTestCatalog(t, vendor, "default.en.json", "en", map[string]string{"save": "Save", "cancel": "Cancel"})
+	writeTestCatalog(t, vendor, "default.de.json", "de", map[string]string{"save": "Speichern"})
+	writeTestCatalog(t, app, "default.en.json", "en", map[string]string{"save": "Save changes", "title": "Dashboard"})
+	writeTestCatalog(t, override, "default.en.json", "en", map[string]string{"title": "Acme Dashboard"})
+	os.WriteFile(filepath.Join(override, "broken.fr.json"), []byte("{"), 0644)
+
+	b := NewBundle()
+	err := b.LoadRoots(vendor, app, override, filepath.Join(override, "missing"))
+	if err == nil || !strings.Contains(err.Error(), "broken.fr.json") {
+		t.Errorf("Expected error for the broken catalog, got %v", err)
+	}
+
+	en := b.GetDictionary("en")
+	if en == nil {
+		t.Fatal("Expected en to be registered")
+	}
+	for key, want := range map[string]string{"save": "Save changes", "cancel": "Cancel", "title": "Acme Dashboard"} {
+		if got := en.Get(key); got != want {
+			t.Errorf("en %q = %q, want %q", key, got, want)
+		}
+	}
+	if de := b.GetDictionary("de"); de == nil || de.Get("save") != "Speichern" {
+		t.Errorf("Expected de from the vendor root, got %v", de)
+	}
+}
+
+func TestLoadDirLanguage(t *testing.T) {
+	dir := t.TempDir()
+	writeTestCatalog(t, dir, "default.en.json", "en", map[string]string{"a": "A"})
+	writeTestCatalog(t, dir, "default.fr.json", "fr", map[string]string{"a": "A (fr)"})
+	writeTestCatalog(t, dir, "auth.fr.json", "fr", map[string]string{"b": "B (fr)"})
+
+	b := NewBundle()
+	en := NewDictionary("en")
+	en.Add("a", "registered")
+	b.Register(en)
+
+	if err := b.LoadDirLanguage(dir, "fr"); err != nil {
+		t.Fatalf("LoadDirLanguage failed: %v", err)
+	}
+	if fr := b.GetDictionary("fr"); fr == nil || fr.Count() != 2 {
+		t.Errorf("Expected merged fr dictionary with 2 keys, got %v", fr)
+	}
+	if b.GetDictionary("en") != en {
+		t.Error("Expected en to be left untouched")
+	}
+
+	os.WriteFile(filepath.Join(dir, "auth.fr.json"), []byte("{"), 0644)
+	fr := b.GetDictionary("fr")
+	if err := b.LoadDirLanguage(dir, "fr"); err == nil {
+		t.Error("Expected error for the broken catalog, got nil")
+	}
+	if b.GetDictionary("fr") != fr {
+		t.Error("Expected the previous fr dictionary to stay registered after a failed reload")
+	}
+}
+
+func TestFileLanguage(t *testing.T) {
+	tests := map[string]string{
+		"locales/default.en.json": "en",
+		"locales/auth.pt-BR.json": "pt-BR",
+		"locales/custom.json":     "",
+		"a.b.fr.json":             "fr",
+	}
+
+	for path, expected := range tests {
+		if result := fileLanguage(path); result != expected {
+			t.Errorf("fileLanguage(%q) = %q, expected %q", path, result, expected)
+		}
+	}
+}
+
+func TestLoadDirContext_Canceled(t *testing.T) {
+	dir := t.TempDir()
+	writeTestCatalog(t, dir, "default.en.json", "en", map[string]string{"a": "A"})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	b := NewBundle()
+	if err := b.LoadDirContext(ctx, dir); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	if len(b.Languages()) != 0 {
+		t.Errorf("Expected nothing registered after cancellation, got %v", b.Languages())
+	}
+}
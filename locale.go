@@ -0,0 +1,99 @@
+package i18n
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// MatchLocale returns the available locale that best matches an Accept-Language
+// header, or "" if none matches. Language ranges are tried in order of
+// descending quality using RFC 4647 lookup: each range is progressively
+// truncated ("zh-Hant-CN" → "zh-Hant" → "zh") until an available locale
+// matches, comparing case-insensitively.
+//
+// Example:
+//
+//	i18n.MatchLocale("fr-CA;q=0.9, en;q=0.8", []string{"en", "fr"}) // "fr"
+//	i18n.MatchLocale("ja", []string{"en", "fr"})                    // ""
+func MatchLocale(acceptLanguageHeader string, available []string) string {
+	return lookupLocale(parseAcceptLanguage(acceptLanguageHeader), available)
+}
+
+// parseAcceptLanguage returns the language ranges of an Accept-Language header
+// ordered by descending quality, dropping ranges with q=0
+func parseAcceptLanguage(header string) []string {
+	type weighted struct {
+		tag string
+		q   float64
+	}
+
+	var tags []weighted
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		tag = strings.TrimSpace(tag)
+		if tag == "" {
+			continue
+		}
+
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if q <= 0 {
+			continue
+		}
+		tags = append(tags, weighted{tag, q})
+	}
+
+	sort.SliceStable(tags, func(i, j int) bool { return tags[i].q > tags[j].q })
+
+	result := make([]string, len(tags))
+	for i, t := range tags {
+		result[i] = t.tag
+	}
+	return result
+}
+
+// lookupLocale implements RFC 4647 section 3.4 lookup of language ranges
+// against the available locales. The wildcard range "*" is skipped.
+func lookupLocale(ranges, available []string) string {
+	for _, r := range ranges {
+		if r == "*" {
+			continue
+		}
+
+		for candidate := normalizeTag(r); candidate != ""; candidate = truncateTag(candidate) {
+			for _, lang := range available {
+				if strings.EqualFold(normalizeTag(lang), candidate) {
+					return lang
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// normalizeTag lowercases a tag and uses "-" as subtag separator
+func normalizeTag(tag string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(tag), "_", "-"))
+}
+
+// truncateTag removes the last subtag of a tag, also removing a preceding
+// single-character subtag (such as the "x" of a private use sequence)
+func truncateTag(tag string) string {
+	i := strings.LastIndex(tag, "-")
+	if i < 0 {
+		return ""
+	}
+	tag = tag[:i]
+
+	if j := strings.LastIndex(tag, "-"); j >= 0 && len(tag)-j-1 == 1 {
+		tag = tag[:j]
+	}
+	return tag
+}
@@ -0,0 +1,70 @@
+package i18n
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMatchLocale(t *testing.T) {
+	available := []string{"en", "fr", "pt-BR", "zh-Hant", "de"}
+
+	tests := []struct {
+		header   string
+		expected string
+	}{
+		{"fr-CA;q=0.9, en;q=0.8", "fr"},
+		{"en;q=0.8, fr-CA;q=0.9", "fr"},
+		{"pt-BR", "pt-BR"},
+		{"pt-br", "pt-BR"},
+		{"pt_BR", "pt-BR"},
+		{"pt", ""},
+		{"zh-Hant-TW", "zh-Hant"},
+		{"de-DE-x-phonebk", "de"},
+		{"ja, *;q=0.5", ""},
+		{"ja, de;q=0.1", "de"},
+		{"fr;q=0, en", "en"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			if result := MatchLocale(tt.header, available); result != tt.expected {
+				t.Errorf("MatchLocale(%q) = %q, expected %q", tt.header, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTruncateTag(t *testing.T) {
+	tests := map[string]string{
+		"zh-hant-cn":      "zh-hant",
+		"zh-hant":         "zh",
+		"zh":              "",
+		"de-de-x-phonebk": "de-de",
+		"en-a-bbb-x-a":    "en-a-bbb",
+	}
+
+	for tag, expected := range tests {
+		if result := truncateTag(tag); result != expected {
+			t.Errorf("truncateTag(%q) = %q, expected %q", tag, result, expected)
+		}
+	}
+}
+
+func TestParseAcceptLanguage(t *testing.T) {
+	tests := map[string]string{
+		"":                              "",
+		"fr":                            "fr",
+		"fr-CA;q=0.9, en;q=0.8":         "fr-CA,en",
+		"en;q=0.1, de, fr;q=0.5":        "de,fr,en",
+		"en;q=0, fr":                    "fr",
+		"en;q=abc, fr":                  "fr",
+		" da , en-gb;q=0.8 , en;q=0.7 ": "da,en-gb,en",
+	}
+
+	for header, expected := range tests {
+		if result := strings.Join(parseAcceptLanguage(header), ","); result != expected {
+			t.Errorf("parseAcceptLanguage(%q) = %q, expected %q", header, result, expected)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package i18n
+
+import "errors"
+
+// Localizer is implemented by values that render differently per locale,
+// such as *LocalizedError. Errors and arguments that implement it are
+// rendered in the locale of the message they are part of.
+type Localizer interface {
+	Localize(locale string) string
+}
+
+// LocalizedError is an error whose message is a translation. Error renders
+// it in the default language, for logs; Localize renders it in the
+// caller's language, for API responses. Arguments that are errors are
+// wrapped like %w wraps them in fmt.Errorf, so errors.Is and errors.As see
+// through it.
+type LocalizedError struct {
+	Key  string // translation key of the message
+	Args []any  // placeholder values, as passed to T
+
+	bundle *Bundle
+}
+
+// Errorf returns a *LocalizedError translating key with args, like T. Error
+// arguments are wrapped, and rendered in the message's locale if they are
+// localized themselves.
+//
+// Example:
+//
+//	var ErrQuota = errors.New("quota exceeded")
+//
+//	err := i18n.Errorf("errors.quota", plan.Name, ErrQuota)
+//	log.Print(err)                           // "Plan Pro has no requests left" (default language)
+//	http.Error(w, i18n.Localize(err, locale), http.StatusTooManyRequests)
+//	errors.Is(err, ErrQuota)                 // true
+func Errorf(key string, args ...any) error {
+	return defaultBundle.Errorf(key, args...)
+}
+
+// Errorf is like the package-level Errorf but uses the bundle's dictionaries
+func (b *Bundle) Errorf(key string, args ...any) error {
+	return &LocalizedError{Key: key, Args: args, bundle: b}
+}
+
+// Error renders the message in the default language of its bundle
+func (e *LocalizedError) Error() string {
+	return e.Localize(e.getBundle().DefaultLanguage())
+}
+
+// Localize renders the message in locale
+func (e *LocalizedError) Localize(locale string) string {
+	return e.getBundle().T(e.Key, localizeArgs(locale, e.Args)...)(locale)
+}
+
+// Unwrap returns the error arguments
+func (e *LocalizedError) Unwrap() []error {
+	var errs []error
+	for _, arg := range e.Args {
+		if err, ok := arg.(error); ok {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+// getBundle returns the bundle the error was created with
+func (e *LocalizedError) getBundle() *Bundle {
+	if e.bundle == nil {
+		return defaultBundle
+	}
+	return e.bundle
+}
+
+// Localize renders err in locale: the message of the first Localizer in its
+// chain (see errors.As), so wrapping context added for logs is left out, or
+// err.Error() if it has none. A nil err renders "".
+func Localize(err error, locale string) string {
+	if err == nil {
+		return ""
+	}
+	var l Localizer
+	if errors.As(err, &l) {
+		return l.Localize(locale)
+	}
+	return err.Error()
+}
+
+// localizeArgs renders the Localizer and TranslatedFunc arguments in locale,
+// including the values of Args
+func localizeArgs(locale string, args []any) []any {
+	localized := make([]any, len(args))
+	for i, arg := range args {
+		if a, ok := arg.(Args); ok {
+			values := make(Args, len(a))
+			for name, value := range a {
+				values[name] = localizeArg(locale, value)
+			}
+			localized[i] = values
+			continue
+		}
+		localized[i] = localizeArg(locale, arg)
+	}
+	return localized
+}
+
+// localizeArg renders arg in locale if it is localized
+func localizeArg(locale string, arg any) any {
+	switch a := arg.(type) {
+	case Localizer:
+		return a.Localize(locale)
+	case TranslatedFunc:
+		if a != nil {
+			return a(locale)
+		}
+	}
+	return arg
+}
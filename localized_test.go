@@ -0,0 +1,71 @@
+package i18n
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"testing"
+)
+
+func TestLocalizedError(t *testing.T) {
+	b := NewBundle()
+	b.SetDefaultLanguage("en")
+	en := NewDictionary("en")
+	en.AddAll(map[string]string{
+		"errors.quota":     "Plan {0} has no requests left: {1}",
+		"errors.not_found": "{name} was not found",
+		"errors.cause":     "the service is busy",
+	})
+	fr := NewDictionary("fr")
+	fr.AddAll(map[string]string{
+		"errors.quota":     "Le forfait {0} n'a plus de requêtes : {1}",
+		"errors.not_found": "{name} est introuvable",
+		"errors.cause":     "le service est occupé",
+	})
+	b.Register(en)
+	b.Register(fr)
+
+	cause := b.Errorf("errors.cause", fs.ErrPermission)
+	err := b.Errorf("errors.quota", "Pro", cause)
+
+	if got := err.Error(); got != "Plan Pro has no requests left: the service is busy" {
+		t.Errorf("Error() = %q", got)
+	}
+	var le *LocalizedError
+	if !errors.As(err, &le) || le.Key != "errors.quota" {
+		t.Fatalf("Expected a *LocalizedError, got %T", err)
+	}
+	if got := le.Localize("fr"); got != "Le forfait Pro n'a plus de requêtes : le service est occupé" {
+		t.Errorf("Localize(fr) = %q", got)
+	}
+	if !errors.Is(err, fs.ErrPermission) {
+		t.Error("Expected errors.Is to see the wrapped error arguments")
+	}
+
+	// Context added with fmt.Errorf is kept for logs and left out for users
+	wrapped := fmt.Errorf("handle request: %w", err)
+	if got := Localize(wrapped, "fr"); got != le.Localize("fr") {
+		t.Errorf("Localize(wrapped) = %q", got)
+	}
+	if got := Localize(errors.New("plain"), "fr"); got != "plain" {
+		t.Errorf("Expected plain errors to render as-is, got %q", got)
+	}
+	if got := Localize(nil, "fr"); got != "" {
+		t.Errorf("Expected nil to render empty, got %q", got)
+	}
+
+	named := b.Errorf("errors.not_found", Args{"name": b.S("Invoice")})
+	if got := Localize(named, "fr"); got != "Invoice est introuvable" {
+		t.Errorf("Expected named arguments to be localized, got %q", got)
+	}
+}
+
+func TestErrorf_DefaultBundle(t *testing.T) {
+	err := Errorf("missing-key {0}", 42)
+	if got := err.Error(); got != "missing-key 42" {
+		t.Errorf("Expected the key with its arguments, got %q", got)
+	}
+	if errs := err.(*LocalizedError).Unwrap(); len(errs) != 0 {
+		t.Errorf("Expected nothing wrapped, got %v", errs)
+	}
+}
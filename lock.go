@@ -0,0 +1,221 @@
+package i18n
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// DefaultLockFile is the default name of the catalog lockfile
+const DefaultLockFile = "i18n.lock"
+
+// lockVersion is the Version of the lockfiles LockDir writes. It changes
+// whenever the hash does, so older lockfiles are reported as outdated rather
+// than as changing every catalog.
+const lockVersion = 2
+
+// Lockfile records the content hash of every catalog in a locales directory.
+// Committing it alongside a release makes it possible to prove exactly which
+// translation content was shipped and to detect unreviewed changes in CI.
+type Lockfile struct {
+	Version  int                  `json:"version"`
+	Catalogs map[string]LockEntry `json:"catalogs"`
+}
+
+// LockEntry describes one locked catalog file
+type LockEntry struct {
+	Lang string `json:"lang"`
+	Name string `json:"name"`
+	Keys int    `json:"keys"`
+	Hash string `json:"hash"`
+}
+
+// LockMismatch describes a difference between a lockfile and the catalogs on disk
+type LockMismatch struct {
+	File   string `json:"file"`
+	Reason string `json:"reason"`
+}
+
+func (m LockMismatch) String() string {
+	return fmt.Sprintf("%s: %s", m.File, m.Reason)
+}
+
+// catalogFiles returns the sorted list of JSON catalog files in a directory
+func catalogFiles(dir string) ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list catalogs in %s: %w", dir, err)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// hashTranslations computes a content hash of translations and lists that
+// ignores key order and formatting
+func hashTranslations(translations map[string]string, lists map[string][]string) string {
+	return hashCatalog(translations, lists, nil, nil)
+}
+
+// hashCatalog computes the content hash of a catalog: its translations,
+// lists, scheduled values and review states
+func hashCatalog(translations map[string]string, lists map[string][]string, schedule map[string][]ScheduledValue, states map[string]State) string {
+	h := sha256.New()
+	writeCatalogHash(h, translations, lists, schedule, states)
+	return "sha256:" + hex.EncodeToString(h.Sum(nil))
+}
+
+// writeCatalogHash writes the entries of a catalog to h in key order
+func writeCatalogHash(h hash.Hash, translations map[string]string, lists map[string][]string, schedule map[string][]ScheduledValue, states map[string]State) {
+	for _, k := range sortedKeys(translations) {
+		hashEntry(h, 't', k, translations[k])
+	}
+	for _, k := range sortedKeys(lists) {
+		hashEntry(h, 'l', append([]string{k}, lists[k]...)...)
+	}
+	for _, k := range sortedKeys(schedule) {
+		for _, v := range schedule[k] {
+			hashEntry(h, 's', k, v.Value, v.ValidFrom.Format(time.RFC3339Nano), v.ValidUntil.Format(time.RFC3339Nano))
+		}
+	}
+	for _, k := range sortedKeys(states) {
+		hashEntry(h, 'r', k, string(states[k]))
+	}
+}
+
+// hashEntry writes one entry of a content hash: a tag naming its kind and
+// the number of its fields, then every field prefixed with its length, so
+// no two different entries, nor a translation and a list, write the same
+// bytes
+func hashEntry(h hash.Hash, tag byte, fields ...string) {
+	fmt.Fprintf(h, "%c%d;", tag, len(fields))
+	for _, f := range fields {
+		fmt.Fprintf(h, "%d:%s", len(f), f)
+	}
+}
+
+// Hash returns a content hash of the dictionary's translations, lists,
+// scheduled values, review states and fuzzy marks that ignores the order
+// they were added in. Without fuzzy marks, it matches the lockfile hash of a
+// catalog file with the same content.
+func (d *Dictionary) Hash() string {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	h := sha256.New()
+	writeCatalogHash(h, d.Translations, d.Lists, d.Schedule, d.states)
+	for _, k := range sortedKeys(d.fuzzy) {
+		hashEntry(h, 'f', k)
+	}
+	return "sha256:" + hex.EncodeToString(h.Sum(nil))
+}
+
+// LockDir hashes every catalog file in dir and returns the resulting
+// lockfile. The hash covers translations, lists, scheduled values and review
+// states, so verifying it catches a changed state or schedule too.
+func LockDir(dir string) (*Lockfile, error) {
+	files, err := catalogFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	lf := &Lockfile{Version: lockVersion, Catalogs: make(map[string]LockEntry, len(files))}
+	for _, file := range files {
+		tf, err := readTranslationFile(file)
+		if err != nil {
+			return nil, err
+		}
+		lf.Catalogs[filepath.Base(file)] = LockEntry{
+			Lang: tf.Meta.Lang,
+			Name: tf.Meta.Name,
+			Keys: len(tf.Translations) + len(tf.Lists),
+			Hash: hashCatalog(tf.Translations, tf.Lists, tf.Schedule, tf.States),
+		}
+	}
+	return lf, nil
+}
+
+// ReadLockfile reads a lockfile from disk
+func ReadLockfile(path string) (*Lockfile, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read lockfile %s: %w", path, err)
+	}
+
+	var lf Lockfile
+	if err := json.Unmarshal(data, &lf); err != nil {
+		return nil, fmt.Errorf("invalid lockfile %s: %w", path, err)
+	}
+	return &lf, nil
+}
+
+// WriteLockfile writes a lockfile to disk
+func WriteLockfile(path string, lf *Lockfile) error {
+	data, err := json.MarshalIndent(lf, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal lockfile: %w", err)
+	}
+
+	if err := os.WriteFile(filepath.Clean(path), append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to save lockfile: %w", err)
+	}
+	return nil
+}
+
+// VerifyLock compares the catalogs in dir against the lockfile at lockPath.
+// It returns one mismatch per added, removed or modified catalog; an empty
+// result means the directory matches the lockfile exactly.
+func VerifyLock(dir, lockPath string) ([]LockMismatch, error) {
+	locked, err := ReadLockfile(lockPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if locked.Version != lockVersion {
+		return []LockMismatch{{
+			File:   filepath.Base(lockPath),
+			Reason: fmt.Sprintf("lockfile version %d is outdated (expected %d): lock the catalogs again", locked.Version, lockVersion),
+		}}, nil
+	}
+
+	current, err := LockDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	return locked.Compare(current), nil
+}
+
+// Compare reports the differences between this lockfile and another one
+func (lf *Lockfile) Compare(other *Lockfile) []LockMismatch {
+	var mismatches []LockMismatch
+
+	for file, want := range lf.Catalogs {
+		got, ok := other.Catalogs[file]
+		switch {
+		case !ok:
+			mismatches = append(mismatches, LockMismatch{File: file, Reason: "catalog removed"})
+		case got.Hash != want.Hash:
+			mismatches = append(mismatches, LockMismatch{
+				File:   file,
+				Reason: fmt.Sprintf("content changed (%d keys locked, %d keys now)", want.Keys, got.Keys),
+			})
+		}
+	}
+
+	for file := range other.Catalogs {
+		if _, ok := lf.Catalogs[file]; !ok {
+			mismatches = append(mismatches, LockMismatch{File: file, Reason: "catalog not in lockfile"})
+		}
+	}
+
+	sort.Slice(mismatches, func(i, j int) bool {
+		return mismatches[i].File < mismatches[j].File
+	})
+	return mismatches
+}
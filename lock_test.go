@@ -0,0 +1,223 @@
+package i18n
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeTestCatalog writes a minimal translation file into dir
+func writeTestCatalog(t *testing.T, dir, file, lang string, translations map[string]string) string {
+	t.Helper()
+
+	content := map[string]any{
+		"meta":         map[string]string{"lang": lang, "name": "default"},
+		"translations": translations,
+	}
+	data, err := json.MarshalIndent(content, "", "  ")
+	if err != nil {
+		t.Fatalf("Failed to marshal test catalog: %v", err)
+	}
+
+	path := filepath.Join(dir, file)
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatalf("Failed to write test catalog: %v", err)
+	}
+	return path
+}
+
+func TestLockDir(t *testing.T) {
+	dir := t.TempDir()
+	writeTestCatalog(t, dir, "default.en.json", "en", map[string]string{"hello": "Hello", "bye": "Bye"})
+	writeTestCatalog(t, dir, "default.fr.json", "fr", map[string]string{"hello": "Bonjour"})
+
+	lf, err := LockDir(dir)
+	if err != nil {
+		t.Fatalf("LockDir failed: %v", err)
+	}
+
+	if len(lf.Catalogs) != 2 {
+		t.Fatalf("Expected 2 locked catalogs, got %d", len(lf.Catalogs))
+	}
+
+	en := lf.Catalogs["default.en.json"]
+	if en.Lang != "en" || en.Keys != 2 {
+		t.Errorf("Unexpected entry for en catalog: %+v", en)
+	}
+	if en.Hash == lf.Catalogs["default.fr.json"].Hash {
+		t.Error("Expected different hashes for different catalogs")
+	}
+}
+
+func TestHashTranslations_IgnoresOrder(t *testing.T) {
+	a := hashTranslations(map[string]string{"a": "1", "b": "2"}, nil)
+	b := hashTranslations(map[string]string{"b": "2", "a": "1"}, nil)
+	if a != b {
+		t.Errorf("Expected identical hashes, got %s and %s", a, b)
+	}
+
+	// Key/value boundaries must be part of the hash
+	c := hashTranslations(map[string]string{"ab": "c"}, nil)
+	d := hashTranslations(map[string]string{"a": "bc"}, nil)
+	if c == d {
+		t.Error("Expected different hashes for different key/value splits")
+	}
+
+	// A translation and a list never hash alike
+	if hashTranslations(map[string]string{"x[]": "a"}, nil) == hashTranslations(nil, map[string][]string{"x": {"a"}}) {
+		t.Error("Expected different hashes for a translation and a list")
+	}
+	if hashTranslations(nil, map[string][]string{"x": {"a", "b"}}) == hashTranslations(nil, map[string][]string{"x": {"a\x00b"}}) {
+		t.Error("Expected different hashes for different list splits")
+	}
+
+	// List values are part of the content
+	e := hashTranslations(nil, map[string][]string{"days": {"Mon", "Tue"}})
+	f := hashTranslations(nil, map[string][]string{"days": {"Mon", "Wed"}})
+	if e == f {
+		t.Error("Expected different hashes for different list values")
+	}
+}
+
+func TestVerifyLock(t *testing.T) {
+	dir := t.TempDir()
+	lockPath := filepath.Join(t.TempDir(), DefaultLockFile)
+	writeTestCatalog(t, dir, "default.en.json", "en", map[string]string{"hello": "Hello"})
+	writeTestCatalog(t, dir, "default.fr.json", "fr", map[string]string{"hello": "Bonjour"})
+
+	lf, err := LockDir(dir)
+	if err != nil {
+		t.Fatalf("LockDir failed: %v", err)
+	}
+	if err := WriteLockfile(lockPath, lf); err != nil {
+		t.Fatalf("WriteLockfile failed: %v", err)
+	}
+
+	mismatches, err := VerifyLock(dir, lockPath)
+	if err != nil {
+		t.Fatalf("VerifyLock failed: %v", err)
+	}
+	if len(mismatches) != 0 {
+		t.Errorf("Expected no mismatches, got %v", mismatches)
+	}
+
+	// Modify one catalog, remove another and add a new one
+	writeTestCatalog(t, dir, "default.en.json", "en", map[string]string{"hello": "Hi"})
+	if err := os.Remove(filepath.Join(dir, "default.fr.json")); err != nil {
+		t.Fatalf("Failed to remove catalog: %v", err)
+	}
+	writeTestCatalog(t, dir, "default.de.json", "de", map[string]string{"hello": "Hallo"})
+
+	mismatches, err = VerifyLock(dir, lockPath)
+	if err != nil {
+		t.Fatalf("VerifyLock failed: %v", err)
+	}
+
+	expected := []string{"default.de.json", "default.en.json", "default.fr.json"}
+	if len(mismatches) != len(expected) {
+		t.Fatalf("Expected %d mismatches, got %v", len(expected), mismatches)
+	}
+	for i, file := range expected {
+		if mismatches[i].File != file {
+			t.Errorf("Expected mismatch %d for %s, got %s", i, file, mismatches[i].File)
+		}
+	}
+}
+
+func TestVerifyLock_StatesAndSchedule(t *testing.T) {
+	dir := t.TempDir()
+	lockPath := filepath.Join(t.TempDir(), DefaultLockFile)
+	path := filepath.Join(dir, "default.fr.json")
+	writeTestFile(t, path, `{"meta": {"lang": "fr", "name": "default"}, "translations": {"welcome": "Bienvenue"}, "states": {"welcome": "draft"}}`)
+
+	lf, err := LockDir(dir)
+	if err != nil {
+		t.Fatalf("LockDir failed: %v", err)
+	}
+	if err := WriteLockfile(lockPath, lf); err != nil {
+		t.Fatalf("WriteLockfile failed: %v", err)
+	}
+
+	for _, content := range []string{
+		`{"meta": {"lang": "fr", "name": "default"}, "translations": {"welcome": "Bienvenue"}, "states": {"welcome": "approved"}}`,
+		`{"meta": {"lang": "fr", "name": "default"}, "translations": {"welcome": "Bienvenue"}, "states": {"welcome": "draft"}, "schedule": {"welcome": [{"value": "Joyeux Noël", "valid_from": "2026-12-20", "valid_until": "2026-12-26"}]}}`,
+	} {
+		writeTestFile(t, path, content)
+		mismatches, err := VerifyLock(dir, lockPath)
+		if err != nil {
+			t.Fatalf("VerifyLock failed: %v", err)
+		}
+		if len(mismatches) != 1 || mismatches[0].File != "default.fr.json" {
+			t.Errorf("Expected the change to be reported for %s, got %v", content, mismatches)
+		}
+	}
+}
+
+func TestVerifyLock_OutdatedVersion(t *testing.T) {
+	dir := t.TempDir()
+	lockPath := filepath.Join(t.TempDir(), DefaultLockFile)
+	writeTestCatalog(t, dir, "default.en.json", "en", map[string]string{"hello": "Hello"})
+	writeTestFile(t, lockPath, `{"version": 1, "catalogs": {"default.en.json": {"lang": "en", "name": "default", "keys": 1, "hash": "sha256:00"}}}`)
+
+	mismatches, err := VerifyLock(dir, lockPath)
+	if err != nil {
+		t.Fatalf("VerifyLock failed: %v", err)
+	}
+	if len(mismatches) != 1 || mismatches[0].File != DefaultLockFile || !strings.Contains(mismatches[0].Reason, "version 1 is outdated") {
+		t.Errorf("Expected the lockfile reported as outdated, got %v", mismatches)
+	}
+}
+
+func TestReadLockfile_Invalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), DefaultLockFile)
+	if err := os.WriteFile(path, []byte("not json"), 0644); err != nil {
+		t.Fatalf("Failed to write lockfile: %v", err)
+	}
+
+	if _, err := ReadLockfile(path); err == nil {
+		t.Error("Expected error for invalid lockfile, got nil")
+	}
+}
+
+func TestDictionaryHash(t *testing.T) {
+	a := NewDictionary("en")
+	a.Add("title", "Dashboard")
+	a.Add("save", "Save")
+	b := NewDictionary("en")
+	b.AddAll(map[string]string{"save": "Save", "title": "Dashboard"})
+
+	if a.Hash() != b.Hash() {
+		t.Error("Expected the same hash regardless of insertion order")
+	}
+	if got, want := a.Hash(), hashTranslations(a.Translations, nil); got != want {
+		t.Errorf("Expected the lockfile hash %s, got %s", want, got)
+	}
+
+	b.Add("title", "Home")
+	if a.Hash() == b.Hash() {
+		t.Error("Expected different hashes for different content")
+	}
+
+	withSchedule := NewDictionary("en")
+	withSchedule.AddAll(a.Translations)
+	withSchedule.AddScheduled("title", ScheduledValue{Value: "Happy holidays"})
+	if withSchedule.Hash() == a.Hash() {
+		t.Error("Expected scheduled values to change the hash")
+	}
+
+	withState := NewDictionary("en")
+	withState.AddAll(a.Translations)
+	withState.SetState("title", StateDraft)
+	if withState.Hash() == a.Hash() {
+		t.Error("Expected review states to change the hash")
+	}
+
+	withFuzzy := NewDictionary("en")
+	withFuzzy.AddAll(a.Translations)
+	withFuzzy.SetFuzzy("title", true)
+	if withFuzzy.Hash() == a.Hash() {
+		t.Error("Expected fuzzy marks to change the hash")
+	}
+}
@@ -0,0 +1,40 @@
+package i18n
+
+import (
+	"log/slog"
+	"sync/atomic"
+)
+
+// currentLogger receives the package's events, see SetLogger
+var currentLogger atomic.Pointer[slog.Logger]
+
+// SetLogger sends the package's structured events to l. Nothing is logged
+// by default, and nothing is printed: set a logger to see
+//
+//   - debug: translation files loaded, translations taken from a fallback
+//     language, translations missing everywhere (see also OnMissing), and
+//     each string the extractor finds
+//   - info: catalogs and code written by the generators
+//   - warn: files that fail to load or validate, extracted keys that still
+//     need source text, and i18n calls whose argument is not a constant, so
+//     nothing could be extracted from them
+//
+// Pass nil to turn logging off again.
+//
+// Example:
+//
+//	i18n.SetLogger(slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug})))
+func SetLogger(l *slog.Logger) {
+	currentLogger.Store(l)
+}
+
+// logger returns the logger set with SetLogger, or one that discards
+// everything
+func logger() *slog.Logger {
+	if l := currentLogger.Load(); l != nil {
+		return l
+	}
+	return discardLogger
+}
+
+var discardLogger = slog.New(slog.DiscardHandler)
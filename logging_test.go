@@ -0,0 +1,108 @@
+package i18n
+
+import (
+	"bytes"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// captureLogs sends debug events to a buffer for the rest of the test
+func captureLogs(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	SetLogger(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	t.Cleanup(func() { SetLogger(nil) })
+	return &buf
+}
+
+func TestSetLogger_Translations(t *testing.T) {
+	logs := captureLogs(t)
+
+	b := NewBundle()
+	en := NewDictionary("en")
+	en.AddAll(map[string]string{"title": "Dashboard", "save": "Save"})
+	fr := NewDictionary("fr")
+	fr.Add("title", "Tableau de bord")
+	b.Register(en)
+	b.Register(fr)
+
+	b.T("title")("fr")
+	b.T("save")("fr")
+	b.T("logout")("fr")
+
+	out := logs.String()
+	for _, want := range []string{
+		`level=DEBUG msg="translation from fallback language" locale=fr key=save lang=en`,
+		`level=DEBUG msg="missing translation" locale=fr key=logout`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %q in logs:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "key=title") {
+		t.Errorf("Expected no event for a translated key:\n%s", out)
+	}
+}
+
+func TestSetLogger_Files(t *testing.T) {
+	logs := captureLogs(t)
+
+	dir := t.TempDir()
+	writeTestCatalog(t, dir, "default.fr.json", "fr", map[string]string{"title": "Tableau"})
+	if err := os.WriteFile(filepath.Join(dir, "broken.de.json"), []byte("not json"), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+	_ = NewBundle().LoadDir(dir)
+
+	dict := NewDictionary("en")
+	dict.Add("broken", "Hello {0")
+	_ = NewBundle().RegisterStrict(dict)
+
+	out := logs.String()
+	for _, want := range []string{
+		`level=DEBUG msg="translation file loaded"`,
+		`lang=fr name=default keys=1`,
+		`level=WARN msg="invalid translation file"`,
+		`broken.de.json`,
+		`level=WARN msg="dictionary failed validation" lang=en`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %q in logs:\n%s", want, out)
+		}
+	}
+}
+
+func TestSetLogger_Generator(t *testing.T) {
+	logs := captureLogs(t)
+
+	dir := t.TempDir()
+	src := "package main\n\nfunc main() { i18n.S(\"Dashboard\"); i18n.T(\"farewell\") }\n"
+	if err := os.WriteFile(filepath.Join(dir, "main.go"), []byte(src), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+	if err := GenerateTranslations("en", dir, filepath.Join(dir, "en.json")); err != nil {
+		t.Fatalf("GenerateTranslations failed: %v", err)
+	}
+
+	out := logs.String()
+	for _, want := range []string{
+		`msg="found translatable string"`,
+		`func=i18n.S text=Dashboard key=dashboard`,
+		`level=INFO msg="catalog written" lang=en entries=2`,
+		`level=WARN msg="keys need source text" lang=en keys=[farewell]`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %q in logs:\n%s", want, out)
+		}
+	}
+}
+
+func TestSetLogger_Nil(t *testing.T) {
+	SetLogger(nil)
+	if logger().Enabled(t.Context(), slog.LevelError) {
+		t.Error("Expected logging to be off without a logger")
+	}
+}
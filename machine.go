@@ -0,0 +1,223 @@
+package i18n
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Translator translates text between two languages, such as a machine
+// translation service. See the mt package for DeepL, Google Cloud
+// Translation and OpenAI implementations.
+type Translator interface {
+	Translate(ctx context.Context, text, from, to string) (string, error)
+}
+
+// TranslatorFunc adapts a function to the Translator interface
+type TranslatorFunc func(ctx context.Context, text, from, to string) (string, error)
+
+// Translate calls f
+func (f TranslatorFunc) Translate(ctx context.Context, text, from, to string) (string, error) {
+	return f(ctx, text, from, to)
+}
+
+// MachineTranslation is what MachineTranslate did to the catalog of one
+// dictionary
+type MachineTranslation struct {
+	Path       string            `json:"path"`
+	Lang       string            `json:"lang"`
+	Translated []string          `json:"translated,omitempty"` // keys filled in
+	Skipped    map[string]string `json:"skipped,omitempty"`    // keys left out, with the reason
+}
+
+// MachineTranslate fills in the missing translations of language to in dir
+// with tr, from the catalogs of language from: every name.from.json catalog
+// gets its name.to.json counterpart updated, or created. A key is missing if
+// the target has no value for it or its value is still a copy of the source
+// text, as the extractor writes for keys nobody has translated yet. Unless
+// onlyMissing is set, keys a previous run filled in are translated again.
+//
+// Filled-in keys are listed in the catalog's "machine_translated" section
+// until a reviewer removes them, lose any fuzzy mark and are drafts (see
+// State). Plural and select templates are skipped, as
+// translation services do not keep their syntax, and so are results whose
+// placeholders differ from the source text's. Catalogs are rewritten holding
+// the lock of dir (see LockCatalogDir); if tr fails, the keys translated
+// until then are written before the error is returned.
+//
+// Example:
+//
+//	results, err := i18n.MachineTranslate(ctx, mt.NewDeepL(apiKey), "locales", "en", "fr", true)
+func MachineTranslate(ctx context.Context, tr Translator, dir, from, to string, onlyMissing bool) ([]MachineTranslation, error) {
+	if from == to {
+		return nil, fmt.Errorf("source and target language are both '%s'", from)
+	}
+	sources, err := filepath.Glob(filepath.Join(dir, "*."+from+".json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list catalogs in %s: %w", dir, err)
+	}
+	sort.Strings(sources)
+
+	var results []MachineTranslation
+	err = withCatalogLock(ctx, dir, func() error {
+		for _, source := range sources {
+			result, err := machineTranslateCatalog(ctx, tr, source, from, to, onlyMissing)
+			if result.Path != "" {
+				results = append(results, result)
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return results, err
+}
+
+// PlanMachineTranslate reports what MachineTranslate would do without
+// calling a translation service or writing anything: for each catalog, the
+// keys it would translate, as Translated, and those it would skip. Results
+// that MachineTranslate would then skip, such as translations with other
+// placeholders, cannot be foreseen and are listed as translated.
+func PlanMachineTranslate(dir, from, to string, onlyMissing bool) ([]MachineTranslation, error) {
+	if from == to {
+		return nil, fmt.Errorf("source and target language are both '%s'", from)
+	}
+	sources, err := filepath.Glob(filepath.Join(dir, "*."+from+".json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list catalogs in %s: %w", dir, err)
+	}
+	sort.Strings(sources)
+
+	var results []MachineTranslation
+	for _, source := range sources {
+		src, target, path, err := machineCatalogs(source, from, to)
+		if err != nil {
+			return results, err
+		}
+		result := MachineTranslation{Path: path, Lang: to}
+		result.Translated = pendingKeys(src, target, onlyMissing, &result)
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// machineCatalogs reads a source catalog and its counterpart in language to,
+// a new catalog if it does not exist yet, and returns the path of the latter
+func machineCatalogs(source, from, to string) (src, target *TranslationFile, path string, err error) {
+	if src, err = readTranslationFile(source); err != nil {
+		return nil, nil, "", err
+	}
+	path = filepath.Join(filepath.Dir(source), strings.TrimSuffix(filepath.Base(source), from+".json")+to+".json")
+	target = newCatalogFile(to, make(map[string]string))
+	target.Meta.Name = src.Meta.Name
+	if _, err := os.Stat(path); err == nil {
+		if target, err = readTranslationFile(path); err != nil {
+			return nil, nil, "", err
+		}
+	}
+	return src, target, path, nil
+}
+
+// pendingKeys returns the keys of src to machine-translate into target, in
+// order, and records the templates it leaves out in result
+func pendingKeys(src, target *TranslationFile, onlyMissing bool, result *MachineTranslation) []string {
+	machine := make(map[string]bool, len(target.MachineTranslated))
+	for _, key := range target.MachineTranslated {
+		machine[key] = true
+	}
+
+	var keys []string
+	for _, key := range sortedKeys(src.Translations) {
+		text := src.Translations[key]
+		value, ok := target.Translations[key]
+		if _, isList := target.Lists[key]; isList {
+			continue
+		}
+		if ok && value != text && (onlyMissing || !machine[key]) {
+			continue
+		}
+		if isBlockTemplate(text) {
+			result.skip(key, "plural and select templates are not machine-translated")
+			continue
+		}
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// machineTranslateCatalog fills in the target catalog of one source catalog
+func machineTranslateCatalog(ctx context.Context, tr Translator, source, from, to string, onlyMissing bool) (MachineTranslation, error) {
+	src, target, path, err := machineCatalogs(source, from, to)
+	if err != nil {
+		return MachineTranslation{}, err
+	}
+	result := MachineTranslation{Path: path, Lang: to}
+
+	machine := make(map[string]bool, len(target.MachineTranslated))
+	for _, key := range target.MachineTranslated {
+		machine[key] = true
+	}
+
+	var translateErr error
+	for _, key := range pendingKeys(src, target, onlyMissing, &result) {
+		text := src.Translations[key]
+		translated, err := tr.Translate(ctx, text, from, to)
+		if err != nil {
+			translateErr = fmt.Errorf("failed to translate '%s' to %s: %w", key, to, err)
+			break
+		}
+		translated = strings.TrimSpace(translated)
+		if translated == "" {
+			result.skip(key, "empty translation")
+			continue
+		}
+		if diff := placeholderDifference(placeholderNames(text), placeholderNames(translated), from); diff != "" {
+			result.skip(key, diff)
+			continue
+		}
+		target.Translations[key] = translated
+		if target.SourceHashes == nil {
+			target.SourceHashes = make(map[string]string)
+		}
+		target.SourceHashes[key] = sourceHash(text)
+		target.Fuzzy = slices.DeleteFunc(target.Fuzzy, func(k string) bool { return k == key })
+		if target.States == nil {
+			target.States = make(map[string]State)
+		}
+		target.States[key] = StateDraft
+		machine[key] = true
+		result.Translated = append(result.Translated, key)
+	}
+
+	if len(result.Translated) > 0 {
+		target.MachineTranslated = sortedKeys(machine)
+		if err := validateTranslationFile(target); err != nil {
+			return result, withPath(err, path, "invalid translation file")
+		}
+		if err := writeCatalog(path, target); err != nil {
+			return result, err
+		}
+		logger().Info("catalog machine-translated", "lang", to, "keys", len(result.Translated), "path", path)
+	}
+	return result, translateErr
+}
+
+// skip records a key left untranslated
+func (m *MachineTranslation) skip(key, reason string) {
+	if m.Skipped == nil {
+		m.Skipped = make(map[string]string)
+	}
+	m.Skipped[key] = reason
+}
+
+// isBlockTemplate reports whether a template has plural or select blocks
+func isBlockTemplate(template string) bool {
+	return slices.ContainsFunc([]string{", plural,", ", select,", ", selectordinal,"}, func(marker string) bool {
+		return strings.Contains(template, marker)
+	})
+}
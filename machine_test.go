@@ -0,0 +1,178 @@
+package i18n
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// prefixTranslator "translates" text by prefixing it with the target language
+func prefixTranslator(prefix string) Translator {
+	return TranslatorFunc(func(ctx context.Context, text, from, to string) (string, error) {
+		if text == "Title" {
+			return "Titre {x}", nil
+		}
+		return prefix + to + ": " + text, nil
+	})
+}
+
+func TestMachineTranslate(t *testing.T) {
+	dir := t.TempDir()
+	writeTestCatalog(t, dir, "default.en.json", "en", map[string]string{
+		"hello-0": "Hello {0}",
+		"goodbye": "Goodbye",
+		"title":   "Title",
+		"items":   "{count, plural, one {# item} other {# items}}",
+		"welcome": "Welcome",
+	})
+	writeTestCatalog(t, dir, "default.fr.json", "fr", map[string]string{
+		"hello-0": "Bonjour {0}",
+		"goodbye": "Goodbye", // a copy of the source text
+	})
+	writeTestFile(t, filepath.Join(dir, "billing.en.json"), `{"meta": {"lang": "en", "name": "billing"}, "translations": {"invoice": "Invoice"}}`)
+
+	results, err := MachineTranslate(context.Background(), prefixTranslator(""), dir, "en", "fr", true)
+	if err != nil {
+		t.Fatalf("MachineTranslate failed: %v", err)
+	}
+	want := []MachineTranslation{
+		{Path: filepath.Join(dir, "billing.fr.json"), Lang: "fr", Translated: []string{"invoice"}},
+		{Path: filepath.Join(dir, "default.fr.json"), Lang: "fr", Translated: []string{"goodbye", "welcome"}, Skipped: map[string]string{
+			"items": "plural and select templates are not machine-translated",
+			"title": "placeholders differ from 'en': has {x}",
+		}},
+	}
+	if !reflect.DeepEqual(results, want) {
+		t.Errorf("Expected %+v, got %+v", want, results)
+	}
+
+	tf, err := readTranslationFile(filepath.Join(dir, "default.fr.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tf.Translations["hello-0"] != "Bonjour {0}" || tf.Translations["goodbye"] != "fr: Goodbye" {
+		t.Errorf("Unexpected translations %v", tf.Translations)
+	}
+	if !reflect.DeepEqual(tf.MachineTranslated, []string{"goodbye", "welcome"}) {
+		t.Errorf("Expected the filled-in keys marked, got %v", tf.MachineTranslated)
+	}
+	if want := map[string]State{"goodbye": StateDraft, "welcome": StateDraft}; !reflect.DeepEqual(tf.States, want) {
+		t.Errorf("Expected the filled-in keys to be drafts, got %v", tf.States)
+	}
+	billing, err := readTranslationFile(filepath.Join(dir, "billing.fr.json"))
+	if err != nil || billing.Meta.Name != "billing" || billing.Translations["invoice"] != "fr: Invoice" {
+		t.Errorf("Expected a new billing catalog, got %+v, %v", billing, err)
+	}
+
+	// Machine translations are refreshed unless only missing keys are asked for
+	results, err = MachineTranslate(context.Background(), prefixTranslator("new "), dir, "en", "fr", true)
+	if err != nil || len(results[1].Translated) != 0 {
+		t.Errorf("Expected nothing translated with onlyMissing, got %+v, %v", results, err)
+	}
+	results, err = MachineTranslate(context.Background(), prefixTranslator("new "), dir, "en", "fr", false)
+	if err != nil || !reflect.DeepEqual(results[1].Translated, []string{"goodbye", "welcome"}) {
+		t.Errorf("Expected the machine translations refreshed, got %+v, %v", results, err)
+	}
+}
+
+func TestPlanMachineTranslate(t *testing.T) {
+	dir := t.TempDir()
+	writeTestCatalog(t, dir, "default.en.json", "en", map[string]string{
+		"hello-0": "Hello {0}",
+		"goodbye": "Goodbye",
+		"items":   "{count, plural, one {# item} other {# items}}",
+	})
+	frPath := writeTestCatalog(t, dir, "default.fr.json", "fr", map[string]string{
+		"hello-0": "Bonjour {0}",
+		"goodbye": "Goodbye",
+	})
+	before, err := os.ReadFile(frPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := PlanMachineTranslate(dir, "en", "fr", true)
+	if err != nil {
+		t.Fatalf("PlanMachineTranslate failed: %v", err)
+	}
+	want := []MachineTranslation{{Path: frPath, Lang: "fr", Translated: []string{"goodbye"}, Skipped: map[string]string{
+		"items": "plural and select templates are not machine-translated",
+	}}}
+	if !reflect.DeepEqual(results, want) {
+		t.Errorf("Expected %+v, got %+v", want, results)
+	}
+	if after, _ := os.ReadFile(frPath); string(after) != string(before) {
+		t.Errorf("Expected the catalog left as is, got %s", after)
+	}
+	if _, err := PlanMachineTranslate(dir, "en", "en", true); err == nil {
+		t.Error("Expected an error for the same source and target language")
+	}
+}
+
+func TestMachineTranslate_Error(t *testing.T) {
+	dir := t.TempDir()
+	writeTestCatalog(t, dir, "default.en.json", "en", map[string]string{"a": "A", "b": "B"})
+
+	failing := TranslatorFunc(func(ctx context.Context, text, from, to string) (string, error) {
+		if text == "B" {
+			return "", errors.New("quota exceeded")
+		}
+		return "x" + text, nil
+	})
+	results, err := MachineTranslate(context.Background(), failing, dir, "en", "de", true)
+	if err == nil || !strings.Contains(err.Error(), "quota exceeded") || !strings.Contains(err.Error(), "'b'") {
+		t.Fatalf("Expected the translator's error, got %v", err)
+	}
+	if len(results) != 1 || !reflect.DeepEqual(results[0].Translated, []string{"a"}) {
+		t.Errorf("Expected the keys translated before the error, got %+v", results)
+	}
+	tf, err := readTranslationFile(filepath.Join(dir, "default.de.json"))
+	if err != nil || tf.Translations["a"] != "xA" {
+		t.Errorf("Expected the translated key written, got %+v, %v", tf, err)
+	}
+
+	if _, err := MachineTranslate(context.Background(), failing, dir, "en", "en", true); err == nil {
+		t.Error("Expected an error for the same source and target language")
+	}
+}
+
+func TestGenerateCatalogs_KeepsMachineTranslated(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "main.go"), `package main
+
+import "github.com/nyxstack/i18n"
+
+func main() {
+	_ = i18n.S("Dashboard")
+	_ = i18n.S("Settings")
+}
+`)
+	localesDir := filepath.Join(dir, "locales")
+	if err := GenerateCatalogs(context.Background(), dir, localesDir, "en", "fr"); err != nil {
+		t.Fatalf("GenerateCatalogs failed: %v", err)
+	}
+	if _, err := MachineTranslate(context.Background(), prefixTranslator(""), localesDir, "en", "fr", true); err != nil {
+		t.Fatalf("MachineTranslate failed: %v", err)
+	}
+
+	writeTestFile(t, filepath.Join(dir, "main.go"), `package main
+
+import "github.com/nyxstack/i18n"
+
+func main() { _ = i18n.S("Dashboard") }
+`)
+	if err := GenerateCatalogs(context.Background(), dir, localesDir, "en", "fr"); err != nil {
+		t.Fatalf("GenerateCatalogs failed: %v", err)
+	}
+	tf, err := readTranslationFile(filepath.Join(localesDir, "default.fr.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(tf.MachineTranslated, []string{"dashboard"}) || tf.Translations["dashboard"] != "fr: Dashboard" {
+		t.Errorf("Expected the mark of the used key kept, got %v in %v", tf.MachineTranslated, tf.Translations)
+	}
+}
@@ -0,0 +1,142 @@
+package i18n
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Message is a validated message template that can be rendered for any
+// locale with this package's plural, select and placeholder engine. Use it
+// for messages that do not come from a catalog, such as CMS content or
+// user-defined notification templates.
+type Message struct {
+	template string
+}
+
+// CompileMessage validates a message template once, so it can be stored and
+// rendered many times. It returns the same errors as catalog validation for
+// malformed plural, select and placeholder syntax.
+//
+// Example:
+//
+//	msg, err := i18n.CompileMessage("{0} has {count, plural, one {# new message} other {# new messages}}")
+//	if err != nil {
+//		return err
+//	}
+//	msg.Render("en", "Alice", i18n.Args{"count": 3}) // "Alice has 3 new messages"
+func CompileMessage(value string) (*Message, error) {
+	if err := validateTemplate("", value); err != nil {
+		return nil, err
+	}
+	return &Message{template: value}, nil
+}
+
+// String returns the source template of the message
+func (m *Message) String() string {
+	return m.template
+}
+
+// Render renders the message for locale. Arguments are passed as to T:
+// positional values fill {0}, {1}, ... and Args fill named placeholders. The
+// argument a block names selects its branch: Args{"count": n} for
+// {count, plural, ...}, Args{"gender": "female"} (or a positional value for
+// {0, select, ...}) for select blocks and the position for
+// {position, selectordinal, ...}. Blocks whose argument is missing render
+// their "other" branch.
+func (m *Message) Render(locale string, args ...any) string {
+	text := m.template
+
+	// Only the plural block is replaced, keeping the text around it
+	if start := strings.Index(text, "{count, plural"); start >= 0 {
+		value, _ := argValue(args, "count")
+		if count, ok := integerValue(value); ok {
+			if end := matchingBrace(text, start); end >= 0 {
+				text = text[:start] + pluralText(locale, text[start:end+1], count) + text[end+1:]
+			}
+		}
+	}
+
+	text = renderBlocks(text, "select", func(name string, cases map[string]string) string {
+		value, _ := argValue(args, name)
+		if branch, ok := cases[fmt.Sprint(value)]; ok && value != nil {
+			return branch
+		}
+		return cases["other"]
+	})
+
+	text = renderBlocks(text, "selectordinal", func(name string, cases map[string]string) string {
+		value, _ := argValue(args, name)
+		position, ok := integerValue(value)
+		if !ok {
+			return cases["other"]
+		}
+		branch, found := cases[determineOrdinalForm(locale, int(position))]
+		if !found {
+			branch = cases["other"]
+		}
+		return strings.ReplaceAll(branch, "#", strconv.FormatInt(position, 10))
+	})
+
+	return replacePlaceholders(text, args)
+}
+
+// renderBlocks replaces every "{name, kind, ...}" block of a template with
+// the text branch returns for the block's argument name and branches
+func renderBlocks(template, kind string, branch func(name string, cases map[string]string) string) string {
+	marker := ", " + kind + ","
+	for {
+		start, end, cases, ok := parseBlock(template, kind)
+		if !ok {
+			return template
+		}
+		name := template[start+1 : start+strings.Index(template[start:], marker)]
+		template = template[:start] + branch(strings.TrimSpace(name), cases) + template[end:]
+	}
+}
+
+// argValue returns the argument a placeholder name refers to: the positional
+// argument for "0", "1", ..., or the entry of an Args argument otherwise
+func argValue(args []any, name string) (any, bool) {
+	index, err := strconv.Atoi(name)
+	positional := err == nil
+
+	i := 0
+	for _, arg := range args {
+		switch a := arg.(type) {
+		case Args:
+			if value, ok := a[name]; ok && !positional {
+				return value, true
+			}
+			continue
+		case StyleOption, RandomOption:
+			continue
+		}
+		if positional && i == index {
+			return arg, true
+		}
+		i++
+	}
+	return nil, false
+}
+
+// integerValue converts an integer, a whole float or a numeric string to int64
+func integerValue(value any) (int64, bool) {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int(), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		if f := v.Float(); f == float64(int64(f)) {
+			return int64(f), true
+		}
+	case reflect.String:
+		if n, err := strconv.ParseInt(v.String(), 10, 64); err == nil {
+			return n, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,54 @@
+package i18n
+
+import "testing"
+
+func TestCompileMessage(t *testing.T) {
+	for _, value := range []string{
+		"Hello {0",
+		"{count, plural, offset:x other {# items}}",
+		"{gender, select, male {him}}",
+	} {
+		if _, err := CompileMessage(value); err == nil {
+			t.Errorf("CompileMessage(%q) expected error, got nil", value)
+		}
+	}
+
+	msg, err := CompileMessage("Hello {name}")
+	if err != nil {
+		t.Fatalf("CompileMessage failed: %v", err)
+	}
+	if msg.String() != "Hello {name}" {
+		t.Errorf("Expected source template, got %q", msg.String())
+	}
+}
+
+func TestMessageRender(t *testing.T) {
+	tests := []struct {
+		template string
+		locale   string
+		args     []any
+		want     string
+	}{
+		{"Hello {0}", "en", []any{"Ada"}, "Hello Ada"},
+		{"{count, plural, =0 {no messages} one {# message} other {# messages}}", "en", []any{Args{"count": 0}}, "no messages"},
+		{"{count, plural, one {# message} other {# messages}}", "en", []any{Args{"count": 1}}, "1 message"},
+		{"{0} has {count, plural, one {# new message} other {# new messages}} today", "en", []any{"Alice", Args{"count": 3}}, "Alice has 3 new messages today"},
+		{"{count, plural, one {# wiadomość} few {# wiadomości} many {# wiadomości} other {# wiadomości}}", "pl", []any{Args{"count": "5"}}, "5 wiadomości"},
+		{"{count, plural, one {# item} other {# items}}", "en", nil, "{count, plural, one {# item} other {# items}}"},
+		{"{0} updated {gender, select, female {her} male {his} other {their}} profile", "en", []any{"Alice", Args{"gender": "female"}}, "Alice updated her profile"},
+		{"{0, select, admin {Admin panel} other {Dashboard}}", "en", []any{"admin"}, "Admin panel"},
+		{"{role, select, admin {Admin} other {User}}", "en", nil, "User"},
+		{"{position, selectordinal, one {#st} two {#nd} few {#rd} other {#th}} place", "en", []any{Args{"position": 22}}, "22nd place"},
+		{"{name} finished {position, selectordinal, one {#st} other {#th}}", "en", []any{Args{"name": "Bo", "position": 11}}, "Bo finished 11th"},
+	}
+
+	for _, tt := range tests {
+		msg, err := CompileMessage(tt.template)
+		if err != nil {
+			t.Fatalf("CompileMessage(%q) failed: %v", tt.template, err)
+		}
+		if got := msg.Render(tt.locale, tt.args...); got != tt.want {
+			t.Errorf("Render(%q, %v) = %q, want %q", tt.template, tt.args, got, tt.want)
+		}
+	}
+}
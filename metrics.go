@@ -0,0 +1,54 @@
+package i18n
+
+import "sync/atomic"
+
+// Collector receives counts of translation lookups, see SetMetrics. Its
+// methods are called on the rendering goroutine, so they must be quick and
+// safe for concurrent use. The contrib/i18nprom module implements it with
+// Prometheus counters.
+type Collector interface {
+	// Lookup is called for every translation rendered for locale
+	Lookup(locale string)
+
+	// Fallback is called when the translation rendered for locale comes
+	// from lang, another language of its fallback chain
+	Fallback(locale, lang string)
+
+	// Missing is called when no dictionary has the translation and the key
+	// or source text is rendered instead
+	Missing(locale, key string)
+
+	// CacheLookup is called for every LocaleCache lookup
+	CacheLookup(hit bool)
+}
+
+// currentMetrics receives lookup counts, see SetMetrics
+var currentMetrics atomic.Pointer[Collector]
+
+// SetMetrics sends lookup counts to c, for every bundle: how many
+// translations each locale renders, how many of them come from a fallback
+// language or are missing altogether, and LocaleCache hits and misses.
+// Nothing is counted by default; pass nil to stop counting again.
+//
+// Example:
+//
+//	metrics, err := i18nprom.New(prometheus.DefaultRegisterer)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	i18n.SetMetrics(metrics)
+func SetMetrics(c Collector) {
+	if c == nil {
+		currentMetrics.Store(nil)
+		return
+	}
+	currentMetrics.Store(&c)
+}
+
+// metrics returns the collector set with SetMetrics, or nil
+func metrics() Collector {
+	if c := currentMetrics.Load(); c != nil {
+		return *c
+	}
+	return nil
+}
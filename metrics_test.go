@@ -0,0 +1,78 @@
+package i18n
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recordingCollector records the events it receives
+type recordingCollector struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (c *recordingCollector) record(event string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.events = append(c.events, event)
+}
+
+func (c *recordingCollector) Lookup(locale string) {
+	c.record("lookup " + locale)
+}
+
+func (c *recordingCollector) Fallback(locale, lang string) {
+	c.record("fallback " + locale + "→" + lang)
+}
+
+func (c *recordingCollector) Missing(locale, key string) {
+	c.record("missing " + locale + " " + key)
+}
+
+func (c *recordingCollector) CacheLookup(hit bool) {
+	if hit {
+		c.record("cache hit")
+	} else {
+		c.record("cache miss")
+	}
+}
+
+func TestSetMetrics(t *testing.T) {
+	c := &recordingCollector{}
+	SetMetrics(c)
+	t.Cleanup(func() { SetMetrics(nil) })
+
+	b := NewBundle()
+	en := NewDictionary("en")
+	en.AddAll(map[string]string{"title": "Dashboard", "save": "Save"})
+	fr := NewDictionary("fr")
+	fr.Add("title", "Tableau de bord")
+	b.Register(en)
+	b.Register(fr)
+
+	b.S("Title")("fr")
+	b.T("save")("fr")
+	b.P("items", 2)("de")
+
+	cache := NewLocaleCache(time.Minute, 0)
+	cache.Resolve("client", func() string { return "fr" })
+	cache.Resolve("client", func() string { return "fr" })
+
+	want := []string{
+		"lookup fr",
+		"lookup fr", "fallback fr→en",
+		"lookup de", "missing de items",
+		"cache miss", "cache hit",
+	}
+	if !reflect.DeepEqual(c.events, want) {
+		t.Errorf("Expected %v, got %v", want, c.events)
+	}
+
+	SetMetrics(nil)
+	b.T("save")("fr")
+	if len(c.events) != len(want) {
+		t.Errorf("Expected no events once metrics are off, got %v", c.events[len(want):])
+	}
+}
@@ -0,0 +1,50 @@
+package mt
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// DeepL translates with the DeepL API
+type DeepL struct {
+	APIKey   string
+	Endpoint string       // translate endpoint; NewDeepL picks the free or pro API by key
+	Client   *http.Client // http.DefaultClient if nil
+}
+
+// NewDeepL returns a DeepL translator. Keys ending in ":fx" use the free API.
+func NewDeepL(apiKey string) *DeepL {
+	endpoint := "https://api.deepl.com/v2/translate"
+	if strings.HasSuffix(apiKey, ":fx") {
+		endpoint = "https://api-free.deepl.com/v2/translate"
+	}
+	return &DeepL{APIKey: apiKey, Endpoint: endpoint}
+}
+
+// Translate translates text from one language to another. DeepL takes
+// source languages without region ("EN") and target languages with one
+// where it distinguishes them ("EN-GB", "PT-BR").
+func (d *DeepL) Translate(ctx context.Context, text, from, to string) (string, error) {
+	source, _, _ := strings.Cut(from, "-")
+	body := map[string]any{
+		"text":        []string{text},
+		"source_lang": strings.ToUpper(source),
+		"target_lang": strings.ToUpper(to),
+	}
+	header := http.Header{"Authorization": {"DeepL-Auth-Key " + d.APIKey}}
+
+	var resp struct {
+		Translations []struct {
+			Text string `json:"text"`
+		} `json:"translations"`
+	}
+	if err := postJSON(ctx, d.Client, d.Endpoint, header, body, &resp); err != nil {
+		return "", fmt.Errorf("deepl: %w", err)
+	}
+	if len(resp.Translations) == 0 {
+		return "", fmt.Errorf("deepl: no translation returned")
+	}
+	return resp.Translations[0].Text, nil
+}
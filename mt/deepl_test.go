@@ -0,0 +1,32 @@
+package mt
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestDeepL(t *testing.T) {
+	var body map[string]any
+	var header http.Header
+	srv := serve(t, http.StatusOK, `{"translations": [{"detected_source_language": "EN", "text": "Bonjour {0}"}]}`, &body, &header)
+
+	d := NewDeepL("key")
+	d.Endpoint = srv.URL
+	got, err := d.Translate(context.Background(), "Hello {0}", "en-US", "pt-br")
+	if err != nil {
+		t.Fatalf("Translate failed: %v", err)
+	}
+	if got != "Bonjour {0}" {
+		t.Errorf("Expected the translation, got %q", got)
+	}
+
+	want := map[string]any{"text": []any{"Hello {0}"}, "source_lang": "EN", "target_lang": "PT-BR"}
+	if !reflect.DeepEqual(body, want) {
+		t.Errorf("Expected request %v, got %v", want, body)
+	}
+	if auth := header.Get("Authorization"); auth != "DeepL-Auth-Key key" {
+		t.Errorf("Unexpected Authorization header %q", auth)
+	}
+}
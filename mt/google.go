@@ -0,0 +1,48 @@
+package mt
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+)
+
+// Google translates with the Google Cloud Translation API (v2, "basic")
+type Google struct {
+	APIKey   string
+	Endpoint string       // translate endpoint
+	Client   *http.Client // http.DefaultClient if nil
+}
+
+// NewGoogle returns a Google Cloud Translation translator
+func NewGoogle(apiKey string) *Google {
+	return &Google{APIKey: apiKey, Endpoint: "https://translation.googleapis.com/language/translate/v2"}
+}
+
+// Translate translates text from one language to another
+func (g *Google) Translate(ctx context.Context, text, from, to string) (string, error) {
+	body := map[string]any{
+		"q":      []string{text},
+		"source": from,
+		"target": to,
+		"format": "text",
+	}
+
+	var resp struct {
+		Data struct {
+			Translations []struct {
+				TranslatedText string `json:"translatedText"`
+			} `json:"translations"`
+		} `json:"data"`
+	}
+	endpoint := g.Endpoint + "?key=" + url.QueryEscape(g.APIKey)
+	if err := postJSON(ctx, g.Client, endpoint, nil, body, &resp); err != nil {
+		return "", fmt.Errorf("google: %w", err)
+	}
+	if len(resp.Data.Translations) == 0 {
+		return "", fmt.Errorf("google: no translation returned")
+	}
+	// Plain text results may still carry entities such as &#39;
+	return html.UnescapeString(resp.Data.Translations[0].TranslatedText), nil
+}
@@ -0,0 +1,34 @@
+package mt
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestGoogle(t *testing.T) {
+	var body map[string]any
+	srv := serve(t, http.StatusOK, `{"data": {"translations": [{"translatedText": "L&#39;heure"}]}}`, &body, nil)
+
+	g := NewGoogle("key")
+	g.Endpoint = srv.URL
+	got, err := g.Translate(context.Background(), "The time", "en", "fr")
+	if err != nil {
+		t.Fatalf("Translate failed: %v", err)
+	}
+	if got != "L'heure" {
+		t.Errorf("Expected the unescaped translation, got %q", got)
+	}
+	if body["source"] != "en" || body["target"] != "fr" || body["format"] != "text" {
+		t.Errorf("Unexpected request %v", body)
+	}
+}
+
+func TestGoogle_Empty(t *testing.T) {
+	srv := serve(t, http.StatusOK, `{"data": {"translations": []}}`, nil, nil)
+	g := NewGoogle("key")
+	g.Endpoint = srv.URL
+	if _, err := g.Translate(context.Background(), "Hi", "en", "fr"); err == nil {
+		t.Error("Expected an error without translations")
+	}
+}
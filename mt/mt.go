@@ -0,0 +1,105 @@
+// Package mt implements i18n.Translator for machine translation services,
+// over their HTTP APIs and without dependencies beyond the standard library:
+//
+//   - DeepL (NewDeepL)
+//   - Google Cloud Translation (NewGoogle)
+//   - OpenAI chat completions (NewOpenAI)
+//
+// Use one with i18n.MachineTranslate to pre-fill missing translations:
+//
+//	results, err := i18n.MachineTranslate(ctx, mt.NewDeepL(os.Getenv("DEEPL_AUTH_KEY")), "locales", "en", "fr", true)
+package mt
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/nyxstack/i18n"
+)
+
+// Providers maps the provider names New accepts to the environment variable
+// holding their API key
+var Providers = map[string]string{
+	"deepl":  "DEEPL_AUTH_KEY",
+	"google": "GOOGLE_API_KEY",
+	"openai": "OPENAI_API_KEY",
+}
+
+// New returns the translator of a provider by name ("deepl", "google" or
+// "openai"), with apiKey or, if empty, the key in the provider's environment
+// variable (see Providers)
+func New(provider, apiKey string) (i18n.Translator, error) {
+	env, ok := Providers[provider]
+	if !ok {
+		names := make([]string, 0, len(Providers))
+		for name := range Providers {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("unknown translation provider '%s' (expected %s)", provider, strings.Join(names, ", "))
+	}
+	if apiKey == "" {
+		apiKey = os.Getenv(env)
+	}
+	if apiKey == "" {
+		return nil, fmt.Errorf("no API key for %s: set %s", provider, env)
+	}
+
+	switch provider {
+	case "deepl":
+		return NewDeepL(apiKey), nil
+	case "google":
+		return NewGoogle(apiKey), nil
+	default:
+		return NewOpenAI(apiKey), nil
+	}
+}
+
+// postJSON posts body as JSON to url and decodes the JSON response into out.
+// Responses other than 2xx are returned as errors with the start of their body.
+func postJSON(ctx context.Context, client *http.Client, url string, header http.Header, body, out any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err = io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		msg := strings.TrimSpace(string(data))
+		if len(msg) > 200 {
+			msg = msg[:200] + "..."
+		}
+		return fmt.Errorf("%s: %s", resp.Status, msg)
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("invalid response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package mt
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// serve starts a server that records the request body and header and replies
+// with response
+func serve(t *testing.T, status int, response string, body *map[string]any, header *http.Header) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if header != nil {
+			*header = r.Header.Clone()
+		}
+		if body != nil {
+			if err := json.NewDecoder(r.Body).Decode(body); err != nil {
+				t.Errorf("Invalid request body: %v", err)
+			}
+		}
+		w.WriteHeader(status)
+		w.Write([]byte(response))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestNew(t *testing.T) {
+	t.Setenv("DEEPL_AUTH_KEY", "key:fx")
+	tr, err := New("deepl", "")
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	if d, ok := tr.(*DeepL); !ok || d.APIKey != "key:fx" || !strings.Contains(d.Endpoint, "api-free") {
+		t.Errorf("Expected a free DeepL translator, got %+v", tr)
+	}
+
+	if tr, err := New("openai", "sk"); err != nil || tr.(*OpenAI).Model != DefaultOpenAIModel {
+		t.Errorf("Expected an OpenAI translator, got %+v, %v", tr, err)
+	}
+
+	t.Setenv("GOOGLE_API_KEY", "")
+	if _, err := New("google", ""); err == nil || !strings.Contains(err.Error(), "GOOGLE_API_KEY") {
+		t.Errorf("Expected a missing key error, got %v", err)
+	}
+	if _, err := New("babel", "key"); err == nil || !strings.Contains(err.Error(), "deepl, google, openai") {
+		t.Errorf("Expected an unknown provider error, got %v", err)
+	}
+}
+
+func TestPostJSON_Error(t *testing.T) {
+	srv := serve(t, http.StatusForbidden, `{"message": "wrong key"}`, nil, nil)
+	d := NewDeepL("key")
+	d.Endpoint = srv.URL
+	_, err := d.Translate(context.Background(), "Hello", "en", "fr")
+	if err == nil || !strings.Contains(err.Error(), "403 Forbidden") || !strings.Contains(err.Error(), "wrong key") {
+		t.Errorf("Expected the status and body in the error, got %v", err)
+	}
+}
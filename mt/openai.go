@@ -0,0 +1,56 @@
+package mt
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// DefaultOpenAIModel is the model NewOpenAI translates with
+const DefaultOpenAIModel = "gpt-4o-mini"
+
+// OpenAI translates with an OpenAI chat completion model, or any service
+// implementing the same API
+type OpenAI struct {
+	APIKey   string
+	Model    string
+	Endpoint string       // chat completions endpoint
+	Client   *http.Client // http.DefaultClient if nil
+}
+
+// NewOpenAI returns an OpenAI translator using DefaultOpenAIModel
+func NewOpenAI(apiKey string) *OpenAI {
+	return &OpenAI{APIKey: apiKey, Model: DefaultOpenAIModel, Endpoint: "https://api.openai.com/v1/chat/completions"}
+}
+
+// Translate translates text from one language to another, asking the model
+// to keep placeholders such as {0} and {name} as they are
+func (o *OpenAI) Translate(ctx context.Context, text, from, to string) (string, error) {
+	prompt := fmt.Sprintf("Translate the user's text from the language with code %s to the language with code %s. "+
+		"Keep placeholders in braces, such as {0} or {name}, unchanged. Reply with the translation only.", from, to)
+	body := map[string]any{
+		"model": o.Model,
+		"messages": []map[string]string{
+			{"role": "system", "content": prompt},
+			{"role": "user", "content": text},
+		},
+		"temperature": 0,
+	}
+	header := http.Header{"Authorization": {"Bearer " + o.APIKey}}
+
+	var resp struct {
+		Choices []struct {
+			Message struct {
+				Content string `json:"content"`
+			} `json:"message"`
+		} `json:"choices"`
+	}
+	if err := postJSON(ctx, o.Client, o.Endpoint, header, body, &resp); err != nil {
+		return "", fmt.Errorf("openai: %w", err)
+	}
+	if len(resp.Choices) == 0 {
+		return "", fmt.Errorf("openai: no translation returned")
+	}
+	return strings.TrimSpace(resp.Choices[0].Message.Content), nil
+}
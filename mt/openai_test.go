@@ -0,0 +1,42 @@
+package mt
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestOpenAI(t *testing.T) {
+	var body map[string]any
+	var header http.Header
+	srv := serve(t, http.StatusOK, `{"choices": [{"message": {"role": "assistant", "content": " Bienvenue {name}\n"}}]}`, &body, &header)
+
+	o := NewOpenAI("sk")
+	o.Endpoint = srv.URL
+	got, err := o.Translate(context.Background(), "Welcome {name}", "en", "fr")
+	if err != nil {
+		t.Fatalf("Translate failed: %v", err)
+	}
+	if got != "Bienvenue {name}" {
+		t.Errorf("Expected the trimmed translation, got %q", got)
+	}
+
+	if body["model"] != DefaultOpenAIModel {
+		t.Errorf("Expected model %s, got %v", DefaultOpenAIModel, body["model"])
+	}
+	messages, _ := body["messages"].([]any)
+	if len(messages) != 2 {
+		t.Fatalf("Expected a system and a user message, got %v", body["messages"])
+	}
+	system := messages[0].(map[string]any)["content"].(string)
+	if !strings.Contains(system, "en") || !strings.Contains(system, "fr") || !strings.Contains(system, "{0}") {
+		t.Errorf("Unexpected system prompt %q", system)
+	}
+	if user := messages[1].(map[string]any)["content"]; user != "Welcome {name}" {
+		t.Errorf("Expected the text as the user message, got %v", user)
+	}
+	if auth := header.Get("Authorization"); auth != "Bearer sk" {
+		t.Errorf("Unexpected Authorization header %q", auth)
+	}
+}
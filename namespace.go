@@ -0,0 +1,113 @@
+package i18n
+
+// Namespace translates keys of one dictionary of a language, such as the
+// "auth" dictionary of auth.en.json. Catalogs loaded from a directory merge
+// the keys of every dictionary other than "default" under their name
+// ("auth.login-title"), so dictionaries can grow independently without
+// their keys colliding.
+//
+// Example:
+//
+//	auth := i18n.NS("auth")
+//	fmt.Println(auth.T("login-title")("fr")) // key "auth.login-title"
+type Namespace struct {
+	bundle *Bundle
+	name   string
+}
+
+// NS returns the namespace of a dictionary in the default bundle
+func NS(name string) Namespace {
+	return defaultBundle.NS(name)
+}
+
+// NS returns the namespace of a dictionary in the bundle
+func (b *Bundle) NS(name string) Namespace {
+	return Namespace{bundle: b, name: name}
+}
+
+// Key returns the full key of key within the namespace
+func (n Namespace) Key(key string) string {
+	return n.name + KeySeparator + key
+}
+
+// T is like Bundle.T for a key of the namespace
+func (n Namespace) T(key string, args ...any) TranslatedFunc {
+	return n.bundle.T(n.Key(key), args...)
+}
+
+// P is like Bundle.P for a key of the namespace
+func (n Namespace) P(key string, count int, args ...any) TranslatedFunc {
+	return n.bundle.P(n.Key(key), count, args...)
+}
+
+// P64 is like Bundle.P64 for a key of the namespace
+func (n Namespace) P64(key string, count int64, args ...any) TranslatedFunc {
+	return n.bundle.P64(n.Key(key), count, args...)
+}
+
+// Pf is like Bundle.Pf for a key of the namespace
+func (n Namespace) Pf(key string, count float64, opts ...NumberOption) TranslatedFunc {
+	return n.bundle.Pf(n.Key(key), count, opts...)
+}
+
+// Sel is like Bundle.Sel for a key of the namespace
+func (n Namespace) Sel(key, selector string, args ...any) TranslatedFunc {
+	return n.bundle.Sel(n.Key(key), selector, args...)
+}
+
+// O is like Bundle.O for a key of the namespace
+func (n Namespace) O(key string, position int) TranslatedFunc {
+	return n.bundle.O(n.Key(key), position)
+}
+
+// TRandom is like Bundle.TRandom for a key of the namespace
+func (n Namespace) TRandom(key string, args ...any) TranslatedFunc {
+	return n.bundle.TRandom(n.Key(key), args...)
+}
+
+// namespaceKeys prefixes every key of a file with its dictionary name,
+// unless it is the default dictionary
+func namespaceKeys(tf *TranslationFile) {
+	if tf.Meta.Name == DefaultDictionary {
+		return
+	}
+	ns := Namespace{name: tf.Meta.Name}
+
+	translations := make(map[string]string, len(tf.Translations))
+	for key, value := range tf.Translations {
+		translations[ns.Key(key)] = value
+	}
+	tf.Translations = translations
+
+	if tf.Lists != nil {
+		lists := make(map[string][]string, len(tf.Lists))
+		for key, list := range tf.Lists {
+			lists[ns.Key(key)] = list
+		}
+		tf.Lists = lists
+	}
+
+	if tf.Schedule != nil {
+		schedule := make(map[string][]ScheduledValue, len(tf.Schedule))
+		for key, values := range tf.Schedule {
+			schedule[ns.Key(key)] = values
+		}
+		tf.Schedule = schedule
+	}
+
+	if tf.Fuzzy != nil {
+		fuzzy := make([]string, len(tf.Fuzzy))
+		for i, key := range tf.Fuzzy {
+			fuzzy[i] = ns.Key(key)
+		}
+		tf.Fuzzy = fuzzy
+	}
+
+	if tf.States != nil {
+		states := make(map[string]State, len(tf.States))
+		for key, state := range tf.States {
+			states[ns.Key(key)] = state
+		}
+		tf.States = states
+	}
+}
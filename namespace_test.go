@@ -0,0 +1,50 @@
+package i18n
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestNS(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"default.en.json": `{"meta": {"lang": "en", "name": "default"}, "translations": {"title": "Dashboard"}}`,
+		"auth.en.json":    `{"meta": {"lang": "en", "name": "auth"}, "translations": {"title": "Sign in", "attempts": "{count, plural, one {# attempt} other {# attempts}}"}}`,
+		"emails.en.json":  `{"meta": {"lang": "en", "name": "emails"}, "translations": {"title": "Welcome {0}", "greetings": ["Hi", "Hello"]}}`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	b := NewBundle()
+	if err := b.LoadDir(dir); err != nil {
+		t.Fatalf("LoadDir failed: %v", err)
+	}
+
+	tests := []struct {
+		fn   TranslatedFunc
+		want string
+	}{
+		{b.T("title"), "Dashboard"},
+		{b.NS("auth").T("title"), "Sign in"},
+		{b.NS("emails").T("title", "Ada"), "Welcome Ada"},
+		{b.NS("auth").P("attempts", 3), "3 attempts"},
+		{b.T("auth.title"), "Sign in"},
+		{b.NS("billing").T("title"), "billing.title"},
+	}
+	for _, tt := range tests {
+		if got := tt.fn("en"); got != tt.want {
+			t.Errorf("Expected %q, got %q", tt.want, got)
+		}
+	}
+
+	if got := b.GetDictionary("en").GetSlice("emails.greetings"); len(got) != 2 {
+		t.Errorf("Expected namespaced list, got %v", got)
+	}
+	if key := NS("auth").Key("login"); key != "auth.login" {
+		t.Errorf("Expected auth.login, got %s", key)
+	}
+}
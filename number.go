@@ -0,0 +1,161 @@
+package i18n
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// decimalSeparators are the built-in decimal separators. Locales without an
+// entry use the separator of their parent language, then the English period.
+var decimalSeparators = map[string]string{
+	"en": ".", "fr": ",", "de": ",", "es": ",", "it": ",", "pt": ",",
+	"nl": ",", "pl": ",", "ru": ",", "uk": ",", "sv": ",", "da": ",",
+	"nb": ",", "fi": ",", "tr": ",", "cs": ",", "ro": ",", "el": ",",
+	"id": ",",
+}
+
+// decimalSeparator returns the decimal separator of a locale
+func decimalSeparator(locale string) string {
+	_, sep := builtinLocale(decimalSeparators, locale)
+	return sep
+}
+
+// NumberOption configures how Pf formats its count
+type NumberOption func(*numberFormat)
+
+// numberFormat holds the options of a formatted number
+type numberFormat struct {
+	decimals int // visible fraction digits, -1 for as many as needed
+}
+
+// Decimals shows exactly n fraction digits. Visible digits change the plural
+// form: in English "1 item" but "1.0 items".
+func Decimals(n int) NumberOption {
+	return func(f *numberFormat) {
+		f.decimals = n
+	}
+}
+
+// pluralOperands are the CLDR operands of a decimal number
+type pluralOperands struct {
+	n float64 // absolute value
+	i int64   // integer digits
+	v int     // number of visible fraction digits
+}
+
+// Pf is like P for decimal counts. The plural form depends on the visible
+// fraction digits as in CLDR: "1.5" is "other" in English but "one" in French.
+// # is replaced with the count formatted with the locale's decimal separator.
+// Exact matches such as =1 and offset:n are selected as in P; =1 also matches
+// 1.0.
+//
+// Example:
+//
+//	fn := i18n.Pf("distance", 1.5)
+//	fmt.Println(fn("en")) // "1.5 kilometers"
+//	fmt.Println(fn("fr")) // "1,5 kilomètre"
+//
+// Dictionary should contain:
+//
+//	"distance": "{count, plural, one {# kilometer} other {# kilometers}}"
+func Pf(key string, count float64, opts ...NumberOption) TranslatedFunc {
+	return defaultBundle.Pf(key, count, opts...)
+}
+
+// Pf is like the package-level Pf but uses the bundle's dictionaries
+func (b *Bundle) Pf(key string, count float64, opts ...NumberOption) TranslatedFunc {
+	format := numberFormat{decimals: -1}
+	for _, opt := range opts {
+		opt(&format)
+	}
+
+	digits := strconv.FormatFloat(math.Abs(count), 'f', format.decimals, 64)
+	ops := operands(digits)
+
+	return b.debugged(func(locale string) string {
+		template, lang := key, locale
+		tr, dict, ok := b.find(locale, key)
+		if dict != nil {
+			lang = dict.Lang
+		}
+		if ok {
+			template = tr
+		}
+
+		sep := decimalSeparator(locale)
+		abs := strings.Replace(digits, ".", sep, 1)
+		number := abs
+		if count < 0 {
+			number = "-" + abs
+		}
+
+		if strings.Contains(template, "{count, plural") {
+			// As in P, forms and # use the count minus the offset, rounded
+			// to the visible fraction digits of the count, and exact
+			// matches compare against the count itself
+			offset, _ := pluralOffset(template)
+			n := count - float64(offset)
+			nDigits := strconv.FormatFloat(math.Abs(n), 'f', ops.v, 64)
+
+			choice := pluralChoice{
+				form:   determineDecimalPluralForm(lang, operands(nDigits)),
+				number: strings.Replace(nDigits, ".", sep, 1),
+			}
+			if n < 0 {
+				choice.number = "-" + choice.number
+			}
+			if count == math.Trunc(count) && math.Abs(count) <= 1<<53 {
+				choice.exact = "=" + strconv.FormatInt(int64(count), 10)
+			}
+			if count < 0 {
+				choice.negative = abs
+			}
+			if result := selectPlural(template, choice); result != "" {
+				return result
+			}
+		}
+
+		return strings.ReplaceAll(template, "{count}", number)
+	}, key)
+}
+
+// operands computes the plural operands of an unsigned decimal string
+func operands(digits string) pluralOperands {
+	ops := pluralOperands{}
+	ops.n, _ = strconv.ParseFloat(digits, 64)
+
+	integer, fraction, _ := strings.Cut(digits, ".")
+	ops.v = len(fraction)
+	if i, err := strconv.ParseInt(integer, 10, 64); err == nil {
+		ops.i = i
+	} else {
+		// Beyond int64: the last digits are all the rules look at
+		ops.i, _ = strconv.ParseInt(integer[len(integer)-2:], 10, 64)
+		ops.i += 100
+	}
+	return ops
+}
+
+// determineDecimalPluralForm determines the plural form of a decimal number.
+// Numbers without visible fraction digits follow the integer rules.
+func determineDecimalPluralForm(locale string, ops pluralOperands) string {
+	if ops.v == 0 {
+		return determinePluralForm(locale, pluralOperand(ops.i))
+	}
+
+	lang, _, _ := strings.Cut(normalizeTag(locale), "-")
+	switch lang {
+	case "fr", "pt":
+		// one: i = 0,1 ("0.5 heure", "1.5 heure")
+		if ops.i == 0 || ops.i == 1 {
+			return "one"
+		}
+	case "es":
+		// one: n = 1 ("1.0 día")
+		if ops.n == 1 {
+			return "one"
+		}
+	}
+	return "other"
+}
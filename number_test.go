@@ -0,0 +1,97 @@
+package i18n
+
+import "testing"
+
+func TestPf(t *testing.T) {
+	b := NewBundle()
+
+	en := NewDictionary("en")
+	en.Add("distance", "{count, plural, one {# kilometer} other {# kilometers}}")
+	en.Add("balance", "{count, plural, negative {# overdrawn} other {# left}}")
+	en.Add("rating", "Rated {count}")
+	en.Add("hours", "{count, plural, =0 {no hours} =1 {one hour exactly} one {# hour} other {# hours}}")
+	en.Add("liked", "{count, plural, offset:1 =0 {nobody} =1 {you} one {you and # other} other {you and # others}}")
+	b.Register(en)
+
+	fr := NewDictionary("fr")
+	fr.Add("distance", "{count, plural, one {# kilomètre} other {# kilomètres}}")
+	b.Register(fr)
+
+	tests := []struct {
+		name     string
+		result   string
+		expected string
+	}{
+		{"en integer", b.Pf("distance", 1)("en"), "1 kilometer"},
+		{"en decimal", b.Pf("distance", 1.5)("en"), "1.5 kilometers"},
+		{"en visible zero", b.Pf("distance", 1, Decimals(1))("en"), "1.0 kilometers"},
+		{"en rounding", b.Pf("distance", 2.345, Decimals(2))("en"), "2.35 kilometers"},
+		{"fr decimal one", b.Pf("distance", 1.5)("fr"), "1,5 kilomètre"},
+		{"fr zero decimal", b.Pf("distance", 0.5)("fr"), "0,5 kilomètre"},
+		{"fr other", b.Pf("distance", 2.5)("fr"), "2,5 kilomètres"},
+		{"negative", b.Pf("balance", -3.25)("en"), "3.25 overdrawn"},
+		{"no plural", b.Pf("rating", 4.5)("en"), "Rated 4.5"},
+		{"exact zero", b.Pf("hours", 0)("en"), "no hours"},
+		{"exact one", b.Pf("hours", 1)("en"), "one hour exactly"},
+		{"exact one with visible zero", b.Pf("hours", 1, Decimals(1))("en"), "one hour exactly"},
+		{"no exact match", b.Pf("hours", 1.5)("en"), "1.5 hours"},
+		{"offset exact zero", b.Pf("liked", 0)("en"), "nobody"},
+		{"offset exact one", b.Pf("liked", 1)("en"), "you"},
+		{"offset one", b.Pf("liked", 2)("en"), "you and 1 other"},
+		{"offset decimal", b.Pf("liked", 2.5)("en"), "you and 1.5 others"},
+		{"offset rounding", b.Pf("liked", 1.1)("en"), "you and 0.1 others"},
+	}
+
+	for _, tt := range tests {
+		if tt.result != tt.expected {
+			t.Errorf("%s: expected '%s', got '%s'", tt.name, tt.expected, tt.result)
+		}
+	}
+}
+
+func TestDetermineDecimalPluralForm(t *testing.T) {
+	tests := []struct {
+		locale   string
+		digits   string
+		expected string
+	}{
+		{"en", "1", "one"},
+		{"en", "1.0", "other"},
+		{"en", "1.5", "other"},
+		{"fr", "1.5", "one"},
+		{"fr", "0.25", "one"},
+		{"fr", "2.5", "other"},
+		{"pt-BR", "1.5", "one"},
+		{"es", "1.0", "one"},
+		{"es", "1.5", "other"},
+		{"ru", "1.5", "other"},
+		{"ru", "3", "few"},
+	}
+
+	for _, tt := range tests {
+		if result := determineDecimalPluralForm(tt.locale, operands(tt.digits)); result != tt.expected {
+			t.Errorf("determineDecimalPluralForm(%q, %s) = %q, expected %q", tt.locale, tt.digits, result, tt.expected)
+		}
+	}
+}
+
+func TestOperands(t *testing.T) {
+	ops := operands("12.50")
+	if ops.n != 12.5 || ops.i != 12 || ops.v != 2 {
+		t.Errorf("Unexpected operands for 12.50: %+v", ops)
+	}
+
+	ops = operands("123456789012345678901234")
+	if ops.i != 134 || ops.v != 0 {
+		t.Errorf("Expected huge integers to keep their last two digits, got %+v", ops)
+	}
+}
+
+func TestDecimalSeparator(t *testing.T) {
+	tests := map[string]string{"en": ".", "fr-CA": ",", "de": ",", "ja": "."}
+	for locale, expected := range tests {
+		if sep := decimalSeparator(locale); sep != expected {
+			t.Errorf("decimalSeparator(%q) = %q, expected %q", locale, sep, expected)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package i18n
+
+import (
+	"strconv"
+	"strings"
+)
+
+// O translates a key for a position using ordinal plural rules ("1st", "2nd",
+// "3rd", "4th" in English). The template uses ICU selectordinal syntax, where
+// # is replaced with the position; {position} is replaced as well.
+//
+// Example:
+//
+//	fn := i18n.O("finished", 22)
+//	fmt.Println(fn("en")) // "You finished in 22nd place"
+//
+// Dictionary should contain:
+//
+//	"finished": "You finished in {position, selectordinal, one {#st} two {#nd} few {#rd} other {#th}} place"
+func O(key string, position int) TranslatedFunc {
+	return defaultBundle.O(key, position)
+}
+
+// O is like the package-level O but uses the bundle's dictionaries
+func (b *Bundle) O(key string, position int) TranslatedFunc {
+	return b.debugged(func(locale string) string {
+		// Ordinal rules follow the dictionary the locale resolves to, so an
+		// unregistered locale does not apply its rules to another language
+		template, lang := key, locale
+		tr, dict, ok := b.find(locale, key)
+		if dict != nil {
+			lang = dict.Lang
+		}
+		if ok {
+			template = tr
+		}
+
+		n := strconv.Itoa(position)
+		form := determineOrdinalForm(lang, position)
+		for {
+			start, end, cases, ok := parseBlock(template, "selectordinal")
+			if !ok {
+				break
+			}
+
+			branch, found := cases[form]
+			if !found {
+				branch = cases["other"]
+			}
+			template = template[:start] + strings.ReplaceAll(branch, "#", n) + template[end:]
+		}
+
+		return strings.ReplaceAll(template, "{position}", n)
+	}, key)
+}
+
+// determineOrdinalForm determines the CLDR ordinal plural form of a position
+func determineOrdinalForm(locale string, position int) string {
+	n := position
+	if n < 0 {
+		n = -n
+	}
+	mod10, mod100 := n%10, n%100
+
+	lang, _, _ := strings.Cut(normalizeTag(locale), "-")
+	switch lang {
+	case "en":
+		// 1st, 2nd, 3rd, 4th, 11th, 12th, 13th, 21st, 22nd, 23rd
+		switch {
+		case mod10 == 1 && mod100 != 11:
+			return "one"
+		case mod10 == 2 && mod100 != 12:
+			return "two"
+		case mod10 == 3 && mod100 != 13:
+			return "few"
+		}
+	case "fr", "ga", "ms", "vi":
+		// 1er, 2e, 3e
+		if n == 1 {
+			return "one"
+		}
+	case "sv":
+		// 1:a, 2:a, 3:e, 11:e, 12:e
+		if (mod10 == 1 || mod10 == 2) && mod100 != 11 && mod100 != 12 {
+			return "one"
+		}
+	case "it":
+		// l'11º, l'8º, l'80º, l'800º
+		if n == 11 || n == 8 || n == 80 || n == 800 {
+			return "many"
+		}
+	case "ca":
+		// 1r, 2n, 3r, 4t
+		switch n {
+		case 1, 3:
+			return "one"
+		case 2:
+			return "two"
+		case 4:
+			return "few"
+		}
+	}
+	return "other"
+}
@@ -0,0 +1,80 @@
+package i18n
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestO(t *testing.T) {
+	b := NewBundle()
+
+	en := NewDictionary("en")
+	en.Add("finished", "You finished in {position, selectordinal, one {#st} two {#nd} few {#rd} other {#th}} place")
+	en.Add("rank", "Rank {position}")
+	b.Register(en)
+
+	fr := NewDictionary("fr")
+	fr.Add("finished", "Vous avez terminé à la {position, selectordinal, one {#re} other {#e}} place")
+	b.Register(fr)
+
+	tests := []struct {
+		locale   string
+		position int
+		expected string
+	}{
+		{"en", 1, "You finished in 1st place"},
+		{"en", 2, "You finished in 2nd place"},
+		{"en", 3, "You finished in 3rd place"},
+		{"en", 4, "You finished in 4th place"},
+		{"en", 11, "You finished in 11th place"},
+		{"en", 22, "You finished in 22nd place"},
+		{"en", 113, "You finished in 113th place"},
+		{"fr", 1, "Vous avez terminé à la 1re place"},
+		{"fr", 2, "Vous avez terminé à la 2e place"},
+		{"de", 3, "You finished in 3rd place"},
+	}
+
+	for _, tt := range tests {
+		if result := b.O("finished", tt.position)(tt.locale); result != tt.expected {
+			t.Errorf("O(finished, %d)(%s) = '%s', expected '%s'", tt.position, tt.locale, result, tt.expected)
+		}
+	}
+
+	if result := b.O("rank", 5)("en"); result != "Rank 5" {
+		t.Errorf("Expected '{position}' to be replaced, got '%s'", result)
+	}
+}
+
+func TestDetermineOrdinalForm(t *testing.T) {
+	tests := []struct {
+		locale   string
+		position int
+		expected string
+	}{
+		{"en", 1, "one"},
+		{"en", 21, "one"},
+		{"en", 11, "other"},
+		{"en", 2, "two"},
+		{"en", 12, "other"},
+		{"en", 3, "few"},
+		{"en", 13, "other"},
+		{"en-GB", 103, "few"},
+		{"en", 0, "other"},
+		{"fr", 1, "one"},
+		{"fr", 2, "other"},
+		{"sv", 2, "one"},
+		{"sv", 12, "other"},
+		{"it", 8, "many"},
+		{"it", 9, "other"},
+		{"ca", 4, "few"},
+		{"de", 1, "other"},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s_%d", tt.locale, tt.position), func(t *testing.T) {
+			if result := determineOrdinalForm(tt.locale, tt.position); result != tt.expected {
+				t.Errorf("determineOrdinalForm(%q, %d) = %q, expected %q", tt.locale, tt.position, result, tt.expected)
+			}
+		})
+	}
+}
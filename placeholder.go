@@ -0,0 +1,104 @@
+package i18n
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// placeholderUse matches a positional placeholder and its ICU argument type,
+// if any: "{0}" or "{0, plural, ...}"
+var placeholderUse = regexp.MustCompile(`\{(\d+)\s*(?:,\s*([a-z]+))?`)
+
+// PlaceholderIssue describes a translation that uses a placeholder in a way
+// that does not match the argument the code passes for it
+type PlaceholderIssue struct {
+	File    string `json:"file"`
+	Lang    string `json:"lang"`
+	Key     string `json:"key"`
+	Message string `json:"message"`
+}
+
+func (p PlaceholderIssue) String() string {
+	return fmt.Sprintf("%s: %s: %s", p.File, p.Key, p.Message)
+}
+
+// verbKind classifies a printf verb as "number", "text" or "any"
+func verbKind(verb string) string {
+	switch verb[len(verb)-1] {
+	case 'd', 'x', 'X', 'o':
+		return "number"
+	case 's', 'q':
+		return "text"
+	}
+	return "any"
+}
+
+// CheckPlaceholderTypes checks every catalog in dir against the printf verbs
+// the extractor recorded in the "placeholders" section. It reports
+// translations that use a placeholder the code does not pass, treat a text
+// argument as a number ({0, plural, ...} for a %s) or select on a number
+// ({0, select, ...} for a %d).
+func CheckPlaceholderTypes(dir string) ([]PlaceholderIssue, error) {
+	files, err := catalogFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	catalogs := make(map[string]*TranslationFile, len(files))
+	verbs := make(map[string][]string)
+	for _, file := range files {
+		tf, err := readTranslationFile(file)
+		if err != nil {
+			return nil, err
+		}
+		catalogs[file] = tf
+		for key, v := range tf.Placeholders {
+			verbs[key] = v
+		}
+	}
+
+	var issues []PlaceholderIssue
+	for _, file := range files {
+		tf := catalogs[file]
+		for _, key := range sortedKeys(tf.Translations) {
+			v, ok := verbs[key]
+			if !ok {
+				continue
+			}
+			for _, message := range placeholderProblems(tf.Translations[key], v) {
+				issues = append(issues, PlaceholderIssue{File: file, Lang: tf.Meta.Lang, Key: key, Message: message})
+			}
+		}
+	}
+	return issues, nil
+}
+
+// placeholderProblems lists the uses of placeholders in value that do not
+// match the argument verbs
+func placeholderProblems(value string, verbs []string) []string {
+	var problems []string
+	for _, m := range placeholderUse.FindAllStringSubmatch(value, -1) {
+		index, err := strconv.Atoi(m[1])
+		if err != nil {
+			continue
+		}
+		if index >= len(verbs) {
+			problems = append(problems, fmt.Sprintf("uses {%d} but the code passes %d argument(s)", index, len(verbs)))
+			continue
+		}
+
+		kind := verbKind(verbs[index])
+		switch m[2] {
+		case "plural", "selectordinal", "number":
+			if kind == "text" {
+				problems = append(problems, fmt.Sprintf("uses {%d} as a number but the code passes text (%s)", index, verbs[index]))
+			}
+		case "select":
+			if kind == "number" {
+				problems = append(problems, fmt.Sprintf("uses {%d} as text but the code passes a number (%s)", index, verbs[index]))
+			}
+		}
+	}
+	return problems
+}
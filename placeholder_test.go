@@ -0,0 +1,62 @@
+package i18n
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCheckPlaceholderTypes(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"default.en.json": `{
+  "meta": {"lang": "en", "name": "default"},
+  "translations": {"welcome-0-you-have-1-messages": "Welcome {0}, you have {1} messages", "hello-0": "Hello {0}"},
+  "placeholders": {"welcome-0-you-have-1-messages": ["%s", "%d"], "hello-0": ["%v"]}
+}`,
+		"default.fr.json": `{
+  "meta": {"lang": "fr", "name": "default"},
+  "translations": {
+    "welcome-0-you-have-1-messages": "{0, plural, one {Bienvenue} other {Bienvenue}} {2} {1, select, other {x}}",
+    "hello-0": "{0, plural, other {Bonjour}}"
+  }
+}`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	issues, err := CheckPlaceholderTypes(dir)
+	if err != nil {
+		t.Fatalf("CheckPlaceholderTypes failed: %v", err)
+	}
+
+	expected := []string{
+		"uses {0} as a number but the code passes text (%s)",
+		"uses {2} but the code passes 2 argument(s)",
+		"uses {1} as text but the code passes a number (%d)",
+	}
+	if len(issues) != len(expected) {
+		t.Fatalf("Expected %d issues, got %v", len(expected), issues)
+	}
+	for i, want := range expected {
+		if issues[i].Lang != "fr" || issues[i].Message != want {
+			t.Errorf("Issue %d: expected fr %q, got %+v", i, want, issues[i])
+		}
+	}
+	if !strings.Contains(issues[0].String(), "default.fr.json: welcome-0-you-have-1-messages:") {
+		t.Errorf("Unexpected issue string: %s", issues[0])
+	}
+}
+
+func TestVerbKind(t *testing.T) {
+	tests := map[string]string{"%d": "number", "%x": "number", "%s": "text", "%q": "text", "%v": "any"}
+	for verb, expected := range tests {
+		if result := verbKind(verb); result != expected {
+			t.Errorf("verbKind(%q) = %q, expected %q", verb, result, expected)
+		}
+	}
+}
@@ -0,0 +1,240 @@
+package i18n
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// marshalPO encodes a translation file as a gettext PO file: one entry per
+// string translation, sorted by key, with the key as msgid and the value as
+// msgstr. Translator comments are written as "#." lines, references as "#:"
+// lines and fuzzy marks as the fuzzy flag; the language goes in the header.
+func marshalPO(tf *TranslationFile) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("msgid \"\"\nmsgstr \"\"\n")
+	fmt.Fprintf(&buf, "%s\n", poQuote("Language: "+tf.Meta.Lang+"\n"))
+	fmt.Fprintf(&buf, "%s\n", poQuote("Content-Type: text/plain; charset=UTF-8\n"))
+
+	for _, key := range sortedKeys(tf.Translations) {
+		buf.WriteByte('\n')
+		if comment := tf.Comments[key]; comment != "" {
+			for line := range strings.SplitSeq(comment, "\n") {
+				fmt.Fprintf(&buf, "#. %s\n", line)
+			}
+		}
+		if refs := tf.References[key]; len(refs) > 0 {
+			fmt.Fprintf(&buf, "#: %s\n", strings.Join(refs, " "))
+		}
+		if slices.Contains(tf.Fuzzy, key) {
+			buf.WriteString("#, fuzzy\n")
+		}
+		fmt.Fprintf(&buf, "msgid %s\nmsgstr %s\n", poQuote(key), poQuote(tf.Translations[key]))
+	}
+	return buf.Bytes()
+}
+
+// poQuote returns s as a PO string literal
+func poQuote(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"', '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// poEntry is an entry of a PO file being read
+type poEntry struct {
+	line     int // line of its msgid
+	msgid    string
+	msgstr   string
+	comments []string
+	refs     []string
+	fuzzy    bool
+}
+
+// decodePO decodes and validates a PO file as written by marshalPO: entries
+// with an empty msgstr are untranslated and left out, as are obsolete ("#~")
+// entries. The language is read from the Language header, or is lang if
+// there is none; pt_BR reads as pt-BR. Plural (msgid_plural) and context (msgctxt) entries are
+// rejected: write plurals as ICU messages in msgstr instead.
+func decodePO(data []byte, lang string) (*TranslationFile, error) {
+	var entries []poEntry
+	var cur poEntry
+	var target *string // string that continuation lines append to
+	started := false
+	flush := func() {
+		if started {
+			entries = append(entries, cur)
+		}
+		cur, target, started = poEntry{}, nil, false
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for num := 1; scanner.Scan(); num++ {
+		line := strings.TrimSpace(scanner.Text())
+		fail := func(format string, args ...any) error {
+			return &FileError{Line: num, Reason: fmt.Sprintf(format, args...)}
+		}
+
+		switch {
+		case line == "":
+			flush()
+		case strings.HasPrefix(line, "#"):
+			// Comments after a msgstr start the next entry
+			if target == &cur.msgstr {
+				flush()
+			}
+			switch {
+			case strings.HasPrefix(line, "#."):
+				cur.comments = append(cur.comments, strings.TrimSpace(line[2:]))
+			case strings.HasPrefix(line, "#:"):
+				cur.refs = append(cur.refs, strings.Fields(line[2:])...)
+			case strings.HasPrefix(line, "#,"):
+				for flag := range strings.SplitSeq(line[2:], ",") {
+					cur.fuzzy = cur.fuzzy || strings.TrimSpace(flag) == "fuzzy"
+				}
+			}
+		case strings.HasPrefix(line, "msgctxt"):
+			return nil, fail("msgctxt is not supported")
+		case strings.HasPrefix(line, "msgid_plural"), strings.HasPrefix(line, "msgstr["):
+			return nil, fail("plural entries are not supported, write an ICU plural in msgstr")
+		case strings.HasPrefix(line, "msgid "):
+			if started {
+				flush()
+			}
+			s, err := poUnquote(strings.TrimSpace(line[len("msgid "):]))
+			if err != nil {
+				return nil, fail("%v", err)
+			}
+			cur.line, cur.msgid, started = num, s, true
+			target = &cur.msgid
+		case strings.HasPrefix(line, "msgstr "):
+			if !started || target != &cur.msgid {
+				return nil, fail("msgstr without msgid")
+			}
+			s, err := poUnquote(strings.TrimSpace(line[len("msgstr "):]))
+			if err != nil {
+				return nil, fail("%v", err)
+			}
+			cur.msgstr = s
+			target = &cur.msgstr
+		case strings.HasPrefix(line, `"`):
+			if target == nil {
+				return nil, fail("string without msgid or msgstr")
+			}
+			s, err := poUnquote(line)
+			if err != nil {
+				return nil, fail("%v", err)
+			}
+			*target += s
+		default:
+			return nil, fail("unexpected line %q", line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	flush()
+
+	tf := newCatalogFile(lang, make(map[string]string))
+	for _, e := range entries {
+		if e.msgid == "" {
+			if header := poHeader(e.msgstr, "Language"); header != "" {
+				tf.Meta.Lang = strings.ReplaceAll(header, "_", "-") // pt_BR
+			}
+			continue
+		}
+		if _, dup := tf.Translations[e.msgid]; dup {
+			return nil, &FileError{Line: e.line, Key: e.msgid, Reason: fmt.Sprintf("duplicate msgid '%s'", e.msgid)}
+		}
+		if e.msgstr == "" {
+			continue
+		}
+		tf.Translations[e.msgid] = e.msgstr
+		if len(e.comments) > 0 {
+			if tf.Comments == nil {
+				tf.Comments = make(map[string]string)
+			}
+			tf.Comments[e.msgid] = strings.Join(e.comments, "\n")
+		}
+		if len(e.refs) > 0 {
+			if tf.References == nil {
+				tf.References = make(map[string][]string)
+			}
+			tf.References[e.msgid] = e.refs
+		}
+		if e.fuzzy {
+			tf.Fuzzy = append(tf.Fuzzy, e.msgid)
+		}
+	}
+	slices.Sort(tf.Fuzzy)
+
+	if tf.Meta.Lang == "" {
+		return nil, &FileError{Reason: "has no Language header: choose the language to convert"}
+	}
+	if err := validateTranslationFile(tf); err != nil {
+		return nil, err
+	}
+	return tf, nil
+}
+
+// poUnquote returns the value of a PO string literal
+func poUnquote(s string) (string, error) {
+	if len(s) < 2 || s[0] != '"' || s[len(s)-1] != '"' {
+		return "", fmt.Errorf("invalid string %s", s)
+	}
+	var b strings.Builder
+	for i := 1; i < len(s)-1; i++ {
+		c := s[i]
+		if c == '"' {
+			return "", fmt.Errorf("unescaped quote in %s", s)
+		}
+		if c != '\\' {
+			b.WriteByte(c)
+			continue
+		}
+		if i++; i == len(s)-1 {
+			return "", fmt.Errorf("invalid escape at the end of %s", s)
+		}
+		switch s[i] {
+		case 'n':
+			b.WriteByte('\n')
+		case 'r':
+			b.WriteByte('\r')
+		case 't':
+			b.WriteByte('\t')
+		case '"', '\\':
+			b.WriteByte(s[i])
+		default:
+			return "", fmt.Errorf("unknown escape \\%c in %s", s[i], s)
+		}
+	}
+	return b.String(), nil
+}
+
+// poHeader returns the value of a field of a PO header entry
+func poHeader(header, field string) string {
+	for line := range strings.SplitSeq(header, "\n") {
+		if name, value, ok := strings.Cut(line, ":"); ok && strings.TrimSpace(name) == field {
+			return strings.TrimSpace(value)
+		}
+	}
+	return ""
+}
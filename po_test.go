@@ -0,0 +1,110 @@
+package i18n
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDecodePO(t *testing.T) {
+	data := `# Translator notes are ignored
+msgid ""
+msgstr ""
+"Language: pt_BR\n"
+"Content-Type: text/plain; charset=UTF-8\n"
+
+#. Shown on the dashboard,
+#. under the title
+#: app.go:3
+#: mail.go:12
+#, fuzzy, c-format
+msgid "welcome"
+msgstr ""
+"Bem-vindo, "
+"{0}!"
+#. Next entry without a blank line
+msgid "bye"
+msgstr "Tchau"
+
+msgid "untranslated"
+msgstr ""
+
+#~ msgid "old"
+#~ msgstr "Velho"
+`
+	tf, err := decodePO([]byte(data), "")
+	if err != nil {
+		t.Fatalf("decodePO failed: %v", err)
+	}
+	if tf.Meta.Lang != "pt-BR" {
+		t.Errorf("Expected pt-BR from the header, got %q", tf.Meta.Lang)
+	}
+	if want := map[string]string{"welcome": "Bem-vindo, {0}!", "bye": "Tchau"}; !reflect.DeepEqual(tf.Translations, want) {
+		t.Errorf("Expected %v, got %v", want, tf.Translations)
+	}
+	if want := map[string]string{"welcome": "Shown on the dashboard,\nunder the title", "bye": "Next entry without a blank line"}; !reflect.DeepEqual(tf.Comments, want) {
+		t.Errorf("Expected comments %v, got %v", want, tf.Comments)
+	}
+	if !reflect.DeepEqual(tf.References["welcome"], []string{"app.go:3", "mail.go:12"}) || !reflect.DeepEqual(tf.Fuzzy, []string{"welcome"}) {
+		t.Errorf("Expected the references and fuzzy mark of welcome, got %v and %v", tf.References, tf.Fuzzy)
+	}
+}
+
+func TestDecodePO_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		lang string
+		data string
+		want string
+		line int
+	}{
+		{"no language", "", "msgid \"a\"\nmsgstr \"A\"\n", "has no Language header", 0},
+		{"plural", "fr", "msgid \"a\"\nmsgid_plural \"as\"\nmsgstr[0] \"A\"\n", "plural entries are not supported", 2},
+		{"context", "fr", "msgctxt \"menu\"\nmsgid \"a\"\nmsgstr \"A\"\n", "msgctxt is not supported", 1},
+		{"duplicate", "fr", "msgid \"a\"\nmsgstr \"A\"\n\nmsgid \"a\"\nmsgstr \"B\"\n", "duplicate msgid 'a'", 4},
+		{"bad string", "fr", "msgid \"a\nmsgstr \"A\"\n", "invalid string", 1},
+		{"invalid template", "fr", "msgid \"a\"\nmsgstr \"{count, plural, one {# item}\"\n", "invalid plural template for key 'a'", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := decodePO([]byte(tt.data), tt.lang)
+			var fe *FileError
+			if !errors.As(err, &fe) || !strings.Contains(err.Error(), tt.want) || fe.Line != tt.line {
+				t.Errorf("Expected an error on line %d containing %q, got %v", tt.line, tt.want, err)
+			}
+		})
+	}
+}
+
+func TestMarshalPO(t *testing.T) {
+	tf := newCatalogFile("fr", map[string]string{"b": "Ligne 1\nLigne 2", "a": `Dit "oui" \o/`})
+	tf.Comments = map[string]string{"a": "Two\nlines"}
+	tf.Fuzzy = []string{"b"}
+
+	want := `msgid ""
+msgstr ""
+"Language: fr\n"
+"Content-Type: text/plain; charset=UTF-8\n"
+
+#. Two
+#. lines
+msgid "a"
+msgstr "Dit \"oui\" \\o/"
+
+#, fuzzy
+msgid "b"
+msgstr "Ligne 1\nLigne 2"
+`
+	if got := string(marshalPO(tf)); got != want {
+		t.Errorf("Expected\n%s\ngot\n%s", want, got)
+	}
+
+	back, err := decodePO(marshalPO(tf), "")
+	if err != nil {
+		t.Fatalf("decodePO failed: %v", err)
+	}
+	if !reflect.DeepEqual(back.Translations, tf.Translations) || !reflect.DeepEqual(back.Comments, tf.Comments) {
+		t.Errorf("Expected the values and comments back, got %v and %v", back.Translations, back.Comments)
+	}
+}
@@ -0,0 +1,157 @@
+package i18n
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Limits applied by a MessagePolicy that leaves them zero
+const (
+	DefaultMaxMessageLength = 2000 // characters
+	DefaultMaxMessageDepth  = 2    // nested plural/select blocks
+)
+
+// MessagePolicy restricts the message templates end users may write, such as
+// workspace admins customizing notification text. Templates compiled with a
+// policy use the same engine as catalog messages but may only refer to the
+// listed variables and are bounded in size and nesting.
+//
+// Example:
+//
+//	policy := i18n.MessagePolicy{Variables: []string{"name", "count"}}
+//	msg, err := policy.Compile(form.Template)
+//	if err != nil {
+//		return err // *i18n.MessageError, ready to show next to the field
+//	}
+//	text := msg.Render(locale, i18n.Args{"name": user.Name, "count": unread})
+type MessagePolicy struct {
+	MaxLength int      // maximum length in characters, DefaultMaxMessageLength if 0
+	MaxDepth  int      // maximum nesting of blocks, DefaultMaxMessageDepth if 0
+	Variables []string // names that placeholders and blocks may use
+}
+
+// MessageError describes why a user template was rejected, in terms suitable
+// for showing in a settings UI
+type MessageError struct {
+	Position int    // 1-based character position of the problem, 0 if unknown
+	Reason   string // human-readable description of the problem
+}
+
+func (e *MessageError) Error() string {
+	if e.Position > 0 {
+		return fmt.Sprintf("character %d: %s", e.Position, e.Reason)
+	}
+	return e.Reason
+}
+
+// Compile validates a user template against the policy and compiles it.
+// Placeholders must be named variables of the policy ({name}); positional
+// placeholders and unknown names are rejected, as are blocks other than
+// {count, plural, ...}, select and selectordinal. Problems are reported as
+// *MessageError.
+func (p MessagePolicy) Compile(value string) (*Message, error) {
+	maxLength := p.MaxLength
+	if maxLength <= 0 {
+		maxLength = DefaultMaxMessageLength
+	}
+	if n := utf8.RuneCountInString(value); n > maxLength {
+		return nil, &MessageError{Reason: fmt.Sprintf("template is too long (%d characters, at most %d)", n, maxLength)}
+	}
+	if err := validateCharacters(value); err != nil {
+		return nil, &MessageError{Reason: fmt.Sprintf("template %s", err)}
+	}
+
+	if err := p.check(value, value, 0, 0); err != nil {
+		return nil, err
+	}
+
+	msg, err := CompileMessage(value)
+	if err != nil {
+		return nil, &MessageError{Reason: err.Error()}
+	}
+	return msg, nil
+}
+
+// check validates the placeholders and blocks of text, found at offset in
+// the whole template, nested depth blocks deep
+func (p MessagePolicy) check(template, text string, offset, depth int) error {
+	maxDepth := p.MaxDepth
+	if maxDepth <= 0 {
+		maxDepth = DefaultMaxMessageDepth
+	}
+	fail := func(at int, format string, args ...any) error {
+		return &MessageError{
+			Position: utf8.RuneCountInString(template[:offset+at]) + 1,
+			Reason:   fmt.Sprintf(format, args...),
+		}
+	}
+
+	for i := 0; i < len(text); i++ {
+		switch text[i] {
+		case '}':
+			return fail(i, "unexpected '}'")
+		case '{':
+			closing := matchingBrace(text, i)
+			if closing < 0 {
+				return fail(i, "missing '}'")
+			}
+
+			inner := text[i+1 : closing]
+			name, rest, isBlock := strings.Cut(inner, ",")
+			name = strings.TrimSpace(name)
+			switch {
+			case name == "":
+				return fail(i, "empty placeholder")
+			case isNumber(name):
+				return fail(i, "use a variable name instead of {%s}", name)
+			case !slices.Contains(p.Variables, name):
+				return fail(i, "unknown variable {%s}; available: %s", name, strings.Join(p.Variables, ", "))
+			}
+			if !isBlock {
+				i = closing
+				continue
+			}
+
+			kind, body, _ := strings.Cut(rest, ",")
+			switch kind = strings.TrimSpace(kind); kind {
+			case "plural":
+				if name != "count" {
+					return fail(i, "plural blocks must use {count, plural, ...}")
+				}
+			case "select", "selectordinal":
+			default:
+				return fail(i, "unknown block type '%s'", kind)
+			}
+			if depth+1 > maxDepth {
+				return fail(i, "blocks are nested too deeply (at most %d levels)", maxDepth)
+			}
+
+			// Check the content of every branch: "one {...} other {...}"
+			bodyOffset := offset + i + 1 + len(inner) - len(body)
+			for j := 0; j < len(body); j++ {
+				if body[j] != '{' {
+					continue
+				}
+				end := matchingBrace(body, j)
+				if end < 0 {
+					return fail(i, "missing '}'")
+				}
+				if err := p.check(template, body[j+1:end], bodyOffset+j+1, depth+1); err != nil {
+					return err
+				}
+				j = end
+			}
+			i = closing
+		}
+	}
+	return nil
+}
+
+// isNumber reports whether s is a decimal number, as in positional placeholders
+func isNumber(s string) bool {
+	_, err := strconv.Atoi(s)
+	return err == nil
+}
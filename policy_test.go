@@ -0,0 +1,55 @@
+package i18n
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestMessagePolicy_Compile(t *testing.T) {
+	policy := MessagePolicy{Variables: []string{"name", "count", "gender"}}
+
+	msg, err := policy.Compile("Hi {name}, you have {count, plural, one {# task} other {# tasks}}")
+	if err != nil {
+		t.Fatalf("Compile failed: %v", err)
+	}
+	if got := msg.Render("en", Args{"name": "Ada", "count": 2}); got != "Hi Ada, you have 2 tasks" {
+		t.Errorf("Unexpected render: %q", got)
+	}
+
+	tests := []struct {
+		template string
+		position int
+		reason   string
+	}{
+		{"Hello {0}", 7, "use a variable name instead of {0}"},
+		{"Hello {email}", 7, "unknown variable {email}"},
+		{"Hello {}", 7, "empty placeholder"},
+		{"Hello name}", 11, "unexpected '}'"},
+		{"Hello {name", 7, "missing '}'"},
+		{"{name, plural, other {x}}", 1, "plural blocks must use {count, plural, ...}"},
+		{"{name, number}", 1, "unknown block type 'number'"},
+		{"{gender, select, female {Hi {secret}} other {Hi}}", 29, "unknown variable {secret}"},
+		{"Café {gender, select, other {{count, plural, other {{gender, select, other {x}}}}}}", 53, "nested too deeply"},
+		{"{gender, select, male {him}}", 0, "no 'other' branch"},
+		{strings.Repeat("x", DefaultMaxMessageLength+1), 0, "too long"},
+		{"Hello\u200B{name}", 0, "zero width space"},
+	}
+
+	for _, tt := range tests {
+		_, err := policy.Compile(tt.template)
+		var me *MessageError
+		if !errors.As(err, &me) {
+			t.Errorf("Compile(%q) = %v, want *MessageError", tt.template, err)
+			continue
+		}
+		if me.Position != tt.position || !strings.Contains(me.Reason, tt.reason) {
+			t.Errorf("Compile(%q) = %+v, want position %d and reason containing %q", tt.template, me, tt.position, tt.reason)
+		}
+	}
+
+	short := MessagePolicy{MaxLength: 5, Variables: []string{"name"}}
+	if _, err := short.Compile("Hello {name}"); err == nil || err.Error() != "template is too long (12 characters, at most 5)" {
+		t.Errorf("Expected length error, got %v", err)
+	}
+}
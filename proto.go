@@ -0,0 +1,169 @@
+package i18n
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultProtoOptions and DefaultProtoKeyOptions are the custom options
+// ProtoExtractor reads source texts and catalog keys from when it has none
+// configured
+var (
+	DefaultProtoOptions    = []string{"(i18n.message)"}
+	DefaultProtoKeyOptions = []string{"(i18n.key)"}
+)
+
+// protoString matches one proto string literal
+const protoString = `"(?:[^"\\\n]|\\.)*"|'(?:[^'\\\n]|\\.)*'`
+
+// ProtoExtractor finds strings set through custom options in .proto files,
+// so localized gRPC error details and enum labels share the catalogs of the
+// Go code. Scalar options are read wherever they appear, as file, message,
+// field or enum value options:
+//
+//	enum Code {
+//	  QUOTA_EXCEEDED = 1 [(i18n.message) = "You have used all %d requests"];
+//	  NOT_FOUND = 2 [(i18n.key) = "errors.not_found"];
+//	}
+//
+// Values of Options are source texts stored under slug keys like F; values
+// of KeyOptions are keys used as-is like T. Adjacent string literals are
+// concatenated as in proto. It is registered for "*.proto" with the default
+// options; register another one for your own option names.
+type ProtoExtractor struct {
+	Options    []string // DefaultProtoOptions if both are empty
+	KeyOptions []string // DefaultProtoKeyOptions if both are empty
+}
+
+// Extract returns the option values of a .proto file
+func (e ProtoExtractor) Extract(path string, src []byte) ([]Entry, error) {
+	options, keyOptions := e.Options, e.KeyOptions
+	if len(options) == 0 && len(keyOptions) == 0 {
+		options, keyOptions = DefaultProtoOptions, DefaultProtoKeyOptions
+	}
+
+	text := stripProtoComments(string(src))
+	type found struct {
+		offset int
+		entry  Entry
+	}
+	var all []found
+	for _, group := range []struct {
+		names []string
+		key   bool
+	}{{options, false}, {keyOptions, true}} {
+		for _, name := range group.names {
+			pattern := regexp.MustCompile(regexp.QuoteMeta(name) + `\s*=\s*((?:` + protoString + `)(?:\s*(?:` + protoString + `))*)`)
+			for _, m := range pattern.FindAllStringSubmatchIndex(text, -1) {
+				line, col := lineColumn(text, m[2])
+				value, err := unquoteProto(text[m[2]:m[3]])
+				if err != nil {
+					return nil, fmt.Errorf("%d:%d: %w", line, col, err)
+				}
+				if value == "" {
+					continue
+				}
+
+				entry := Entry{Pos: fmt.Sprintf("%s:%d:%d", path, line, col), Func: name}
+				if group.key {
+					entry.Key = value
+				} else {
+					entry.Text = value
+				}
+				all = append(all, found{m[2], entry})
+			}
+		}
+	}
+
+	// Report entries in file order, whatever option they come from
+	sort.SliceStable(all, func(i, j int) bool { return all[i].offset < all[j].offset })
+	entries := make([]Entry, len(all))
+	for i, f := range all {
+		entries[i] = f.entry
+	}
+	return entries, nil
+}
+
+// stripProtoComments blanks out // and /* */ comments, keeping newlines so
+// offsets and line numbers stay valid
+func stripProtoComments(src string) string {
+	out := []byte(src)
+	for i := 0; i < len(out); i++ {
+		switch {
+		case out[i] == '"' || out[i] == '\'':
+			quote := out[i]
+			for i++; i < len(out) && out[i] != quote && out[i] != '\n'; i++ {
+				if out[i] == '\\' {
+					i++
+				}
+			}
+		case out[i] == '/' && i+1 < len(out) && out[i+1] == '/':
+			for ; i < len(out) && out[i] != '\n'; i++ {
+				out[i] = ' '
+			}
+		case out[i] == '/' && i+1 < len(out) && out[i+1] == '*':
+			end := strings.Index(string(out[i+2:]), "*/")
+			stop := len(out)
+			if end >= 0 {
+				stop = i + 2 + end + 2
+			}
+			for ; i < stop; i++ {
+				if out[i] != '\n' {
+					out[i] = ' '
+				}
+			}
+			i--
+		}
+	}
+	return string(out)
+}
+
+// unquoteProto returns the value of adjacent proto string literals
+func unquoteProto(literals string) (string, error) {
+	var value strings.Builder
+	for _, lit := range regexp.MustCompile(protoString).FindAllString(literals, -1) {
+		if lit[0] == '\'' {
+			lit = doubleQuoted(lit[1 : len(lit)-1])
+		}
+		s, err := strconv.Unquote(lit)
+		if err != nil {
+			return "", fmt.Errorf("invalid string %s", lit)
+		}
+		value.WriteString(s)
+	}
+	return value.String(), nil
+}
+
+// doubleQuoted rewrites the body of a single-quoted literal as a
+// double-quoted Go literal with the same escapes
+func doubleQuoted(body string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for i := 0; i < len(body); i++ {
+		switch {
+		case body[i] == '\\' && i+1 < len(body) && body[i+1] == '\'':
+			b.WriteByte('\'')
+			i++
+		case body[i] == '\\' && i+1 < len(body):
+			b.WriteString(body[i : i+2])
+			i++
+		case body[i] == '"':
+			b.WriteString(`\"`)
+		default:
+			b.WriteByte(body[i])
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// lineColumn returns the 1-based line and column of a byte offset
+func lineColumn(text string, offset int) (line, col int) {
+	before := text[:offset]
+	line = strings.Count(before, "\n") + 1
+	col = offset - strings.LastIndex(before, "\n")
+	return line, col
+}
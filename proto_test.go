@@ -0,0 +1,81 @@
+package i18n
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const testProto = `syntax = "proto3";
+
+import "i18n/options.proto";
+
+// (i18n.message) = "in a comment"
+enum Code {
+  QUOTA_EXCEEDED = 1 [(i18n.message) = "You have used all %d requests"];
+  NOT_FOUND = 2 [(i18n.key) = "errors.not_found"];
+  /* (i18n.message) = "also a comment" */
+  LOCKED = 3 [
+    (i18n.message) = "Your account is "
+                     'locked. Contact "support".'
+  ];
+}
+`
+
+func TestProtoExtractor(t *testing.T) {
+	entries, err := ProtoExtractor{}.Extract("errors.proto", []byte(testProto))
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	want := []Entry{
+		{Text: "You have used all %d requests", Pos: "errors.proto:7:40", Func: "(i18n.message)"},
+		{Key: "errors.not_found", Pos: "errors.proto:8:31", Func: "(i18n.key)"},
+		{Text: `Your account is locked. Contact "support".`, Pos: "errors.proto:11:22", Func: "(i18n.message)"},
+	}
+	if !reflect.DeepEqual(entries, want) {
+		t.Errorf("Expected %+v, got %+v", want, entries)
+	}
+}
+
+func TestProtoExtractor_CustomOptions(t *testing.T) {
+	src := `message Quota {
+  option (acme.errors.detail) = "Quota exceeded";
+  string id = 1 [(i18n.message) = "ignored"];
+}
+`
+	entries, err := ProtoExtractor{Options: []string{"(acme.errors.detail)"}}.Extract("quota.proto", []byte(src))
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Text != "Quota exceeded" {
+		t.Errorf("Expected only the custom option, got %+v", entries)
+	}
+
+	_, err = ProtoExtractor{}.Extract("bad.proto", []byte(`x = 1 [(i18n.message) = "bad \q escape"];`))
+	if err == nil {
+		t.Error("Expected an invalid escape to be reported")
+	}
+}
+
+func TestGenerateTranslations_Proto(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "errors.proto"), []byte(testProto), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	output := filepath.Join(dir, "locales", "default.en.json")
+	if err := GenerateTranslations("en", dir, output); err != nil {
+		t.Fatalf("GenerateTranslations failed: %v", err)
+	}
+	tf, err := readTranslationFile(output)
+	if err != nil {
+		t.Fatalf("Failed to read catalog: %v", err)
+	}
+	if got := tf.Translations["you-have-used-all-0-requests"]; got != "You have used all {0} requests" {
+		t.Errorf("Expected the normalized option text, got %q (%v)", got, tf.Translations)
+	}
+	if !reflect.DeepEqual(tf.NeedsSource, []string{"errors.not_found"}) {
+		t.Errorf("Expected the key option to need source text, got %v", tf.NeedsSource)
+	}
+}
@@ -0,0 +1,92 @@
+package i18n
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"path/filepath"
+	"slices"
+)
+
+// FindUnusedKeys returns the keys of the catalog at localeFile that the i18n
+// calls under root no longer use, sorted, like the Unused keys of
+// DiffTranslations. Keys matching one of the keep patterns (path.Match
+// syntax, e.g. "errors.*" or "status.*.label") are left out, for keys the
+// code builds at run time where the extractor cannot see them.
+//
+// Example:
+//
+//	unused, err := i18n.FindUnusedKeys(".", "locales/default.en.json", "errors.*")
+//	if err == nil {
+//		_, err = i18n.PruneKeys("locales/default.en.json", unused...)
+//	}
+func FindUnusedKeys(root, localeFile string, keep ...string) ([]string, error) {
+	return FindUnusedKeysContext(context.Background(), root, localeFile, keep...)
+}
+
+// FindUnusedKeysContext is like FindUnusedKeys but stops scanning and
+// returns ctx's error once ctx is done
+func FindUnusedKeysContext(ctx context.Context, root, localeFile string, keep ...string) ([]string, error) {
+	for _, pattern := range keep {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid keep pattern %q: %w", pattern, err)
+		}
+	}
+	diff, err := DiffTranslationsContext(ctx, root, localeFile)
+	if err != nil {
+		return nil, err
+	}
+	unused := slices.DeleteFunc(diff.Unused, func(key string) bool {
+		return slices.ContainsFunc(keep, func(pattern string) bool {
+			ok, _ := path.Match(pattern, key)
+			return ok
+		})
+	})
+	return unused, nil
+}
+
+// PruneKeys removes keys from the catalog at localeFile, along with their
+// comments, placeholders, references, source hashes and states, and returns
+// how many of them it had. The catalog is rewritten only if it changes, holding the
+// lock of its directory (see LockCatalogDir).
+func PruneKeys(localeFile string, keys ...string) (int, error) {
+	var removed int
+	err := withCatalogLock(context.Background(), filepath.Dir(localeFile), func() error {
+		tf, err := readTranslationFile(localeFile)
+		if err != nil {
+			return err
+		}
+		for _, key := range keys {
+			_, ok := tf.Translations[key]
+			_, isList := tf.Lists[key]
+			if !ok && !isList {
+				continue
+			}
+			removed++
+			delete(tf.Translations, key)
+			delete(tf.Lists, key)
+			delete(tf.Schedule, key)
+			delete(tf.Comments, key)
+			delete(tf.Placeholders, key)
+			delete(tf.References, key)
+			delete(tf.SourceHashes, key)
+			delete(tf.States, key)
+			tf.MachineTranslated = slices.DeleteFunc(tf.MachineTranslated, func(k string) bool { return k == key })
+			tf.Fuzzy = slices.DeleteFunc(tf.Fuzzy, func(k string) bool { return k == key })
+			for group, members := range tf.Groups {
+				tf.Groups[group] = slices.DeleteFunc(members, func(k string) bool { return k == key })
+				if len(tf.Groups[group]) == 0 {
+					delete(tf.Groups, group)
+				}
+			}
+		}
+		if removed == 0 {
+			return nil
+		}
+		return writeCatalog(localeFile, tf)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return removed, nil
+}
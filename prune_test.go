@@ -0,0 +1,137 @@
+package i18n
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestFindUnusedKeys(t *testing.T) {
+	root := t.TempDir()
+	writeTestFile(t, filepath.Join(root, "main.go"), `package main
+
+import "github.com/nyxstack/i18n"
+
+var _, _ = i18n.S("Dashboard"), i18n.T("errors.quota")
+`)
+	dir := t.TempDir()
+	catalog := filepath.Join(dir, "default.en.json")
+	writeTestCatalog(t, dir, "default.en.json", "en", map[string]string{
+		"dashboard":        "Dashboard",
+		"errors.quota":     "Quota exceeded",
+		"errors.not_found": "Not found",
+		"old_title":        "Old title",
+		"status.open":      "Open",
+	})
+
+	unused, err := FindUnusedKeys(root, catalog)
+	if err != nil {
+		t.Fatalf("FindUnusedKeys failed: %v", err)
+	}
+	if want := []string{"errors.not_found", "old_title", "status.open"}; !reflect.DeepEqual(unused, want) {
+		t.Errorf("Expected %v, got %v", want, unused)
+	}
+
+	unused, err = FindUnusedKeys(root, catalog, "errors.*", "status.*")
+	if err != nil {
+		t.Fatalf("FindUnusedKeys failed: %v", err)
+	}
+	if want := []string{"old_title"}; !reflect.DeepEqual(unused, want) {
+		t.Errorf("Expected kept keys to be left out, got %v", unused)
+	}
+
+	if _, err := FindUnusedKeys(root, catalog, "["); err == nil {
+		t.Error("Expected an error for an invalid pattern")
+	}
+}
+
+func TestFindUnusedKeys_ValueAccessors(t *testing.T) {
+	root := t.TempDir()
+	writeTestFile(t, filepath.Join(root, "main.go"), `package main
+
+import (
+	"time"
+
+	"github.com/nyxstack/i18n"
+)
+
+type Banner struct{ Title, Body string }
+
+func settings(locale string) (int, bool, time.Duration, []string, Banner) {
+	perPage, _ := i18n.GetInt(locale, "items_per_page")
+	beta, _ := i18n.GetBool(locale, "beta_enabled")
+	timeout, _ := i18n.GetDuration(locale, "session_timeout")
+	var banner Banner
+	_ = i18n.GetInto(locale, "empty_state", &banner)
+	_ = i18n.GetInto(locale, "promo", &banner)
+	return perPage, beta, timeout, i18n.GetSlice(locale, "weekdays"), banner
+}
+`)
+	dir := t.TempDir()
+	catalog := filepath.Join(dir, "default.en.json")
+	writeTestFile(t, catalog, `{
+  "meta": {"lang": "en", "name": "default"},
+  "translations": {
+    "items_per_page": 20,
+    "beta_enabled": true,
+    "session_timeout": "30m",
+    "weekdays": ["Mon", "Tue"],
+    "empty_state": {"title": "Nothing here yet", "body": "Create your first project"},
+    "empty_states": "Not a field of empty_state",
+    "old_title": "Old title"
+  }
+}`)
+
+	unused, err := FindUnusedKeys(root, catalog)
+	if err != nil {
+		t.Fatalf("FindUnusedKeys failed: %v", err)
+	}
+	if want := []string{"empty_states", "old_title"}; !reflect.DeepEqual(unused, want) {
+		t.Errorf("Expected %v, got %v", want, unused)
+	}
+
+	diff, err := DiffTranslations(root, catalog)
+	if err != nil {
+		t.Fatalf("DiffTranslations failed: %v", err)
+	}
+	if want := []string{"promo"}; !reflect.DeepEqual(diff.Missing, want) {
+		t.Errorf("Expected the object without fields to be missing, got %v", diff.Missing)
+	}
+}
+
+func TestPruneKeys(t *testing.T) {
+	dir := t.TempDir()
+	catalog := filepath.Join(dir, "default.fr.json")
+	writeTestFile(t, catalog, `{
+  "meta": {"lang": "fr", "name": "default"},
+  "translations": {"keep": "Garder", "old": "Ancien", "days": ["lun", "mar"]},
+  "comments": {"old": "A stale note"},
+  "references": {"old": ["app.go:3"], "keep": ["app.go:4"]},
+  "machine_translated": ["keep", "old"]
+}`)
+
+	n, err := PruneKeys(catalog, "old", "days", "missing")
+	if err != nil {
+		t.Fatalf("PruneKeys failed: %v", err)
+	}
+	if n != 2 {
+		t.Errorf("Expected 2 keys removed, got %d", n)
+	}
+	tf, err := readTranslationFile(catalog)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(tf.Translations, map[string]string{"keep": "Garder"}) || len(tf.Lists) != 0 {
+		t.Errorf("Expected only keep to remain, got %v and %v", tf.Translations, tf.Lists)
+	}
+	if tf.Comments != nil || !reflect.DeepEqual(tf.References, map[string][]string{"keep": {"app.go:4"}}) {
+		t.Errorf("Expected the metadata of removed keys to go, got %v and %v", tf.Comments, tf.References)
+	}
+	if !reflect.DeepEqual(tf.MachineTranslated, []string{"keep"}) {
+		t.Errorf("Expected the machine-translation mark of old to go, got %v", tf.MachineTranslated)
+	}
+
+	if n, err := PruneKeys(catalog, "missing"); err != nil || n != 0 {
+		t.Errorf("Expected nothing to remove, got %d, %v", n, err)
+	}
+}
@@ -0,0 +1,70 @@
+package i18n
+
+import (
+	"hash/fnv"
+	"math/rand/v2"
+)
+
+// RandomOption configures how TRandom picks an alternative
+type RandomOption struct {
+	id string
+}
+
+// StableFor makes TRandom pick the same alternative every time for id (e.g. a
+// user ID), so copy does not change between renders for the same user
+func StableFor(id string) RandomOption {
+	return RandomOption{id: id}
+}
+
+// TRandom translates a key that holds alternative phrasings as a list, picking
+// one at random on each render. An alternative listed twice is twice as
+// likely. Placeholders are replaced with args as in T, and a plain string
+// value is used as the only alternative.
+//
+// Example:
+//
+//	fn := i18n.TRandom("empty_inbox", i18n.StableFor(user.ID))
+//	fmt.Println(fn("en")) // "Inbox zero!" or "Nothing to see here"
+//
+// Dictionary should contain:
+//
+//	"empty_inbox": ["Inbox zero!", "Nothing to see here", "Nothing to see here"]
+func TRandom(key string, args ...any) TranslatedFunc {
+	return defaultBundle.TRandom(key, args...)
+}
+
+// TRandom is like the package-level TRandom but uses the bundle's dictionaries
+func (b *Bundle) TRandom(key string, args ...any) TranslatedFunc {
+	values := newPlaceholderArgs(args)
+
+	return b.debugged(func(locale string) string {
+		var alternatives []string
+		if dict := b.dictionaryFor(locale); dict != nil {
+			alternatives = dict.GetSlice(key)
+		}
+		if len(alternatives) == 0 {
+			template := key
+			if tr, _, ok := b.find(locale, key); ok {
+				template = tr
+			}
+			return values.replace(template)
+		}
+
+		return values.replace(alternatives[pick(key, len(alternatives), args)])
+	}, key)
+}
+
+// pick returns the index of the alternative to render: random, or derived
+// from the key and the id of a StableFor option
+func pick(key string, n int, args []any) int {
+	for _, arg := range args {
+		if opt, ok := arg.(RandomOption); ok {
+			h := fnv.New32a()
+			h.Write([]byte(opt.id))
+			h.Write([]byte{0})
+			h.Write([]byte(key))
+			return int(h.Sum32() % uint32(n))
+		}
+	}
+	return rand.IntN(n)
+}
@@ -0,0 +1,57 @@
+package i18n
+
+import "testing"
+
+func TestTRandom(t *testing.T) {
+	setupTestDictionaries()
+	defer func() {
+		defaultBundle.mu.Lock()
+		defaultBundle.dictionaries = make(map[string]*Dictionary)
+		defaultBundle.mu.Unlock()
+	}()
+
+	dict := GetDictionary("en")
+	dict.mu.Lock()
+	dict.Lists = map[string][]string{
+		"empty":    {"Nothing here, {0}", "All clear, {0}", "Inbox zero, {0}"},
+		"weighted": {"a", "b", "b", "b"},
+	}
+	dict.mu.Unlock()
+	dict.Add("single", "Only {0}")
+
+	seen := make(map[string]bool)
+	for i := 0; i < 200; i++ {
+		seen[TRandom("empty", "Ann")("en")] = true
+	}
+	for _, want := range []string{"Nothing here, Ann", "All clear, Ann", "Inbox zero, Ann"} {
+		if !seen[want] {
+			t.Errorf("Expected %q among rendered alternatives, got %v", want, seen)
+		}
+	}
+	if len(seen) != 3 {
+		t.Errorf("Expected 3 distinct alternatives, got %v", seen)
+	}
+
+	// Stable per id, and StableFor does not take a positional index
+	first := TRandom("empty", StableFor("user-42"), "Ann")("en")
+	for i := 0; i < 20; i++ {
+		if result := TRandom("empty", StableFor("user-42"), "Ann")("en"); result != first {
+			t.Fatalf("Expected stable alternative %q, got %q", first, result)
+		}
+	}
+
+	counts := make(map[string]int)
+	for i := 0; i < 2000; i++ {
+		counts[TRandom("weighted")("en")]++
+	}
+	if counts["b"] < 2*counts["a"] {
+		t.Errorf("Expected duplicated alternative to be picked more often, got %v", counts)
+	}
+
+	if result := TRandom("single", "Ann")("en"); result != "Only Ann" {
+		t.Errorf("Expected string value as only alternative, got %q", result)
+	}
+	if result := TRandom("missing")("en"); result != "missing" {
+		t.Errorf("Expected key for missing alternatives, got %q", result)
+	}
+}
@@ -0,0 +1,127 @@
+package i18n
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// now returns the current time; tests replace it to move the clock
+var now = time.Now
+
+// ScheduledValue is a translation value that replaces the base value of its
+// key only between ValidFrom (inclusive) and ValidUntil (exclusive), such as
+// a holiday greeting or a promo banner. A zero bound leaves that side open.
+type ScheduledValue struct {
+	Value      string    `json:"value"`
+	ValidFrom  time.Time `json:"valid_from,omitzero"`
+	ValidUntil time.Time `json:"valid_until,omitzero"`
+}
+
+// UnmarshalJSON decodes a scheduled value. Bounds are RFC 3339 timestamps or
+// dates (2006-01-02, UTC); a date valid_until includes the whole day.
+func (sv *ScheduledValue) UnmarshalJSON(data []byte) error {
+	var aux struct {
+		Value      string `json:"value"`
+		ValidFrom  string `json:"valid_from"`
+		ValidUntil string `json:"valid_until"`
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	from, err := parseScheduleTime(aux.ValidFrom, false)
+	if err != nil {
+		return fmt.Errorf("invalid valid_from: %w", err)
+	}
+	until, err := parseScheduleTime(aux.ValidUntil, true)
+	if err != nil {
+		return fmt.Errorf("invalid valid_until: %w", err)
+	}
+
+	*sv = ScheduledValue{Value: aux.Value, ValidFrom: from, ValidUntil: until}
+	return nil
+}
+
+// parseScheduleTime parses an RFC 3339 timestamp or a date. With endOfDay, a
+// date is moved to the start of the next day so the bound covers it entirely.
+func parseScheduleTime(s string, endOfDay bool) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+
+	t, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("expected RFC 3339 timestamp or date, got '%s'", s)
+	}
+	if endOfDay {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t, nil
+}
+
+// Active reports whether the value applies at t
+func (sv ScheduledValue) Active(t time.Time) bool {
+	if !sv.ValidFrom.IsZero() && t.Before(sv.ValidFrom) {
+		return false
+	}
+	return sv.ValidUntil.IsZero() || t.Before(sv.ValidUntil)
+}
+
+// AddScheduled adds a value that replaces the base translation of key while
+// its window is active. The first active value of a key wins.
+func (d *Dictionary) AddScheduled(key string, value ScheduledValue) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.Schedule == nil {
+		d.Schedule = make(map[string][]ScheduledValue)
+	}
+	d.Schedule[key] = append(d.Schedule[key], value)
+}
+
+// scheduled returns the active scheduled value of key, if any. The caller
+// must hold d.mu.
+func (d *Dictionary) scheduled(key string) (string, bool) {
+	values := d.Schedule[key]
+	if len(values) == 0 {
+		return "", false
+	}
+
+	t := now()
+	for _, sv := range values {
+		if sv.Active(t) {
+			return sv.Value, true
+		}
+	}
+	return "", false
+}
+
+// validateSchedule checks the scheduled values of a translation file: each
+// key needs a base value to show outside its windows, and each window must
+// have a value and end after it starts
+func validateSchedule(tf *TranslationFile) error {
+	lang := tf.Meta.Lang
+	for _, key := range sortedKeys(tf.Schedule) {
+		if _, ok := tf.Translations[key]; !ok {
+			return fileError(lang, key, "scheduled key '%s' has no base value", key)
+		}
+
+		for i, sv := range tf.Schedule[key] {
+			if sv.Value == "" {
+				return fileError(lang, key, "scheduled key '%s' has empty value at index %d", key, i)
+			}
+			if !sv.ValidFrom.IsZero() && !sv.ValidUntil.IsZero() && !sv.ValidUntil.After(sv.ValidFrom) {
+				return fileError(lang, key, "scheduled key '%s' ends before it starts at index %d", key, i)
+			}
+			if err := validateTemplate(key, sv.Value); err != nil {
+				fe := fileError(lang, key, "invalid template for key '%s'", key)
+				fe.Err = err
+				return fe
+			}
+		}
+	}
+	return nil
+}
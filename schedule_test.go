@@ -0,0 +1,114 @@
+package i18n
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func setClock(t *testing.T, at string) {
+	t.Helper()
+	ts, err := time.Parse(time.RFC3339, at)
+	if err != nil {
+		t.Fatalf("Invalid test time: %v", err)
+	}
+	now = func() time.Time { return ts }
+	t.Cleanup(func() { now = time.Now })
+}
+
+func TestScheduledValues(t *testing.T) {
+	content := `{
+  "meta": {"lang": "en", "name": "default"},
+  "translations": {"greeting": "Welcome {0}", "banner": "Shop now"},
+  "schedule": {
+    "greeting": [
+      {"value": "Happy holidays, {0}!", "valid_from": "2026-12-20", "valid_until": "2026-12-31"}
+    ],
+    "banner": [
+      {"value": "Summer sale", "valid_from": "2026-06-01T00:00:00+02:00", "valid_until": "2026-07-01T00:00:00+02:00"},
+      {"value": "Launch week", "valid_from": "2026-09-01"}
+    ]
+  }
+}`
+	path := filepath.Join(t.TempDir(), "default.en.json")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	dict, err := LoadDictionaryFile(path)
+	if err != nil {
+		t.Fatalf("LoadDictionaryFile failed: %v", err)
+	}
+
+	b := NewBundle()
+	b.Register(dict)
+
+	tests := []struct {
+		at       string
+		key      string
+		expected string
+	}{
+		{"2026-12-19T23:59:59Z", "greeting", "Welcome Ann"},
+		{"2026-12-20T00:00:00Z", "greeting", "Happy holidays, Ann!"},
+		{"2026-12-31T23:59:59Z", "greeting", "Happy holidays, Ann!"},
+		{"2027-01-01T00:00:00Z", "greeting", "Welcome Ann"},
+		{"2026-05-31T21:59:59Z", "banner", "Shop now"},
+		{"2026-05-31T22:00:00Z", "banner", "Summer sale"},
+		{"2026-08-01T00:00:00Z", "banner", "Shop now"},
+		{"2030-01-01T00:00:00Z", "banner", "Launch week"},
+	}
+
+	for _, tt := range tests {
+		setClock(t, tt.at)
+		if result := b.T(tt.key, "Ann")("en"); result != tt.expected {
+			t.Errorf("At %s, T(%q) = %q, expected %q", tt.at, tt.key, result, tt.expected)
+		}
+	}
+}
+
+func TestScheduledValues_Invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		schedule string
+		errMsg   string
+	}{
+		{"no base value", `"missing": [{"value": "X"}]`, "has no base value"},
+		{"empty value", `"greeting": [{"value": ""}]`, "has empty value at index 0"},
+		{"ends before start", `"greeting": [{"value": "X", "valid_from": "2026-12-20", "valid_until": "2026-12-01"}]`, "ends before it starts"},
+		{"bad date", `"greeting": [{"value": "X", "valid_from": "20 Dec"}]`, "invalid valid_from"},
+		{"bad template", `"greeting": [{"value": "Hi {0"}]`, "invalid template"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content := `{"meta": {"lang": "en", "name": "default"}, "translations": {"greeting": "Hi"}, "schedule": {` + tt.schedule + `}}`
+			_, err := decodeTranslationFile([]byte(content))
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("Expected error containing %q, got %v", tt.errMsg, err)
+			}
+		})
+	}
+}
+
+func TestAddScheduled(t *testing.T) {
+	setClock(t, "2026-12-24T12:00:00Z")
+
+	dict := NewDictionary("en")
+	dict.Add("greeting", "Hello")
+	dict.AddScheduled("greeting", ScheduledValue{
+		Value:      "Merry Christmas",
+		ValidFrom:  time.Date(2026, 12, 24, 0, 0, 0, 0, time.UTC),
+		ValidUntil: time.Date(2026, 12, 27, 0, 0, 0, 0, time.UTC),
+	})
+
+	if result := dict.Get("greeting"); result != "Merry Christmas" {
+		t.Errorf("Expected scheduled value, got %q", result)
+	}
+
+	setClock(t, "2026-12-27T00:00:00Z")
+	if result := dict.Get("greeting"); result != "Hello" {
+		t.Errorf("Expected base value after the window, got %q", result)
+	}
+}
@@ -0,0 +1,81 @@
+package i18n
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// SchemaVersion is the catalog format version this package reads and writes
+// as meta.schema_version. Files without one are version 1. Older files are
+// upgraded at load time by the registered migrations, so catalogs written for
+// a previous format keep loading after the format changes.
+const SchemaVersion = 1
+
+// migration upgrades a decoded catalog document by one schema version
+type migration func(doc map[string]any) error
+
+// migrations maps a schema version to the migration that upgrades it to the
+// next version. Add an entry here whenever SchemaVersion is increased.
+var migrations = map[int]migration{}
+
+// migrateCatalog returns data upgraded to SchemaVersion by the registered
+// migrations. Data that is not a JSON object is returned as-is for the
+// decoder to report.
+func migrateCatalog(data []byte) ([]byte, error) {
+	return upgradeCatalog(data, SchemaVersion, migrations)
+}
+
+// upgradeCatalog returns data upgraded to version to by migrations
+func upgradeCatalog(data []byte, to int, migrations map[int]migration) ([]byte, error) {
+	var peek struct {
+		Meta struct {
+			SchemaVersion int `json:"schema_version"`
+		} `json:"meta"`
+	}
+	if err := json.Unmarshal(data, &peek); err != nil {
+		return data, nil
+	}
+
+	version := max(peek.Meta.SchemaVersion, 1)
+	if version == to {
+		return data, nil
+	}
+	if version > to {
+		return nil, fileError("", "", "schema_version %d is newer than the supported version %d; upgrade github.com/nyxstack/i18n",
+			version, to)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var doc map[string]any
+	if err := dec.Decode(&doc); err != nil {
+		return data, nil
+	}
+	if err := applyMigrations(doc, version, to, migrations); err != nil {
+		return nil, err
+	}
+	return json.Marshal(doc)
+}
+
+// applyMigrations upgrades doc from version from to version to, one version at
+// a time, and records the new version in its meta
+func applyMigrations(doc map[string]any, from, to int, migrations map[int]migration) error {
+	for v := from; v < to; v++ {
+		migrate, ok := migrations[v]
+		if !ok {
+			return fileError("", "", "no migration from schema_version %d to %d", v, v+1)
+		}
+		if err := migrate(doc); err != nil {
+			return &FileError{Reason: fmt.Sprintf("failed to migrate from schema_version %d to %d", v, v+1), Err: err}
+		}
+	}
+
+	meta, _ := doc["meta"].(map[string]any)
+	if meta == nil {
+		meta = make(map[string]any)
+		doc["meta"] = meta
+	}
+	meta["schema_version"] = to
+	return nil
+}
@@ -0,0 +1,125 @@
+package i18n
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestMigrateCatalog_CurrentVersion(t *testing.T) {
+	for _, content := range []string{
+		`{"meta": {"lang": "en", "name": "default"}, "translations": {"a": "A"}}`,
+		`{"meta": {"lang": "en", "name": "default", "schema_version": 1}, "translations": {"a": "A"}}`,
+		`not json`,
+	} {
+		data, err := migrateCatalog([]byte(content))
+		if err != nil {
+			t.Fatalf("migrateCatalog(%s) failed: %v", content, err)
+		}
+		if string(data) != content {
+			t.Errorf("Expected %s to be returned as-is, got %s", content, data)
+		}
+	}
+}
+
+func TestMigrateCatalog_NewerVersion(t *testing.T) {
+	_, err := LoadDictionaryBytes([]byte(`{"meta": {"lang": "en", "name": "default", "schema_version": 99}, "translations": {}}`))
+	var fe *FileError
+	if !errors.As(err, &fe) || !strings.Contains(fe.Reason, "newer than the supported version") {
+		t.Errorf("Expected a newer-version error, got %v", err)
+	}
+}
+
+func TestApplyMigrations(t *testing.T) {
+	// Pretend v1 stored entries as objects with a "text" field and v2
+	// requires a translations section
+	steps := map[int]migration{
+		1: func(doc map[string]any) error {
+			entries, _ := doc["translations"].(map[string]any)
+			for key, entry := range entries {
+				if obj, ok := entry.(map[string]any); ok {
+					entries[key] = obj["text"]
+				}
+			}
+			return nil
+		},
+		2: func(doc map[string]any) error {
+			if _, ok := doc["translations"]; !ok {
+				return errors.New("missing translations")
+			}
+			return nil
+		},
+	}
+
+	var doc map[string]any
+	content := `{"meta": {"lang": "en", "name": "default"}, "translations": {"title": {"text": "Dashboard"}}}`
+	if err := json.Unmarshal([]byte(content), &doc); err != nil {
+		t.Fatalf("Failed to unmarshal: %v", err)
+	}
+	if err := applyMigrations(doc, 1, 3, steps); err != nil {
+		t.Fatalf("applyMigrations failed: %v", err)
+	}
+
+	data, _ := json.Marshal(doc)
+	dict, err := LoadDictionaryBytes(data)
+	if err == nil {
+		t.Fatalf("Expected schema_version 3 to be newer than supported, loaded %v", dict)
+	}
+
+	var tf TranslationFile
+	if err := json.Unmarshal(data, &tf); err != nil {
+		t.Fatalf("Failed to decode migrated catalog: %v", err)
+	}
+	if tf.Translations["title"] != "Dashboard" || tf.Meta.SchemaVersion != 3 {
+		t.Errorf("Unexpected migrated catalog: %+v", tf)
+	}
+
+	if err := applyMigrations(map[string]any{"translations": map[string]any{}}, 1, 4, steps); err == nil || !strings.Contains(err.Error(), "no migration from schema_version 3") {
+		t.Errorf("Expected missing migration error, got %v", err)
+	}
+	if err := applyMigrations(map[string]any{}, 2, 3, steps); err == nil || !strings.Contains(err.Error(), "missing translations") {
+		t.Errorf("Expected migration error, got %v", err)
+	}
+}
+
+func TestUpgradeCatalog(t *testing.T) {
+	// Pretend v2 renamed meta.direction to meta.dir and back again in v3
+	steps := map[int]migration{
+		1: func(doc map[string]any) error {
+			meta := doc["meta"].(map[string]any)
+			meta["dir"] = meta["direction"]
+			delete(meta, "direction")
+			return nil
+		},
+		2: func(doc map[string]any) error {
+			meta := doc["meta"].(map[string]any)
+			meta["direction"] = meta["dir"]
+			delete(meta, "dir")
+			return nil
+		},
+	}
+	content := `{"meta": {"lang": "ar", "name": "default", "direction": "rtl"}, "translations": {"count": 3, "title": "لوحة التحكم"}}`
+
+	data, err := upgradeCatalog([]byte(content), 3, steps)
+	if err != nil {
+		t.Fatalf("upgradeCatalog failed: %v", err)
+	}
+	var tf TranslationFile
+	if err := json.Unmarshal(data, &tf); err != nil {
+		t.Fatalf("Failed to decode upgraded catalog %s: %v", data, err)
+	}
+	if tf.Meta.SchemaVersion != 3 || tf.Meta.Direction != "rtl" || tf.Translations["title"] != "لوحة التحكم" {
+		t.Errorf("Unexpected upgraded catalog: %+v", tf)
+	}
+	if !strings.Contains(string(data), `"count":3`) {
+		t.Errorf("Expected numbers to be kept as written, got %s", data)
+	}
+
+	if _, err := upgradeCatalog([]byte(content), 4, steps); err == nil || !strings.Contains(err.Error(), "no migration from schema_version 3") {
+		t.Errorf("Expected missing migration error, got %v", err)
+	}
+	if _, err := upgradeCatalog(data, 2, steps); err == nil || !strings.Contains(err.Error(), "newer than the supported version 2") {
+		t.Errorf("Expected newer-version error, got %v", err)
+	}
+}
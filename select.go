@@ -0,0 +1,136 @@
+package i18n
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Sel translates a key whose template uses ICU select syntax, picking the
+// branch that matches selector (or "other" if none does). Remaining
+// placeholders are replaced with args as in T.
+//
+// Example:
+//
+//	fn := i18n.Sel("invite", "female", "Alice")
+//	fmt.Println(fn("en")) // "Alice invited you to her team"
+//
+// Dictionary should contain:
+//
+//	"invite": "{0} invited you to {gender, select, male {his team} female {her team} other {their team}}"
+func Sel(key, selector string, args ...any) TranslatedFunc {
+	return defaultBundle.Sel(key, selector, args...)
+}
+
+// Sel is like the package-level Sel but uses the bundle's dictionaries
+func (b *Bundle) Sel(key, selector string, args ...any) TranslatedFunc {
+	values := newPlaceholderArgs(args)
+
+	return b.debugged(func(locale string) string {
+		template := key
+		if tr, _, ok := b.find(locale, key); ok {
+			template = tr
+		}
+
+		return values.replace(selectForm(template, selector))
+	}, key)
+}
+
+// selectForm replaces every "{arg, select, ...}" block of a template with the
+// branch matching selector, falling back to the "other" branch. Blocks without
+// a matching branch are removed.
+func selectForm(template, selector string) string {
+	for {
+		start, end, cases, ok := parseBlock(template, "select")
+		if !ok {
+			return template
+		}
+
+		branch, found := cases[selector]
+		if !found {
+			branch = cases["other"]
+		}
+		template = template[:start] + branch + template[end:]
+	}
+}
+
+// parseBlock finds the first "{arg, kind, ...}" block of a template, where
+// kind is "select" or "selectordinal". It returns the block's bounds and its
+// branches by selector value.
+func parseBlock(template, kind string) (start, end int, cases map[string]string, ok bool) {
+	marker := ", " + kind + ","
+	at := strings.Index(template, marker)
+	if at < 0 {
+		return 0, 0, nil, false
+	}
+	start = strings.LastIndex(template[:at], "{")
+	if start < 0 {
+		return 0, 0, nil, false
+	}
+
+	cases = make(map[string]string)
+	rest := template[at+len(marker):]
+	offset := at + len(marker)
+	for {
+		trimmed := strings.TrimLeft(rest, " \t\n")
+		offset += len(rest) - len(trimmed)
+		rest = trimmed
+
+		if rest == "" {
+			return 0, 0, nil, false
+		}
+		if rest[0] == '}' {
+			return start, offset + 1, cases, true
+		}
+
+		// Branch: "value {content}"
+		open := strings.IndexByte(rest, '{')
+		if open < 0 {
+			return 0, 0, nil, false
+		}
+		value := strings.TrimSpace(rest[:open])
+
+		closing := matchingBrace(rest, open)
+		if closing < 0 {
+			return 0, 0, nil, false
+		}
+		cases[value] = rest[open+1 : closing]
+
+		offset += closing + 1
+		rest = rest[closing+1:]
+	}
+}
+
+// matchingBrace returns the index of the brace closing the one at open, or -1
+func matchingBrace(s string, open int) int {
+	depth := 0
+	for i := open; i < len(s); i++ {
+		switch s[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// validateSelectTemplate checks that every select and selectordinal block of
+// a template parses and has an "other" branch
+func validateSelectTemplate(template string) error {
+	for _, kind := range []string{"select", "selectordinal"} {
+		for strings.Contains(template, ", "+kind+",") {
+			start, end, cases, ok := parseBlock(template, kind)
+			if !ok {
+				return fmt.Errorf("malformed %s block", kind)
+			}
+			if _, ok := cases["other"]; !ok {
+				return fmt.Errorf("%s block has no 'other' branch", kind)
+			}
+			template = template[:start] + template[end:]
+		}
+	}
+	return nil
+}
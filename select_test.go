@@ -0,0 +1,94 @@
+package i18n
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSel(t *testing.T) {
+	b := NewBundle()
+
+	en := NewDictionary("en")
+	en.AddAll(map[string]string{
+		"invite": "{0} invited you to {gender, select, male {his team} female {her team} other {their team}}",
+		"greet":  "{gender, select, female {Welcome, {name}!} other {Hi {name}}}",
+	})
+	b.Register(en)
+
+	fr := NewDictionary("fr")
+	fr.Add("invite", "{0} vous a {gender, select, female {invitée} other {invité}} dans son équipe")
+	b.Register(fr)
+
+	tests := []struct {
+		name     string
+		result   string
+		expected string
+	}{
+		{"male", b.Sel("invite", "male", "Bob")("en"), "Bob invited you to his team"},
+		{"female", b.Sel("invite", "female", "Alice")("en"), "Alice invited you to her team"},
+		{"other fallback", b.Sel("invite", "unknown", "Sam")("en"), "Sam invited you to their team"},
+		{"translated", b.Sel("invite", "female", "Alice")("fr"), "Alice vous a invitée dans son équipe"},
+		{"named args in branch", b.Sel("greet", "female", Args{"name": "Ada"})("en"), "Welcome, Ada!"},
+		{"default language", b.Sel("greet", "male", Args{"name": "Bob"})("de"), "Hi Bob"},
+		{"missing key", b.Sel("missing", "male")("en"), "missing"},
+	}
+
+	for _, tt := range tests {
+		if tt.result != tt.expected {
+			t.Errorf("%s: expected '%s', got '%s'", tt.name, tt.expected, tt.result)
+		}
+	}
+}
+
+func TestParseBlock(t *testing.T) {
+	template := "Hi {gender, select, male {Mr {name}} other {friend}}!"
+
+	start, end, cases, ok := parseBlock(template, "select")
+	if !ok {
+		t.Fatal("Expected select block to parse")
+	}
+	if template[start:end] != "{gender, select, male {Mr {name}} other {friend}}" {
+		t.Errorf("Unexpected block bounds: %q", template[start:end])
+	}
+	if expected := map[string]string{"male": "Mr {name}", "other": "friend"}; !reflect.DeepEqual(cases, expected) {
+		t.Errorf("Expected cases %v, got %v", expected, cases)
+	}
+
+	for _, malformed := range []string{
+		"{gender, select, male {his}",
+		"{gender, select, male his}",
+		"no select here",
+		"{n, selectordinal, one {#st} other {#th}}",
+	} {
+		if _, _, _, ok := parseBlock(malformed, "select"); ok {
+			t.Errorf("Expected %q not to parse", malformed)
+		}
+	}
+}
+
+func TestValidateSelectTemplate(t *testing.T) {
+	tests := []struct {
+		template string
+		errMsg   string
+	}{
+		{"{g, select, male {he} other {they}}", ""},
+		{"{a, select, x {1} other {2}} and {b, select, y {3} other {4}}", ""},
+		{"{g, select, male {he} female {she}}", "no 'other' branch"},
+		{"{g, select, male {he}", "malformed select block"},
+		{"plain text", ""},
+		{"{n, selectordinal, one {#st} other {#th}}", ""},
+		{"{n, selectordinal, one {#st}}", "selectordinal block has no 'other' branch"},
+	}
+
+	for _, tt := range tests {
+		err := validateSelectTemplate(tt.template)
+		if tt.errMsg == "" {
+			if err != nil {
+				t.Errorf("%q: unexpected error %v", tt.template, err)
+			}
+		} else if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+			t.Errorf("%q: expected error containing %q, got %v", tt.template, tt.errMsg, err)
+		}
+	}
+}
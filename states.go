@@ -0,0 +1,121 @@
+package i18n
+
+import (
+	"maps"
+	"os"
+)
+
+// State is the review state of a translation, recorded per key in the
+// "states" section of a catalog
+type State string
+
+// Review states, in workflow order. Keys without a recorded state are
+// approved, so catalogs that do not use states are served as before.
+const (
+	StateDraft    State = "draft"    // written, not reviewed yet
+	StateReviewed State = "reviewed" // reviewed, awaiting approval
+	StateApproved State = "approved" // approved for production
+)
+
+// MinStateEnv is the environment variable that sets the least state new
+// bundles, including the default one, serve: I18N_MIN_STATE=approved
+const MinStateEnv = "I18N_MIN_STATE"
+
+// rank returns the position of a state in the workflow, or 0 if it is unknown
+func (s State) rank() int32 {
+	switch s {
+	case StateDraft:
+		return 1
+	case StateReviewed:
+		return 2
+	case StateApproved:
+		return 3
+	}
+	return 0
+}
+
+// minStateFromEnv returns the rank of the state MinStateEnv names, 0 to
+// serve every state if it is unset or unknown
+func minStateFromEnv() int32 {
+	return State(os.Getenv(MinStateEnv)).rank()
+}
+
+// SetMinState serves only the translations whose state is at least state:
+// StateApproved in production leaves drafts and reviewed values out, and
+// their keys are looked up along the fallback chain instead, while staging
+// keeps serving drafts with StateDraft, the default. Set MinStateEnv to
+// choose it per environment without code.
+//
+// Example:
+//
+//	if os.Getenv("ENV") == "production" {
+//		i18n.SetMinState(i18n.StateApproved)
+//	}
+func SetMinState(state State) {
+	defaultBundle.SetMinState(state)
+}
+
+// SetMinState is like the package-level SetMinState but applies to the
+// bundle's dictionaries
+func (b *Bundle) SetMinState(state State) {
+	b.minState.Store(state.rank())
+}
+
+// SetState records the review state of key. StateApproved, like no state,
+// is served in every environment.
+func (d *Dictionary) SetState(key string, state State) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.states == nil {
+		d.states = make(map[string]State)
+	}
+	d.states[key] = state
+}
+
+// State returns the review state of key, StateApproved if none is recorded
+func (d *Dictionary) State(key string) State {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	if state, ok := d.states[key]; ok {
+		return state
+	}
+	return StateApproved
+}
+
+// States returns the recorded review states by key
+func (d *Dictionary) States() map[string]State {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return maps.Clone(d.states)
+}
+
+// belowMinState reports whether the state of key is below the least state
+// the dictionary's bundle serves. The caller must hold d.mu.
+func (d *Dictionary) belowMinState(key string) bool {
+	state, ok := d.states[key]
+	if !ok {
+		return false
+	}
+	b := d.bundle
+	if b == nil {
+		b = defaultBundle
+	}
+	return state.rank() < b.minState.Load()
+}
+
+// validateStates checks the states of a translation file: each must be a
+// known state of a key that has a value
+func validateStates(tf *TranslationFile) error {
+	lang := tf.Meta.Lang
+	for _, key := range sortedKeys(tf.States) {
+		if tf.States[key].rank() == 0 {
+			return fileError(lang, key, "invalid state '%s' for key '%s' (expected draft, reviewed or approved)", tf.States[key], key)
+		}
+		_, ok := tf.Translations[key]
+		_, isList := tf.Lists[key]
+		if !ok && !isList {
+			return fileError(lang, key, "key '%s' has a state but no value", key)
+		}
+	}
+	return nil
+}
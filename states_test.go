@@ -0,0 +1,201 @@
+package i18n
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSetMinState(t *testing.T) {
+	b := NewBundle()
+	en := NewDictionary("en")
+	en.AddAll(map[string]string{"welcome": "Welcome", "goodbye": "Goodbye", "terms": "Terms"})
+	en.AddSlice("days", []string{"Mon", "Tue"})
+	b.Register(en)
+
+	fr, err := LoadDictionaryBytes([]byte(`{
+  "meta": {"lang": "fr", "name": "default"},
+  "translations": {"welcome": "Bienvenue", "goodbye": "Au revoir", "terms": "Conditions", "days": ["lun", "mar"]},
+  "states": {"welcome": "draft", "goodbye": "reviewed", "terms": "approved", "days": "draft"}
+}`))
+	if err != nil {
+		t.Fatalf("LoadDictionaryBytes failed: %v", err)
+	}
+	b.Register(fr)
+
+	translate := func() []string {
+		return []string{b.T("welcome")("fr"), b.T("goodbye")("fr"), b.T("terms")("fr"), strings.Join(fr.GetSlice("days"), ",")}
+	}
+	tests := []struct {
+		min  State
+		want []string
+	}{
+		{StateDraft, []string{"Bienvenue", "Au revoir", "Conditions", "lun,mar"}},
+		{StateReviewed, []string{"Welcome", "Au revoir", "Conditions", "Mon,Tue"}},
+		{StateApproved, []string{"Welcome", "Goodbye", "Conditions", "Mon,Tue"}},
+	}
+	if got := translate(); !reflect.DeepEqual(got, tests[0].want) {
+		t.Errorf("Expected every state served by default, got %v", got)
+	}
+	for _, tt := range tests {
+		b.SetMinState(tt.min)
+		if got := translate(); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("SetMinState(%s): expected %v, got %v", tt.min, tt.want, got)
+		}
+	}
+
+	if got := b.Clone().T("welcome")("fr"); got != "Welcome" {
+		t.Errorf("Expected a clone to keep the least state served, got %q", got)
+	}
+
+	fr.SetState("welcome", StateApproved)
+	if got := b.T("welcome")("fr"); got != "Bienvenue" {
+		t.Errorf("Expected the translation once approved, got %q", got)
+	}
+}
+
+func TestDictionary_State(t *testing.T) {
+	dict := NewDictionary("fr")
+	dict.Add("welcome", "Bienvenue")
+	dict.Add("goodbye", "Au revoir")
+	if got := dict.State("welcome"); got != StateApproved {
+		t.Errorf("Expected keys without a state to be approved, got %q", got)
+	}
+
+	dict.SetState("welcome", StateDraft)
+	if got := dict.State("welcome"); got != StateDraft {
+		t.Errorf("Expected draft, got %q", got)
+	}
+	if got := dict.States(); !reflect.DeepEqual(got, map[string]State{"welcome": StateDraft}) {
+		t.Errorf("Expected the recorded states, got %v", got)
+	}
+	if tf := dict.translationFile(); !reflect.DeepEqual(tf.States, map[string]State{"welcome": StateDraft}) {
+		t.Errorf("Expected the states to be exported, got %v", tf.States)
+	}
+
+	dict.Remove("welcome")
+	if got := dict.States(); len(got) != 0 {
+		t.Errorf("Expected no states after Remove, got %v", got)
+	}
+}
+
+func TestMinStateFromEnv(t *testing.T) {
+	t.Setenv(MinStateEnv, "approved")
+	b := NewBundle()
+	dict := NewDictionary("fr")
+	dict.Add("welcome", "Bienvenue")
+	dict.SetState("welcome", StateReviewed)
+	b.Register(dict)
+
+	if got := b.T("welcome")("fr"); got != "welcome" {
+		t.Errorf("Expected the reviewed value left out, got %q", got)
+	}
+}
+
+func TestLoadDictionaryBytes_InvalidState(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{"unknown state", `{"meta": {"lang": "fr", "name": "default"}, "translations": {"a": "A"}, "states": {"a": "done"}}`, "invalid state 'done' for key 'a'"},
+		{"no value", `{"meta": {"lang": "fr", "name": "default"}, "translations": {"a": "A"}, "states": {"b": "draft"}}`, "key 'b' has a state but no value"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadDictionaryBytes([]byte(tt.data))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Expected an error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestLoadDir_NamespacedStates(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "billing.fr.json"), `{"meta": {"lang": "fr", "name": "billing"}, "translations": {"invoice": "Facture"}, "states": {"invoice": "draft"}}`)
+
+	b := NewBundle()
+	if err := b.LoadDir(dir); err != nil {
+		t.Fatalf("LoadDir failed: %v", err)
+	}
+	if got := b.GetDictionary("fr").State("billing.invoice"); got != StateDraft {
+		t.Errorf("Expected the namespaced key to be a draft, got %q", got)
+	}
+}
+
+func TestGenerateCatalogs_States(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "main.go"), `package main
+
+import "github.com/nyxstack/i18n"
+
+var _ = i18n.T("welcome")
+`)
+	localesDir := filepath.Join(dir, "locales")
+	if err := os.MkdirAll(localesDir, 0755); err != nil {
+		t.Fatalf("Failed to create locales dir: %v", err)
+	}
+	writeTestCatalog(t, localesDir, "default.en.json", "en", map[string]string{"welcome": "Welcome"})
+	frPath := filepath.Join(localesDir, "default.fr.json")
+	writeTestFile(t, frPath, `{"meta": {"lang": "fr", "name": "default"}, "translations": {"welcome": "Bienvenue", "stale": "Périmé"}, "states": {"welcome": "reviewed", "stale": "draft"}}`)
+
+	if err := GenerateCatalogs(context.Background(), dir, localesDir, "en", "fr"); err != nil {
+		t.Fatalf("GenerateCatalogs failed: %v", err)
+	}
+	fr, err := readTranslationFile(frPath)
+	if err != nil {
+		t.Fatalf("Failed to read fr catalog: %v", err)
+	}
+	if want := map[string]State{"welcome": StateReviewed}; !reflect.DeepEqual(fr.States, want) {
+		t.Errorf("Expected the states of the keys still used, got %v", fr.States)
+	}
+}
+
+func TestBundleReloadLanguage_States(t *testing.T) {
+	t.Chdir(t.TempDir())
+	path := filepath.Join(DefaultFolder, "default.fr.json")
+	writeTestFile(t, path, `{"meta": {"lang": "fr", "name": "default"}, "translations": {"welcome": "Bienvenue"}, "states": {"welcome": "draft"}}`)
+
+	b := NewBundle()
+	b.SetMinState(StateApproved)
+	if err := b.ReloadLanguage("fr"); err != nil {
+		t.Fatalf("ReloadLanguage failed: %v", err)
+	}
+	if got := b.T("welcome")("fr"); got != "welcome" {
+		t.Errorf("Expected the draft left out, got %q", got)
+	}
+
+	// Only the state changes
+	writeTestFile(t, path, `{"meta": {"lang": "fr", "name": "default"}, "translations": {"welcome": "Bienvenue"}, "states": {"welcome": "approved"}}`)
+	if err := b.ReloadLanguage("fr"); err != nil {
+		t.Fatalf("ReloadLanguage failed: %v", err)
+	}
+	if got := b.T("welcome")("fr"); got != "Bienvenue" {
+		t.Errorf("Expected the translation once approved, got %q", got)
+	}
+}
+
+func TestBundleReset_MinState(t *testing.T) {
+	b := NewBundle()
+	fr := NewDictionary("fr")
+	fr.Add("welcome", "Bienvenue")
+	fr.SetState("welcome", StateDraft)
+	b.SetMinState(StateApproved)
+	b.Reset()
+
+	b.Register(fr)
+	if got := b.T("welcome")("fr"); got != "Bienvenue" {
+		t.Errorf("Expected Reset to serve drafts again, got %q", got)
+	}
+
+	t.Setenv(MinStateEnv, "approved")
+	b.Reset()
+	b.Register(fr)
+	if got := b.T("welcome")("fr"); got != "welcome" {
+		t.Errorf("Expected Reset to restore the least state of %s, got %q", MinStateEnv, got)
+	}
+}
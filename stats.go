@@ -0,0 +1,114 @@
+package i18n
+
+import (
+	"slices"
+	"unicode/utf8"
+)
+
+// LocaleStats is the translation progress of one locale, see CoverageReport
+type LocaleStats struct {
+	Lang       string       `json:"lang"`
+	Keys       int          `json:"keys"`            // keys of the default language
+	Translated int          `json:"translated"`      // keys of the default language the locale translates
+	Identical  int          `json:"identical"`       // translated values equal to the default language's, 0 for the default language
+	Extra      int          `json:"extra"`           // keys of the locale the default language does not have
+	Fuzzy      []string     `json:"fuzzy,omitempty"` // keys whose value is marked fuzzy, see TranslationFile.Fuzzy
+	Longest    *ValueLength `json:"longest,omitempty"`
+	Shortest   *ValueLength `json:"shortest,omitempty"`
+}
+
+// ValueLength is a translation key and the length of its value in characters
+type ValueLength struct {
+	Key    string `json:"key"`
+	Length int    `json:"length"`
+}
+
+// Ratio returns the translated fraction of the keys, 1 if there are none
+func (s LocaleStats) Ratio() float64 {
+	if s.Keys == 0 {
+		return 1
+	}
+	return float64(s.Translated) / float64(s.Keys)
+}
+
+// CoverageReport returns the statistics of every registered language against
+// defaultLang, in language order: how many of its keys each translates, how
+// many values are still a copy of the default language's, and the keys with
+// the longest and shortest values, so dashboards can track localization
+// progress. Fuzzy values are listed in Fuzzy and do not count as translated
+// until a translator clears their mark. Only translations are counted, not
+// lists or scheduled values. It returns nil if no dictionary is registered
+// for defaultLang.
+//
+// Example:
+//
+//	for _, s := range i18n.CoverageReport("en") {
+//		fmt.Printf("%s: %.0f%% translated\n", s.Lang, s.Ratio()*100)
+//	}
+func CoverageReport(defaultLang string) []LocaleStats {
+	return defaultBundle.CoverageReport(defaultLang)
+}
+
+// CoverageReport is like the package-level CoverageReport but reports the
+// bundle's languages
+func (b *Bundle) CoverageReport(defaultLang string) []LocaleStats {
+	reference := b.registered(defaultLang)
+	if reference == nil {
+		return nil
+	}
+	source := dictionaryValues(reference)
+
+	langs := b.Languages()
+	report := make([]LocaleStats, 0, len(langs))
+	for _, lang := range langs {
+		dict := b.registered(lang)
+		if dict == nil {
+			continue
+		}
+		fuzzy := dict.Fuzzy()
+		stats := localeStats(lang, dictionaryValues(dict), source, fuzzy, lang == defaultLang)
+		stats.Fuzzy = fuzzy
+		report = append(report, stats)
+	}
+	return report
+}
+
+// localeStats computes the statistics of a locale's values against the
+// default language's. Fuzzy values do not count as translated.
+func localeStats(lang string, values, source map[string]string, fuzzy []string, isDefault bool) LocaleStats {
+	stats := LocaleStats{Lang: lang, Keys: len(source)}
+	for key, value := range values {
+		if sourceValue, ok := source[key]; ok {
+			if slices.Contains(fuzzy, key) {
+				continue
+			}
+			stats.Translated++
+			if !isDefault && value == sourceValue {
+				stats.Identical++
+			}
+		} else {
+			stats.Extra++
+		}
+	}
+
+	for _, key := range sortedKeys(values) {
+		length := utf8.RuneCountInString(values[key])
+		if stats.Longest == nil || length > stats.Longest.Length {
+			stats.Longest = &ValueLength{Key: key, Length: length}
+		}
+		if stats.Shortest == nil || length < stats.Shortest.Length {
+			stats.Shortest = &ValueLength{Key: key, Length: length}
+		}
+	}
+	return stats
+}
+
+// dictionaryValues returns a snapshot of a dictionary's translations
+func dictionaryValues(d *Dictionary) map[string]string {
+	values := make(map[string]string, d.Count())
+	d.Range(func(key, value string) bool {
+		values[key] = value
+		return true
+	})
+	return values
+}
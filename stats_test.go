@@ -0,0 +1,45 @@
+package i18n
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCoverageReport(t *testing.T) {
+	b := NewBundle()
+	en := NewDictionary("en")
+	en.AddAll(map[string]string{"hello": "Hello", "bye": "Goodbye", "ok": "OK", "welcome": "Welcome back"})
+	fr := NewDictionary("fr")
+	fr.AddAll(map[string]string{"hello": "Bonjour", "ok": "OK", "extra": "En plus"})
+	de := NewDictionary("de")
+	b.Register(en)
+	b.Register(fr)
+	b.Register(de)
+
+	want := []LocaleStats{
+		{Lang: "de", Keys: 4},
+		{Lang: "en", Keys: 4, Translated: 4, Longest: &ValueLength{Key: "welcome", Length: 12}, Shortest: &ValueLength{Key: "ok", Length: 2}},
+		{Lang: "fr", Keys: 4, Translated: 2, Identical: 1, Extra: 1, Longest: &ValueLength{Key: "extra", Length: 7}, Shortest: &ValueLength{Key: "ok", Length: 2}},
+	}
+	got := b.CoverageReport("en")
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %+v, got %+v", want, got)
+	}
+	if r := got[2].Ratio(); r != 0.5 {
+		t.Errorf("Expected a ratio of 0.5, got %g", r)
+	}
+	if r := got[0].Ratio(); r != 0 {
+		t.Errorf("Expected a ratio of 0, got %g", r)
+	}
+}
+
+func TestCoverageReport_NoDefault(t *testing.T) {
+	b := NewBundle()
+	b.Register(NewDictionary("fr"))
+	if got := b.CoverageReport("en"); got != nil {
+		t.Errorf("Expected nil without a default dictionary, got %+v", got)
+	}
+	if r := (LocaleStats{}).Ratio(); r != 1 {
+		t.Errorf("Expected a ratio of 1 without keys, got %g", r)
+	}
+}
@@ -0,0 +1,71 @@
+package i18n
+
+// UnitStyle is the length of a message variant, for space-constrained UI
+// elements: "3 messages" (Long), "3 msgs" (Short) or "3" (Narrow)
+type UnitStyle int
+
+const (
+	Long UnitStyle = iota
+	Short
+	Narrow
+)
+
+// String returns the name of the style, also used as key suffix
+func (s UnitStyle) String() string {
+	switch s {
+	case Short:
+		return "short"
+	case Narrow:
+		return "narrow"
+	default:
+		return "long"
+	}
+}
+
+// StyleOption selects the style variant of a message, see Style
+type StyleOption struct {
+	style UnitStyle
+}
+
+// Style selects a style variant of a plural message. Variants are stored as
+// related keys "key.short" and "key.narrow", or as an object value:
+//
+//	"item-count": {
+//	  "long": "{count, plural, one {# message} other {# messages}}",
+//	  "short": "{count, plural, one {# msg} other {# msgs}}",
+//	  "narrow": "{count, plural, other {#}}"
+//	}
+//
+// A missing variant falls back to the next longer one of the same language.
+//
+// Example:
+//
+//	fn := i18n.P("item-count", 3, i18n.Style(i18n.Short))
+//	fmt.Println(fn("en")) // "3 msgs"
+func Style(s UnitStyle) StyleOption {
+	return StyleOption{style: s}
+}
+
+// styleOf returns the style selected among options, Long by default
+func styleOf(opts []any) UnitStyle {
+	style := Long
+	for _, opt := range opts {
+		if o, ok := opt.(StyleOption); ok {
+			style = o.style
+		}
+	}
+	return style
+}
+
+// styleKeys returns the keys tried, in order, for a message in a style
+func styleKeys(key string, style UnitStyle) []string {
+	long := []string{key, key + KeySeparator + Long.String()}
+	switch style {
+	case Short:
+		return append([]string{key + KeySeparator + Short.String()}, long...)
+	case Narrow:
+		return append([]string{key + KeySeparator + Narrow.String(), key + KeySeparator + Short.String()}, long...)
+	default:
+		return long
+	}
+}
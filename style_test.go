@@ -0,0 +1,61 @@
+package i18n
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPluralStyles(t *testing.T) {
+	b := NewBundle()
+
+	en := NewDictionary("en")
+	en.AddAll(map[string]string{
+		"messages":        "{count, plural, one {# message} other {# messages}}",
+		"messages.short":  "{count, plural, one {# msg} other {# msgs}}",
+		"messages.narrow": "{count, plural, other {#}}",
+		"files.long":      "{count, plural, one {# file} other {# files}}",
+		"files.short":     "{count, plural, other {# f}}",
+	})
+	b.Register(en)
+
+	fr := NewDictionary("fr")
+	fr.Add("messages", "{count, plural, one {# message} other {# messages}}")
+	b.Register(fr)
+
+	tests := []struct {
+		name     string
+		result   string
+		expected string
+	}{
+		{"long", b.P("messages", 3)("en"), "3 messages"},
+		{"explicit long", b.P("messages", 3, Style(Long))("en"), "3 messages"},
+		{"short", b.P("messages", 3, Style(Short))("en"), "3 msgs"},
+		{"narrow", b.P("messages", 3, Style(Narrow))("en"), "3"},
+		{"object long", b.P("files", 1)("en"), "1 file"},
+		{"narrow falls back to short", b.P("files", 2, Style(Narrow))("en"), "2 f"},
+		{"same language long preferred", b.P("messages", 3, Style(Short))("fr"), "3 messages"},
+	}
+
+	for _, tt := range tests {
+		if tt.result != tt.expected {
+			t.Errorf("%s: expected '%s', got '%s'", tt.name, tt.expected, tt.result)
+		}
+	}
+}
+
+func TestStyleKeys(t *testing.T) {
+	tests := []struct {
+		style    UnitStyle
+		expected []string
+	}{
+		{Long, []string{"k", "k.long"}},
+		{Short, []string{"k.short", "k", "k.long"}},
+		{Narrow, []string{"k.narrow", "k.short", "k", "k.long"}},
+	}
+
+	for _, tt := range tests {
+		if keys := styleKeys("k", tt.style); !reflect.DeepEqual(keys, tt.expected) {
+			t.Errorf("styleKeys(%s) = %v, expected %v", tt.style, keys, tt.expected)
+		}
+	}
+}
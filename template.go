@@ -0,0 +1,215 @@
+package i18n
+
+import (
+	"fmt"
+	"html/template"
+	"sort"
+	"strings"
+	"text/template/parse"
+)
+
+// IfLocale reports whether locale matches any of matchers, for content that
+// differs by locale as a whole (legal text, address forms) rather than by
+// key. A matcher matches the locale itself and every more specific tag, so
+// "pt" matches "pt-BR", "pt-PT" and "pt"; "*" matches any locale.
+// Comparison is case-insensitive and accepts "_" for "-".
+//
+// Example:
+//
+//	if i18n.IfLocale(locale, "ja", "zh") {
+//		renderVerticalAddress()
+//	}
+func IfLocale(locale string, matchers ...string) bool {
+	tag := normalizeTag(locale)
+	for _, m := range matchers {
+		m = normalizeTag(m)
+		if m == "*" || tag == m || strings.HasPrefix(tag, m+"-") {
+			return true
+		}
+	}
+	return false
+}
+
+// TemplateFuncs returns template functions bound to locale, for use with
+// text/template or html/template:
+//
+//   - iflocale "ja" "zh" reports whether locale matches (see IfLocale)
+//   - locale returns locale
+//   - dir returns its text direction, "ltr" or "rtl" (see Direction)
+//   - t, s and f render T, S and F for locale
+//   - thtml renders THTML for locale, with the bundle's escape mode
+//
+// Example:
+//
+//	tmpl := template.New("page").Funcs(i18n.TemplateFuncs(locale))
+//
+//	<html lang="{{ locale }}" dir="{{ dir }}">
+//	{{ if iflocale "ja" }}〒{{ .Postcode }} {{ .Prefecture }}{{ else }}{{ .Street }}, {{ .City }}{{ end }}
+//	<h1>{{ s "Dashboard" }}</h1>
+func TemplateFuncs(locale string) map[string]any {
+	return defaultBundle.TemplateFuncs(locale)
+}
+
+// TemplateFuncs is like the package-level TemplateFuncs but uses the bundle's
+// dictionaries
+func (b *Bundle) TemplateFuncs(locale string) map[string]any {
+	return map[string]any{
+		"iflocale": func(matchers ...string) bool { return IfLocale(locale, matchers...) },
+		"locale":   func() string { return locale },
+		"dir":      func() string { return b.Direction(locale) },
+		"t":        func(key string, args ...any) string { return b.T(key, args...)(locale) },
+		"s":        func(text string) string { return b.S(text)(locale) },
+		"f":        func(format string, args ...any) string { return b.F(format, args...)(locale) },
+		"thtml":    func(key string, args ...any) template.HTML { return b.THTML(key, args...)(locale) },
+	}
+}
+
+// DefaultTemplateKeyFuncs and DefaultTemplateTextFuncs are the template
+// functions TemplateExtractor reads catalog keys and source texts from when
+// it has none configured: those of TemplateFuncs
+var (
+	DefaultTemplateKeyFuncs  = []string{"t", "thtml"}
+	DefaultTemplateTextFuncs = []string{"s", "f"}
+)
+
+// TemplateExtractor finds the literal first arguments of the i18n functions
+// of Go templates (see TemplateFuncs), so the strings of a web app's
+// templates share the catalogs of its Go code:
+//
+//	<h1>{{ s "Dashboard" }}</h1> {{ t "welcome" .Name }}
+//	<p>{{ "Sign out" | s }}</p>
+//
+// Arguments of KeyFuncs are keys used as-is like T; arguments of TextFuncs
+// are source texts stored under slug keys like S. It is registered for
+// "*.html", "*.tmpl" and "*.gotmpl" with the default functions; register
+// another one for your own function names or delimiters. Files that do not
+// parse as templates yield no entries.
+type TemplateExtractor struct {
+	KeyFuncs   []string // DefaultTemplateKeyFuncs if both are empty
+	TextFuncs  []string // DefaultTemplateTextFuncs if both are empty
+	LeftDelim  string   // "{{" if empty
+	RightDelim string   // "}}" if empty
+}
+
+// Extract returns the i18n calls of a template file
+func (e TemplateExtractor) Extract(path string, src []byte) ([]Entry, error) {
+	keyFuncs, textFuncs := e.KeyFuncs, e.TextFuncs
+	if len(keyFuncs) == 0 && len(textFuncs) == 0 {
+		keyFuncs, textFuncs = DefaultTemplateKeyFuncs, DefaultTemplateTextFuncs
+	}
+	funcs := make(map[string]bool, len(keyFuncs)+len(textFuncs))
+	for _, name := range textFuncs {
+		funcs[name] = false
+	}
+	for _, name := range keyFuncs {
+		funcs[name] = true
+	}
+
+	tree := parse.New(path)
+	tree.Mode = parse.SkipFuncCheck
+	trees := make(map[string]*parse.Tree)
+	if _, err := tree.Parse(string(src), e.LeftDelim, e.RightDelim, trees); err != nil {
+		logger().Debug("skipping template that does not parse", "path", path, "error", err)
+		return nil, nil
+	}
+
+	text := string(src)
+	type found struct {
+		offset int
+		entry  Entry
+	}
+	var all []found
+	add := func(name string, arg *parse.StringNode) {
+		if arg.Text == "" {
+			return
+		}
+		line, col := lineColumn(text, int(arg.Pos))
+		entry := Entry{Pos: fmt.Sprintf("%s:%d:%d", path, line, col), Func: name}
+		if funcs[name] {
+			entry.Key = arg.Text
+		} else {
+			entry.Text = arg.Text
+		}
+		all = append(all, found{int(arg.Pos), entry})
+	}
+
+	var walk func(n parse.Node)
+	walk = func(n parse.Node) {
+		switch n := n.(type) {
+		case *parse.ListNode:
+			if n != nil {
+				for _, child := range n.Nodes {
+					walk(child)
+				}
+			}
+		case *parse.ActionNode:
+			walk(n.Pipe)
+		case *parse.IfNode:
+			walk(n.Pipe)
+			walk(n.List)
+			walk(n.ElseList)
+		case *parse.RangeNode:
+			walk(n.Pipe)
+			walk(n.List)
+			walk(n.ElseList)
+		case *parse.WithNode:
+			walk(n.Pipe)
+			walk(n.List)
+			walk(n.ElseList)
+		case *parse.TemplateNode:
+			walk(n.Pipe)
+		case *parse.PipeNode:
+			if n == nil {
+				return
+			}
+			for i, cmd := range n.Cmds {
+				// {{ "Sign out" | s }} passes the literal as the last argument
+				if name, ok := templateFunc(cmd); ok && len(cmd.Args) == 1 && i > 0 {
+					if _, ok := funcs[name]; ok {
+						prev := n.Cmds[i-1]
+						if lit, ok := prev.Args[0].(*parse.StringNode); ok && len(prev.Args) == 1 {
+							add(name, lit)
+						}
+					}
+				}
+				walk(cmd)
+			}
+		case *parse.CommandNode:
+			if name, ok := templateFunc(n); ok && len(n.Args) > 1 {
+				if _, ok := funcs[name]; ok {
+					if lit, ok := n.Args[1].(*parse.StringNode); ok {
+						add(name, lit)
+					}
+				}
+			}
+			for _, arg := range n.Args {
+				walk(arg)
+			}
+		case *parse.ChainNode:
+			walk(n.Node)
+		}
+	}
+	for _, t := range trees {
+		walk(t.Root)
+	}
+
+	// Report entries in file order, whatever template they are defined in
+	sort.SliceStable(all, func(i, j int) bool { return all[i].offset < all[j].offset })
+	entries := make([]Entry, len(all))
+	for i, f := range all {
+		entries[i] = f.entry
+	}
+	return entries, nil
+}
+
+// templateFunc returns the name of the function a template command calls
+func templateFunc(cmd *parse.CommandNode) (string, bool) {
+	if len(cmd.Args) == 0 {
+		return "", false
+	}
+	ident, ok := cmd.Args[0].(*parse.IdentifierNode)
+	if !ok {
+		return "", false
+	}
+	return ident.Ident, true
+}
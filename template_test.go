@@ -0,0 +1,123 @@
+package i18n
+
+import (
+	htmltemplate "html/template"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"text/template"
+)
+
+func TestIfLocale(t *testing.T) {
+	tests := []struct {
+		locale   string
+		matchers []string
+		want     bool
+	}{
+		{"ja", []string{"ja"}, true},
+		{"ja-JP", []string{"ja"}, true},
+		{"pt_BR", []string{"pt-br"}, true},
+		{"jam", []string{"ja"}, false},
+		{"pt", []string{"pt-BR"}, false},
+		{"de", []string{"fr", "de"}, true},
+		{"en", []string{"*"}, true},
+		{"en", nil, false},
+	}
+
+	for _, tt := range tests {
+		if got := IfLocale(tt.locale, tt.matchers...); got != tt.want {
+			t.Errorf("IfLocale(%q, %q) = %v, want %v", tt.locale, tt.matchers, got, tt.want)
+		}
+	}
+}
+
+func TestTemplateFuncs(t *testing.T) {
+	b := NewBundle()
+	fr := NewDictionary("fr")
+	fr.AddAll(map[string]string{"dashboard": "Tableau de bord", "welcome": "Bienvenue {0}"})
+	b.Register(fr)
+
+	const text = `{{ if iflocale "ja" }}〒{{ .Postcode }}{{ else }}{{ .City }} {{ .Postcode }}{{ end }} {{ s "Dashboard" }} {{ t "welcome" "Anne" }} {{ locale }} {{ dir }}`
+	data := map[string]string{"Postcode": "75001", "City": "Paris"}
+
+	var out strings.Builder
+	tmpl := template.Must(template.New("page").Funcs(b.TemplateFuncs("fr")).Parse(text))
+	if err := tmpl.Execute(&out, data); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if want := "Paris 75001 Tableau de bord Bienvenue Anne fr ltr"; out.String() != want {
+		t.Errorf("Expected %q, got %q", want, out.String())
+	}
+
+	out.Reset()
+	html := htmltemplate.Must(htmltemplate.New("page").Funcs(b.TemplateFuncs("ja-JP")).Parse(text))
+	if err := html.Execute(&out, data); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if !strings.HasPrefix(out.String(), "〒75001 Dashboard") {
+		t.Errorf("Expected the ja block, got %q", out.String())
+	}
+}
+
+const testTemplate = `{{ define "nav" }}<a href="/">{{ s "Home" }}</a>{{ end }}
+<h1>{{ t "page.title" }}</h1>
+{{ if .User }}{{ f "Signed in as %s" .User.Name }}{{ else }}{{ "Sign in" | s }}{{ end }}
+{{ range .Items }}{{ thtml "item_html" .Name }}{{ end }}
+<p>{{ printf "%s!" (s "Welcome") }} {{ s .Dynamic }} {{ upper "not extracted" }}</p>
+`
+
+func TestTemplateExtractor(t *testing.T) {
+	entries, err := TemplateExtractor{}.Extract("page.html", []byte(testTemplate))
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	want := []Entry{
+		{Text: "Home", Pos: "page.html:1:36", Func: "s"},
+		{Key: "page.title", Pos: "page.html:2:10", Func: "t"},
+		{Text: "Signed in as %s", Pos: "page.html:3:20", Func: "f"},
+		{Text: "Sign in", Pos: "page.html:3:64", Func: "s"},
+		{Key: "item_html", Pos: "page.html:4:28", Func: "thtml"},
+		{Text: "Welcome", Pos: "page.html:5:23", Func: "s"},
+	}
+	if !reflect.DeepEqual(entries, want) {
+		t.Errorf("Expected %+v, got %+v", want, entries)
+	}
+
+	// Unparsable files, such as other frameworks' templates, are skipped
+	entries, err = TemplateExtractor{}.Extract("app.html", []byte(`<p>{{ user.name | capitalize }}</p>`))
+	if err != nil || len(entries) != 0 {
+		t.Errorf("Expected no entries, got %+v, %v", entries, err)
+	}
+}
+
+func TestTemplateExtractor_Custom(t *testing.T) {
+	src := `<h1>[[ ts "Dashboard" ]]</h1> [[ s "ignored" ]]`
+	entries, err := TemplateExtractor{TextFuncs: []string{"ts"}, LeftDelim: "[[", RightDelim: "]]"}.Extract("page.tmpl", []byte(src))
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Text != "Dashboard" {
+		t.Errorf("Expected only the custom function, got %+v", entries)
+	}
+}
+
+func TestGenerateTranslations_Template(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "templates", "page.gotmpl"), testTemplate)
+
+	output := filepath.Join(dir, "locales", "default.en.json")
+	if err := GenerateTranslations("en", dir, output); err != nil {
+		t.Fatalf("GenerateTranslations failed: %v", err)
+	}
+	tf, err := readTranslationFile(output)
+	if err != nil {
+		t.Fatalf("Failed to read catalog: %v", err)
+	}
+	if got := tf.Translations["signed-in-as-0"]; got != "Signed in as {0}" {
+		t.Errorf("Expected the template text, got %q (%v)", got, tf.Translations)
+	}
+	if !reflect.DeepEqual(tf.NeedsSource, []string{"item_html", "page.title"}) {
+		t.Errorf("Expected the template keys to need source text, got %v", tf.NeedsSource)
+	}
+}
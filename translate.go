@@ -4,9 +4,22 @@
 // - T(key, args...) - Translate by key with placeholder substitution
 // - F(format, args...) - Translate by format string (auto-generates key from format)
 // - S(text) - Translate static text (auto-generates key from text)
-// - P(key, count) - Pluralization support
+// - P(key, count) - Pluralization support (P64 for int64, Pf for decimal counts)
+// - Sel(key, selector, args...) - ICU select (gender) support
+// - TRandom(key, args...) - Random pick among alternative phrasings
+// - O(key, position) - Ordinal pluralization (1st, 2nd, 3rd)
 // - R(locale, format) - Direct translation (no function wrapping)
 //
+// Every function resolves a locale to a dictionary the same way: the locale's
+// own dictionary, then its fallback chain (parent tags, configured fallbacks,
+// the default language), with base catalogs standing in for languages
+// without one. When no dictionary is found, including when none is
+// registered, or the key is missing from it, T, P, P64, Pf, Sel, O and
+// TRandom render the key itself with its placeholders filled in, F renders
+// the format string with its arguments, and S and R return the text as-is.
+// Plural and ordinal forms follow the rules of the language of the
+// dictionary the locale resolved to, or of the locale when there is none.
+//
 // Example usage:
 //
 //	greeting := i18n.T("hello_world")
@@ -16,16 +29,98 @@ package i18n
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 )
 
 // TranslatedFunc returns a localized string when called with a locale.
 // This allows you to prepare a translation function and call it later with different locales.
+// Its arguments are formatted once, on the first call, and reused for every locale.
 type TranslatedFunc func(locale string) string
 
+// Bind returns a function that resolves TranslatedFuncs for locale, handy
+// when building a view model out of many of them.
+//
+// Example:
+//
+//	tr := i18n.Bind("fr")
+//	page := Page{Title: tr(i18n.S("Dashboard")), Greeting: tr(i18n.F("Hello %s", user))}
+func Bind(locale string) func(TranslatedFunc) string {
+	return func(fn TranslatedFunc) string {
+		if fn == nil {
+			return ""
+		}
+		return fn(locale)
+	}
+}
+
+// BindAll resolves every fn for locale, returning the strings in order.
+// A nil fn resolves to "".
+//
+// Example:
+//
+//	labels := i18n.BindAll("de", i18n.S("Save"), i18n.S("Cancel"))
+func BindAll(locale string, fns ...TranslatedFunc) []string {
+	tr := Bind(locale)
+	out := make([]string, len(fns))
+	for i, fn := range fns {
+		out[i] = tr(fn)
+	}
+	return out
+}
+
+// Default renders the translation in the default language of the default
+// bundle (see SetDefaultLanguage)
+func (fn TranslatedFunc) Default() string {
+	return fn(defaultBundle.DefaultLanguage())
+}
+
+// String renders the translation in the default language, so a
+// TranslatedFunc can be passed to fmt and loggers as-is
+func (fn TranslatedFunc) String() string {
+	return fn.Default()
+}
+
+// Map returns a TranslatedFunc that applies f to the translation, such as
+// strings.ToUpper or a Markdown renderer
+//
+// Example:
+//
+//	heading := i18n.S("Dashboard").Map(strings.ToUpper)
+func (fn TranslatedFunc) Map(f func(string) string) TranslatedFunc {
+	return func(locale string) string {
+		return f(fn(locale))
+	}
+}
+
+// Join returns a TranslatedFunc that renders every fn in the same locale and
+// joins the results with sep. A nil fn renders "".
+//
+// Example:
+//
+//	status := i18n.Join(" · ", i18n.S("Paid"), i18n.P("item_count", 3))
+//	fmt.Println(status("en")) // "Paid · 3 items"
+func Join(sep string, fns ...TranslatedFunc) TranslatedFunc {
+	return func(locale string) string {
+		return strings.Join(BindAll(locale, fns...), sep)
+	}
+}
+
+// Args holds values for named placeholders such as {name}. Pass it among the
+// arguments of T or F; it does not take a positional index.
+//
+// Example:
+//
+//	fn := i18n.T("welcome", i18n.Args{"name": "John", "count": 5})
+//
+// Dictionary should contain:
+//
+//	"welcome": "Welcome {name}, you have {count} new messages"
+type Args map[string]any
+
 // T translates by exact key with placeholder substitution.
 // Use this when you have predefined translation keys in your dictionary files.
-// Placeholders are numbered: {0}, {1}, {2}, etc.
+// Placeholders are numbered: {0}, {1}, {2}, etc., or named when using Args.
 //
 // Example:
 //
@@ -37,28 +132,21 @@ type TranslatedFunc func(locale string) string
 //
 //	"welcome_user": "Welcome {0}!"
 func T(key string, args ...any) TranslatedFunc {
-	return func(locale string) string {
-		dict := GetDictionary(locale)
-		template := key
+	return calls().T(key, args...)
+}
 
-		if dict != nil {
-			if tr := dict.Get(key); tr != "" && tr != key {
-				template = tr
-			}
-		} else if defaultDict := GetDictionary(DefaultLanguage()); defaultDict != nil {
-			if tr := defaultDict.Get(key); tr != "" && tr != key {
-				template = tr
-			}
-		}
+// T is like the package-level T but uses the bundle's dictionaries
+func (b *Bundle) T(key string, args ...any) TranslatedFunc {
+	values := newPlaceholderArgs(args)
 
-		// Replace placeholders {0}, {1}, {2}, etc.
-		for i, arg := range args {
-			placeholder := fmt.Sprintf("{%d}", i)
-			template = strings.ReplaceAll(template, placeholder, fmt.Sprint(arg))
+	return b.debugged(func(locale string) string {
+		template := key
+		if tr, _, ok := b.find(locale, key); ok && tr != "" {
+			template = tr
 		}
 
-		return template
-	}
+		return values.replace(template)
+	}, key)
 }
 
 // F translates by format string with auto-generated key.
@@ -76,31 +164,23 @@ func T(key string, args ...any) TranslatedFunc {
 //
 //	"hello-1-you-have-2-messages": "Bonjour {0}, vous avez {1} messages"
 func F(format string, args ...any) TranslatedFunc {
+	return calls().F(format, args...)
+}
+
+// F is like the package-level F but uses the bundle's dictionaries
+func (b *Bundle) F(format string, args ...any) TranslatedFunc {
 	key := slugify(format)
 	normalizedTemplate, _ := normalize(format)
+	values := newPlaceholderArgs(args)
 
-	return func(locale string) string {
-		dict := GetDictionary(locale)
+	return b.debugged(func(locale string) string {
 		template := normalizedTemplate
-
-		if dict != nil {
-			if tr := dict.Get(key); tr != "" && tr != key {
-				template = tr
-			}
-		} else if defaultDict := GetDictionary(DefaultLanguage()); defaultDict != nil {
-			if tr := defaultDict.Get(key); tr != "" && tr != key {
-				template = tr
-			}
+		if tr, _, ok := b.find(locale, key); ok && tr != "" && tr != key {
+			template = tr
 		}
 
-		// Replace placeholders {0}, {1}, {2}, etc.
-		for i, arg := range args {
-			placeholder := fmt.Sprintf("{%d}", i)
-			template = strings.ReplaceAll(template, placeholder, fmt.Sprint(arg))
-		}
-
-		return template
-	}
+		return values.replace(template)
+	}, key)
 }
 
 // S translates static text with auto-generated key.
@@ -117,29 +197,26 @@ func F(format string, args ...any) TranslatedFunc {
 //
 //	"dashboard": "Tableau de bord"
 func S(text string) TranslatedFunc {
-	key := slugify(text)
-
-	return func(locale string) string {
-		dict := GetDictionary(locale)
+	return calls().S(text)
+}
 
-		if dict != nil {
-			if tr := dict.Get(key); tr != "" && tr != key {
-				return tr
-			}
-		}
+// S is like the package-level S but uses the bundle's dictionaries
+func (b *Bundle) S(text string) TranslatedFunc {
+	key := slugify(text)
 
-		if defaultDict := GetDictionary(DefaultLanguage()); defaultDict != nil {
-			if tr := defaultDict.Get(key); tr != "" && tr != key {
-				return tr
-			}
+	return b.debugged(func(locale string) string {
+		if tr, _, ok := b.find(locale, key); ok && tr != "" && tr != key {
+			return tr
 		}
 
 		return text
-	}
+	}, key)
 }
 
 // P handles pluralization for a given key and count.
-// Supports ICU-style plural forms: zero, one, two, few, many, other.
+// Supports ICU-style plural forms: zero, one, two, few, many, other, exact
+// matches such as =0 and an offset:n that is subtracted from the count before
+// the form is chosen and # is printed.
 //
 // Example:
 //
@@ -149,38 +226,129 @@ func S(text string) TranslatedFunc {
 // Dictionary should contain:
 //
 //	"item_count": "{count, plural, zero {no items} one {# item} other {# items}}"
-func P(key string, count int) TranslatedFunc {
-	return func(locale string) string {
-		dict := GetDictionary(locale)
-		template := key
+//
+// Extra args fill {0}, {1} and named (Args) placeholders once the plural form
+// is chosen, and options such as Style(Short) select a variant of the message:
+//
+//	i18n.P("unread", 5, "Alice") // "Alice has 5 unread messages"
+//	// "unread": "{count, plural, one {{0} has # unread message} other {{0} has # unread messages}}"
+//
+//	i18n.P("liked", 3) // "you and 2 others"
+//	// "liked": "{count, plural, offset:1 =0 {nobody} =1 {you} one {you and # other} other {you and # others}}"
+func P(key string, count int, args ...any) TranslatedFunc {
+	return calls().P(key, count, args...)
+}
+
+// P is like the package-level P but uses the bundle's dictionaries
+func (b *Bundle) P(key string, count int, args ...any) TranslatedFunc {
+	return b.P64(key, int64(count), args...)
+}
+
+// P64 is like P for int64 counts such as account balances and deltas.
+// Plural forms are selected by the absolute value of count, as in CLDR, so
+// -1 uses "one" and -5 uses "other". Negative counts use the optional
+// "negative" form when the template defines one; inside it, # is replaced
+// with the absolute value.
+//
+// Example:
+//
+//	fn := i18n.P64("balance", -25)
+//	fmt.Println(fn("en")) // "overdrawn by 25 credits"
+//
+// Dictionary should contain:
+//
+//	"balance": "{count, plural, negative {overdrawn by # credits} one {# credit} other {# credits}}"
+func P64(key string, count int64, args ...any) TranslatedFunc {
+	return defaultBundle.P64(key, count, args...)
+}
 
-		if dict != nil {
-			template = dict.Get(key)
-		} else if defaultDict := GetDictionary(DefaultLanguage()); defaultDict != nil {
-			template = defaultDict.Get(key)
+// P64 is like the package-level P64 but uses the bundle's dictionaries
+func (b *Bundle) P64(key string, count int64, args ...any) TranslatedFunc {
+	keys := styleKeys(key, styleOf(args))
+	values := newPlaceholderArgs(args)
+
+	return b.debugged(func(locale string) string {
+		return values.replace(b.plural(locale, key, keys, count))
+	}, keys...)
+}
+
+// plural resolves the plural form of the first of keys found for a locale,
+// or of key itself if none is found
+func (b *Bundle) plural(locale, key string, keys []string, count int64) string {
+	// Plural rules follow the dictionary the locale resolves to, as in O
+	tr, dict, ok := b.find(locale, keys...)
+	template, lang := key, locale
+
+	if dict != nil {
+		lang = dict.Lang
+	}
+	if ok {
+		template = tr
+	}
+
+	return pluralText(lang, template, count)
+}
+
+// pluralText renders the plural form of template for count in locale
+func pluralText(locale, template string, count int64) string {
+	// Handle ICU-style plural syntax
+	if strings.Contains(template, "{count, plural") {
+		// Forms and # use the count minus the offset; exact matches (=0, =1)
+		// compare against the count itself
+		offset, _ := pluralOffset(template)
+		n := count - offset
+
+		choice := pluralChoice{
+			exact:  "=" + strconv.FormatInt(count, 10),
+			form:   determinePluralForm(locale, pluralOperand(n)),
+			number: fmt.Sprint(n),
 		}
+		if count < 0 {
+			choice.negative = fmt.Sprint(absCount(count))
+		}
+		if result := selectPlural(template, choice); result != "" {
+			return result
+		}
+	}
 
-		// Handle ICU-style plural syntax
-		if strings.Contains(template, "{count, plural") {
-			// Determine the appropriate plural form for the locale
-			form := determinePluralForm(locale, count)
-
-			// Extract the appropriate plural form from template
-			if result := extractPluralForm(template, form, count); result != "" {
-				return result
-			}
-
-			// Fallback to "other" if specific form not found
-			if form != "other" {
-				if result := extractPluralForm(template, "other", count); result != "" {
-					return result
-				}
-			}
+	// Fallback: simple string substitution
+	return strings.ReplaceAll(template, "{count}", fmt.Sprint(count))
+}
+
+// pluralChoice is a count as the selection of an ICU plural form sees it
+type pluralChoice struct {
+	exact    string // exact match selector such as "=1", or ""
+	negative string // absolute value for the negative form, or ""
+	form     string // plural form of the count minus the offset
+	number   string // the count minus the offset, as printed by #
+}
+
+// selectPlural returns the form of an ICU plural template chosen for c: an
+// exact match, then the negative form, then c.form and finally "other". It
+// returns "" if the template has none of them.
+func selectPlural(template string, c pluralChoice) string {
+	if c.exact != "" {
+		if result := extractPluralText(template, c.exact, c.number); result != "" {
+			return result
 		}
+	}
 
-		// Fallback: simple string substitution
-		return strings.ReplaceAll(template, "{count}", fmt.Sprint(count))
+	if c.negative != "" {
+		if result := extractPluralText(template, "negative", c.negative); result != "" {
+			return result
+		}
 	}
+
+	// Extract the appropriate plural form from template
+	if result := extractPluralText(template, c.form, c.number); result != "" {
+		return result
+	}
+
+	// Fallback to "other" if specific form not found
+	if c.form != "other" {
+		return extractPluralText(template, "other", c.number)
+	}
+	return ""
 }
 
 // R performs direct translation without function wrapping.
@@ -191,20 +359,17 @@ func P(key string, count int) TranslatedFunc {
 //	text := i18n.R("en", "Dashboard")
 //	fmt.Println(text) // "Dashboard"
 func R(locale, text string) string {
-	key := slugify(text)
-	dict := GetDictionary(locale)
-
-	if dict != nil {
-		if tr := dict.Get(key); tr != "" && tr != key {
-			return tr
-		}
-	}
+	return calls().R(locale, text)
+}
 
-	if defaultDict := GetDictionary(DefaultLanguage()); defaultDict != nil {
-		if tr := defaultDict.Get(key); tr != "" && tr != key {
+// R is like the package-level R but uses the bundle's dictionaries
+func (b *Bundle) R(locale, text string) string {
+	key := slugify(text)
+	return b.debugged(func(locale string) string {
+		if tr, _, ok := b.find(locale, key); ok && tr != "" && tr != key {
 			return tr
 		}
-	}
 
-	return text
+		return text
+	}, key)(locale)
 }
@@ -2,14 +2,16 @@ package i18n
 
 import (
 	"fmt"
+	"math"
+	"strings"
 	"testing"
 )
 
 func setupTestDictionaries() {
 	// Clean up existing dictionaries
-	muDicts.Lock()
-	dictionaries = make(map[string]*Dictionary)
-	muDicts.Unlock()
+	defaultBundle.mu.Lock()
+	defaultBundle.dictionaries = make(map[string]*Dictionary)
+	defaultBundle.mu.Unlock()
 
 	// Set up English dictionary
 	enDict := NewDictionary("en")
@@ -41,9 +43,9 @@ func setupTestDictionaries() {
 func TestT_BasicTranslation(t *testing.T) {
 	setupTestDictionaries()
 	defer func() {
-		muDicts.Lock()
-		dictionaries = make(map[string]*Dictionary)
-		muDicts.Unlock()
+		defaultBundle.mu.Lock()
+		defaultBundle.dictionaries = make(map[string]*Dictionary)
+		defaultBundle.mu.Unlock()
 	}()
 
 	fn := T("hello-0", "John")
@@ -62,9 +64,9 @@ func TestT_BasicTranslation(t *testing.T) {
 func TestT_FallbackToDefault(t *testing.T) {
 	setupTestDictionaries()
 	defer func() {
-		muDicts.Lock()
-		dictionaries = make(map[string]*Dictionary)
-		muDicts.Unlock()
+		defaultBundle.mu.Lock()
+		defaultBundle.dictionaries = make(map[string]*Dictionary)
+		defaultBundle.mu.Unlock()
 	}()
 
 	fn := T("goodbye")
@@ -79,9 +81,9 @@ func TestT_FallbackToDefault(t *testing.T) {
 func TestT_NoTranslation(t *testing.T) {
 	setupTestDictionaries()
 	defer func() {
-		muDicts.Lock()
-		dictionaries = make(map[string]*Dictionary)
-		muDicts.Unlock()
+		defaultBundle.mu.Lock()
+		defaultBundle.dictionaries = make(map[string]*Dictionary)
+		defaultBundle.mu.Unlock()
 	}()
 
 	fn := T("nonexistent-key", "arg")
@@ -92,12 +94,70 @@ func TestT_NoTranslation(t *testing.T) {
 	}
 }
 
+func TestBind(t *testing.T) {
+	setupTestDictionaries()
+	defer func() {
+		defaultBundle.mu.Lock()
+		defaultBundle.dictionaries = make(map[string]*Dictionary)
+		defaultBundle.mu.Unlock()
+	}()
+
+	tr := Bind("fr")
+	if got := tr(S("Dashboard")); got != "Tableau de bord" {
+		t.Errorf("Expected 'Tableau de bord', got '%s'", got)
+	}
+	if got := tr(nil); got != "" {
+		t.Errorf("Expected empty string for nil func, got '%s'", got)
+	}
+
+	got := BindAll("fr", T("welcome"), T("hello-0", "John"), nil)
+	want := []string{"Bienvenue", "Bonjour John", ""}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("BindAll() = %q, want %q", got, want)
+	}
+	if got := BindAll("en"); len(got) != 0 {
+		t.Errorf("Expected no strings, got %q", got)
+	}
+}
+
+func TestTranslatedFuncComposition(t *testing.T) {
+	setupTestDictionaries()
+	defer func() {
+		defaultBundle.mu.Lock()
+		defaultBundle.dictionaries = make(map[string]*Dictionary)
+		defaultBundle.mu.Unlock()
+		SetDefaultLanguage("en")
+	}()
+
+	SetDefaultLanguage("fr")
+	title := S("Dashboard")
+	if got := title.Default(); got != "Tableau de bord" {
+		t.Errorf("Default() = %q, want %q", got, "Tableau de bord")
+	}
+	if got := fmt.Sprintf("page %s, %v", title, title); got != "page Tableau de bord, Tableau de bord" {
+		t.Errorf("Expected fmt to use String(), got %q", got)
+	}
+
+	upper := title.Map(strings.ToUpper)
+	if got := upper("en"); got != "DASHBOARD" {
+		t.Errorf("Map() = %q, want %q", got, "DASHBOARD")
+	}
+
+	joined := Join(" | ", T("welcome"), upper, nil)
+	if got := joined("fr"); got != "Bienvenue | TABLEAU DE BORD | " {
+		t.Errorf("Join() = %q", got)
+	}
+	if got := Join(", ")("fr"); got != "" {
+		t.Errorf("Expected an empty Join to render nothing, got %q", got)
+	}
+}
+
 func TestF_BasicFormat(t *testing.T) {
 	setupTestDictionaries()
 	defer func() {
-		muDicts.Lock()
-		dictionaries = make(map[string]*Dictionary)
-		muDicts.Unlock()
+		defaultBundle.mu.Lock()
+		defaultBundle.dictionaries = make(map[string]*Dictionary)
+		defaultBundle.mu.Unlock()
 	}()
 
 	fn := F("Hello %s World", "Beautiful")
@@ -116,9 +176,9 @@ func TestF_BasicFormat(t *testing.T) {
 func TestF_NoTranslation(t *testing.T) {
 	setupTestDictionaries()
 	defer func() {
-		muDicts.Lock()
-		dictionaries = make(map[string]*Dictionary)
-		muDicts.Unlock()
+		defaultBundle.mu.Lock()
+		defaultBundle.dictionaries = make(map[string]*Dictionary)
+		defaultBundle.mu.Unlock()
 	}()
 
 	fn := F("Unknown %s format", "test")
@@ -133,9 +193,9 @@ func TestF_NoTranslation(t *testing.T) {
 func TestS_StaticText(t *testing.T) {
 	setupTestDictionaries()
 	defer func() {
-		muDicts.Lock()
-		dictionaries = make(map[string]*Dictionary)
-		muDicts.Unlock()
+		defaultBundle.mu.Lock()
+		defaultBundle.dictionaries = make(map[string]*Dictionary)
+		defaultBundle.mu.Unlock()
 	}()
 
 	fn := S("Dashboard")
@@ -154,9 +214,9 @@ func TestS_StaticText(t *testing.T) {
 func TestS_FallbackToOriginal(t *testing.T) {
 	setupTestDictionaries()
 	defer func() {
-		muDicts.Lock()
-		dictionaries = make(map[string]*Dictionary)
-		muDicts.Unlock()
+		defaultBundle.mu.Lock()
+		defaultBundle.dictionaries = make(map[string]*Dictionary)
+		defaultBundle.mu.Unlock()
 	}()
 
 	fn := S("Unknown Text")
@@ -175,9 +235,9 @@ func TestS_FallbackToOriginal(t *testing.T) {
 func TestP_Pluralization(t *testing.T) {
 	setupTestDictionaries()
 	defer func() {
-		muDicts.Lock()
-		dictionaries = make(map[string]*Dictionary)
-		muDicts.Unlock()
+		defaultBundle.mu.Lock()
+		defaultBundle.dictionaries = make(map[string]*Dictionary)
+		defaultBundle.mu.Unlock()
 	}()
 
 	// Add more comprehensive plural templates
@@ -259,12 +319,118 @@ func TestP_Pluralization(t *testing.T) {
 	}
 }
 
+func TestP64_NegativeAndLargeCounts(t *testing.T) {
+	setupTestDictionaries()
+	defer func() {
+		defaultBundle.mu.Lock()
+		defaultBundle.dictionaries = make(map[string]*Dictionary)
+		defaultBundle.mu.Unlock()
+	}()
+
+	GetDictionary("en").Add("balance", "{count, plural, negative {overdrawn by # credits} one {# credit} other {# credits}}")
+	GetDictionary("en").Add("delta", "{count, plural, one {# point} other {# points}}")
+
+	tests := []struct {
+		key      string
+		count    int64
+		expected string
+	}{
+		{"balance", -25, "overdrawn by 25 credits"},
+		{"balance", 1, "1 credit"},
+		{"balance", 9_000_000_000, "9000000000 credits"},
+		{"delta", -1, "-1 point"},
+		{"delta", -5, "-5 points"},
+		{"delta", math.MaxInt64, "9223372036854775807 points"},
+		{"delta", math.MinInt64, "-9223372036854775808 points"},
+	}
+
+	for _, tt := range tests {
+		if result := P64(tt.key, tt.count)("en"); result != tt.expected {
+			t.Errorf("P64(%q, %d) = '%s', expected '%s'", tt.key, tt.count, result, tt.expected)
+		}
+	}
+
+	if result := P("balance", -3)("en"); result != "overdrawn by 3 credits" {
+		t.Errorf("Expected P to use the negative form, got '%s'", result)
+	}
+}
+
+func TestP_ExtraArgs(t *testing.T) {
+	setupTestDictionaries()
+	defer func() {
+		defaultBundle.mu.Lock()
+		defaultBundle.dictionaries = make(map[string]*Dictionary)
+		defaultBundle.mu.Unlock()
+	}()
+
+	GetDictionary("en").AddAll(map[string]string{
+		"unread":       "{count, plural, one {{0} has # unread message} other {{0} has # unread messages}}",
+		"unread.short": "{count, plural, other {{0}: #}}",
+		"moved":        "{count, plural, one {# file moved to {folder}} other {# files moved to {folder}}}",
+	})
+
+	tests := []struct {
+		name     string
+		result   string
+		expected string
+	}{
+		{"one", P("unread", 1, "Alice")("en"), "Alice has 1 unread message"},
+		{"other", P("unread", 5, "Alice")("en"), "Alice has 5 unread messages"},
+		{"style is not positional", P("unread", 5, Style(Short), "Alice")("en"), "Alice: 5"},
+		{"named", P("moved", 2, Args{"folder": "Archive"})("en"), "2 files moved to Archive"},
+		{"no args", P("unread", 1)("en"), "{0} has 1 unread message"},
+	}
+
+	for _, tt := range tests {
+		if tt.result != tt.expected {
+			t.Errorf("%s: expected '%s', got '%s'", tt.name, tt.expected, tt.result)
+		}
+	}
+}
+
+func TestP_ExactMatchAndOffset(t *testing.T) {
+	setupTestDictionaries()
+	defer func() {
+		defaultBundle.mu.Lock()
+		defaultBundle.dictionaries = make(map[string]*Dictionary)
+		defaultBundle.mu.Unlock()
+	}()
+
+	GetDictionary("en").AddAll(map[string]string{
+		"liked":   "{count, plural, offset:1 =0 {nobody} =1 {you} one {you and # other} other {you and # others}}",
+		"guests":  "{count, plural, =0 {no guests} =2 {a couple} one {# guest} other {# guests}}",
+		"ignored": "{count, plural, offset:x one {# item} other {# items}}",
+	})
+
+	tests := []struct {
+		count    int
+		key      string
+		expected string
+	}{
+		{0, "liked", "nobody"},
+		{1, "liked", "you"},
+		{2, "liked", "you and 1 other"},
+		{5, "liked", "you and 4 others"},
+		{0, "guests", "no guests"},
+		{1, "guests", "1 guest"},
+		{2, "guests", "a couple"},
+		{3, "guests", "3 guests"},
+		{3, "ignored", "3 items"},
+	}
+
+	for _, tt := range tests {
+		if result := P(tt.key, tt.count)("en"); result != tt.expected {
+			t.Errorf("P(%q, %d) = %q, expected %q", tt.key, tt.count, result, tt.expected)
+		}
+	}
+}
+
 func TestP_FallbackToSimpleSubstitution(t *testing.T) {
 	setupTestDictionaries()
 	defer func() {
-		muDicts.Lock()
-		dictionaries = make(map[string]*Dictionary)
-		muDicts.Unlock()
+		defaultBundle.mu.Lock()
+		defaultBundle.dictionaries = make(map[string]*Dictionary)
+		defaultBundle.mu.Unlock()
 	}()
 
 	// Add a simple template without ICU plural syntax
@@ -281,9 +447,9 @@ func TestP_FallbackToSimpleSubstitution(t *testing.T) {
 func TestR_DirectTranslation(t *testing.T) {
 	setupTestDictionaries()
 	defer func() {
-		muDicts.Lock()
-		dictionaries = make(map[string]*Dictionary)
-		muDicts.Unlock()
+		defaultBundle.mu.Lock()
+		defaultBundle.dictionaries = make(map[string]*Dictionary)
+		defaultBundle.mu.Unlock()
 	}()
 
 	result := R("en", "Dashboard")
@@ -305,9 +471,9 @@ func TestR_DirectTranslation(t *testing.T) {
 func TestMultipleArgs(t *testing.T) {
 	setupTestDictionaries()
 	defer func() {
-		muDicts.Lock()
-		dictionaries = make(map[string]*Dictionary)
-		muDicts.Unlock()
+		defaultBundle.mu.Lock()
+		defaultBundle.dictionaries = make(map[string]*Dictionary)
+		defaultBundle.mu.Unlock()
 	}()
 
 	// Add templates with multiple placeholders
@@ -332,12 +498,33 @@ func TestMultipleArgs(t *testing.T) {
 	}
 }
 
+func TestT_NamedArgs(t *testing.T) {
+	setupTestDictionaries()
+	defer func() {
+		defaultBundle.mu.Lock()
+		defaultBundle.dictionaries = make(map[string]*Dictionary)
+		defaultBundle.mu.Unlock()
+	}()
+
+	GetDictionary("en").Add("inbox", "Welcome {name}, you have {count} new messages")
+	GetDictionary("fr").Add("inbox", "{count} nouveaux messages pour {name}")
+
+	fn := T("inbox", Args{"name": "John", "count": 5})
+
+	if result := fn("en"); result != "Welcome John, you have 5 new messages" {
+		t.Errorf("Expected named placeholders to be replaced, got '%s'", result)
+	}
+	if result := fn("fr"); result != "5 nouveaux messages pour John" {
+		t.Errorf("Expected reordered named placeholders to be replaced, got '%s'", result)
+	}
+}
+
 func TestEmptyArgs(t *testing.T) {
 	setupTestDictionaries()
 	defer func() {
-		muDicts.Lock()
-		dictionaries = make(map[string]*Dictionary)
-		muDicts.Unlock()
+		defaultBundle.mu.Lock()
+		defaultBundle.dictionaries = make(map[string]*Dictionary)
+		defaultBundle.mu.Unlock()
 	}()
 
 	fn := T("welcome")
@@ -356,9 +543,9 @@ func TestEmptyArgs(t *testing.T) {
 func TestNonExistentLocale(t *testing.T) {
 	setupTestDictionaries()
 	defer func() {
-		muDicts.Lock()
-		dictionaries = make(map[string]*Dictionary)
-		muDicts.Unlock()
+		defaultBundle.mu.Lock()
+		defaultBundle.dictionaries = make(map[string]*Dictionary)
+		defaultBundle.mu.Unlock()
 	}()
 
 	fn := T("welcome")
@@ -369,3 +556,70 @@ func TestNonExistentLocale(t *testing.T) {
 		t.Errorf("Expected 'Welcome' (fallback), got '%s'", result)
 	}
 }
+
+func TestMissingDictionarySemantics(t *testing.T) {
+	empty := NewBundle()
+
+	b := NewBundle()
+	en := NewDictionary("en")
+	en.AddAll(map[string]string{
+		"welcome":   "Welcome {0}",
+		"hello-0":   "Hello {0}",
+		"dashboard": "Dashboard",
+		"items":     "{count, plural, one {# item} few {# items (few)} other {# items}}",
+		"invite":    "{gender, select, female {her team} other {their team}}",
+		"finished":  "{position, selectordinal, one {#st} two {#nd} few {#rd} other {#th}}",
+	})
+	b.Register(en)
+	fr := NewDictionary("fr")
+	fr.AddAll(map[string]string{"welcome": "Bienvenue {0}", "dashboard": "Tableau de bord"})
+	b.Register(fr)
+
+	tests := []struct {
+		name   string
+		fn     TranslatedFunc
+		locale string
+		want   string
+	}{
+		// No dictionaries at all: keys and source texts are rendered
+		{"T empty", empty.T("welcome {0}", "Ada"), "fr", "welcome Ada"},
+		{"F empty", empty.F("Hello %s", "Ada"), "fr", "Hello Ada"},
+		{"S empty", empty.S("Dashboard"), "fr", "Dashboard"},
+		{"P empty", empty.P("items", 3), "fr", "items"},
+		{"Sel empty", empty.Sel("invite", "female"), "fr", "invite"},
+		{"O empty", empty.O("finished", 2), "fr", "finished"},
+
+		// Unknown locale: the default language
+		{"T unknown", b.T("welcome", "Ada"), "xx", "Welcome Ada"},
+		{"F unknown", b.F("Hello %s", "Ada"), "xx", "Hello Ada"},
+		{"S unknown", b.S("Dashboard"), "xx", "Dashboard"},
+		{"P unknown", b.P("items", 1), "xx", "1 item"},
+		{"Sel unknown", b.Sel("invite", "female"), "xx", "her team"},
+		{"O unknown", b.O("finished", 2), "xx", "2nd"},
+
+		// Regional locale: its parent language
+		{"T regional", b.T("welcome", "Ada"), "fr-CA", "Bienvenue Ada"},
+		{"S regional", b.S("Dashboard"), "fr-CA", "Tableau de bord"},
+
+		// Missing key in a registered language: the default language, then the key
+		{"T missing key", b.T("hello-0", "Ada"), "fr", "Hello Ada"},
+		{"T missing everywhere", b.T("nope {0}", "Ada"), "fr", "nope Ada"},
+		{"P missing everywhere", b.P("nope", 3), "fr", "nope"},
+
+		// Plural rules follow the language of the dictionary used, not the locale
+		{"P rules", b.P("items", 3), "pl", "3 items"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.fn(tt.locale); got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+
+	if got := empty.R("fr", "Dashboard"); got != "Dashboard" {
+		t.Errorf("R empty: got %q, want %q", got, "Dashboard")
+	}
+	if got := b.R("xx", "Dashboard"); got != "Dashboard" {
+		t.Errorf("R unknown: got %q, want %q", got, "Dashboard")
+	}
+}
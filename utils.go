@@ -2,8 +2,11 @@ package i18n
 
 import (
 	"fmt"
+	"math"
 	"regexp"
+	"strconv"
 	"strings"
+	"sync"
 )
 
 // Pre-compiled regex pattern for better performance
@@ -66,6 +69,55 @@ func normalize(format string) (string, []string) {
 	return out, matches
 }
 
+// replacePlaceholders substitutes positional {0}, {1}, … placeholders with
+// args and named {name} placeholders with the entries of Args arguments.
+// Args and options such as Style and StableFor do not take a positional
+// index, and substituted values are never expanded again.
+func replacePlaceholders(template string, args []any) string {
+	return newPlaceholderArgs(args).replace(template)
+}
+
+// placeholderArgs formats the arguments of a translation once, on first
+// use, and reuses the result for every locale the translation is rendered
+// in, so rendering a message in several languages formats each argument once
+type placeholderArgs struct {
+	args     []any
+	once     sync.Once
+	replacer *strings.Replacer
+}
+
+// newPlaceholderArgs returns the placeholder values of args
+func newPlaceholderArgs(args []any) *placeholderArgs {
+	return &placeholderArgs{args: args}
+}
+
+// replace substitutes the placeholders of template as replacePlaceholders does
+func (p *placeholderArgs) replace(template string) string {
+	if len(p.args) == 0 || !strings.Contains(template, "{") {
+		return template
+	}
+
+	p.once.Do(func() {
+		var pairs []string
+		index := 0
+		for _, arg := range p.args {
+			switch a := arg.(type) {
+			case Args:
+				for name, value := range a {
+					pairs = append(pairs, "{"+name+"}", fmt.Sprint(value))
+				}
+				continue
+			case StyleOption, RandomOption, EscapeMode:
+				continue
+			}
+			pairs = append(pairs, fmt.Sprintf("{%d}", index), fmt.Sprint(arg))
+			index++
+		}
+		p.replacer = strings.NewReplacer(pairs...)
+	})
+	return p.replacer.Replace(template)
+}
+
 // determinePluralForm determines the appropriate plural form based on locale and count
 func determinePluralForm(locale string, count int) string {
 	// Simplified plural rules for common languages
@@ -138,8 +190,62 @@ func determinePluralForm(locale string, count int) string {
 	}
 }
 
+// pluralOperand returns the value plural rules are evaluated on: the absolute
+// value of count. Values beyond the range of int keep their last two digits,
+// which is all the plural rules look at for large numbers.
+func pluralOperand(count int64) int {
+	n := uint64(count)
+	if count < 0 {
+		n = -n
+	}
+	if n > math.MaxInt {
+		return int(n%100 + 100)
+	}
+	return int(n)
+}
+
+// absCount returns the absolute value of count as printed in plural forms.
+// math.MinInt64 has no int64 absolute value and is returned unchanged.
+func absCount(count int64) int64 {
+	if count < 0 && count != math.MinInt64 {
+		return -count
+	}
+	return count
+}
+
+// pluralOffset returns the n of an "offset:n" in the header of an ICU plural
+// template, or 0 if there is none. ok is false if the offset is malformed.
+func pluralOffset(template string) (offset int64, ok bool) {
+	header := "{count, plural,"
+	at := strings.Index(template, header)
+	if at < 0 {
+		return 0, true
+	}
+
+	rest := strings.TrimLeft(template[at+len(header):], " \t\n")
+	if !strings.HasPrefix(rest, "offset:") {
+		return 0, true
+	}
+
+	digits := rest[len("offset:"):]
+	if end := strings.IndexAny(digits, " \t\n{"); end >= 0 {
+		digits = digits[:end]
+	}
+	n, err := strconv.ParseInt(digits, 10, 64)
+	if err != nil || n < 0 {
+		return 0, false
+	}
+	return n, true
+}
+
 // extractPluralForm extracts the appropriate plural form from an ICU-style template
-func extractPluralForm(template, form string, count int) string {
+func extractPluralForm(template, form string, count int64) string {
+	return extractPluralText(template, form, fmt.Sprint(count))
+}
+
+// extractPluralText extracts a plural form from an ICU-style template,
+// replacing # with number
+func extractPluralText(template, form, number string) string {
 	// Look for the pattern: "form {content}"
 	start := fmt.Sprintf("%s {", form)
 	idx := strings.Index(template, start)
@@ -170,6 +276,6 @@ func extractPluralForm(template, form string, count int) string {
 
 	result := content[:end]
 	// Replace # with the actual count
-	result = strings.ReplaceAll(result, "#", fmt.Sprint(count))
+	result = strings.ReplaceAll(result, "#", number)
 	return strings.TrimSpace(result)
 }
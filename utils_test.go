@@ -2,6 +2,7 @@ package i18n
 
 import (
 	"fmt"
+	"math"
 	"testing"
 )
 
@@ -257,7 +258,7 @@ func TestExtractPluralForm(t *testing.T) {
 	tests := []struct {
 		template string
 		form     string
-		count    int
+		count    int64
 		expected string
 	}{
 		{
@@ -343,3 +344,110 @@ func min(a, b int) int {
 	}
 	return b
 }
+
+func TestReplacePlaceholders(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		args     []any
+		expected string
+	}{
+		{"positional", "Hello {0}, you have {1} messages", []any{"John", 5}, "Hello John, you have 5 messages"},
+		{"named", "Hello {name}, you have {count} messages", []any{Args{"name": "John", "count": 5}}, "Hello John, you have 5 messages"},
+		{"reordered", "{count} messages for {name}", []any{Args{"name": "John", "count": 5}}, "5 messages for John"},
+		{"mixed", "{0} sent {count} files to {1}", []any{"Ann", Args{"count": 2}, "Bob"}, "Ann sent 2 files to Bob"},
+		{"no expansion of values", "{0} and {1}", []any{"{1}", "x"}, "{1} and x"},
+		{"unknown placeholders kept", "Hello {name} {0}", []any{Args{"other": 1}}, "Hello {name} {0}"},
+		{"no args", "Hello {0}", nil, "Hello {0}"},
+		{"options skipped", "{0} {1}", []any{Style(Short), "a", "b"}, "a b"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := replacePlaceholders(tt.template, tt.args); result != tt.expected {
+				t.Errorf("replacePlaceholders(%q) = %q, expected %q", tt.template, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPluralOperand(t *testing.T) {
+	tests := []struct {
+		count    int64
+		expected int
+	}{
+		{0, 0},
+		{1, 1},
+		{-1, 1},
+		{-42, 42},
+		{math.MinInt64, 108}, // beyond int: last two digits (08) kept
+	}
+
+	for _, tt := range tests {
+		if result := pluralOperand(tt.count); result != tt.expected {
+			t.Errorf("pluralOperand(%d) = %d, expected %d", tt.count, result, tt.expected)
+		}
+	}
+
+	if form := determinePluralForm("ru", pluralOperand(-3)); form != "few" {
+		t.Errorf("Expected -3 to use 'few' in Russian, got %q", form)
+	}
+}
+
+func TestPluralOffset(t *testing.T) {
+	tests := []struct {
+		template string
+		offset   int64
+		ok       bool
+	}{
+		{"{count, plural, one {# item} other {# items}}", 0, true},
+		{"{count, plural, offset:1 =0 {nobody} other {# others}}", 1, true},
+		{"{count, plural,  offset:12 other {# others}}", 12, true},
+		{"{count, plural, offset:x other {# others}}", 0, false},
+		{"Set offset:3 manually", 0, true},
+	}
+
+	for _, tt := range tests {
+		offset, ok := pluralOffset(tt.template)
+		if offset != tt.offset || ok != tt.ok {
+			t.Errorf("pluralOffset(%q) = %d, %v, expected %d, %v", tt.template, offset, ok, tt.offset, tt.ok)
+		}
+	}
+}
+
+// countingStringer counts how often it is formatted
+type countingStringer struct{ calls *int }
+
+func (c countingStringer) String() string {
+	*c.calls++
+	return "Ada"
+}
+
+func TestPlaceholderArgs_FormatsOnce(t *testing.T) {
+	b := NewBundle()
+	en := NewDictionary("en")
+	en.Add("greeting", "Hello {0}, {0}!")
+	fr := NewDictionary("fr")
+	fr.Add("greeting", "Bonjour {0}, {0} !")
+	b.Register(en)
+	b.Register(fr)
+
+	calls := 0
+	fn := b.T("greeting", countingStringer{&calls})
+	if got := fn("en"); got != "Hello Ada, Ada!" {
+		t.Errorf("Expected 'Hello Ada, Ada!', got %q", got)
+	}
+	if got := fn("fr"); got != "Bonjour Ada, Ada !" {
+		t.Errorf("Expected 'Bonjour Ada, Ada !', got %q", got)
+	}
+	if calls != 1 {
+		t.Errorf("Expected the argument to be formatted once, got %d", calls)
+	}
+
+	// Templates without placeholders never format the arguments
+	calls = 0
+	b.T("plain", countingStringer{&calls})("en")
+	if calls != 0 {
+		t.Errorf("Expected no formatting, got %d", calls)
+	}
+}
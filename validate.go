@@ -0,0 +1,183 @@
+package i18n
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ValidationIssue is a problem ValidateDir or LintDir found in a catalog
+type ValidationIssue struct {
+	File    string `json:"file"`
+	Lang    string `json:"lang,omitempty"`
+	Key     string `json:"key,omitempty"`
+	Line    int    `json:"line,omitempty"`
+	Column  int    `json:"column,omitempty"`
+	Rule    string `json:"rule,omitempty"` // lint rule, see LintDir
+	Message string `json:"message"`
+}
+
+// String formats the issue as "file:line:column: key: message", leaving out
+// the parts that are not known
+func (v ValidationIssue) String() string {
+	var b strings.Builder
+	b.WriteString(v.File)
+	if v.Line > 0 {
+		fmt.Fprintf(&b, ":%d", v.Line)
+		if v.Column > 0 {
+			fmt.Fprintf(&b, ":%d", v.Column)
+		}
+	}
+	b.WriteString(": ")
+	if v.Key != "" {
+		b.WriteString(v.Key + ": ")
+	}
+	b.WriteString(v.Message)
+	return b.String()
+}
+
+// ValidateDir checks every catalog in dir without registering any of them
+// and reports all the problems it finds rather than stopping at the first:
+// catalogs that do not decode or fail the checks LoadDir runs, file names
+// whose language differs from meta.lang, and translations whose placeholders
+// ({0}, {1}, {name}) differ from those of the default language's catalog of
+// the same name. The error is only for a directory that cannot be listed.
+//
+// Example:
+//
+//	issues, err := i18n.ValidateDir("locales")
+//	for _, issue := range issues {
+//		log.Println(issue)
+//	}
+func ValidateDir(dir string) ([]ValidationIssue, error) {
+	return defaultBundle.ValidateDir(dir)
+}
+
+// ValidateDir is like the package-level ValidateDir but compares placeholders
+// with the bundle's default language
+func (b *Bundle) ValidateDir(dir string) ([]ValidationIssue, error) {
+	files, err := catalogFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var issues []ValidationIssue
+	var catalogs []catalogData
+	for _, file := range files {
+		data, err := os.ReadFile(filepath.Clean(file))
+		if err != nil {
+			issues = append(issues, ValidationIssue{File: file, Message: fmt.Sprintf("failed to read file: %v", err)})
+			continue
+		}
+		tf, err := decodeTranslationFile(data)
+		if err != nil {
+			issues = append(issues, errorIssue(file, err))
+			continue
+		}
+		if lang := fileLanguage(file); lang != "" && lang != tf.Meta.Lang {
+			issues = append(issues, ValidationIssue{File: file, Lang: tf.Meta.Lang,
+				Message: fmt.Sprintf("file name language '%s' does not match meta.lang '%s'", lang, tf.Meta.Lang)})
+			continue
+		}
+		catalogs = append(catalogs, catalogData{path: file, data: data, tf: tf})
+	}
+
+	issues = append(issues, lintCatalogs(catalogs, b.DefaultLanguage(), placeholderRule)...)
+	return issues, nil
+}
+
+// errorIssue converts a decode or validation error of file into an issue,
+// keeping the language, key and position of a *FileError
+func errorIssue(file string, err error) ValidationIssue {
+	var fe *FileError
+	if !errors.As(err, &fe) {
+		return ValidationIssue{File: file, Message: err.Error()}
+	}
+	reason := *fe
+	reason.Path, reason.Line, reason.Column = "", 0, 0
+	return ValidationIssue{File: file, Lang: fe.Lang, Key: fe.Key, Line: fe.Line, Column: fe.Column, Message: reason.Error()}
+}
+
+// placeholderDifference describes how the placeholders of a translation
+// differ from those of the default language, or returns "" if they match
+func placeholderDifference(expected, got map[string]bool, defaultLang string) string {
+	var missing, extra []string
+	for name := range expected {
+		if !got[name] {
+			missing = append(missing, "{"+name+"}")
+		}
+	}
+	for name := range got {
+		if !expected[name] {
+			extra = append(extra, "{"+name+"}")
+		}
+	}
+	if len(missing) == 0 && len(extra) == 0 {
+		return ""
+	}
+	sort.Strings(missing)
+	sort.Strings(extra)
+
+	var parts []string
+	if len(missing) > 0 {
+		parts = append(parts, "missing "+strings.Join(missing, ", "))
+	}
+	if len(extra) > 0 {
+		parts = append(parts, "has "+strings.Join(extra, ", "))
+	}
+	return fmt.Sprintf("placeholders differ from '%s': %s", defaultLang, strings.Join(parts, "; "))
+}
+
+// placeholderNames returns the names of the placeholders of a template:
+// "{0}" and "{name}", and the arguments of "{count, plural, ...}" and
+// "{gender, select, ...}" blocks, whose branches are searched in turn. The
+// text of a branch ("one {# item}") is not taken for a placeholder.
+func placeholderNames(template string) map[string]bool {
+	names := make(map[string]bool)
+	collectPlaceholders(template, names)
+	return names
+}
+
+// collectPlaceholders adds the placeholders of a message to names
+func collectPlaceholders(message string, names map[string]bool) {
+	for i := 0; i < len(message); i++ {
+		if message[i] != '{' {
+			continue
+		}
+		end := matchingBrace(message, i)
+		if end < 0 {
+			return
+		}
+		parts := strings.SplitN(message[i+1:end], ",", 3)
+		if name := strings.TrimSpace(parts[0]); name != "" && !strings.ContainsAny(name, " \t\n{}#") {
+			names[name] = true
+		}
+		if len(parts) == 3 {
+			switch strings.TrimSpace(parts[1]) {
+			case "plural", "selectordinal", "select":
+				collectBranches(parts[2], names)
+			}
+		}
+		i = end
+	}
+}
+
+// collectBranches adds the placeholders of the branches of a plural or select
+// block ("one {# item} other {# items}") to names
+func collectBranches(branches string, names map[string]bool) {
+	for {
+		open := strings.IndexByte(branches, '{')
+		if open < 0 {
+			return
+		}
+		end := matchingBrace(branches, open)
+		if end < 0 {
+			return
+		}
+		collectPlaceholders(branches[open+1:end], names)
+		branches = branches[end+1:]
+	}
+}
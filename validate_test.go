@@ -0,0 +1,99 @@
+package i18n
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestValidateDir(t *testing.T) {
+	dir := t.TempDir()
+	writeTestCatalog(t, dir, "default.en.json", "en", map[string]string{
+		"welcome":    "Welcome {name}, you have {0} messages",
+		"item_count": "{count, plural, one {# item in {place}} other {# items in {place}}}",
+		"gender":     "{gender, select, male {he} female {she} other {they}}",
+		"plain":      "Hello",
+	})
+	writeTestCatalog(t, dir, "default.fr.json", "fr", map[string]string{
+		"welcome":    "Bienvenue {nom}, vous avez {0} messages",
+		"item_count": "{count, plural, one {# élément dans {place}} other {# éléments dans {place}}}",
+		"gender":     "{gender, select, male {il} female {elle} other {iel}}",
+		"plain":      "Bonjour {0}",
+	})
+	writeTestCatalog(t, dir, "default.de.json", "de", map[string]string{
+		"welcome": "Willkommen {name}, Sie haben {0} Nachrichten",
+		"plain":   "",
+	})
+	writeTestFile(t, filepath.Join(dir, "default.es.json"), `{"meta": {"lang": "it", "name": "default"}, "translations": {}}`)
+	writeTestFile(t, filepath.Join(dir, "broken.en.json"), `{"meta": {"lang": "en"`)
+
+	issues, err := ValidateDir(dir)
+	if err != nil {
+		t.Fatalf("ValidateDir failed: %v", err)
+	}
+
+	var got []string
+	for _, issue := range issues {
+		got = append(got, filepath.Base(issue.File)+" "+issue.Key+" "+issue.Message)
+	}
+	want := []string{
+		"broken.en.json  invalid translation file: unexpected end of JSON input",
+		"default.de.json plain translation key 'plain' has empty value",
+		"default.es.json  file name language 'es' does not match meta.lang 'it'",
+		"default.fr.json plain placeholders differ from 'en': has {0}",
+		"default.fr.json welcome placeholders differ from 'en': missing {name}; has {nom}",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected issues\n%s\ngot\n%s", strings.Join(want, "\n"), strings.Join(got, "\n"))
+	}
+
+	for _, issue := range issues {
+		if issue.Key != "" && issue.Line == 0 {
+			t.Errorf("Expected a line for %s", issue)
+		}
+	}
+}
+
+func TestValidateDir_Valid(t *testing.T) {
+	dir := t.TempDir()
+	writeTestCatalog(t, dir, "default.en.json", "en", map[string]string{"hello-0": "Hello {0}"})
+	writeTestCatalog(t, dir, "default.fr.json", "fr", map[string]string{"hello-0": "Bonjour {0}", "extra": "Extra {1}"})
+
+	issues, err := ValidateDir(dir)
+	if err != nil {
+		t.Fatalf("ValidateDir failed: %v", err)
+	}
+	if len(issues) != 0 {
+		t.Errorf("Expected no issues, got %v", issues)
+	}
+}
+
+func TestPlaceholderNames(t *testing.T) {
+	for _, tt := range []struct {
+		template string
+		want     []string
+	}{
+		{"Hello {0} and {1}", []string{"0", "1"}},
+		{"Hello {name}", []string{"name"}},
+		{"{count, plural, offset:1 =0 {nobody} one {{host} alone} other {# guests of {host}}}", []string{"count", "host"}},
+		{"{gender, select, male {he} other {they}}", []string{"gender"}},
+		{"{0, number}", []string{"0"}},
+		{"No placeholders", nil},
+	} {
+		var got []string
+		for _, name := range sortedKeys(placeholderNames(tt.template)) {
+			got = append(got, name)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("placeholderNames(%q) = %q, expected %q", tt.template, got, tt.want)
+		}
+	}
+}
+
+func TestValidationIssue_String(t *testing.T) {
+	issue := ValidationIssue{File: "locales/default.fr.json", Key: "hello", Line: 4, Column: 5, Message: "bad"}
+	if got, want := issue.String(), "locales/default.fr.json:4:5: hello: bad"; got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
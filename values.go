@@ -0,0 +1,106 @@
+package i18n
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// ErrKeyNotFound is returned by typed accessors when a key is missing from the
+// requested dictionary and from the default language dictionary
+var ErrKeyNotFound = errors.New("translation key not found")
+
+// GetInt returns a translation value parsed as an integer, with fallback to the default language.
+//
+// Example:
+//
+//	perPage, err := dict.GetInt("items_per_page")
+//
+// Dictionary should contain:
+//
+//	"items_per_page": 20
+func (d *Dictionary) GetInt(key string) (int, error) {
+	value, ok := d.lookup(key)
+	return parseInt(key, value, ok)
+}
+
+// GetBool returns a translation value parsed as a boolean, with fallback to the default language
+func (d *Dictionary) GetBool(key string) (bool, error) {
+	value, ok := d.lookup(key)
+	return parseBool(key, value, ok)
+}
+
+// GetDuration returns a translation value parsed with time.ParseDuration ("90s", "1h30m"),
+// with fallback to the default language
+func (d *Dictionary) GetDuration(key string) (time.Duration, error) {
+	value, ok := d.lookup(key)
+	return parseDuration(key, value, ok)
+}
+
+// GetInt returns the integer value of a key for a locale
+func GetInt(locale, key string) (int, error) {
+	value, ok := defaultBundle.lookup(locale, key)
+	return parseInt(key, value, ok)
+}
+
+// GetBool returns the boolean value of a key for a locale
+func GetBool(locale, key string) (bool, error) {
+	value, ok := defaultBundle.lookup(locale, key)
+	return parseBool(key, value, ok)
+}
+
+// GetDuration returns the duration value of a key for a locale
+func GetDuration(locale, key string) (time.Duration, error) {
+	value, ok := defaultBundle.lookup(locale, key)
+	return parseDuration(key, value, ok)
+}
+
+// GetSlice returns the list value of a key for a locale, or nil if there is none.
+//
+// Example:
+//
+//	days := i18n.GetSlice("fr", "weekdays") // ["lundi", "mardi", ...]
+//
+// Dictionary should contain:
+//
+//	"weekdays": ["lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi", "dimanche"]
+func GetSlice(locale, key string) []string {
+	if dict := defaultBundle.dictionaryFor(locale); dict != nil {
+		return dict.GetSlice(key)
+	}
+	return nil
+}
+
+func parseInt(key, value string, ok bool) (int, error) {
+	if !ok {
+		return 0, fmt.Errorf("%w: '%s'", ErrKeyNotFound, key)
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("translation key '%s' is not an integer: %w", key, err)
+	}
+	return n, nil
+}
+
+func parseBool(key, value string, ok bool) (bool, error) {
+	if !ok {
+		return false, fmt.Errorf("%w: '%s'", ErrKeyNotFound, key)
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("translation key '%s' is not a boolean: %w", key, err)
+	}
+	return b, nil
+}
+
+func parseDuration(key, value string, ok bool) (time.Duration, error) {
+	if !ok {
+		return 0, fmt.Errorf("%w: '%s'", ErrKeyNotFound, key)
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("translation key '%s' is not a duration: %w", key, err)
+	}
+	return d, nil
+}
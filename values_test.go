@@ -0,0 +1,248 @@
+package i18n
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func setupValueDictionaries() {
+	defaultBundle.mu.Lock()
+	defaultBundle.dictionaries = make(map[string]*Dictionary)
+	defaultBundle.mu.Unlock()
+
+	enDict := NewDictionary("en")
+	enDict.AddAll(map[string]string{
+		"items_per_page": "20",
+		"show_weekends":  "true",
+		"session_ttl":    "30m",
+		"title":          "Dashboard",
+	})
+	Register(enDict)
+
+	deDict := NewDictionary("de")
+	deDict.AddAll(map[string]string{
+		"items_per_page": "25",
+		"show_weekends":  "false",
+	})
+	Register(deDict)
+
+	SetDefaultLanguage("en")
+}
+
+func TestDictionaryTypedAccessors(t *testing.T) {
+	setupValueDictionaries()
+	defer func() {
+		defaultBundle.mu.Lock()
+		defaultBundle.dictionaries = make(map[string]*Dictionary)
+		defaultBundle.mu.Unlock()
+	}()
+
+	de := GetDictionary("de")
+
+	if n, err := de.GetInt("items_per_page"); err != nil || n != 25 {
+		t.Errorf("Expected 25, got %d (err: %v)", n, err)
+	}
+	if b, err := de.GetBool("show_weekends"); err != nil || b {
+		t.Errorf("Expected false, got %v (err: %v)", b, err)
+	}
+
+	// Falls back to the default language
+	if d, err := de.GetDuration("session_ttl"); err != nil || d != 30*time.Minute {
+		t.Errorf("Expected 30m, got %v (err: %v)", d, err)
+	}
+
+	if _, err := de.GetInt("missing"); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("Expected ErrKeyNotFound, got %v", err)
+	}
+	if _, err := de.GetInt("title"); err == nil || errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("Expected parse error, got %v", err)
+	}
+	if _, err := de.GetBool("title"); err == nil {
+		t.Error("Expected parse error for non-boolean value, got nil")
+	}
+	if _, err := de.GetDuration("title"); err == nil {
+		t.Error("Expected parse error for non-duration value, got nil")
+	}
+}
+
+func TestTypedAccessorsByLocale(t *testing.T) {
+	setupValueDictionaries()
+	defer func() {
+		defaultBundle.mu.Lock()
+		defaultBundle.dictionaries = make(map[string]*Dictionary)
+		defaultBundle.mu.Unlock()
+	}()
+
+	if n, err := GetInt("de", "items_per_page"); err != nil || n != 25 {
+		t.Errorf("Expected 25, got %d (err: %v)", n, err)
+	}
+
+	// Unknown locale uses the default language dictionary
+	if n, err := GetInt("ja", "items_per_page"); err != nil || n != 20 {
+		t.Errorf("Expected 20, got %d (err: %v)", n, err)
+	}
+	if b, err := GetBool("ja", "show_weekends"); err != nil || !b {
+		t.Errorf("Expected true, got %v (err: %v)", b, err)
+	}
+	if d, err := GetDuration("de", "session_ttl"); err != nil || d != 30*time.Minute {
+		t.Errorf("Expected 30m, got %v (err: %v)", d, err)
+	}
+	if _, err := GetBool("de", "missing"); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("Expected ErrKeyNotFound, got %v", err)
+	}
+}
+
+func TestLoadDictionaryFile_NumericAndBooleanValues(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "config.json")
+	content := `{
+  "meta": {"lang": "en", "name": "config"},
+  "translations": {
+    "items_per_page": 20,
+    "ratio": 1.5,
+    "show_weekends": true,
+    "title": "Dashboard"
+  }
+}`
+	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	dict, err := LoadDictionaryFile(filePath)
+	if err != nil {
+		t.Fatalf("Failed to load dictionary: %v", err)
+	}
+
+	expected := map[string]string{
+		"items_per_page": "20",
+		"ratio":          "1.5",
+		"show_weekends":  "true",
+		"title":          "Dashboard",
+	}
+	for key, value := range expected {
+		if got := dict.Get(key); got != value {
+			t.Errorf("Expected '%s' for key '%s', got '%s'", value, key, got)
+		}
+	}
+
+	if n, err := dict.GetInt("items_per_page"); err != nil || n != 20 {
+		t.Errorf("Expected 20, got %d (err: %v)", n, err)
+	}
+}
+
+func TestTranslationFileUnmarshal_UnsupportedValue(t *testing.T) {
+	var tf TranslationFile
+	err := json.Unmarshal([]byte(`{"meta":{"lang":"en","name":"x"},"translations":{"a":{"b":[{"c":1}]}}}`), &tf)
+	if err == nil {
+		t.Error("Expected error for array of objects, got nil")
+	}
+}
+
+func TestDictionaryGetSlice(t *testing.T) {
+	defaultBundle.mu.Lock()
+	defaultBundle.dictionaries = make(map[string]*Dictionary)
+	defaultBundle.mu.Unlock()
+	defer func() {
+		defaultBundle.mu.Lock()
+		defaultBundle.dictionaries = make(map[string]*Dictionary)
+		defaultBundle.mu.Unlock()
+	}()
+	SetDefaultLanguage("en")
+
+	enDict := NewDictionary("en")
+	enDict.AddSlice("weekdays", []string{"Mon", "Tue"})
+	enDict.AddSlice("tips", []string{"Tip one", "Tip two"})
+	Register(enDict)
+
+	frDict := NewDictionary("fr")
+	frDict.AddSlice("weekdays", []string{"lun.", "mar."})
+	Register(frDict)
+
+	if days := frDict.GetSlice("weekdays"); len(days) != 2 || days[0] != "lun." {
+		t.Errorf("Expected French weekdays, got %v", days)
+	}
+
+	// Falls back to the default language
+	if tips := frDict.GetSlice("tips"); len(tips) != 2 || tips[1] != "Tip two" {
+		t.Errorf("Expected fallback tips, got %v", tips)
+	}
+
+	if missing := frDict.GetSlice("missing"); missing != nil {
+		t.Errorf("Expected nil for missing list, got %v", missing)
+	}
+
+	// Callers cannot mutate the stored list
+	frDict.GetSlice("weekdays")[0] = "changed"
+	if frDict.GetSlice("weekdays")[0] != "lun." {
+		t.Error("Expected GetSlice to return a copy")
+	}
+
+	if days := GetSlice("ja", "weekdays"); len(days) != 2 || days[0] != "Mon" {
+		t.Errorf("Expected default weekdays for unknown locale, got %v", days)
+	}
+}
+
+func TestTranslationFile_ListRoundTrip(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "lists.json")
+	content := `{
+  "meta": {"lang": "en", "name": "default"},
+  "translations": {
+    "title": "Dashboard",
+    "weekdays": ["Mon", "Tue", "Wed"]
+  }
+}`
+	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	dict, err := LoadDictionaryFile(filePath)
+	if err != nil {
+		t.Fatalf("Failed to load dictionary: %v", err)
+	}
+	if days := dict.GetSlice("weekdays"); len(days) != 3 || days[2] != "Wed" {
+		t.Errorf("Expected weekdays list, got %v", days)
+	}
+	if dict.Get("title") != "Dashboard" {
+		t.Errorf("Expected 'Dashboard', got '%s'", dict.Get("title"))
+	}
+
+	var tf TranslationFile
+	if err := json.Unmarshal([]byte(content), &tf); err != nil {
+		t.Fatalf("Failed to unmarshal: %v", err)
+	}
+	data, err := json.Marshal(tf)
+	if err != nil {
+		t.Fatalf("Failed to marshal: %v", err)
+	}
+
+	var decoded TranslationFile
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Failed to unmarshal marshaled file: %v", err)
+	}
+	if len(decoded.Lists["weekdays"]) != 3 || decoded.Translations["title"] != "Dashboard" {
+		t.Errorf("Round trip lost data: %+v", decoded)
+	}
+}
+
+func TestTranslationFile_InvalidLists(t *testing.T) {
+	tests := map[string]string{
+		"non-string element": `{"meta":{"lang":"en","name":"x"},"translations":{"a":["x", 1]}}`,
+		"empty list":         `{"meta":{"lang":"en","name":"x"},"translations":{"a":[]}}`,
+		"empty element":      `{"meta":{"lang":"en","name":"x"},"translations":{"a":["x", ""]}}`,
+	}
+
+	for name, content := range tests {
+		t.Run(name, func(t *testing.T) {
+			filePath := filepath.Join(t.TempDir(), "lists.json")
+			if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+				t.Fatalf("Failed to write test file: %v", err)
+			}
+			if _, err := LoadDictionaryFile(filePath); err == nil {
+				t.Error("Expected error, got nil")
+			}
+		})
+	}
+}
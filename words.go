@@ -0,0 +1,88 @@
+package i18n
+
+// commonWords are built-in translations of the most common UI words, keyed
+// by language then word. Locales without an entry use their parent language,
+// then English.
+var commonWords = map[string]map[string]string{
+	"en": {"yes": "Yes", "no": "No", "on": "On", "off": "Off"},
+	"fr": {"yes": "Oui", "no": "Non", "on": "Activé", "off": "Désactivé"},
+	"de": {"yes": "Ja", "no": "Nein", "on": "An", "off": "Aus"},
+	"es": {"yes": "Sí", "no": "No", "on": "Activado", "off": "Desactivado"},
+	"it": {"yes": "Sì", "no": "No", "on": "Attivo", "off": "Disattivo"},
+	"pt": {"yes": "Sim", "no": "Não", "on": "Ligado", "off": "Desligado"},
+	"nl": {"yes": "Ja", "no": "Nee", "on": "Aan", "off": "Uit"},
+	"pl": {"yes": "Tak", "no": "Nie", "on": "Wł.", "off": "Wył."},
+	"ru": {"yes": "Да", "no": "Нет", "on": "Вкл.", "off": "Выкл."},
+	"uk": {"yes": "Так", "no": "Ні", "on": "Увімк.", "off": "Вимк."},
+	"sv": {"yes": "Ja", "no": "Nej", "on": "På", "off": "Av"},
+	"da": {"yes": "Ja", "no": "Nej", "on": "Til", "off": "Fra"},
+	"nb": {"yes": "Ja", "no": "Nei", "on": "På", "off": "Av"},
+	"fi": {"yes": "Kyllä", "no": "Ei", "on": "Päällä", "off": "Pois"},
+	"tr": {"yes": "Evet", "no": "Hayır", "on": "Açık", "off": "Kapalı"},
+	"ar": {"yes": "نعم", "no": "لا", "on": "تشغيل", "off": "إيقاف"},
+	"he": {"yes": "כן", "no": "לא", "on": "מופעל", "off": "כבוי"},
+	"ja": {"yes": "はい", "no": "いいえ", "on": "オン", "off": "オフ"},
+	"ko": {"yes": "예", "no": "아니요", "on": "켜기", "off": "끄기"},
+	"zh": {"yes": "是", "no": "否", "on": "开", "off": "关"},
+}
+
+// Yes returns the localized word for "yes"
+func Yes(locale string) string {
+	return defaultBundle.Yes(locale)
+}
+
+// No returns the localized word for "no"
+func No(locale string) string {
+	return defaultBundle.No(locale)
+}
+
+// YesNo returns the localized "yes" or "no" for a boolean
+func YesNo(locale string, value bool) string {
+	return defaultBundle.YesNo(locale, value)
+}
+
+// OnOff returns the localized "on" or "off" for a boolean
+func OnOff(locale string, value bool) string {
+	return defaultBundle.OnOff(locale, value)
+}
+
+// Yes is like the package-level Yes but uses the bundle's dictionaries
+func (b *Bundle) Yes(locale string) string {
+	return b.word(locale, "yes")
+}
+
+// No is like the package-level No but uses the bundle's dictionaries
+func (b *Bundle) No(locale string) string {
+	return b.word(locale, "no")
+}
+
+// YesNo is like the package-level YesNo but uses the bundle's dictionaries
+func (b *Bundle) YesNo(locale string, value bool) string {
+	if value {
+		return b.Yes(locale)
+	}
+	return b.No(locale)
+}
+
+// OnOff is like the package-level OnOff but uses the bundle's dictionaries
+func (b *Bundle) OnOff(locale string, value bool) string {
+	if value {
+		return b.word(locale, "on")
+	}
+	return b.word(locale, "off")
+}
+
+// word returns a common word from the locale's own dictionary, which
+// overrides the built-in translation, or from the built-in catalog. The
+// default language dictionary is not consulted, so an English override does
+// not replace the built-in word of another language.
+func (b *Bundle) word(locale, key string) string {
+	if dict := b.GetDictionary(locale); dict != nil {
+		if value, ok := dict.translation(key); ok {
+			return value
+		}
+	}
+
+	_, words := builtinLocale(commonWords, locale)
+	return words[key]
+}
@@ -0,0 +1,46 @@
+package i18n
+
+import "testing"
+
+func TestCommonWords(t *testing.T) {
+	b := NewBundle()
+
+	en := NewDictionary("en")
+	en.Add("yes", "Yep")
+	b.Register(en)
+
+	de := NewDictionary("de")
+	de.Add("off", "Ausgeschaltet")
+	b.Register(de)
+
+	tests := []struct {
+		name     string
+		result   string
+		expected string
+	}{
+		{"builtin", b.Yes("fr"), "Oui"},
+		{"builtin no", b.No("fr"), "Non"},
+		{"override", b.Yes("en"), "Yep"},
+		{"override not used for other languages", b.Yes("es"), "Sí"},
+		{"builtin with dictionary", b.OnOff("de", true), "An"},
+		{"override off", b.OnOff("de", false), "Ausgeschaltet"},
+		{"parent language", b.YesNo("pt-BR", false), "Não"},
+		{"unknown locale", b.YesNo("xx", true), "Yes"},
+	}
+
+	for _, tt := range tests {
+		if tt.result != tt.expected {
+			t.Errorf("%s: expected '%s', got '%s'", tt.name, tt.expected, tt.result)
+		}
+	}
+}
+
+func TestCommonWords_Complete(t *testing.T) {
+	for lang, words := range commonWords {
+		for _, key := range []string{"yes", "no", "on", "off"} {
+			if words[key] == "" {
+				t.Errorf("Built-in catalog '%s' is missing '%s'", lang, key)
+			}
+		}
+	}
+}
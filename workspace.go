@@ -0,0 +1,269 @@
+package i18n
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Module is a Go module of a workspace, see FindModules
+type Module struct {
+	Dir       string `json:"dir"`       // directory of its go.mod
+	Path      string `json:"path"`      // module path, such as "github.com/acme/billing"
+	Namespace string `json:"namespace"` // dictionary name of its catalogs, the last element of Path before any /vN
+}
+
+// FindModules returns the Go modules of a workspace, sorted by directory:
+// those listed by the use directives of root/go.work if there is one,
+// otherwise every go.mod under root, leaving out vendor, testdata and
+// hidden directories. Two modules with the same namespace are an error.
+func FindModules(root string) ([]Module, error) {
+	dirs, err := workspaceDirs(root)
+	if err != nil {
+		return nil, err
+	}
+
+	modules := make([]Module, 0, len(dirs))
+	seen := make(map[string]string, len(dirs))
+	for _, dir := range dirs {
+		path, err := modulePath(filepath.Join(dir, "go.mod"))
+		if err != nil {
+			return nil, err
+		}
+		ns := moduleNamespace(path)
+		if other, ok := seen[ns]; ok {
+			return nil, fmt.Errorf("modules %s and %s share the namespace '%s'", other, path, ns)
+		}
+		seen[ns] = path
+		modules = append(modules, Module{Dir: dir, Path: path, Namespace: ns})
+	}
+
+	sort.Slice(modules, func(i, j int) bool { return modules[i].Dir < modules[j].Dir })
+	return modules, nil
+}
+
+// moduleNamespace returns the last element of a module path, skipping a
+// major version suffix ("github.com/acme/billing/v2" is "billing")
+func moduleNamespace(path string) string {
+	elems := strings.Split(path, "/")
+	ns := elems[len(elems)-1]
+	if len(elems) > 1 && len(ns) > 1 && ns[0] == 'v' {
+		if _, err := strconv.Atoi(ns[1:]); err == nil {
+			ns = elems[len(elems)-2]
+		}
+	}
+	return ns
+}
+
+// workspaceDirs returns the module directories of the workspace at root
+func workspaceDirs(root string) ([]string, error) {
+	data, err := os.ReadFile(filepath.Join(root, "go.work"))
+	if errors.Is(err, os.ErrNotExist) {
+		return findModuleDirs(root)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read go.work: %w", err)
+	}
+
+	var dirs []string
+	for _, use := range goDirectives(data, "use") {
+		dirs = append(dirs, resolvePath(root, use))
+	}
+	return dirs, nil
+}
+
+// findModuleDirs returns the directories under root with a go.mod
+func findModuleDirs(root string) ([]string, error) {
+	var dirs []string
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			return nil
+		}
+		name := info.Name()
+		if path != root && (name == "vendor" || name == "testdata" || strings.HasPrefix(name, ".")) {
+			return filepath.SkipDir
+		}
+		if _, err := os.Stat(filepath.Join(path, "go.mod")); err == nil {
+			dirs = append(dirs, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error walking files: %w", err)
+	}
+	return dirs, nil
+}
+
+// modulePath returns the module path declared by a go.mod file
+func modulePath(gomod string) (string, error) {
+	data, err := os.ReadFile(filepath.Clean(gomod))
+	if err != nil {
+		return "", fmt.Errorf("failed to read module: %w", err)
+	}
+	if paths := goDirectives(data, "module"); len(paths) > 0 && paths[0] != "" {
+		return paths[0], nil
+	}
+	return "", &FileError{Path: gomod, Reason: "no module directive"}
+}
+
+// goDirectives returns the arguments of a directive of a go.mod or go.work
+// file, in single-line and block ("use ( ... )") form
+func goDirectives(data []byte, directive string) []string {
+	var args []string
+	inBlock := false
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.Index(line, "//"); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+		switch {
+		case len(fields) == 0:
+		case inBlock && fields[0] == ")":
+			inBlock = false
+		case inBlock:
+			args = append(args, unquoteDirective(fields[0]))
+		case fields[0] == directive && len(fields) > 1 && fields[1] == "(":
+			inBlock = true
+		case fields[0] == directive && len(fields) > 1:
+			args = append(args, unquoteDirective(fields[1]))
+		}
+	}
+	return args
+}
+
+// unquoteDirective returns a directive argument without its quotes
+func unquoteDirective(arg string) string {
+	if s, err := strconv.Unquote(arg); err == nil {
+		return s
+	}
+	return arg
+}
+
+// ModuleCoverage is the translation coverage of one locale of a module
+type ModuleCoverage struct {
+	Module     string `json:"module"`     // namespace of the module
+	Lang       string `json:"lang"`       // locale
+	Keys       int    `json:"keys"`       // keys of the module
+	Translated int    `json:"translated"` // keys translated in the locale
+}
+
+// Ratio returns the translated fraction of the keys, 1 if there are none
+func (c ModuleCoverage) Ratio() float64 {
+	if c.Keys == 0 {
+		return 1
+	}
+	return float64(c.Translated) / float64(c.Keys)
+}
+
+// GenerateWorkspace extracts every module of the workspace at root (see
+// FindModules) on its own and writes its catalogs into dir as the
+// dictionary of its namespace, such as billing.fr.json, so the services of
+// a monorepo share one locales directory without their keys colliding.
+// Loaded with LoadDir, the keys of a module are read through NS. The files
+// of modules nested in another module's directory are only extracted into
+// their own catalogs. The first locale is the source language, as in
+// GenerateCatalogs.
+//
+// It returns the coverage of every locale of every module, in module and
+// locale order. Keys whose translation is still a copy of the source text
+// count as untranslated, and so do keys without source text.
+func GenerateWorkspace(ctx context.Context, root, dir string, locales ...string) ([]ModuleCoverage, error) {
+	modules, err := FindModules(root)
+	if err != nil {
+		return nil, err
+	}
+	if dir == "" {
+		dir = filepath.Join(root, DefaultFolder)
+	}
+
+	var report []ModuleCoverage
+	err = withCatalogLock(ctx, dir, func() error {
+		for _, m := range modules {
+			catalogs, err := planCatalogs(ctx, []string{m.Dir}, nestedModules(m, modules), dir, m.Namespace, locales)
+			if err != nil {
+				return fmt.Errorf("%s: %w", m.Path, err)
+			}
+			if err := writeCatalogs(catalogs); err != nil {
+				return err
+			}
+			report = append(report, catalogCoverage(m.Namespace, catalogs)...)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// PlanWorkspace reports the changes GenerateWorkspace would make to the
+// catalogs of every module, by namespace, without writing anything
+func PlanWorkspace(ctx context.Context, root, dir string, locales ...string) (map[string][]LocaleChanges, error) {
+	modules, err := FindModules(root)
+	if err != nil {
+		return nil, err
+	}
+	if dir == "" {
+		dir = filepath.Join(root, DefaultFolder)
+	}
+
+	plan := make(map[string][]LocaleChanges, len(modules))
+	for _, m := range modules {
+		catalogs, err := planCatalogs(ctx, []string{m.Dir}, nestedModules(m, modules), dir, m.Namespace, locales)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", m.Path, err)
+		}
+		plan[m.Namespace] = catalogChanges(catalogs)
+	}
+	return plan, nil
+}
+
+// nestedModules returns the directories of the modules inside m's
+// directory, relative to it, as exclude patterns
+func nestedModules(m Module, modules []Module) []string {
+	var nested []string
+	for _, other := range modules {
+		rel, err := filepath.Rel(m.Dir, other.Dir)
+		if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue
+		}
+		nested = append(nested, filepath.ToSlash(rel))
+	}
+	return nested
+}
+
+// catalogCoverage returns the coverage of planned catalogs, counting keys
+// whose value is the source text as untranslated outside the source catalog
+func catalogCoverage(module string, catalogs []plannedCatalog) []ModuleCoverage {
+	if len(catalogs) == 0 {
+		return nil
+	}
+	source := catalogs[0]
+	keys := len(source.translations) + len(source.needsSource)
+
+	report := make([]ModuleCoverage, 0, len(catalogs))
+	for i, c := range catalogs {
+		translated := len(c.translations)
+		if i > 0 {
+			for key, value := range c.translations {
+				if value == source.translations[key] {
+					translated--
+				}
+			}
+		}
+		report = append(report, ModuleCoverage{Module: module, Lang: c.changes.Lang, Keys: keys, Translated: translated})
+	}
+	return report
+}
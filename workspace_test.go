@@ -0,0 +1,120 @@
+package i18n
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// writeWorkspace writes a monorepo with a root module and two services, one
+// of them nested in the root module's directory and at a major version
+func writeWorkspace(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	writeTestFile(t, filepath.Join(root, "go.mod"), "module github.com/acme/platform\n")
+	writeTestFile(t, filepath.Join(root, "platform.go"), `package platform
+
+import "github.com/nyxstack/i18n"
+
+var title = i18n.S("Platform")
+`)
+	writeTestFile(t, filepath.Join(root, "services", "billing", "go.mod"), "// Billing service\nmodule \"github.com/acme/billing/v2\"\n\ngo 1.24\n")
+	writeTestFile(t, filepath.Join(root, "services", "billing", "invoice.go"), `package billing
+
+import "github.com/nyxstack/i18n"
+
+var title, paid = i18n.S("Invoice"), i18n.S("Paid")
+`)
+	writeTestFile(t, filepath.Join(root, "services", "auth", "go.mod"), "module github.com/acme/auth\n")
+	writeTestFile(t, filepath.Join(root, "services", "auth", "login.go"), `package auth
+
+import "github.com/nyxstack/i18n"
+
+var title = i18n.S("Sign in")
+`)
+	writeTestFile(t, filepath.Join(root, "vendor", "x", "go.mod"), "module example.com/x\n")
+	return root
+}
+
+func TestFindModules(t *testing.T) {
+	root := writeWorkspace(t)
+
+	modules, err := FindModules(root)
+	if err != nil {
+		t.Fatalf("FindModules failed: %v", err)
+	}
+	want := []Module{
+		{Dir: root, Path: "github.com/acme/platform", Namespace: "platform"},
+		{Dir: filepath.Join(root, "services", "auth"), Path: "github.com/acme/auth", Namespace: "auth"},
+		{Dir: filepath.Join(root, "services", "billing"), Path: "github.com/acme/billing/v2", Namespace: "billing"},
+	}
+	if !reflect.DeepEqual(modules, want) {
+		t.Errorf("Expected %+v, got %+v", want, modules)
+	}
+
+	// go.work decides which modules are part of the workspace
+	writeTestFile(t, filepath.Join(root, "go.work"), "go 1.24\n\nuse (\n\t./services/billing // payments\n)\nuse ./services/auth\n")
+	modules, err = FindModules(root)
+	if err != nil {
+		t.Fatalf("FindModules failed: %v", err)
+	}
+	if len(modules) != 2 || modules[0].Namespace != "auth" || modules[1].Namespace != "billing" {
+		t.Errorf("Expected the auth and billing modules of go.work, got %+v", modules)
+	}
+
+	writeTestFile(t, filepath.Join(root, "go.work"), "use ./services/auth\nuse ./services/auth2\n")
+	writeTestFile(t, filepath.Join(root, "services", "auth2", "go.mod"), "module github.com/other/auth\n")
+	if _, err := FindModules(root); err == nil {
+		t.Error("Expected modules sharing a namespace to be rejected")
+	}
+}
+
+func TestGenerateWorkspace(t *testing.T) {
+	root := writeWorkspace(t)
+	dir := filepath.Join(root, DefaultFolder)
+	writeTestFile(t, filepath.Join(dir, "billing.fr.json"),
+		`{"meta": {"lang": "fr", "name": "billing"}, "translations": {"paid": "Payée"}}`)
+
+	plan, err := PlanWorkspace(context.Background(), root, "", "en", "fr")
+	if err != nil {
+		t.Fatalf("PlanWorkspace failed: %v", err)
+	}
+	if len(plan) != 3 || len(plan["billing"]) != 2 || len(plan["billing"][0].Added) != 2 {
+		t.Errorf("Expected a plan adding the billing keys, got %+v", plan)
+	}
+
+	report, err := GenerateWorkspace(context.Background(), root, "", "en", "fr")
+	if err != nil {
+		t.Fatalf("GenerateWorkspace failed: %v", err)
+	}
+	want := []ModuleCoverage{
+		{Module: "platform", Lang: "en", Keys: 1, Translated: 1},
+		{Module: "platform", Lang: "fr", Keys: 1, Translated: 0},
+		{Module: "auth", Lang: "en", Keys: 1, Translated: 1},
+		{Module: "auth", Lang: "fr", Keys: 1, Translated: 0},
+		{Module: "billing", Lang: "en", Keys: 2, Translated: 2},
+		{Module: "billing", Lang: "fr", Keys: 2, Translated: 1},
+	}
+	if !reflect.DeepEqual(report, want) {
+		t.Errorf("Expected %+v, got %+v", want, report)
+	}
+	if r := report[5].Ratio(); r != 0.5 {
+		t.Errorf("Expected a ratio of 0.5, got %v", r)
+	}
+
+	// Each module's keys load under its namespace
+	b := NewBundle()
+	if err := b.LoadDir(dir); err != nil {
+		t.Fatalf("LoadDir failed: %v", err)
+	}
+	if got := b.NS("billing").T("paid")("fr"); got != "Payée" {
+		t.Errorf("Expected billing.paid to keep its translation, got %q", got)
+	}
+	if got := b.NS("platform").T("platform")("en"); got != "Platform" {
+		t.Errorf("Expected the root module's own key, got %q", got)
+	}
+	if b.GetDictionary("en").Has("platform.invoice") {
+		t.Error("Expected the nested billing module to be left out of the root module")
+	}
+}
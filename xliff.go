@@ -0,0 +1,122 @@
+package i18n
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"strings"
+)
+
+// xliffNamespace is the namespace of XLIFF 1.2 documents
+const xliffNamespace = "urn:oasis:names:tc:xliff:document:1.2"
+
+// xliffDocument is an XLIFF 1.2 document
+type xliffDocument struct {
+	XMLName xml.Name    `xml:"xliff"`
+	Xmlns   string      `xml:"xmlns,attr,omitempty"`
+	Version string      `xml:"version,attr"`
+	Files   []xliffFile `xml:"file"`
+}
+
+// xliffFile is a file element, holding the translation units of a catalog
+type xliffFile struct {
+	Original       string      `xml:"original,attr"`
+	SourceLanguage string      `xml:"source-language,attr"`
+	TargetLanguage string      `xml:"target-language,attr,omitempty"`
+	Datatype       string      `xml:"datatype,attr"`
+	Units          []xliffUnit `xml:"body>trans-unit"`
+}
+
+// xliffUnit is a translation unit: a key, its value and translator notes
+type xliffUnit struct {
+	ID     string   `xml:"id,attr"`
+	Source string   `xml:"source"`
+	Target *string  `xml:"target"`
+	Notes  []string `xml:"note"`
+}
+
+// marshalXLIFF encodes a translation file as an XLIFF 1.2 document: one
+// trans-unit per string translation, sorted by key, with the key as id, the
+// value as source and the translator comment as note. The file element
+// carries the language as source-language and the dictionary name as
+// original.
+func marshalXLIFF(tf *TranslationFile) ([]byte, error) {
+	file := xliffFile{Original: tf.Meta.Name, SourceLanguage: tf.Meta.Lang, Datatype: "plaintext"}
+	for _, key := range sortedKeys(tf.Translations) {
+		unit := xliffUnit{ID: key, Source: tf.Translations[key]}
+		if comment := tf.Comments[key]; comment != "" {
+			unit.Notes = []string{comment}
+		}
+		file.Units = append(file.Units, unit)
+	}
+	doc := xliffDocument{Xmlns: xliffNamespace, Version: "1.2", Files: []xliffFile{file}}
+
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	enc := xml.NewEncoder(&buf)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return nil, err
+	}
+	buf.WriteByte('\n')
+	return buf.Bytes(), nil
+}
+
+// decodeXLIFF decodes and validates an XLIFF 1.2 document of one file
+// element. The value of a unit is its target, or its source if it has no
+// target, as in documents written by marshalXLIFF; units without a value
+// are left out. The language is the target-language of the file, or its
+// source-language, or lang if it has neither, and an original that is a bare
+// name rather than a file name is the dictionary name.
+func decodeXLIFF(data []byte, lang string) (*TranslationFile, error) {
+	var doc xliffDocument
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		return nil, &FileError{Reason: "invalid XLIFF document", Err: err}
+	}
+	if len(doc.Files) != 1 {
+		return nil, &FileError{Reason: fmt.Sprintf("has %d file elements (expected 1)", len(doc.Files))}
+	}
+	file := doc.Files[0]
+
+	switch {
+	case file.TargetLanguage != "":
+		lang = file.TargetLanguage
+	case file.SourceLanguage != "":
+		lang = file.SourceLanguage
+	}
+	tf := newCatalogFile(lang, make(map[string]string, len(file.Units)))
+	if file.Original != "" && !strings.ContainsAny(file.Original, `/\.`) {
+		tf.Meta.Name = file.Original
+	}
+
+	for _, unit := range file.Units {
+		if unit.ID == "" {
+			return nil, &FileError{Lang: lang, Reason: "trans-unit without id"}
+		}
+		if _, dup := tf.Translations[unit.ID]; dup {
+			return nil, fileError(lang, unit.ID, "duplicate trans-unit '%s'", unit.ID)
+		}
+		value := unit.Source
+		if unit.Target != nil {
+			value = *unit.Target
+		}
+		if value == "" {
+			continue
+		}
+		tf.Translations[unit.ID] = value
+		if len(unit.Notes) > 0 {
+			if tf.Comments == nil {
+				tf.Comments = make(map[string]string)
+			}
+			tf.Comments[unit.ID] = strings.Join(unit.Notes, "\n")
+		}
+	}
+
+	if tf.Meta.Lang == "" {
+		return nil, &FileError{Reason: "has no source-language or target-language: choose the language to convert"}
+	}
+	if err := validateTranslationFile(tf); err != nil {
+		return nil, err
+	}
+	return tf, nil
+}
@@ -0,0 +1,90 @@
+package i18n
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDecodeXLIFF(t *testing.T) {
+	// As written by translation tools, with targets
+	data := `<?xml version="1.0" encoding="UTF-8"?>
+<xliff version="1.2" xmlns="urn:oasis:names:tc:xliff:document:1.2">
+  <file original="messages.json" source-language="en" target-language="fr" datatype="plaintext">
+    <body>
+      <trans-unit id="welcome">
+        <source>Welcome, {0}!</source>
+        <target>Bienvenue, {0} !</target>
+        <note>Dashboard title</note>
+      </trans-unit>
+      <trans-unit id="bye">
+        <source>Goodbye</source>
+        <target/>
+      </trans-unit>
+    </body>
+  </file>
+</xliff>`
+	tf, err := decodeXLIFF([]byte(data), "")
+	if err != nil {
+		t.Fatalf("decodeXLIFF failed: %v", err)
+	}
+	if tf.Meta.Lang != "fr" || tf.Meta.Name != DefaultDictionary {
+		t.Errorf("Expected the default fr dictionary, got %+v", tf.Meta)
+	}
+	if want := map[string]string{"welcome": "Bienvenue, {0} !"}; !reflect.DeepEqual(tf.Translations, want) {
+		t.Errorf("Expected the translated targets only, got %v", tf.Translations)
+	}
+	if tf.Comments["welcome"] != "Dashboard title" {
+		t.Errorf("Expected the note as comment, got %v", tf.Comments)
+	}
+}
+
+func TestDecodeXLIFF_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{"not xml", "<xliff", "invalid XLIFF document"},
+		{"no file", `<xliff version="1.2"></xliff>`, "has 0 file elements"},
+		{"no language", `<xliff version="1.2"><file><body><trans-unit id="a"><source>A</source></trans-unit></body></file></xliff>`, "has no source-language or target-language"},
+		{"no id", `<xliff version="1.2"><file source-language="en"><body><trans-unit><source>A</source></trans-unit></body></file></xliff>`, "trans-unit without id"},
+		{"duplicate", `<xliff version="1.2"><file source-language="en"><body><trans-unit id="a"><source>A</source></trans-unit><trans-unit id="a"><source>B</source></trans-unit></body></file></xliff>`, "duplicate trans-unit 'a'"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := decodeXLIFF([]byte(tt.data), "")
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Expected an error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestMarshalXLIFF(t *testing.T) {
+	tf := newCatalogFile("de", map[string]string{"total": "Summe", "invoice": "Rechnung <{0}>"})
+	tf.Comments = map[string]string{"invoice": "Page title"}
+
+	data, err := marshalXLIFF(tf)
+	if err != nil {
+		t.Fatalf("marshalXLIFF failed: %v", err)
+	}
+	want := `<?xml version="1.0" encoding="UTF-8"?>
+<xliff xmlns="urn:oasis:names:tc:xliff:document:1.2" version="1.2">
+  <file original="default" source-language="de" datatype="plaintext">
+    <body>
+      <trans-unit id="invoice">
+        <source>Rechnung &lt;{0}&gt;</source>
+        <note>Page title</note>
+      </trans-unit>
+      <trans-unit id="total">
+        <source>Summe</source>
+      </trans-unit>
+    </body>
+  </file>
+</xliff>
+`
+	if string(data) != want {
+		t.Errorf("Expected\n%s\ngot\n%s", want, data)
+	}
+}
@@ -0,0 +1,130 @@
+package i18n
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// marshalCatalogYAML encodes a translation file as YAML with the structure
+// of its JSON form, in the same order: block mappings for objects, flow
+// sequences for lists and scheduled values, and double-quoted strings, so
+// parseYAML reads it back without loss.
+func marshalCatalogYAML(tf *TranslationFile) ([]byte, error) {
+	data, err := MarshalCatalog(tf)
+	if err != nil {
+		return nil, err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if _, err := dec.Token(); err != nil { // {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := writeYAMLMapping(&buf, dec, 0); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// writeYAMLMapping writes the members of the JSON object dec is in as a
+// block mapping at indent, consuming the closing brace
+func writeYAMLMapping(buf *bytes.Buffer, dec *json.Decoder, indent int) error {
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(buf, "%s%s:", strings.Repeat(" ", indent), yamlKey(tok.(string)))
+
+		if tok, err = dec.Token(); err != nil {
+			return err
+		}
+		if tok == json.Delim('{') && dec.More() {
+			buf.WriteByte('\n')
+			if err := writeYAMLMapping(buf, dec, indent+2); err != nil {
+				return err
+			}
+			continue
+		}
+		buf.WriteByte(' ')
+		if err := writeYAMLFlow(buf, dec, tok); err != nil {
+			return err
+		}
+		buf.WriteByte('\n')
+	}
+	_, err := dec.Token() // }
+	return err
+}
+
+// writeYAMLFlow writes the JSON value starting with tok in flow style
+func writeYAMLFlow(buf *bytes.Buffer, dec *json.Decoder, tok json.Token) error {
+	switch tok := tok.(type) {
+	case json.Delim:
+		buf.WriteString(tok.String())
+		for i := 0; dec.More(); i++ {
+			if i > 0 {
+				buf.WriteString(", ")
+			}
+			next, err := dec.Token()
+			if err != nil {
+				return err
+			}
+			if tok == '{' {
+				fmt.Fprintf(buf, "%s: ", yamlKey(next.(string)))
+				if next, err = dec.Token(); err != nil {
+					return err
+				}
+			}
+			if err := writeYAMLFlow(buf, dec, next); err != nil {
+				return err
+			}
+		}
+		end, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		buf.WriteString(end.(json.Delim).String())
+	case string:
+		buf.WriteString(strconv.Quote(tok))
+	case json.Number:
+		buf.WriteString(tok.String())
+	case bool:
+		buf.WriteString(strconv.FormatBool(tok))
+	case nil:
+		buf.WriteString("null")
+	}
+	return nil
+}
+
+// plainYAMLKey matches the keys written without quotes
+var plainYAMLKey = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9_.\-/]*$`)
+
+// yamlKey returns key as written in a mapping, quoted unless it is plain
+func yamlKey(key string) string {
+	if plainYAMLKey.MatchString(key) {
+		return key
+	}
+	return strconv.Quote(key)
+}
+
+// decodeCatalogYAML decodes and validates a translation file written as
+// YAML, with the structure of its JSON form
+func decodeCatalogYAML(data []byte) (*TranslationFile, error) {
+	tree, err := parseYAML(string(data))
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := tree.(map[string]any); !ok {
+		return nil, &FileError{Line: 1, Reason: "expected a mapping of 'meta' and 'translations'"}
+	}
+	encoded, err := json.Marshal(tree)
+	if err != nil {
+		return nil, &FileError{Reason: "invalid translation file", Err: err}
+	}
+	return decodeTranslationFile(encoded)
+}
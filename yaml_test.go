@@ -0,0 +1,88 @@
+package i18n
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMarshalCatalogYAML(t *testing.T) {
+	tf, err := decodeTranslationFile([]byte(`{
+  "meta": {"lang": "fr", "name": "default"},
+  "translations": {
+    "auth": {"login": "Connexion", "hint: first": "Astuce"},
+    "tags": ["a", "b, c"]
+  }
+}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := marshalCatalogYAML(tf)
+	if err != nil {
+		t.Fatalf("marshalCatalogYAML failed: %v", err)
+	}
+	want := `meta:
+  lang: "fr"
+  name: "default"
+translations:
+  auth:
+    "hint: first": "Astuce"
+    login: "Connexion"
+  tags: ["a", "b, c"]
+`
+	if string(data) != want {
+		t.Errorf("Expected\n%s\ngot\n%s", want, data)
+	}
+
+	back, err := decodeCatalogYAML(data)
+	if err != nil {
+		t.Fatalf("decodeCatalogYAML failed: %v", err)
+	}
+	if !reflect.DeepEqual(back.Translations, tf.Translations) || !reflect.DeepEqual(back.Lists, tf.Lists) || !back.Nested {
+		t.Errorf("Expected the nested catalog back, got %v, %v", back.Translations, back.Lists)
+	}
+}
+
+func TestDecodeCatalogYAML(t *testing.T) {
+	// As written by hand, with plain scalars and comments
+	tf, err := decodeCatalogYAML([]byte(`# Spanish catalog
+meta:
+  lang: es
+  name: default
+translations:
+  items_per_page: 20
+  welcome: '¡Bienvenido, {0}!'  # shown once
+  weekdays: [lunes, martes]
+comments:
+  welcome: Dashboard title
+`))
+	if err != nil {
+		t.Fatalf("decodeCatalogYAML failed: %v", err)
+	}
+	if want := map[string]string{"items_per_page": "20", "welcome": "¡Bienvenido, {0}!"}; !reflect.DeepEqual(tf.Translations, want) {
+		t.Errorf("Expected %v, got %v", want, tf.Translations)
+	}
+	if !reflect.DeepEqual(tf.Lists["weekdays"], []string{"lunes", "martes"}) || tf.Comments["welcome"] != "Dashboard title" {
+		t.Errorf("Expected the list and comment, got %v and %v", tf.Lists, tf.Comments)
+	}
+}
+
+func TestDecodeCatalogYAML_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{"not a mapping", "- a\n- b\n", "expected a mapping"},
+		{"syntax", "meta:\n  lang: fr\n bad\n", "unexpected indentation"},
+		{"no lang", "meta:\n  name: default\ntranslations:\n  a: A\n", "missing required 'meta.lang' field"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := decodeCatalogYAML([]byte(tt.data))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Expected an error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}